@@ -0,0 +1,12 @@
+data "deltastream_current_session" "current" {
+  lifecycle {
+    postcondition {
+      condition     = self.role == "sysadmin"
+      error_message = "Expected to run as sysadmin, got ${self.role}."
+    }
+  }
+}
+
+output "current_role_privileges" {
+  value = data.deltastream_current_session.current.privileges
+}
@@ -0,0 +1,3 @@
+data "deltastream_queries" "all_queries" {
+  include_stopped = true
+}
@@ -0,0 +1,11 @@
+variable "pageviews_query_id" {
+  type = string
+}
+
+data "deltastream_query_savepoints" "pageviews" {
+  query_id = var.pageviews_query_id
+}
+
+output "pageviews_savepoints" {
+  value = [for s in data.deltastream_query_savepoints.pageviews.savepoints : "${s.query_version}: ${length(s.positions)} positions"]
+}
@@ -0,0 +1,7 @@
+data "deltastream_query_state" "pageviews" {
+  query_id = deltastream_query.pageviews.query_id
+}
+
+output "pageviews_positions" {
+  value = data.deltastream_query_state.pageviews.positions
+}
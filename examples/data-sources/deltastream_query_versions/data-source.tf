@@ -0,0 +1,7 @@
+data "deltastream_query_versions" "pageviews" {
+  query_name = "pageviews_query"
+}
+
+output "pageviews_query_history" {
+  value = [for v in data.deltastream_query_versions.pageviews.versions : "${v.query_version}: ${v.state} since ${v.updated_at}"]
+}
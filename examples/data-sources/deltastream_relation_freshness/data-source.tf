@@ -0,0 +1,14 @@
+data "deltastream_relation_freshness" "pageviews" {
+  database    = deltastream_database.example.name
+  schema      = "public"
+  name        = "pageviews"
+  sample_size = 5
+  timeout     = "1m"
+}
+
+check "pageviews_flowing" {
+  assert {
+    condition     = data.deltastream_relation_freshness.pageviews.sampled_rows > 0
+    error_message = "No records were read from pageviews"
+  }
+}
@@ -0,0 +1,11 @@
+data "deltastream_relation_queries" "pageviews" {
+  fqn = "${deltastream_database.example.name}.public.pageviews"
+}
+
+output "pageviews_producers" {
+  value = [for q in data.deltastream_relation_queries.pageviews.producers : q.query_id]
+}
+
+output "pageviews_consumers" {
+  value = [for q in data.deltastream_relation_queries.pageviews.consumers : q.query_id]
+}
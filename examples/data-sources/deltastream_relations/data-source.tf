@@ -2,3 +2,8 @@ data "deltastream_relations" "all_in_example_public" {
   database = deltastream_database.example.name
   schema   = "public"
 }
+
+# items_by_name keys the relations by name, so for_each does not depend on their order
+output "relation_owners" {
+  value = { for name, relation in data.deltastream_relations.all_in_example_public.items_by_name : name => relation.owner }
+}
@@ -2,3 +2,9 @@ data "deltastream_schema" "example" {
   database = "example_database"
   name     = "example_schema"
 }
+
+data "deltastream_schema" "snapshot" {
+  database        = "example_database"
+  name            = "example_schema"
+  include_objects = true
+}
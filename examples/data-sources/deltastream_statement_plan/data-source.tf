@@ -0,0 +1,5 @@
+data "deltastream_statement_plan" "pageviews_user_1" {
+  database = deltastream_database.example.name
+  schema   = "public"
+  sql      = "CREATE STREAM pageviews_user_1 AS SELECT * FROM pageviews WHERE userid = 'User_1';"
+}
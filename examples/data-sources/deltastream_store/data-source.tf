@@ -1,3 +1,8 @@
 data "deltastream_store" "example" {
   name = "example_store"
 }
+
+# details not modelled by the data source yet can be read from the raw DESCRIBE STORE result
+output "store_describe" {
+  value = jsondecode(data.deltastream_store.example.raw_describe_json)
+}
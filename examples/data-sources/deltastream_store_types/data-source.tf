@@ -0,0 +1,6 @@
+data "deltastream_store_types" "supported" {
+}
+
+output "kafka_required_properties" {
+  value = one([for t in data.deltastream_store_types.supported.items : t.required_properties if t.type == "KAFKA"])
+}
@@ -0,0 +1,9 @@
+data "deltastream_topic_relations" "pageviews" {
+  store    = "kafka_store"
+  topic    = "pageviews"
+  database = "analytics"
+}
+
+output "pageviews_relations" {
+  value = [for r in data.deltastream_topic_relations.pageviews.relations : r.fqn]
+}
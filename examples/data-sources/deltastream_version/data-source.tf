@@ -0,0 +1,14 @@
+data "deltastream_version" "current" {}
+
+output "deltastream_server_version" {
+  value = data.deltastream_version.current.server_version
+}
+
+resource "deltastream_query" "restarting" {
+  count = data.deltastream_version.current.server_major >= 2 ? 1 : 0
+
+  source_relation_fqns = [deltastream_relation.pageviews.fqn]
+  sink_relation_fqns   = [deltastream_relation.pageviews_copy.fqn]
+  restart_policy       = "on-failure"
+  sql                  = "INSERT INTO ${deltastream_relation.pageviews_copy.fqn} SELECT * FROM ${deltastream_relation.pageviews.fqn};"
+}
@@ -0,0 +1,8 @@
+# Read the last records of a topic without persisting them, for example from terraform console
+ephemeral "deltastream_entity_data" "pageviews" {
+  store          = deltastream_store.kafka.name
+  entity_path    = ["pageviews"]
+  max_records    = 5
+  duration       = "30s"
+  from_beginning = true
+}
@@ -2,4 +2,21 @@ provider "deltastream" {
   auth_token   = "your_auth_token_here"
   organization = "your_organization_name_here"
   role         = "sysadmin"
+
+  strict_role_isolation = true
+  strict_drift_checks   = true
+  statement_log_file    = "deltastream-statements.jsonl"
+  default_access_region = "AWS us-east-1"
+  case_sensitivity      = "insensitive"
+  manifest_file         = "deltastream-manifest.json"
+
+  default_owners = {
+    store    = "infra_admin"
+    relation = "data_eng"
+  }
+
+  default_tags = {
+    team        = "data"
+    cost-center = "42"
+  }
 }
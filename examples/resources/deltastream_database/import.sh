@@ -0,0 +1,2 @@
+# Databases are imported by name
+terraform import deltastream_database.analytics analytics
@@ -0,0 +1,3 @@
+# Entities are imported by store name and entity path, with path segments separated by slashes
+terraform import deltastream_entity.pageviews kafka_store:pageviews
+terraform import deltastream_entity.orders snowflake_store:ANALYTICS/PUBLIC/ORDERS
@@ -0,0 +1,23 @@
+resource "deltastream_entity_set" "topics" {
+  store       = deltastream_store.kafka_with_sasl.name
+  concurrency = 8
+
+  entities = {
+    "orders" = {
+      topic_partitions = 6
+      topic_replicas   = 3
+      value_format     = "json"
+    }
+    "payments" = {
+      topic_partitions = 3
+      topic_replicas   = 3
+      configs = {
+        "retention.ms" = "604800000"
+      }
+    }
+    "shipments" = {
+      topic_partitions = 3
+      topic_replicas   = 3
+    }
+  }
+}
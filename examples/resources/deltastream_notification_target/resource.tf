@@ -0,0 +1,25 @@
+resource "deltastream_notification_target" "slack" {
+  name = "example_slack_alerts"
+  slack = {
+    webhook_url = var.slack_webhook_url
+    channel     = "#data-alerts"
+  }
+}
+
+resource "deltastream_notification_target" "pagerduty" {
+  name = "example_pagerduty_alerts"
+  pagerduty = {
+    routing_key = var.pagerduty_routing_key
+    severity    = "error"
+  }
+}
+
+resource "deltastream_notification_target" "webhook" {
+  name = "example_webhook_alerts"
+  webhook = {
+    url = "https://alerts.example.com/deltastream"
+    headers = {
+      Authorization = "Bearer ${var.alerts_token}"
+    }
+  }
+}
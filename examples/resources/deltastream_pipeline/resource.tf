@@ -0,0 +1,14 @@
+resource "deltastream_pipeline" "pageviews_by_user" {
+  database   = deltastream_database.example.name
+  schema     = "public"
+  store      = deltastream_store.kafka.name
+  source_sql = <<EOF
+    CREATE STREAM pageviews (viewtime BIGINT, userid VARCHAR, pageid VARCHAR) WITH ('topic'='pageviews', 'value.format'='json');
+  EOF
+  sink_sql   = <<EOF
+    CREATE STREAM pageviews_user_2 (viewtime BIGINT, userid VARCHAR, pageid VARCHAR) WITH ('topic'='pageviews_user_2', 'value.format'='json');
+  EOF
+  query_sql  = <<EOF
+    INSERT INTO pageviews_user_2 SELECT * FROM pageviews WHERE userid = 'User_2';
+  EOF
+}
@@ -0,0 +1,24 @@
+variable "pageviews_query_id" {
+  type = string
+}
+
+variable "pageviews_sql" {
+  type = string
+}
+
+# Record the committed positions of the running query before its SQL changes
+resource "deltastream_query_savepoint" "before_upgrade" {
+  query_id = var.pageviews_query_id
+  triggers = {
+    sql = var.pageviews_sql
+  }
+  timeout = "15m"
+}
+
+resource "deltastream_query" "pageviews" {
+  source_relation_fqns = [deltastream_relation.pageviews.fqn]
+  sink_relation_fqns   = [deltastream_relation.pageviews_copy.fqn]
+  sql                  = var.pageviews_sql
+
+  depends_on = [deltastream_query_savepoint.before_upgrade]
+}
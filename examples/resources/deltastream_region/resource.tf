@@ -0,0 +1,14 @@
+resource "deltastream_region" "euw1" {
+  name = "AWS eu-west-1"
+}
+
+resource "deltastream_store" "kafka_eu" {
+  name          = "kafka_eu"
+  access_region = deltastream_region.euw1.name
+  kafka = {
+    uris               = var.kafka_url
+    sasl_hash_function = "PLAIN"
+    sasl_username      = var.kafka_sasl_username
+    sasl_password      = var.kafka_sasl_password
+  }
+}
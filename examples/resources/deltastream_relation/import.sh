@@ -0,0 +1,2 @@
+# Relations are imported by fully qualified name, their statement is taken from the configuration on the first apply
+terraform import deltastream_relation.pageviews analytics.public.pageviews
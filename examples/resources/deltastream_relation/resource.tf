@@ -15,3 +15,32 @@ resource "deltastream_relation" "user_last_page" {
     CREATE CHANGELOG user_last_page (viewtime BIGINT, userid VARCHAR, pageid VARCHAR, PRIMARY KEY(userid)) WITH ('topic'='pageviews', 'value.format'='json');
   EOF
 }
+
+# database, schema and store are derived from a fully qualified statement when not set
+resource "deltastream_relation" "qualified_pageviews" {
+  sql = <<EOF
+    CREATE STREAM example_db.public.qualified_pageviews (viewtime BIGINT, userid VARCHAR, pageid VARCHAR) WITH ('store'='kafka', 'topic'='pageviews', 'value.format'='json');
+  EOF
+}
+
+# rename_to renames the relation in place, the statement is not re-run
+resource "deltastream_relation" "renamed_pageviews" {
+  database  = deltastream_database.example.name
+  schema    = "public"
+  rename_to = "page_views"
+  sql       = <<EOF
+    CREATE STREAM pageviews_v1 (viewtime BIGINT, userid VARCHAR, pageid VARCHAR) WITH ('topic'='pageviews', 'value.format'='json');
+  EOF
+}
+
+# retention_ms and retention_bytes update the topic of the relation in place
+resource "deltastream_relation" "retained_pageviews" {
+  database        = deltastream_database.example.name
+  schema          = "public"
+  store           = deltastream_store.kafka.name
+  retention_ms    = 604800000
+  retention_bytes = -1
+  sql             = <<EOF
+    CREATE STREAM RETAINED_PAGEVIEWS (viewtime BIGINT, userid VARCHAR, pageid VARCHAR) WITH ('topic'='retained_pageviews', 'value.format'='json');
+  EOF
+}
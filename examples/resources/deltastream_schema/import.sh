@@ -0,0 +1,2 @@
+# Schemas are imported by database and schema name, separated by a dot
+terraform import deltastream_schema.public analytics.public
@@ -0,0 +1,17 @@
+resource "deltastream_schema_exchange" "orders_value" {
+  schema_registry = deltastream_schema_registry.confluent_cloud.name
+  subject         = "orders-value"
+  compatibility   = "BACKWARD"
+  username        = var.schema_registry_key
+  password        = var.schema_registry_secret
+
+  schema = jsonencode({
+    type      = "record"
+    name      = "Order"
+    namespace = "com.example"
+    fields = [
+      { name = "order_id", type = "string" },
+      { name = "amount", type = "double" },
+    ]
+  })
+}
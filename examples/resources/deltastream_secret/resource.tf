@@ -4,4 +4,8 @@ resource "deltastream_secret" "example" {
   description   = "secret description"
   access_region = "AWS us-east-1"
   string_value  = "some value"
+
+  tags = {
+    owner = "payments"
+  }
 }
@@ -0,0 +1,15 @@
+variable "api_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "api_token_version" {
+  type = number
+}
+
+# Bump api_token_version together with api_token to rotate the secret
+resource "deltastream_secret_version" "example" {
+  secret       = deltastream_secret.example.name
+  version      = var.api_token_version
+  string_value = var.api_token
+}
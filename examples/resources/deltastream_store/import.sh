@@ -0,0 +1,2 @@
+# Stores are imported by name, their connection settings are taken from the configuration on the first apply
+terraform import deltastream_store.kafka kafka_store
@@ -1,6 +1,28 @@
 resource "deltastream_store" "kafka_with_sasl" {
   name          = "kafka_with_sasl_${random_id.suffix.hex}"
   access_region = "AWS us-west-2"
+  kafka = {
+    uris               = var.kafka_url
+    sasl_hash_function = "PLAIN"
+    sasl_username      = var.kafka_sasl_username
+    sasl_password      = var.kafka_sasl_password
+    client_properties = {
+      "client.dns.lookup"  = "use_all_dns_ips"
+      "request.timeout.ms" = "60000"
+    }
+
+    # topics of sinks are created by the platform team, not by queries
+    topic_auto_create        = false
+    topic_default_partitions = 6
+    topic_default_replicas   = 3
+  }
+}
+
+# refresh re-tests the connection so expired broker credentials show up in connectivity_error
+resource "deltastream_store" "kafka_verified" {
+  name                        = "kafka_verified_${random_id.suffix.hex}"
+  access_region               = "AWS us-west-2"
+  verify_connectivity_on_read = true
   kafka = {
     uris               = var.kafka_url
     sasl_hash_function = "PLAIN"
@@ -20,6 +42,18 @@ resource "deltastream_store" "confluent_kafka_with_sasl" {
   }
 }
 
+resource "deltastream_store" "confluent_cloud" {
+  name          = "confluent_cloud_${random_id.suffix.hex}"
+  access_region = "AWS us-west-2"
+  confluent_kafka = {
+    uris                 = var.confluent_bootstrap_url
+    sasl_hash_function   = "PLAIN"
+    cluster_api_key      = var.confluent_cluster_api_key
+    cluster_api_secret   = var.confluent_cluster_api_secret
+    schema_registry_name = var.confluent_schema_registry_name
+  }
+}
+
 resource "deltastream_store" "kafka_with_iam" {
   name          = "kafka_with_iam_${random_id.suffix.hex}"
   access_region = "AWS us-west-2"
@@ -38,6 +72,19 @@ resource "deltastream_store" "kinesis_creds" {
     uris              = var.kinesis_url
     access_key_id     = var.kinesis_key
     secret_access_key = var.kinesis_secret
+    stream_mode       = "ON_DEMAND"
+    enhanced_fan_out  = true
+  }
+}
+
+resource "deltastream_store" "kinesis_role" {
+  name          = "kinesis_with_role_${random_id.suffix.hex}"
+  access_region = var.kinesis_region
+  kinesis = {
+    uris        = var.kinesis_url
+    role_arn             = var.kinesis_role_arn
+    external_id          = var.kinesis_external_id
+    schema_registry_name = var.kinesis_schema_registry_name
   }
 }
 
@@ -79,3 +126,44 @@ resource "deltastream_store" "snowflake" {
 #     password = var.postgres_password
 #   }
 # }
+
+# The credentials are kept in AWS Secrets Manager and fetched by DeltaStream, only the secret ARN is passed
+resource "deltastream_store" "kafka_with_secret" {
+  name          = "kafka_with_secret_${random_id.suffix.hex}"
+  access_region = "AWS us-west-2"
+  kafka = {
+    uris                   = var.kafka_url
+    sasl_hash_function     = "SHA512"
+    credentials_secret_arn = var.kafka_credentials_secret_arn
+  }
+}
+
+# Rotating the credentials creates the replacement store under a new name before the old one is dropped
+resource "deltastream_store" "kafka_rotated" {
+  name_prefix   = "kafka_"
+  access_region = "AWS us-west-2"
+  kafka = {
+    uris               = var.kafka_url
+    sasl_hash_function = "PLAIN"
+    sasl_username      = var.kafka_sasl_username
+    sasl_password      = var.kafka_sasl_password
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+# kafka_client carries the broker settings in the form the Mongey/kafka provider expects:
+#
+# provider "kafka" {
+#   bootstrap_servers = deltastream_store.kafka_with_sasl.kafka_client.bootstrap_servers
+#   sasl_mechanism    = deltastream_store.kafka_with_sasl.kafka_client.sasl_mechanism
+#   tls_enabled       = deltastream_store.kafka_with_sasl.kafka_client.tls_enabled
+#   skip_tls_verify   = deltastream_store.kafka_with_sasl.kafka_client.skip_tls_verify
+#   sasl_username     = var.kafka_sasl_username
+#   sasl_password     = var.kafka_sasl_password
+# }
+output "kafka_bootstrap_servers" {
+  value = deltastream_store.kafka_with_sasl.kafka_client.bootstrap_servers
+}
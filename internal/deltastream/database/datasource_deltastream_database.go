@@ -11,8 +11,10 @@ import (
 
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -41,10 +43,51 @@ func (d *DatabaseDataSource) Configure(ctx context.Context, req datasource.Confi
 	d.cfg = cfg
 }
 
-type DatabaseDatasourceData = DatabaseResourceData
+type DatabaseDatasourceData struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Owner      types.String `tfsdk:"owner"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+	Namespaces types.List   `tfsdk:"namespaces"`
+}
+
+type DatabaseNamespaceData struct {
+	Name               types.String `tfsdk:"name"`
+	RelationCount      types.Int64  `tfsdk:"relation_count"`
+	RelationTypeCounts types.Map    `tfsdk:"relation_type_counts"`
+}
+
+func (DatabaseNamespaceData) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":                 types.StringType,
+		"relation_count":       types.Int64Type,
+		"relation_type_counts": types.MapType{ElemType: types.Int64Type},
+	}
+}
 
 func (d *DatabaseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = getDatabaseSchema()
+	resp.Schema.Attributes["namespaces"] = schema.ListNestedAttribute{
+		Description: "Namespaces (schemas) in the Database",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Description: "Name of the Schema",
+					Computed:    true,
+				},
+				"relation_count": schema.Int64Attribute{
+					Description: "Number of relations in the Schema",
+					Computed:    true,
+				},
+				"relation_type_counts": schema.MapAttribute{
+					Description: "Number of relations in the Schema by relation type",
+					ElementType: types.Int64Type,
+					Computed:    true,
+				},
+			},
+		},
+	}
 }
 
 func getDatabaseSchema() schema.Schema {
@@ -52,6 +95,10 @@ func getDatabaseSchema() schema.Schema {
 		MarkdownDescription: "Database resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Database",
+				Computed:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Name of the Database",
 				Required:    true,
@@ -106,8 +153,69 @@ func (d *DatabaseDataSource) Read(ctx context.Context, req datasource.ReadReques
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read database", err)
 		return
 	}
+	database.ID = util.ResourceID(d.cfg.Organization, "database", database.Name.ValueString())
 	database.Owner = types.StringValue(owner)
-	database.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
+	database.CreatedAt = util.TimestampValue(createdAt)
+
+	namespaces, err := listNamespaces(ctx, conn, database.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list database namespaces", err)
+		return
+	}
+	var dg diag.Diagnostics
+	database.Namespaces, dg = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: DatabaseNamespaceData{}.AttributeTypes()}, namespaces)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &database)...)
 }
+
+// listNamespaces returns the schemas of a database along with the number of relations in each of them.
+func listNamespaces(ctx context.Context, conn *sql.Conn, databaseName string) ([]DatabaseNamespaceData, error) {
+	names := []string{}
+	counts := map[string]map[string]int64{}
+	if err := util.QueryRows(ctx, conn, fmt.Sprintf(`LIST SCHEMAS IN DATABASE "%s";`, databaseName), func(rows *sql.Rows) error {
+		var discard any
+		var name string
+		if err := rows.Scan(&name, &discard, &discard, &discard); err != nil {
+			return err
+		}
+		names = append(names, name)
+		counts[name] = map[string]int64{}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := util.QueryRows(ctx, conn, fmt.Sprintf(`SELECT schema_name, relation_type FROM deltastream.sys."relations" WHERE database_name = '%s';`, databaseName), func(rows *sql.Rows) error {
+		var schemaName string
+		var kind string
+		if err := rows.Scan(&schemaName, &kind); err != nil {
+			return err
+		}
+		if _, ok := counts[schemaName]; ok {
+			counts[schemaName][kind]++
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]DatabaseNamespaceData, 0, len(names))
+	for _, name := range names {
+		total := int64(0)
+		typeCounts := map[string]attr.Value{}
+		for kind, n := range counts[name] {
+			total += n
+			typeCounts[kind] = types.Int64Value(n)
+		}
+		namespaces = append(namespaces, DatabaseNamespaceData{
+			Name:               types.StringValue(name),
+			RelationCount:      types.Int64Value(total),
+			RelationTypeCounts: types.MapValueMust(types.Int64Type, typeCounts),
+		})
+	}
+	return namespaces, nil
+}
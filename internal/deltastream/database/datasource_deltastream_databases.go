@@ -5,6 +5,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -55,6 +56,7 @@ func (d *DatabasesDataSource) Schema(ctx context.Context, req datasource.SchemaR
 			},
 		},
 	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["items"].(schema.ListNestedAttribute), "name")
 }
 
 func (d *DatabasesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -63,6 +65,8 @@ func (d *DatabasesDataSource) Metadata(ctx context.Context, req datasource.Metad
 
 type DatabasesDatasourceData struct {
 	Items types.List `tfsdk:"items"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
 }
 
 func (d *DatabasesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -80,32 +84,31 @@ func (d *DatabasesDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, `SELECT name, "owner", created_at FROM deltastream.sys."databases";`)
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list databases", err)
-		return
-	}
-	defer rows.Close()
-
-	items := []DatabaseDatasourceData{}
-	for rows.Next() {
+	items := []DatabaseResourceData{}
+	if err := util.QueryRows(ctx, conn, `SELECT name, "owner", created_at FROM deltastream.sys."databases";`, func(rows *sql.Rows) error {
 		var name string
 		var owner string
 		var createdAt time.Time
 		if err := rows.Scan(&name, &owner, &createdAt); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read database", err)
-			return
+			return err
 		}
-		items = append(items, DatabaseDatasourceData{
+		items = append(items, DatabaseResourceData{
+			ID:        util.ResourceID(d.cfg.Organization, "database", name),
 			Name:      types.StringValue(name),
 			Owner:     types.StringValue(owner),
-			CreatedAt: types.StringValue(createdAt.Format(time.RFC3339)),
+			CreatedAt: util.TimestampValue(createdAt),
 		})
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list databases", err)
+		return
 	}
 
 	var dg diag.Diagnostics
 	databases.Items, dg = types.ListValueFrom(ctx, databases.Items.ElementType(ctx), items)
 	resp.Diagnostics.Append(dg...)
+	databases.ItemsByName, dg = util.ItemsByName(ctx, databases.Items, "name")
+	resp.Diagnostics.Append(dg...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &databases)...)
 }
@@ -12,8 +12,11 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sethvargo/go-retry"
@@ -25,6 +28,8 @@ import (
 
 var _ resource.Resource = &DatabaseResource{}
 var _ resource.ResourceWithConfigure = &DatabaseResource{}
+var _ resource.ResourceWithModifyPlan = &DatabaseResource{}
+var _ resource.ResourceWithImportState = &DatabaseResource{}
 
 func NewDatabaseResource() resource.Resource {
 	return &DatabaseResource{}
@@ -35,9 +40,15 @@ type DatabaseResource struct {
 }
 
 type DatabaseResourceData struct {
+	ID        types.String `tfsdk:"id"`
 	Name      types.String `tfsdk:"name"`
 	Owner     types.String `tfsdk:"owner"`
 	CreatedAt types.String `tfsdk:"created_at"`
+
+	Tags    types.Map `tfsdk:"tags"`
+	TagsAll types.Map `tfsdk:"tags_all"`
+
+	StatementID types.String `tfsdk:"statement_id"`
 }
 
 func (d *DatabaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -45,6 +56,13 @@ func (d *DatabaseResource) Schema(ctx context.Context, req resource.SchemaReques
 		MarkdownDescription: "Database resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Database",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
 				Description: "Name of the Database",
 				Required:    true,
@@ -60,6 +78,24 @@ func (d *DatabaseResource) Schema(ctx context.Context, req resource.SchemaReques
 				Description: "Creation date of the Database",
 				Computed:    true,
 			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the Database. Tags can be changed without replacing the Database",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  util.TagValidators,
+			},
+			"tags_all": schema.MapAttribute{
+				Description: "Tags of the Database merged with the default_tags of the provider",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the Database, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -83,7 +119,22 @@ func (d *DatabaseResource) Metadata(ctx context.Context, req resource.MetadataRe
 	resp.TypeName = req.ProviderTypeName + "_database"
 }
 
-const createStatement = `CREATE DATABASE "{{.Name}}";`
+func (d *DatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (d *DatabaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultOwner(ctx, "database", req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ValidateOwner(ctx, resp.Plan, req.State)...)
+	resp.Diagnostics.Append(d.cfg.ValidateIdentifierCase(ctx, resp.Plan, req.State, "name")...)
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultTags(ctx, &resp.Plan)...)
+}
+
+const createStatement = `CREATE DATABASE "{{.Name}}"{{if .Tags}} WITH ({{.Tags}}){{end}};`
 
 // Create implements resource.Resource.
 func (d *DatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -107,14 +158,31 @@ func (d *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	defer conn.Close()
 
+	tags, dg := util.TagsProperty(ctx, database.TagsAll)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	b := bytes.NewBuffer(nil)
 	template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
-		"Name": database.Name.ValueString(),
+		"Name": d.cfg.ObjectName(database.Name.ValueString()),
+		"Tags": tags,
 	})
-	if _, err := conn.ExecContext(ctx, b.String()); err != nil {
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "database", database.Name.ValueString())...)
+		return
+	}
+	createCtx, statement := util.WithStatementRecorder(ctx)
+	// databases created in parallel contend on the backend like the schemas created in one database
+	if err := util.RetryCreateOnConflict(createCtx, "database", func(ctx context.Context) error {
+		_, err := conn.ExecContext(ctx, b.String())
+		return err
+	}); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create database", err)
 		return
 	}
+	database.StatementID = statement.ID()
 
 	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
 		database, err = d.updateComputed(ctx, conn, database)
@@ -127,7 +195,7 @@ func (d *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 		}
 		return nil
 	}); err != nil {
-		if _, derr := conn.ExecContext(ctx, `DROP DATABASE "`+database.Name.ValueString()+`";`); derr != nil {
+		if _, derr := conn.ExecContext(ctx, `DROP DATABASE "`+d.cfg.ObjectName(database.Name.ValueString())+`";`); derr != nil {
 			tflog.Error(ctx, "failed to clean up database", map[string]any{
 				"name":  database.Name.ValueString(),
 				"error": derr.Error(),
@@ -138,11 +206,12 @@ func (d *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 	tflog.Info(ctx, "Database created", map[string]any{"name": database.Name.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_database", database.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, database)...)
 }
 
 func (d *DatabaseResource) updateComputed(ctx context.Context, conn *sql.Conn, db DatabaseResourceData) (DatabaseResourceData, error) {
-	row := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT "owner", created_at FROM deltastream.sys."databases" WHERE name = '%s';`, db.Name.ValueString()))
+	row := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT "owner", created_at FROM deltastream.sys."databases" WHERE name = '%s';`, d.cfg.ObjectName(db.Name.ValueString())))
 	if err := row.Err(); err != nil {
 		return db, err
 	}
@@ -151,12 +220,13 @@ func (d *DatabaseResource) updateComputed(ctx context.Context, conn *sql.Conn, d
 	var createdAt time.Time
 	if err := row.Scan(&owner, &createdAt); err != nil {
 		if err == sql.ErrNoRows {
-			return DatabaseResourceData{}, &gods.ErrSQLError{SQLCode: gods.SqlStateInvalidDatabase}
+			return DatabaseResourceData{}, gods.ErrSQLError{SQLCode: gods.SqlStateInvalidDatabase}
 		}
 		return db, err
 	}
+	db.ID = util.ResourceID(d.cfg.Organization, "database", db.Name.ValueString())
 	db.Owner = types.StringValue(owner)
-	db.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
+	db.CreatedAt = util.TimestampValue(createdAt)
 	return db, nil
 }
 
@@ -168,6 +238,11 @@ func (d *DatabaseResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "database", database.Name.ValueString())...)
+		return
+	}
+
 	roleName := d.cfg.Role
 	if !database.Owner.IsNull() && !database.Owner.IsUnknown() {
 		roleName = database.Owner.ValueString()
@@ -180,23 +255,69 @@ func (d *DatabaseResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 	defer conn.Close()
 
-	if err = retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) error {
-		if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP DATABASE "%s";`, database.Name.ValueString())); err != nil {
-			var sqlErr gods.ErrSQLError
-			if !errors.As(err, &sqlErr) || sqlErr.SQLCode != gods.SqlStateInvalidDatabase {
-				return retry.RetryableError(err)
-			}
-		}
-		return nil
-	}); err != nil {
+	if err := d.drop(ctx, conn, database); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to delete database", err)
 		return
 	}
 	tflog.Info(ctx, "Database deleted", map[string]any{"name": database.Name.ValueString()})
 }
 
+// drop drops the database and waits until it is no longer listed, so that creating it again under the same name right
+// after succeeds.
+func (d *DatabaseResource) drop(ctx context.Context, conn *sql.Conn, database DatabaseResourceData) error {
+	return util.DropAndWait(ctx, conn, "database", fmt.Sprintf(`DROP DATABASE "%s";`, d.cfg.ObjectName(database.Name.ValueString())), func(ctx context.Context) error {
+		_, err := d.updateComputed(ctx, conn, database)
+		return err
+	})
+}
+
+// Update only supports changing the tags of the database.
 func (d *DatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("database updates not supported"))
+	var plan, database DatabaseResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &database)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Name.Equal(database.Name) || (!plan.Owner.IsUnknown() && !plan.Owner.Equal(database.Owner)) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("database updates not supported"))
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "database", database.Name.ValueString())...)
+		return
+	}
+
+	roleName := d.cfg.Role
+	if !database.Owner.IsNull() && !database.Owner.IsUnknown() {
+		roleName = database.Owner.ValueString()
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if !plan.TagsAll.Equal(database.TagsAll) {
+		stmt, dg := util.SetTagsStatement(ctx, "DATABASE", `"`+d.cfg.ObjectName(database.Name.ValueString())+`"`, plan.TagsAll)
+		resp.Diagnostics.Append(dg...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set database tags", err)
+			return
+		}
+		resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_database", database.Name.ValueString())...)
+	}
+
+	database.Tags = plan.Tags
+	database.TagsAll = plan.TagsAll
+	resp.Diagnostics.Append(resp.State.Set(ctx, database)...)
 }
 
 func (d *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -220,16 +341,17 @@ func (d *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 	defer conn.Close()
 
+	recorded := database
 	database, err = d.updateComputed(ctx, conn, database)
 	if err != nil {
-		var godsErr gods.ErrSQLError
-		if errors.As(err, &godsErr) && godsErr.SQLCode == gods.SqlStateInvalidDatabase {
-			resp.State.RemoveResource(ctx)
+		if util.RemoveIfNotFound(ctx, &resp.State, "database", err) {
 			return
 		}
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read database state", err)
 		return
 	}
+	resp.Diagnostics.Append(d.cfg.ReportDrift("database", database.Name.ValueString(), path.Root("owner"), recorded.Owner, database.Owner)...)
+	resp.Diagnostics.Append(d.cfg.ReadTags(ctx, conn, fmt.Sprintf(`DESCRIBE DATABASE "%s";`, d.cfg.ObjectName(database.Name.ValueString())), &database.Tags, &database.TagsAll)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, database)...)
 }
@@ -0,0 +1,43 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+)
+
+const testOrganization = "00000000-0000-0000-0000-000000000001"
+
+func TestDatabaseDropWaitsUntilGone(t *testing.T) {
+	ctx := context.Background()
+	server, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DROP DATABASE "analytics";$`,
+	}, {
+		Statement: `^SELECT "owner", created_at FROM deltastream.sys."databases" WHERE name = 'analytics';$`,
+		Columns: []mockserver.Column{
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+		},
+	}})
+
+	d := &DatabaseResource{cfg: &config.DeltaStreamProviderCfg{Organization: testOrganization}}
+	if err := d.drop(ctx, conn, DatabaseResourceData{Name: types.StringValue("analytics")}); err != nil {
+		t.Fatalf("drop() error = %v", err)
+	}
+
+	want := []string{
+		`DROP DATABASE "analytics";`,
+		`SELECT "owner", created_at FROM deltastream.sys."databases" WHERE name = 'analytics';`,
+	}
+	if got := server.Statements(); !reflect.DeepEqual(got[len(got)-2:], want) {
+		t.Errorf("expected statements %v, got %v", want, got)
+	}
+}
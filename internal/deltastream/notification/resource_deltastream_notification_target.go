@@ -0,0 +1,443 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ resource.Resource = &NotificationTargetResource{}
+var _ resource.ResourceWithConfigure = &NotificationTargetResource{}
+var _ resource.ResourceWithModifyPlan = &NotificationTargetResource{}
+
+func NewNotificationTargetResource() resource.Resource {
+	return &NotificationTargetResource{}
+}
+
+type NotificationTargetResource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+type NotificationTargetResourceData struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Slack     types.Object `tfsdk:"slack"`
+	PagerDuty types.Object `tfsdk:"pagerduty"`
+	Webhook   types.Object `tfsdk:"webhook"`
+	Owner     types.String `tfsdk:"owner"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+
+	StatementID types.String `tfsdk:"statement_id"`
+}
+
+type SlackTarget struct {
+	WebhookUrl types.String `tfsdk:"webhook_url"`
+	Channel    types.String `tfsdk:"channel"`
+}
+
+type PagerDutyTarget struct {
+	RoutingKey types.String `tfsdk:"routing_key"`
+	Severity   types.String `tfsdk:"severity"`
+}
+
+type WebhookTarget struct {
+	Url     types.String `tfsdk:"url"`
+	Headers types.Map    `tfsdk:"headers"`
+}
+
+// pagerDutySeverities are the severities PagerDuty events can be raised with.
+var pagerDutySeverities = []string{"critical", "error", "warning", "info"}
+
+var httpsUrl = regexp.MustCompile(`^https://[^\s]+$`)
+
+// headerName matches the token HTTP header names are made of.
+var headerName = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+func (d *NotificationTargetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Notification target resource. DeltaStream notifies the targets set in the `notification_targets` of a query when the query fails.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the notification target",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the notification target",
+				Required:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the notification target",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"slack": schema.SingleNestedAttribute{
+				Description: "Slack incoming webhook notified on query failures",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"webhook_url": schema.StringAttribute{
+						Description: "URL of the Slack incoming webhook",
+						Required:    true,
+						Sensitive:   true,
+					},
+					"channel": schema.StringAttribute{
+						Description: "Channel to post to, overriding the channel of the webhook",
+						Optional:    true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ExactlyOneOf(path.MatchRoot("pagerduty"), path.MatchRoot("webhook")),
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"pagerduty": schema.SingleNestedAttribute{
+				Description: "PagerDuty service notified on query failures through the Events API",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"routing_key": schema.StringAttribute{
+						Description: "Integration key of the PagerDuty service",
+						Required:    true,
+						Sensitive:   true,
+					},
+					"severity": schema.StringAttribute{
+						Description: "Severity of the events raised. One of " + strings.Join(pagerDutySeverities, ", ") + ". Defaults to the server default",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(pagerDutySeverities...),
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"webhook": schema.SingleNestedAttribute{
+				Description: "HTTP endpoint a JSON description of the failure is posted to",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Description: "URL of the endpoint",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(httpsUrl, "must be an https URL"),
+						},
+					},
+					"headers": schema.MapAttribute{
+						Description: "HTTP headers sent with every notification, such as an authorization header",
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+						Validators: []validator.Map{
+							mapvalidator.KeysAre(stringvalidator.RegexMatches(headerName, "must be an HTTP header name")),
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Description: "Owning role of the notification target",
+				Optional:    true,
+				Computed:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Creation date of the notification target",
+				Computed:    true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "Last update date of the notification target",
+				Computed:    true,
+			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the notification target, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (d *NotificationTargetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+func (d *NotificationTargetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_target"
+}
+
+func (d *NotificationTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultOwner(ctx, "notification_target", req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ValidateOwner(ctx, resp.Plan, req.State)...)
+	resp.Diagnostics.Append(d.cfg.ValidateIdentifierCase(ctx, resp.Plan, req.State, "name")...)
+}
+
+// createStatement returns the statement creating the notification target and its type.
+func createStatement(ctx context.Context, target NotificationTargetResourceData) (string, string, diag.Diagnostics) {
+	var dg diag.Diagnostics
+	var kind string
+	props := []string{}
+	quote := func(v string) string { return "'" + strings.ReplaceAll(v, "'", "''") + "'" }
+
+	switch {
+	case !target.Slack.IsNull() && !target.Slack.IsUnknown():
+		var slack SlackTarget
+		dg.Append(target.Slack.As(ctx, &slack, basetypes.ObjectAsOptions{})...)
+		kind = "SLACK"
+		props = append(props, "'slack.webhook_url' = "+quote(slack.WebhookUrl.ValueString()))
+		if !slack.Channel.IsNull() && !slack.Channel.IsUnknown() {
+			props = append(props, "'slack.channel' = "+quote(slack.Channel.ValueString()))
+		}
+	case !target.PagerDuty.IsNull() && !target.PagerDuty.IsUnknown():
+		var pagerDuty PagerDutyTarget
+		dg.Append(target.PagerDuty.As(ctx, &pagerDuty, basetypes.ObjectAsOptions{})...)
+		kind = "PAGERDUTY"
+		props = append(props, "'pagerduty.routing_key' = "+quote(pagerDuty.RoutingKey.ValueString()))
+		if !pagerDuty.Severity.IsNull() && !pagerDuty.Severity.IsUnknown() {
+			props = append(props, "'pagerduty.severity' = "+quote(pagerDuty.Severity.ValueString()))
+		}
+	case !target.Webhook.IsNull() && !target.Webhook.IsUnknown():
+		var webhook WebhookTarget
+		dg.Append(target.Webhook.As(ctx, &webhook, basetypes.ObjectAsOptions{})...)
+		kind = "WEBHOOK"
+		props = append(props, "'webhook.url' = "+quote(webhook.Url.ValueString()))
+		headers := map[string]string{}
+		if !webhook.Headers.IsNull() && !webhook.Headers.IsUnknown() {
+			dg.Append(webhook.Headers.ElementsAs(ctx, &headers, false)...)
+		}
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			props = append(props, fmt.Sprintf("'webhook.header.%s' = %s", strings.ToLower(name), quote(headers[name])))
+		}
+	default:
+		dg.AddError("invalid notification target", "exactly one of slack, pagerduty or webhook must be set")
+	}
+	if dg.HasError() {
+		return "", "", dg
+	}
+
+	return fmt.Sprintf(`CREATE NOTIFICATION TARGET "%s" WITH ('type' = %s, %s);`, target.Name.ValueString(), kind, strings.Join(props, ", ")), kind, dg
+}
+
+// Create implements resource.Resource.
+func (d *NotificationTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var target NotificationTargetResourceData
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &target)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleName := d.cfg.Role
+	if !target.Owner.IsNull() && !target.Owner.IsUnknown() {
+		roleName = target.Owner.ValueString()
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	// the target is created under the name the provider looks it up with
+	named := target
+	named.Name = types.StringValue(d.cfg.ObjectName(target.Name.ValueString()))
+	stmt, kind, dg := createStatement(ctx, named)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	target.Type = types.StringValue(strings.ToLower(kind))
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "notification target", target.Name.ValueString())...)
+		return
+	}
+	createCtx, statement := util.WithStatementRecorder(ctx)
+	if _, err := conn.ExecContext(createCtx, stmt); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create notification target", err)
+		return
+	}
+	target.StatementID = statement.ID()
+
+	target, err = d.updateComputed(ctx, conn, target)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read notification target", err)
+		return
+	}
+
+	tflog.Info(ctx, "Notification target created", map[string]any{"name": target.Name.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_notification_target", target.Name.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, target)...)
+}
+
+// updateComputed reads the computed attributes of the notification target from LIST NOTIFICATION TARGETS. It returns
+// sql.ErrNoRows when the notification target does not exist.
+func (d *NotificationTargetResource) updateComputed(ctx context.Context, conn *sql.Conn, target NotificationTargetResourceData) (NotificationTargetResourceData, error) {
+	found := false
+	if err := util.QueryRows(ctx, conn, `LIST NOTIFICATION TARGETS;`, func(rows *sql.Rows) error {
+		var name string
+		var kind string
+		var owner string
+		var createdAt time.Time
+		var updatedAt time.Time
+		if err := rows.Scan(&name, &kind, &owner, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+		if name != d.cfg.ObjectName(target.Name.ValueString()) {
+			return nil
+		}
+		found = true
+		target.ID = util.ResourceID(d.cfg.Organization, "notification_target", name)
+		target.Type = types.StringValue(strings.ToLower(kind))
+		target.Owner = types.StringValue(owner)
+		target.CreatedAt = util.TimestampValue(createdAt)
+		target.UpdatedAt = util.TimestampValue(updatedAt)
+		return util.ErrStopRows
+	}); err != nil {
+		return target, err
+	}
+	if !found {
+		return target, sql.ErrNoRows
+	}
+	return target, nil
+}
+
+func (d *NotificationTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var target NotificationTargetResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &target)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "notification target", target.Name.ValueString())...)
+		return
+	}
+
+	roleName := d.cfg.Role
+	if !target.Owner.IsNull() && !target.Owner.IsUnknown() {
+		roleName = target.Owner.ValueString()
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	// DeltaStream has no dedicated SQL state for missing notification targets, look the target up before dropping it
+	if _, err := d.updateComputed(ctx, conn, target); err != nil {
+		if util.IsNotFound("notification_target", err) {
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read notification target", err)
+		return
+	}
+
+	if err := util.DropAndWait(ctx, conn, "notification_target", fmt.Sprintf(`DROP NOTIFICATION TARGET "%s";`, d.cfg.ObjectName(target.Name.ValueString())), func(ctx context.Context) error {
+		_, err := d.updateComputed(ctx, conn, target)
+		return err
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to delete notification target", err)
+		return
+	}
+	tflog.Info(ctx, "Notification target deleted", map[string]any{"name": target.Name.ValueString()})
+}
+
+func (d *NotificationTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("notification target updates not supported"))
+}
+
+func (d *NotificationTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var target NotificationTargetResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &target)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	target, err = d.updateComputed(ctx, conn, target)
+	if err != nil {
+		if util.RemoveIfNotFound(ctx, &resp.State, "notification_target", err) {
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, target)...)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCreateStatement(t *testing.T) {
+	ctx := context.Background()
+	slackType := map[string]attr.Type{"webhook_url": types.StringType, "channel": types.StringType}
+	pagerDutyType := map[string]attr.Type{"routing_key": types.StringType, "severity": types.StringType}
+	webhookType := map[string]attr.Type{"url": types.StringType, "headers": types.MapType{ElemType: types.StringType}}
+
+	target := func(slack, pagerDuty, webhook types.Object) NotificationTargetResourceData {
+		return NotificationTargetResourceData{Name: types.StringValue("alerts"), Slack: slack, PagerDuty: pagerDuty, Webhook: webhook}
+	}
+	tests := []struct {
+		name   string
+		target NotificationTargetResourceData
+		want   string
+		kind   string
+	}{
+		{
+			name: "slack",
+			target: target(types.ObjectValueMust(slackType, map[string]attr.Value{
+				"webhook_url": types.StringValue("https://hooks.slack.com/services/T0/B0/x'y"),
+				"channel":     types.StringValue("#alerts"),
+			}), types.ObjectNull(pagerDutyType), types.ObjectNull(webhookType)),
+			want: `CREATE NOTIFICATION TARGET "alerts" WITH ('type' = SLACK, 'slack.webhook_url' = 'https://hooks.slack.com/services/T0/B0/x''y', 'slack.channel' = '#alerts');`,
+			kind: "SLACK",
+		},
+		{
+			name: "pagerduty without severity",
+			target: target(types.ObjectNull(slackType), types.ObjectValueMust(pagerDutyType, map[string]attr.Value{
+				"routing_key": types.StringValue("key"),
+				"severity":    types.StringNull(),
+			}), types.ObjectNull(webhookType)),
+			want: `CREATE NOTIFICATION TARGET "alerts" WITH ('type' = PAGERDUTY, 'pagerduty.routing_key' = 'key');`,
+			kind: "PAGERDUTY",
+		},
+		{
+			name: "webhook headers sorted",
+			target: target(types.ObjectNull(slackType), types.ObjectNull(pagerDutyType), types.ObjectValueMust(webhookType, map[string]attr.Value{
+				"url": types.StringValue("https://example.com/alerts"),
+				"headers": types.MapValueMust(types.StringType, map[string]attr.Value{
+					"X-Team":        types.StringValue("data"),
+					"Authorization": types.StringValue("Bearer t"),
+				}),
+			})),
+			want: `CREATE NOTIFICATION TARGET "alerts" WITH ('type' = WEBHOOK, 'webhook.url' = 'https://example.com/alerts', 'webhook.header.authorization' = 'Bearer t', 'webhook.header.x-team' = 'data');`,
+			kind: "WEBHOOK",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, kind, dg := createStatement(ctx, tt.target)
+			if dg.HasError() {
+				t.Fatalf("createStatement() diagnostics: %v", dg)
+			}
+			if got != tt.want {
+				t.Errorf("createStatement() = %s, want %s", got, tt.want)
+			}
+			if kind != tt.kind {
+				t.Errorf("createStatement() kind = %s, want %s", kind, tt.kind)
+			}
+		})
+	}
+
+	if _, _, dg := createStatement(ctx, target(types.ObjectNull(slackType), types.ObjectNull(pagerDutyType), types.ObjectNull(webhookType))); !dg.HasError() {
+		t.Error("createStatement() without a destination succeeded")
+	}
+}
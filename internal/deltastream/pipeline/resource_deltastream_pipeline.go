@@ -0,0 +1,580 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sethvargo/go-retry"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ resource.Resource = &PipelineResource{}
+var _ resource.ResourceWithConfigure = &PipelineResource{}
+var _ resource.ResourceWithModifyPlan = &PipelineResource{}
+
+func NewPipelineResource() resource.Resource {
+	return &PipelineResource{}
+}
+
+type PipelineResource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+type PipelineResourceData struct {
+	ID       types.String `tfsdk:"id"`
+	Database types.String `tfsdk:"database"`
+	Schema   types.String `tfsdk:"schema"`
+	Store    types.String `tfsdk:"store"`
+	Owner    types.String `tfsdk:"owner"`
+
+	SourceSql types.String `tfsdk:"source_sql"`
+	SinkSql   types.String `tfsdk:"sink_sql"`
+	QuerySql  types.String `tfsdk:"query_sql"`
+
+	SourceRelation types.String `tfsdk:"source_relation_fqn"`
+	SinkRelation   types.String `tfsdk:"sink_relation_fqn"`
+	QueryID        types.String `tfsdk:"query_id"`
+	State          types.String `tfsdk:"state"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+
+	StatementID types.String `tfsdk:"statement_id"`
+}
+
+func (d *PipelineResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Pipeline resource. Creates a source relation, a sink relation and the query between them as a unit. " +
+			"If any stage fails the stages already created are rolled back",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the pipeline",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Name of the Database used to resolve unqualified names",
+				Required:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "Name of the Schema used to resolve unqualified names",
+				Required:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"store": schema.StringAttribute{
+				Description: "Name of the Store used when a statement does not specify one",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Description: "Owning role of the relations and query",
+				Optional:    true,
+				Computed:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_sql": schema.StringAttribute{
+				Description: "SQL statement to create the source relation",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sink_sql": schema.StringAttribute{
+				Description: "SQL statement to create the sink relation",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"query_sql": schema.StringAttribute{
+				Description: "INSERT INTO statement reading from the source relation and writing to the sink relation",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_relation_fqn": schema.StringAttribute{
+				Description: "Fully qualified name of the source relation",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sink_relation_fqn": schema.StringAttribute{
+				Description: "Fully qualified name of the sink relation",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"query_id": schema.StringAttribute{
+				Description: "Query ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description: "State of the query",
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Creation date of the query",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the pipeline query, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (d *PipelineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+func (d *PipelineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline"
+}
+
+func (d *PipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultOwner(ctx, "pipeline", req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ValidateOwner(ctx, resp.Plan, req.State)...)
+}
+
+func describe(ctx context.Context, conn *sql.Conn, statement string, kinds ...string) (util.StatementPlan, error) {
+	kind, plan, err := util.DescribeStatement(ctx, conn, statement)
+	if err != nil {
+		return plan, err
+	}
+	if !util.ContainsAny(kinds, kind) {
+		return plan, fmt.Errorf("invalid statement type: %s", kind)
+	}
+	return plan, nil
+}
+
+func execute(ctx context.Context, conn *sql.Conn, statement string) (string, error) {
+	artifactDDL := util.ArtifactDDL{}
+	if err := conn.QueryRowContext(ctx, statement).Scan(&artifactDDL.Type, &artifactDDL.Name, &artifactDDL.Command, &artifactDDL.Summary); err != nil {
+		return "", err
+	}
+	return artifactDDL.Name, nil
+}
+
+// createRelation plans and executes a CREATE STREAM or CREATE CHANGELOG statement and returns the FQN of the new
+// relation.
+func createRelation(ctx context.Context, conn *sql.Conn, statement string) (string, error) {
+	plan, err := describe(ctx, conn, statement, "CREATE_STREAM", "CREATE_CHANGELOG")
+	if err != nil {
+		return "", err
+	}
+	if plan.Ddl == nil {
+		return "", fmt.Errorf("invalid relation plan")
+	}
+	return execute(ctx, conn, statement)
+}
+
+// rollbackStep undoes a stage of a pipeline that failed to be created. await, when set, waits for the statement to
+// take effect before the earlier stages are undone.
+type rollbackStep struct {
+	statement string
+	await     func() error
+}
+
+func containsRelation(relations []util.RelationPlan, organization, fqn string) bool {
+	for _, rel := range relations {
+		if rel.Fqn == organization+"."+fqn || rel.Fqn == fqn {
+			return true
+		}
+	}
+	return false
+}
+
+// Create implements resource.Resource.
+func (d *PipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var pipeline PipelineResourceData
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &pipeline)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleName := d.cfg.Role
+	if !pipeline.Owner.IsNull() && !pipeline.Owner.IsUnknown() {
+		roleName = pipeline.Owner.ValueString()
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if !pipeline.Store.IsNull() {
+		if err := util.WaitForStoreReady(ctx, conn, pipeline.Store.ValueString()); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "store not ready", err)
+			return
+		}
+	}
+
+	if err := util.SetSqlContext(ctx, conn, pipeline.Database.ValueStringPointer(), pipeline.Schema.ValueStringPointer(), pipeline.Store.ValueStringPointer()); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set sql context", err)
+		return
+	}
+
+	// the query cannot be planned before its source and sink exist, only the relations are planned in a dry run
+	if d.cfg.DryRun {
+		fqns := []string{}
+		for _, statement := range []string{pipeline.SourceSql.ValueString(), pipeline.SinkSql.ValueString()} {
+			plan, err := describe(ctx, conn, statement, "CREATE_STREAM", "CREATE_CHANGELOG")
+			if err != nil {
+				resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to plan relation", err)
+				return
+			}
+			if plan.Ddl == nil {
+				resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("invalid relation plan"))
+				return
+			}
+			fqns = append(fqns, plan.Ddl.Fqn)
+		}
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "pipeline", strings.Join(fqns, " -> "))...)
+		return
+	}
+
+	// rollback undoes the stages created so far, in reverse order
+	rollback := []rollbackStep{}
+	defer func() {
+		if !resp.Diagnostics.HasError() {
+			return
+		}
+		for i := len(rollback) - 1; i >= 0; i-- {
+			step := rollback[i]
+			_, derr := conn.ExecContext(ctx, step.statement)
+			if derr == nil && step.await != nil {
+				derr = step.await()
+			}
+			if derr != nil {
+				tflog.Error(ctx, "failed to roll back pipeline stage", map[string]any{
+					"statement": step.statement,
+					"error":     derr.Error(),
+				})
+			}
+		}
+	}()
+
+	sourceFqn, err := createRelation(ctx, conn, pipeline.SourceSql.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create source relation", err)
+		return
+	}
+	rollback = append(rollback, rollbackStep{statement: fmt.Sprintf(`DROP RELATION %s;`, sourceFqn)})
+	pipeline.SourceRelation = types.StringValue(sourceFqn)
+
+	sinkFqn, err := createRelation(ctx, conn, pipeline.SinkSql.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create sink relation", err)
+		return
+	}
+	rollback = append(rollback, rollbackStep{statement: fmt.Sprintf(`DROP RELATION %s;`, sinkFqn)})
+	pipeline.SinkRelation = types.StringValue(sinkFqn)
+
+	queryPlan, err := describe(ctx, conn, pipeline.QuerySql.ValueString(), "INSERT_INTO")
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to plan query", err)
+		return
+	}
+	if !containsRelation(queryPlan.Sources, d.cfg.Organization, sourceFqn) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("query does not read from source relation %s", sourceFqn))
+		return
+	}
+	if !containsRelation(queryPlan.AllSinks(), d.cfg.Organization, sinkFqn) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("query does not write to sink relation %s", sinkFqn))
+		return
+	}
+
+	launchCtx, statement := util.WithStatementRecorder(ctx)
+	queryID, err := execute(launchCtx, conn, pipeline.QuerySql.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to launch query", err)
+		return
+	}
+	pipeline.StatementID = statement.ID()
+	pipeline.QueryID = types.StringValue(queryID)
+	// the relations cannot be dropped while the query still reads from or writes to them
+	launched := pipeline
+	rollback = append(rollback, rollbackStep{
+		statement: fmt.Sprintf(`TERMINATE QUERY %s;`, queryID),
+		await: func() error {
+			_, err := d.awaitStopped(ctx, conn, launched)
+			return err
+		},
+	})
+
+	// single wait loop for all stages
+	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*10, retry.NewConstant(time.Second*5)), func(ctx context.Context) (err error) {
+		for _, fqn := range []string{sourceFqn, sinkFqn} {
+			state, err := relationState(ctx, conn, fqn)
+			if err != nil {
+				return retry.RetryableError(err)
+			}
+			if state != "created" {
+				return retry.RetryableError(fmt.Errorf("relation %s not yet created", fqn))
+			}
+		}
+
+		pipeline, err = d.updateComputed(ctx, conn, pipeline)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		switch pipeline.State.ValueString() {
+		case "running":
+			return nil
+		case "errored":
+			return fmt.Errorf("query errored while starting")
+		}
+		return retry.RetryableError(fmt.Errorf("query not yet running"))
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "pipeline failed to start", err)
+		return
+	}
+
+	tflog.Info(ctx, "Pipeline created", map[string]any{
+		"source":   sourceFqn,
+		"sink":     sinkFqn,
+		"query_id": queryID,
+	})
+	// a pipeline is named after the relation its query writes to
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_pipeline", sinkFqn, queryID)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, pipeline)...)
+}
+
+func relationState(ctx context.Context, conn *sql.Conn, fqn string) (string, error) {
+	row := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT "state" FROM deltastream.sys."relations" WHERE database_name || '.' || schema_name || '.' || name = '%s';`, fqn))
+	if err := row.Err(); err != nil {
+		return "", err
+	}
+
+	var state string
+	if err := row.Scan(&state); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", gods.ErrSQLError{SQLCode: gods.SqlStateInvalidRelation}
+		}
+		return "", err
+	}
+	return state, nil
+}
+
+func (d *PipelineResource) updateComputed(ctx context.Context, conn *sql.Conn, pipeline PipelineResourceData) (PipelineResourceData, error) {
+	found := false
+	if err := util.QueryRows(ctx, conn, `LIST QUERIES WITH ('all');`, func(rows *sql.Rows) error {
+		var (
+			id            string
+			name          string
+			version       int64
+			intendedState string
+			actualState   string
+			query         string
+			owner         string
+			createdAt     time.Time
+			updatedAt     time.Time
+		)
+
+		if err := rows.Scan(&id, &name, &version, &intendedState, &actualState, &query, &owner, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+		if id != pipeline.QueryID.ValueString() {
+			return nil
+		}
+		found = true
+		pipeline.ID = util.ResourceID(d.cfg.Organization, "pipeline", id)
+		pipeline.State = types.StringValue(actualState)
+		pipeline.Owner = types.StringValue(owner)
+		pipeline.CreatedAt = util.TimestampValue(createdAt)
+		return util.ErrStopRows
+	}); err != nil {
+		return pipeline, err
+	}
+	if !found {
+		return pipeline, gods.ErrSQLError{SQLCode: gods.SqlStateInvalidQuery}
+	}
+	return pipeline, nil
+}
+
+// awaitStopped waits for the terminated query of a pipeline to stop, a query that is no longer listed is stopped.
+func (d *PipelineResource) awaitStopped(ctx context.Context, conn *sql.Conn, pipeline PipelineResourceData) (PipelineResourceData, error) {
+	err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) error {
+		current, err := d.updateComputed(ctx, conn, pipeline)
+		if err != nil {
+			var sqlErr gods.ErrSQLError
+			if errors.As(err, &sqlErr) && sqlErr.SQLCode == gods.SqlStateInvalidQuery {
+				return nil
+			}
+			return err
+		}
+		pipeline = current
+
+		if pipeline.State.ValueString() == "stopped" {
+			return nil
+		}
+		return retry.RetryableError(fmt.Errorf("query not yet terminated"))
+	})
+	return pipeline, err
+}
+
+func (d *PipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var pipeline PipelineResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &pipeline)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "pipeline", pipeline.ID.ValueString())...)
+		return
+	}
+
+	roleName := d.cfg.Role
+	if !pipeline.Owner.IsNull() && !pipeline.Owner.IsUnknown() {
+		roleName = pipeline.Owner.ValueString()
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`TERMINATE QUERY %s;`, pipeline.QueryID.ValueString())); err != nil {
+		var sqlErr gods.ErrSQLError
+		if !errors.As(err, &sqlErr) || sqlErr.SQLCode != gods.SqlStateInvalidQuery {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to terminate query", err)
+			return
+		}
+	}
+
+	pipeline, err = d.awaitStopped(ctx, conn, pipeline)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to terminate query", err)
+		return
+	}
+
+	// the sink is dropped before the source, reversing creation order
+	for _, fqn := range []string{pipeline.SinkRelation.ValueString(), pipeline.SourceRelation.ValueString()} {
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP RELATION %s;`, fqn)); err != nil {
+			var sqlErr gods.ErrSQLError
+			if !errors.As(err, &sqlErr) || sqlErr.SQLCode != gods.SqlStateInvalidRelation {
+				resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to drop relation", err)
+				return
+			}
+		}
+
+		if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) error {
+			if _, err := relationState(ctx, conn, fqn); err != nil {
+				var sqlErr gods.ErrSQLError
+				if errors.As(err, &sqlErr) && sqlErr.SQLCode == gods.SqlStateInvalidRelation {
+					return nil
+				}
+				return err
+			}
+			return retry.RetryableError(fmt.Errorf("relation %s not yet deleted", fqn))
+		}); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to cleanup relation", err)
+			return
+		}
+	}
+
+	tflog.Info(ctx, "Pipeline deleted", map[string]any{"query_id": pipeline.QueryID.ValueString()})
+}
+
+func (d *PipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("pipeline updates not supported"))
+}
+
+func (d *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var pipeline PipelineResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &pipeline)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleName := d.cfg.Role
+	if !pipeline.Owner.IsNull() && !pipeline.Owner.IsUnknown() {
+		roleName = pipeline.Owner.ValueString()
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	pipeline, err = d.updateComputed(ctx, conn, pipeline)
+	if err != nil {
+		if util.RemoveIfNotFound(ctx, &resp.State, "pipeline", err) {
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, pipeline)...)
+}
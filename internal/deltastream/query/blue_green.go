@@ -0,0 +1,238 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sethvargo/go-retry"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// Strategies for applying changes to the statement of a query.
+const (
+	updateStrategyReplace   = "replace"
+	updateStrategyBlueGreen = "blue_green"
+
+	defaultCatchUpTimeout = time.Minute * 30
+)
+
+type CatchUp struct {
+	MaxLag  types.Int64  `tfsdk:"max_lag"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+var catchUpAttributeTypes = map[string]attr.Type{
+	"max_lag": types.Int64Type,
+	"timeout": types.StringType,
+}
+
+// blueGreen reports whether changes to the statement of the planned query are rolled out by launching the new
+// statement next to the running query instead of replacing it.
+func blueGreen(ctx context.Context, plan tfsdk.Plan) (bool, diag.Diagnostics) {
+	var strategy types.String
+	dg := plan.GetAttribute(ctx, path.Root("update_strategy"), &strategy)
+	return strategy.ValueString() == updateStrategyBlueGreen, dg
+}
+
+// The statement attributes require replacement unless the query is rolled out blue/green.
+var (
+	sqlRequiresReplace = stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		ok, dg := blueGreen(ctx, req.Plan)
+		resp.Diagnostics.Append(dg...)
+		resp.RequiresReplace = !ok
+	}, "Requires replacement unless update_strategy is blue_green", "Requires replacement unless `update_strategy` is `blue_green`")
+	sourcesRequiresReplace = listplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+		ok, dg := blueGreen(ctx, req.Plan)
+		resp.Diagnostics.Append(dg...)
+		resp.RequiresReplace = !ok
+	}, "Requires replacement unless update_strategy is blue_green", "Requires replacement unless `update_strategy` is `blue_green`")
+	pinnedVersionRequiresReplace = int64planmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+		ok, dg := blueGreen(ctx, req.Plan)
+		resp.Diagnostics.Append(dg...)
+		resp.RequiresReplace = !ok
+	}, "Requires replacement unless update_strategy is blue_green", "Requires replacement unless `update_strategy` is `blue_green`")
+)
+
+// statementChanged reports whether the planned query runs a different statement than the current one.
+func statementChanged(planned, current QueryResourceData) bool {
+	return !planned.Sql.Equal(current.Sql) || !planned.SourceRelations.Equal(current.SourceRelations) || !planned.PinnedVersion.Equal(current.PinnedVersion)
+}
+
+// planRollout marks the attributes of the query that change when a new statement is rolled out blue/green unknown.
+// The query name is kept, the new statement is launched as the next version of the query.
+func planRollout(ctx context.Context, plan *tfsdk.Plan, planned, current QueryResourceData) (dg diag.Diagnostics) {
+	unknown := map[string]attr.Value{
+		"id":            types.StringUnknown(),
+		"query_id":      types.StringUnknown(),
+		"query_version": types.Int64Unknown(),
+		"state":         types.StringUnknown(),
+		"created_at":    types.StringUnknown(),
+		"updated_at":    types.StringUnknown(),
+		"statement_id":  types.StringUnknown(),
+		"restart_count": types.Int64Unknown(),
+
+		"resumed_from_query_id": types.StringUnknown(),
+	}
+	// the statement of a pinned version is only known once the version is looked up
+	if !planned.PinnedVersion.Equal(current.PinnedVersion) && !planned.PinnedVersion.IsNull() {
+		unknown["sql"] = types.StringUnknown()
+	}
+	for name, v := range unknown {
+		dg.Append(plan.SetAttribute(ctx, path.Root(name), v)...)
+	}
+	return
+}
+
+// catchUpSettings returns how many records the new query may be behind the old one on each source partition before
+// the old one is retired, and how long to wait for it.
+func catchUpSettings(ctx context.Context, query QueryResourceData) (int64, time.Duration, diag.Diagnostics) {
+	var dg diag.Diagnostics
+	maxLag, timeout := int64(0), defaultCatchUpTimeout
+	if query.CatchUp.IsNull() || query.CatchUp.IsUnknown() {
+		return maxLag, timeout, dg
+	}
+
+	var catchUp CatchUp
+	dg.Append(query.CatchUp.As(ctx, &catchUp, basetypes.ObjectAsOptions{})...)
+	if dg.HasError() {
+		return maxLag, timeout, dg
+	}
+	if !catchUp.MaxLag.IsNull() && !catchUp.MaxLag.IsUnknown() {
+		maxLag = catchUp.MaxLag.ValueInt64()
+	}
+	if !catchUp.Timeout.IsNull() && !catchUp.Timeout.IsUnknown() {
+		d, err := time.ParseDuration(catchUp.Timeout.ValueString())
+		if err != nil {
+			dg.AddError("invalid catch_up timeout", err.Error())
+			return maxLag, timeout, dg
+		}
+		timeout = d
+	}
+	return maxLag, timeout, dg
+}
+
+// offsets returns the committed offset of every source partition, keyed by relation and partition. Partitions that
+// have not committed an offset yet are left out.
+func (p queryPositions) offsets() map[string]int64 {
+	offsets := map[string]int64{}
+	for _, row := range p {
+		offset, err := strconv.ParseInt(row["offset"], 10, 64)
+		if err != nil {
+			continue
+		}
+		offsets[row["relation_name"]+"/"+row["partition"]] = offset
+	}
+	return offsets
+}
+
+// caughtUpWith reports whether the query is at most maxLag records behind the reference query on every source
+// partition the reference committed an offset on. DeltaStream does not report the end offsets of the sources, the
+// running query being replaced is the reference for how far the sources have been read.
+func (p queryPositions) caughtUpWith(reference queryPositions, maxLag int64) bool {
+	offsets := p.offsets()
+	for key, referenceOffset := range reference.offsets() {
+		offset, ok := offsets[key]
+		if !ok || referenceOffset-offset > maxLag {
+			return false
+		}
+	}
+	return true
+}
+
+// waitCaughtUp waits until the query caught up with the reference query, failing if it stops running.
+func (d *QueryResource) waitCaughtUp(ctx context.Context, conn *sql.Conn, query QueryResourceData, referenceQueryID string, maxLag int64, timeout time.Duration) error {
+	return retry.Do(ctx, retry.WithMaxDuration(timeout, retry.NewConstant(time.Second*15)), func(ctx context.Context) error {
+		current, err := d.updateComputed(ctx, conn, query, true)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		if state := current.State.ValueString(); state != "running" {
+			return fmt.Errorf("query %s is %s while catching up", query.QueryID.ValueString(), state)
+		}
+
+		// the reference is described first, the query catching up can only have moved further since
+		reference, err := describeQueryState(ctx, conn, referenceQueryID)
+		if err != nil {
+			return retry.RetryableError(fmt.Errorf("unable to lookup query state: %w", err))
+		}
+		positions, err := describeQueryState(ctx, conn, query.QueryID.ValueString())
+		if err != nil {
+			return retry.RetryableError(fmt.Errorf("unable to lookup query state: %w", err))
+		}
+		if !positions.caughtUpWith(reference, maxLag) {
+			return retry.RetryableError(fmt.Errorf("query %s has not caught up with query %s", query.QueryID.ValueString(), referenceQueryID))
+		}
+		return nil
+	})
+}
+
+// rollout launches the new statement of the query next to the running query, waits for it to catch up and then
+// terminates the running query. The new query is terminated and the running one kept if it fails to catch up. Both
+// queries write to the sinks while the new one catches up.
+func (d *QueryResource) rollout(ctx context.Context, conn *sql.Conn, newQuery, currentQuery QueryResourceData) (QueryResourceData, diag.Diagnostics) {
+	var dg diag.Diagnostics
+
+	maxLag, timeout, settingsDg := catchUpSettings(ctx, newQuery)
+	dg.Append(settingsDg...)
+	if dg.HasError() {
+		return currentQuery, dg
+	}
+
+	newQuery, validateDg := d.validateStatement(ctx, conn, newQuery)
+	dg.Append(validateDg...)
+	if dg.HasError() {
+		return currentQuery, dg
+	}
+
+	previousQueryID := ""
+	if newQuery.ResumeFrom.ValueString() == resumeFromLastCommitted {
+		previousQueryID = currentQuery.QueryID.ValueString()
+	}
+	newQuery, launchDg := d.launch(ctx, conn, newQuery, previousQueryID)
+	dg.Append(launchDg...)
+	if dg.HasError() {
+		return currentQuery, dg
+	}
+	tflog.Info(ctx, "query launched, waiting for it to catch up", map[string]any{
+		"Query ID":          newQuery.QueryID.ValueString(),
+		"previous Query ID": currentQuery.QueryID.ValueString(),
+	})
+
+	if err := d.waitCaughtUp(ctx, conn, newQuery, currentQuery.QueryID.ValueString(), maxLag, timeout); err != nil {
+		dg = util.LogError(ctx, dg, "query failed to catch up", fmt.Errorf("query %s is kept running: %w", currentQuery.QueryID.ValueString(), err))
+		if _, derr := conn.ExecContext(ctx, fmt.Sprintf(`TERMINATE QUERY %s;`, newQuery.QueryID.ValueString())); derr != nil {
+			tflog.Error(ctx, "failed to clean up query", map[string]any{
+				"Query ID": newQuery.QueryID.ValueString(),
+				"error":    derr.Error(),
+			})
+		}
+		return currentQuery, dg
+	}
+
+	// the previous query is retired with the stop mode it was created with
+	if _, _, terminateDg := d.terminate(ctx, conn, currentQuery); terminateDg.HasError() {
+		dg.Append(terminateDg...)
+		dg.AddError("previous query still running",
+			fmt.Sprintf("Query %s replaced query %s, which could not be terminated and must be terminated manually", newQuery.QueryID.ValueString(), currentQuery.QueryID.ValueString()))
+		return newQuery, dg
+	}
+	return newQuery, dg
+}
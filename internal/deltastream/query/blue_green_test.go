@@ -0,0 +1,114 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+)
+
+func TestCaughtUpWith(t *testing.T) {
+	position := func(partition, offset string) map[string]string {
+		return map[string]string{"relation_name": "db.public.pageviews", "partition": partition, "offset": offset, "state": "running"}
+	}
+	reference := queryPositions{position("0", "100"), position("1", "50")}
+
+	tests := []struct {
+		name      string
+		positions queryPositions
+		reference queryPositions
+		maxLag    int64
+		want      bool
+	}{
+		{name: "no positions", positions: queryPositions{}, reference: reference, want: false},
+		{name: "no offset committed", positions: queryPositions{{"relation_name": "db.public.pageviews", "partition": "0"}, position("1", "50")}, reference: reference, want: false},
+		{name: "caught up", positions: queryPositions{position("0", "100"), position("1", "50")}, reference: reference, want: true},
+		{name: "ahead", positions: queryPositions{position("0", "120"), position("1", "51")}, reference: reference, want: true},
+		{name: "behind", positions: queryPositions{position("0", "100"), position("1", "38")}, reference: reference, want: false},
+		{name: "within max lag", positions: queryPositions{position("0", "97"), position("1", "38")}, reference: reference, maxLag: 20, want: true},
+		{name: "nothing committed by the reference", positions: queryPositions{}, reference: queryPositions{}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.positions.caughtUpWith(tt.reference, tt.maxLag); got != tt.want {
+				t.Errorf("caughtUpWith(%d) = %v, want %v", tt.maxLag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatchUpSettings(t *testing.T) {
+	ctx := context.Background()
+
+	maxLag, timeout, dg := catchUpSettings(ctx, QueryResourceData{CatchUp: types.ObjectNull(catchUpAttributeTypes)})
+	if dg.HasError() || maxLag != 0 || timeout != defaultCatchUpTimeout {
+		t.Errorf("catchUpSettings(null) = %d, %s, %v", maxLag, timeout, dg)
+	}
+
+	maxLag, timeout, dg = catchUpSettings(ctx, QueryResourceData{CatchUp: types.ObjectValueMust(catchUpAttributeTypes, map[string]attr.Value{
+		"max_lag": types.Int64Value(100),
+		"timeout": types.StringValue("5m"),
+	})})
+	if dg.HasError() || maxLag != 100 || timeout != 5*time.Minute {
+		t.Errorf("catchUpSettings() = %d, %s, %v", maxLag, timeout, dg)
+	}
+}
+
+// queryStateColumns are the columns of DESCRIBE QUERY STATE.
+var queryStateColumns = []mockserver.Column{
+	{Name: "Relation Name", Type: "VARCHAR"},
+	{Name: "Partition", Type: "VARCHAR"},
+	{Name: "Offset", Type: "VARCHAR", Nullable: true},
+	{Name: "State", Type: "VARCHAR"},
+}
+
+func TestWaitCaughtUp(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{
+		{
+			Statement: `^LIST QUERIES WITH \('all'\);$`,
+			Columns: []mockserver.Column{
+				{Name: "id", Type: "VARCHAR"},
+				{Name: "name", Type: "VARCHAR"},
+				{Name: "version", Type: "BIGINT"},
+				{Name: "intended_state", Type: "VARCHAR"},
+				{Name: "actual_state", Type: "VARCHAR"},
+				{Name: "query", Type: "VARCHAR"},
+				{Name: "owner", Type: "VARCHAR"},
+				{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+				{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+			},
+			Rows: [][]*string{
+				mockserver.Row("q2", "pageviews_query", "2", "running", "running", "INSERT INTO b SELECT * FROM a;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+				mockserver.Row("q3", "pageviews_query", "3", "running", "errored", "INSERT INTO b SELECT * FROM a;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+			},
+		},
+		{
+			Statement: `^DESCRIBE QUERY STATE q1;$`,
+			Columns:   queryStateColumns,
+			Rows:      [][]*string{mockserver.Row("db.public.pageviews", "0", "100", "running"), mockserver.Row("db.public.pageviews", "1", "50", "running")},
+		},
+		{
+			Statement: `^DESCRIBE QUERY STATE q2;$`,
+			Columns:   queryStateColumns,
+			Rows:      [][]*string{mockserver.Row("db.public.pageviews", "0", "100", "running"), mockserver.Row("db.public.pageviews", "1", "48", "running")},
+		},
+	})
+
+	d := &QueryResource{cfg: &config.DeltaStreamProviderCfg{Organization: testOrganization}}
+	if err := d.waitCaughtUp(ctx, conn, QueryResourceData{QueryID: types.StringValue("q2")}, "q1", 2, time.Minute); err != nil {
+		t.Errorf("waitCaughtUp(q2) error = %v", err)
+	}
+	if err := d.waitCaughtUp(ctx, conn, QueryResourceData{QueryID: types.StringValue("q3")}, "q1", 2, time.Minute); err == nil || !strings.Contains(err.Error(), "errored") {
+		t.Errorf("waitCaughtUp(q3) error = %v, want errored", err)
+	}
+}
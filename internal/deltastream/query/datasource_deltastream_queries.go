@@ -0,0 +1,204 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &QueriesDataSource{}
+var _ datasource.DataSourceWithConfigure = &QueriesDataSource{}
+
+func NewQueriesDataSource() datasource.DataSource {
+	return &QueriesDataSource{}
+}
+
+type QueriesDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *QueriesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type QueriesDataSourceData struct {
+	IncludeStopped types.Bool `tfsdk:"include_stopped"`
+	Items          types.List `tfsdk:"items"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
+}
+
+type QueryDataSourceData struct {
+	ID        types.String `tfsdk:"id"`
+	QueryID   types.String `tfsdk:"query_id"`
+	Name      types.String `tfsdk:"query_name"`
+	Version   types.Int64  `tfsdk:"query_version"`
+	State     types.String `tfsdk:"state"`
+	Sql       types.String `tfsdk:"sql"`
+	Owner     types.String `tfsdk:"owner"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+func (QueryDataSourceData) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":            types.StringType,
+		"query_id":      types.StringType,
+		"query_name":    types.StringType,
+		"query_version": types.Int64Type,
+		"state":         types.StringType,
+		"sql":           types.StringType,
+		"owner":         types.StringType,
+		"created_at":    types.StringType,
+		"updated_at":    types.StringType,
+	}
+}
+
+func (d *QueriesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Queries data source",
+
+		Attributes: map[string]schema.Attribute{
+			"include_stopped": schema.BoolAttribute{
+				Description: "Include queries that are no longer running",
+				Optional:    true,
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "List of queries",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Global identifier of the query",
+							Computed:    true,
+						},
+						"query_id": schema.StringAttribute{
+							Description: "Query ID",
+							Computed:    true,
+						},
+						"query_name": schema.StringAttribute{
+							Description: "Query Name",
+							Computed:    true,
+						},
+						"query_version": schema.Int64Attribute{
+							Description: "Query version",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "State of the query",
+							Computed:    true,
+						},
+						"sql": schema.StringAttribute{
+							Description: "SQL statement of the query",
+							Computed:    true,
+						},
+						"owner": schema.StringAttribute{
+							Description: "Owning role of the query",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Creation date of the query",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "Last update date of the query",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["items"].(schema.ListNestedAttribute), "query_id")
+}
+
+func (d *QueriesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_queries"
+}
+
+func (d *QueriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	queries := QueriesDataSourceData{}
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &queries)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	stmt := `LIST QUERIES;`
+	if queries.IncludeStopped.ValueBool() {
+		stmt = `LIST QUERIES WITH ('all');`
+	}
+
+	items := []QueryDataSourceData{}
+	if err := util.QueryRows(ctx, conn, stmt, func(rows *sql.Rows) error {
+		var (
+			id            string
+			name          string
+			version       int64
+			intendedState string
+			actualState   string
+			query         string
+			owner         string
+			createdAt     time.Time
+			updatedAt     time.Time
+		)
+		if err := rows.Scan(&id, &name, &version, &intendedState, &actualState, &query, &owner, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+		items = append(items, QueryDataSourceData{
+			ID:        util.ResourceID(d.cfg.Organization, "query", id),
+			QueryID:   types.StringValue(id),
+			Name:      types.StringValue(name),
+			Version:   types.Int64Value(version),
+			State:     types.StringValue(actualState),
+			Sql:       types.StringValue(query),
+			Owner:     types.StringValue(owner),
+			CreatedAt: util.TimestampValue(createdAt),
+			UpdatedAt: util.TimestampValue(updatedAt),
+		})
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list queries", err)
+		return
+	}
+
+	var dg diag.Diagnostics
+	queries.Items, dg = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: QueryDataSourceData{}.AttributeTypes()}, items)
+	resp.Diagnostics.Append(dg...)
+	queries.ItemsByName, dg = util.ItemsByName(ctx, queries.Items, "query_id")
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &queries)...)
+}
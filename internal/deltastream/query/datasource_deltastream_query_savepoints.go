@@ -0,0 +1,156 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &QuerySavepointsDataSource{}
+var _ datasource.DataSourceWithConfigure = &QuerySavepointsDataSource{}
+
+func NewQuerySavepointsDataSource() datasource.DataSource {
+	return &QuerySavepointsDataSource{}
+}
+
+// QuerySavepointsDataSource lists the positions committed by every version of a query.
+type QuerySavepointsDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *QuerySavepointsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type QuerySavepointsDataSourceData struct {
+	ID         types.String `tfsdk:"id"`
+	QueryID    types.String `tfsdk:"query_id"`
+	Savepoints types.List   `tfsdk:"savepoints"`
+}
+
+type QuerySavepointData struct {
+	QueryID   types.String `tfsdk:"query_id"`
+	Version   types.Int64  `tfsdk:"query_version"`
+	State     types.String `tfsdk:"state"`
+	Positions types.List   `tfsdk:"positions"`
+}
+
+func (QuerySavepointData) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"query_id":      types.StringType,
+		"query_version": types.Int64Type,
+		"state":         types.StringType,
+		"positions":     types.ListType{ElemType: types.MapType{ElemType: types.StringType}},
+	}
+}
+
+func (d *QuerySavepointsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Query savepoints data source. Lists the positions committed by every version of a query, including the versions that are no longer running, as reported by DESCRIBE QUERY STATE. Use `deltastream_query_savepoint` to record the positions of the running query.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Query",
+				Computed:    true,
+			},
+			"query_id": schema.StringAttribute{
+				Description: "ID of any version of the Query",
+				Required:    true,
+			},
+			"savepoints": schema.ListNestedAttribute{
+				Description: "Positions committed by each version of the query, from the oldest version to the latest",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"query_id": schema.StringAttribute{
+							Description: "Query ID of the version",
+							Computed:    true,
+						},
+						"query_version": schema.Int64Attribute{
+							Description: "Query version",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "State of the version",
+							Computed:    true,
+						},
+						"positions": schema.ListAttribute{
+							Description: "Positions committed by the version, one per source partition. Each position maps the columns reported by the server, in snake case, to their value",
+							Computed:    true,
+							ElementType: types.MapType{ElemType: types.StringType},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *QuerySavepointsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_savepoints"
+}
+
+func (d *QuerySavepointsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	state := QuerySavepointsDataSourceData{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	savepoints, err := listQuerySavepoints(ctx, conn, d.cfg.Organization, state.QueryID.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list query savepoints", err)
+		return
+	}
+
+	items := make([]QuerySavepointData, 0, len(savepoints))
+	for _, s := range savepoints {
+		positions, dg := types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, s.Positions)
+		resp.Diagnostics.Append(dg...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		items = append(items, QuerySavepointData{
+			QueryID:   types.StringValue(s.QueryID),
+			Version:   types.Int64Value(s.Version),
+			State:     types.StringValue(s.State),
+			Positions: positions,
+		})
+	}
+
+	state.ID = util.ResourceID(d.cfg.Organization, "query", state.QueryID.ValueString())
+	savepointList, dg := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: QuerySavepointData{}.AttributeTypes()}, items)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Savepoints = savepointList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &QueryStateDataSource{}
+var _ datasource.DataSourceWithConfigure = &QueryStateDataSource{}
+
+func NewQueryStateDataSource() datasource.DataSource {
+	return &QueryStateDataSource{}
+}
+
+// QueryStateDataSource reports the positions a query committed on each of its sources, as listed by DESCRIBE QUERY
+// STATE. DeltaStream does not expose the consumer groups of its queries on the store, the committed positions are
+// what runbooks can check before cutting a pipeline over. Lag is not reported: DeltaStream does not expose the end
+// offsets of the source entities, so lag can only be measured between two queries reading the same sources.
+type QueryStateDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *QueryStateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type QueryStateDataSourceData struct {
+	ID        types.String `tfsdk:"id"`
+	QueryID   types.String `tfsdk:"query_id"`
+	Positions types.List   `tfsdk:"positions"`
+	Completed types.Bool   `tfsdk:"completed"`
+}
+
+func (d *QueryStateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Query state data source. Reports the positions committed by a query on each of its sources. Use it in `check` blocks to validate that a query caught up before cutting a pipeline over. Lag behind the end of the sources is not reported, DeltaStream does not expose the end offsets of source entities; compare the offsets of two queries reading the same sources instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Query",
+				Computed:    true,
+			},
+			"query_id": schema.StringAttribute{
+				Description: "ID of the Query",
+				Required:    true,
+			},
+			"positions": schema.ListAttribute{
+				Description: "Positions committed by the query, one per source partition. Each position maps the columns reported by the server, in snake case, to their value",
+				Computed:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+			},
+			"completed": schema.BoolAttribute{
+				Description: "Whether every source of the query reached its final state",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *QueryStateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_state"
+}
+
+func (d *QueryStateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	state := QueryStateDataSourceData{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	positions, err := describeQueryState(ctx, conn, state.QueryID.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to describe query state", err)
+		return
+	}
+
+	state.ID = util.ResourceID(d.cfg.Organization, "query", state.QueryID.ValueString())
+	positionList, dg := types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, positions)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Positions = positionList
+	state.Completed = types.BoolValue(positions.completed())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
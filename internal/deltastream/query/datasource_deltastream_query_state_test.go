@@ -0,0 +1,44 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestDescribeQueryState(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE QUERY STATE q1;$`,
+		Columns: []mockserver.Column{
+			{Name: "Relation Name", Type: "VARCHAR"},
+			{Name: "Partition", Type: "VARCHAR"},
+			{Name: "Offset", Type: "VARCHAR", Nullable: true},
+			{Name: "State", Type: "VARCHAR"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("db.public.pageviews", "0", "42", "running"),
+			append(append(mockserver.Row("db.public.pageviews", "1"), nil), mockserver.Row("completed")...),
+		},
+	}})
+
+	positions, err := describeQueryState(ctx, conn, "q1")
+	if err != nil {
+		t.Fatalf("describeQueryState() error = %v", err)
+	}
+	want := queryPositions{
+		{"relation_name": "db.public.pageviews", "partition": "0", "offset": "42", "state": "running"},
+		{"relation_name": "db.public.pageviews", "partition": "1", "state": "completed"},
+	}
+	if !reflect.DeepEqual(positions, want) {
+		t.Errorf("describeQueryState() = %v, want %v", positions, want)
+	}
+	if positions.completed() {
+		t.Error("expected positions with a running source not to be completed")
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &QueryVersionsDataSource{}
+var _ datasource.DataSourceWithConfigure = &QueryVersionsDataSource{}
+
+func NewQueryVersionsDataSource() datasource.DataSource {
+	return &QueryVersionsDataSource{}
+}
+
+// QueryVersionsDataSource lists the history of a named query. Together with the pinned_version attribute of the query
+// resource it lets a previous version be redeployed.
+type QueryVersionsDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *QueryVersionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type QueryVersionsDataSourceData struct {
+	Name     types.String `tfsdk:"query_name"`
+	Versions types.List   `tfsdk:"versions"`
+}
+
+func (d *QueryVersionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Query versions data source. Lists every version of a named query, including the versions that are no longer running, from the oldest to the latest.",
+
+		Attributes: map[string]schema.Attribute{
+			"query_name": schema.StringAttribute{
+				Description: "Query Name",
+				Required:    true,
+			},
+			"versions": schema.ListNestedAttribute{
+				Description: "Versions of the query",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Global identifier of the query",
+							Computed:    true,
+						},
+						"query_id": schema.StringAttribute{
+							Description: "Query ID",
+							Computed:    true,
+						},
+						"query_name": schema.StringAttribute{
+							Description: "Query Name",
+							Computed:    true,
+						},
+						"query_version": schema.Int64Attribute{
+							Description: "Query version",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "State of the query",
+							Computed:    true,
+						},
+						"sql": schema.StringAttribute{
+							Description: "SQL statement of the query",
+							Computed:    true,
+						},
+						"owner": schema.StringAttribute{
+							Description: "Owning role of the query",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Creation date of the query",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "Last update date of the query",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *QueryVersionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_versions"
+}
+
+func (d *QueryVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	data := QueryVersionsDataSourceData{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	versions, err := listQueryVersions(ctx, conn, d.cfg.Organization, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list query versions", err)
+		return
+	}
+
+	versionList, dg := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: QueryDataSourceData{}.AttributeTypes()}, versions)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Versions = versionList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func deletionProtectedError(queryID string) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(path.Root("deletion_protection"), "Query is protected from deletion",
+		fmt.Sprintf("query %s has deletion_protection set and cannot be terminated. Set deletion_protection to false and apply before destroying or replacing the query.", queryID))
+}
+
+// checkDeletionProtection fails plans that destroy or replace a query whose state has deletion protection, so that
+// the run stops before anything is changed. The protection recorded in state applies, turning it off in the same run
+// as the destroy does not lift it.
+func checkDeletionProtection(ctx context.Context, state tfsdk.State, plan tfsdk.Plan, requiresReplace path.Paths) (d diag.Diagnostics) {
+	if state.Raw.IsNull() || (!plan.Raw.IsNull() && len(requiresReplace) == 0) {
+		return
+	}
+
+	var protected types.Bool
+	var queryID types.String
+	d.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	d.Append(state.GetAttribute(ctx, path.Root("query_id"), &queryID)...)
+	if d.HasError() || !protected.ValueBool() {
+		return
+	}
+	d.Append(deletionProtectedError(queryID.ValueString()))
+	return
+}
@@ -0,0 +1,54 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCheckDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		"query_id":            schema.StringAttribute{Computed: true},
+		"deletion_protection": schema.BoolAttribute{Optional: true},
+	}}
+	objectType := s.Type().TerraformType(ctx)
+	value := func(protected any) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"query_id":            tftypes.NewValue(tftypes.String, "q1"),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, protected),
+		})
+	}
+	null := tftypes.NewValue(objectType, nil)
+
+	tests := []struct {
+		name     string
+		state    tftypes.Value
+		plan     tftypes.Value
+		replace  path.Paths
+		expected bool
+	}{
+		{name: "create", state: null, plan: value(true)},
+		{name: "destroy protected", state: value(true), plan: null, expected: true},
+		{name: "destroy unprotected", state: value(false), plan: null},
+		{name: "destroy without setting", state: value(nil), plan: null},
+		{name: "replace protected", state: value(true), plan: value(true), replace: path.Paths{path.Root("sql")}, expected: true},
+		{name: "protection lifted with replace", state: value(true), plan: value(false), replace: path.Paths{path.Root("sql")}, expected: true},
+		{name: "update protected", state: value(true), plan: value(true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dg := checkDeletionProtection(ctx, tfsdk.State{Schema: s, Raw: tt.state}, tfsdk.Plan{Schema: s, Raw: tt.plan}, tt.replace)
+			if dg.HasError() != tt.expected {
+				t.Errorf("checkDeletionProtection() diagnostics = %v, want error %v", dg, tt.expected)
+			}
+		})
+	}
+}
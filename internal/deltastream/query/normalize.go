@@ -0,0 +1,53 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// normalizeSQL returns the form statements are compared in: the tokens of the statement joined by single spaces,
+// without spaces around punctuation and without the trailing semicolon. Comments and whitespace are not tokens, and
+// string literals and quoted identifiers are kept verbatim.
+func normalizeSQL(stmt string) string {
+	tokens := tokenizeSQL(stmt)
+	for len(tokens) > 0 && tokens[len(tokens)-1].text == ";" {
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	var b strings.Builder
+	for i, tok := range tokens {
+		if i > 0 && tok.start > tokens[i-1].end && !strings.Contains("(),;", tok.text) && !strings.Contains("(,", tokens[i-1].text) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(tok.text)
+	}
+	return b.String()
+}
+
+// normalizedSQL keeps the statement of a query in state when the planned statement only differs from it by
+// formatting or comments, so that reformatting the SQL of a query does not restart it.
+type normalizedSQL struct{}
+
+var _ planmodifier.String = normalizedSQL{}
+
+func (normalizedSQL) Description(ctx context.Context) string {
+	return "Statements that only differ by whitespace or comments are not changes"
+}
+
+func (m normalizedSQL) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (normalizedSQL) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	if normalizeSQL(req.PlanValue.ValueString()) == normalizeSQL(req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	base := `INSERT INTO "pv_copy" SELECT viewtime, pageid FROM pageviews WHERE userid = 'User  1';`
+	for _, stmt := range []string{
+		"INSERT INTO \"pv_copy\"\n  SELECT viewtime,\n         pageid\n  FROM pageviews\n  WHERE userid = 'User  1'",
+		"-- copy the page views\nINSERT INTO \"pv_copy\" SELECT viewtime , pageid FROM pageviews /* all of them */ WHERE userid = 'User  1' ;\n",
+	} {
+		if got, want := normalizeSQL(stmt), normalizeSQL(base); got != want {
+			t.Errorf("normalizeSQL(%q) = %q, want %q", stmt, got, want)
+		}
+	}
+
+	for _, stmt := range []string{
+		`INSERT INTO "pv_copy" SELECT viewtime, pageid FROM pageviews WHERE userid = 'User 1';`,
+		`INSERT INTO "pv_copy" SELECT viewtime, pageid FROM pageviews WHERE userid = 'User  1 -- not a comment';`,
+		`INSERT INTO "pv copy" SELECT viewtime, pageid FROM pageviews WHERE userid = 'User  1';`,
+		`INSERT INTO "pv_copy" SELECT viewtime, pageid FROM pageviews WHERE userid = 'it''s';`,
+	} {
+		if normalizeSQL(stmt) == normalizeSQL(base) {
+			t.Errorf("normalizeSQL(%q) is the same as the normalized %q", stmt, base)
+		}
+	}
+
+	for stmt, want := range map[string]string{
+		`SELECT * FROM ( SELECT 'a''b' AS x ) t;`:        `SELECT * FROM(SELECT 'a''b' AS x) t`,
+		"SELECT 'a\\'b -- c' , x\nFROM t -- trailing\n;": `SELECT 'a\'b -- c',x FROM t`,
+		`SELECT "a""b" /* c */ FROM t;;`:                 `SELECT "a""b" FROM t`,
+	} {
+		if got := normalizeSQL(stmt); got != want {
+			t.Errorf("normalizeSQL(%q) = %q, want %q", stmt, got, want)
+		}
+	}
+}
+
+func TestNormalizedSQL(t *testing.T) {
+	state := types.StringValue("INSERT INTO b SELECT * FROM a;")
+	for plan, kept := range map[string]bool{
+		"INSERT INTO b\nSELECT * FROM a -- same\n": true,
+		"INSERT INTO c SELECT * FROM a;":           false,
+	} {
+		resp := &planmodifier.StringResponse{PlanValue: types.StringValue(plan)}
+		normalizedSQL{}.PlanModifyString(context.Background(), planmodifier.StringRequest{PlanValue: resp.PlanValue, StateValue: state}, resp)
+		if got := resp.PlanValue.Equal(state); got != kept {
+			t.Errorf("plan %q kept state = %v, want %v", plan, got, kept)
+		}
+	}
+}
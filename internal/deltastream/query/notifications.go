@@ -0,0 +1,30 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// notificationProperties returns the query properties setting the notification targets alerted when the query
+// fails, or nil when none are configured. Removing the targets from the configuration clears them, so an empty set
+// is rendered as an empty list.
+func notificationProperties(query QueryResourceData) []string {
+	if query.NotificationTargets.IsNull() || query.NotificationTargets.IsUnknown() {
+		return nil
+	}
+
+	names := []string{}
+	for _, elem := range query.NotificationTargets.Elements() {
+		if name, ok := elem.(types.String); ok && !name.IsNull() && !name.IsUnknown() {
+			names = append(names, strings.ReplaceAll(name.ValueString(), "'", "''"))
+		}
+	}
+	sort.Strings(names)
+	return []string{fmt.Sprintf(`'notification.targets' = '%s'`, strings.Join(names, ","))}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNotificationProperties(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets types.Set
+		want    []string
+	}{
+		{
+			name:    "not configured",
+			targets: types.SetNull(types.StringType),
+		},
+		{
+			name:    "empty",
+			targets: types.SetValueMust(types.StringType, []attr.Value{}),
+			want:    []string{`'notification.targets' = ''`},
+		},
+		{
+			name:    "sorted",
+			targets: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("slack_alerts"), types.StringValue("oncall")}),
+			want:    []string{`'notification.targets' = 'oncall,slack_alerts'`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notificationProperties(QueryResourceData{NotificationTargets: tt.targets}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("notificationProperties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const (
+	resumeFromLatest        = "latest"
+	resumeFromEarliest      = "earliest"
+	resumeFromLastCommitted = "last_committed"
+
+	// committedPositionsKey is the private state key holding the positions last reported for the query
+	committedPositionsKey = "committed_positions"
+	// replacedQueryKey is the private state key handing the ID of a query being replaced over to the plan of the
+	// query replacing it
+	replacedQueryKey = "replaced_query"
+)
+
+// planResumedFrom plans resumed_from_query_id of a query being created. replaced is the replacedQueryKey private state
+// recorded while planning the replacement, the query resumes from it when resume_from is last_committed.
+func planResumedFrom(ctx context.Context, replaced []byte, plan *tfsdk.Plan) (dg diag.Diagnostics) {
+	var resumeFrom types.String
+	dg.Append(plan.GetAttribute(ctx, path.Root("resume_from"), &resumeFrom)...)
+	if dg.HasError() {
+		return
+	}
+
+	resumedFrom := types.StringNull()
+	if resumeFrom.ValueString() == resumeFromLastCommitted && len(replaced) > 0 {
+		var queryID string
+		if err := json.Unmarshal(replaced, &queryID); err != nil {
+			dg.AddError("invalid replaced query", fmt.Sprintf("failed to read the query being replaced from private state: %s", err))
+			return
+		}
+		if queryID != "" {
+			resumedFrom = types.StringValue(queryID)
+		}
+	}
+	dg.Append(plan.SetAttribute(ctx, path.Root("resumed_from_query_id"), resumedFrom)...)
+	return
+}
+
+// queryPositions holds the rows reported by DESCRIBE QUERY STATE, keyed by their snake cased column names.
+type queryPositions []map[string]string
+
+// completed reports whether every source of the query reached its final state.
+func (p queryPositions) completed() bool {
+	for _, row := range p {
+		if row["state"] != "completed" {
+			return false
+		}
+	}
+	return true
+}
+
+func describeQueryState(ctx context.Context, conn *sql.Conn, queryID string) (queryPositions, error) {
+	rows, err := util.DescribeRows(ctx, conn, fmt.Sprintf(`DESCRIBE QUERY STATE %s;`, queryID))
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(queryPositions, 0, len(rows))
+	for _, row := range rows {
+		positions = append(positions, row.Map())
+	}
+	return positions, nil
+}
+
+// withQueryProperties adds properties to the QUERY WITH clause of a statement, creating the clause when the
+// statement has none. The statement is tokenized so that comments, string literals and clauses nested in
+// parentheses are left alone.
+func withQueryProperties(stmt string, props []string) string {
+	if len(props) == 0 {
+		return stmt
+	}
+
+	tokens := tokenizeSQL(stmt)
+	for i := len(tokens) - 3; i >= 0; i-- {
+		if tokens[i].depth != 0 || !strings.EqualFold(tokens[i].text, "QUERY") || !strings.EqualFold(tokens[i+1].text, "WITH") || tokens[i+2].text != "(" {
+			continue
+		}
+		end := tokens[i+2].end
+		if i+3 < len(tokens) && tokens[i+3].text == ")" {
+			return stmt[:end] + strings.Join(props, ", ") + stmt[end:]
+		}
+		return stmt[:end] + strings.Join(props, ", ") + ", " + stmt[end:]
+	}
+
+	// append the clause after the last token of the statement, dropping the terminator and any trailing comment
+	last := len(tokens) - 1
+	for last >= 0 && tokens[last].text == ";" {
+		last--
+	}
+	end := len(stmt)
+	if last >= 0 {
+		end = tokens[last].end
+	}
+	return fmt.Sprintf("%s QUERY WITH (%s);", stmt[:end], strings.Join(props, ", "))
+}
+
+// resumeProperties returns the query properties selecting where the sources of a new query start reading.
+// previousQueryID is only used when resuming from the positions committed by the query being replaced.
+func resumeProperties(resumeFrom, previousQueryID string) []string {
+	switch resumeFrom {
+	case resumeFromLatest, resumeFromEarliest:
+		return []string{fmt.Sprintf(`'resume.from' = '%s'`, resumeFrom)}
+	case resumeFromLastCommitted:
+		if previousQueryID == "" {
+			return nil
+		}
+		return []string{fmt.Sprintf(`'resume.from' = '%s'`, resumeFrom), fmt.Sprintf(`'resume.from.query_id' = '%s'`, previousQueryID)}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestWithQueryProperties(t *testing.T) {
+	props := []string{`'resume.from' = 'earliest'`}
+	tests := []struct {
+		name string
+		stmt string
+		want string
+	}{
+		{
+			name: "no clause",
+			stmt: "INSERT INTO sink SELECT * FROM source;\n",
+			want: "INSERT INTO sink SELECT * FROM source QUERY WITH ('resume.from' = 'earliest');",
+		},
+		{
+			name: "existing clause",
+			stmt: "INSERT INTO sink SELECT * FROM source query with ('state.ttl.millis' = '1000');",
+			want: "INSERT INTO sink SELECT * FROM source query with ('resume.from' = 'earliest', 'state.ttl.millis' = '1000');",
+		},
+		{
+			name: "empty clause",
+			stmt: "INSERT INTO sink SELECT * FROM source QUERY WITH ();",
+			want: "INSERT INTO sink SELECT * FROM source QUERY WITH ('resume.from' = 'earliest');",
+		},
+		{
+			name: "clause in a comment",
+			stmt: "INSERT INTO sink /* QUERY WITH ('a' = 'b') */ SELECT * FROM source; -- QUERY WITH (",
+			want: "INSERT INTO sink /* QUERY WITH ('a' = 'b') */ SELECT * FROM source QUERY WITH ('resume.from' = 'earliest');",
+		},
+		{
+			name: "clause in a string literal",
+			stmt: "INSERT INTO sink SELECT 'it''s QUERY WITH (' AS note, \"query with (\" FROM source",
+			want: "INSERT INTO sink SELECT 'it''s QUERY WITH (' AS note, \"query with (\" FROM source QUERY WITH ('resume.from' = 'earliest');",
+		},
+		{
+			name: "trailing comment without terminator",
+			stmt: "INSERT INTO sink SELECT * FROM source -- copy everything\n",
+			want: "INSERT INTO sink SELECT * FROM source QUERY WITH ('resume.from' = 'earliest');",
+		},
+		{
+			name: "non ascii identifiers",
+			stmt: "INSERT INTO \"données\" SELECT * FROM voilà;",
+			want: "INSERT INTO \"données\" SELECT * FROM voilà QUERY WITH ('resume.from' = 'earliest');",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withQueryProperties(tt.stmt, props); got != tt.want {
+				t.Errorf("withQueryProperties() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := withQueryProperties("SELECT 1;", nil); got != "SELECT 1;" {
+		t.Errorf("withQueryProperties() without properties = %q", got)
+	}
+}
+
+func TestResumeProperties(t *testing.T) {
+	if got := resumeProperties(resumeFromLastCommitted, ""); got != nil {
+		t.Errorf("resumeProperties(last_committed, \"\") = %v, want nil", got)
+	}
+	if got := resumeProperties(resumeFromLastCommitted, "q1"); len(got) != 2 || got[1] != `'resume.from.query_id' = 'q1'` {
+		t.Errorf("resumeProperties(last_committed, q1) = %v", got)
+	}
+	if got := resumeProperties("", ""); got != nil {
+		t.Errorf("resumeProperties(\"\") = %v, want nil", got)
+	}
+}
+
+func TestPlanResumedFrom(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := resource.SchemaResponse{}
+	(&QueryResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := func(resumeFrom string) tfsdk.Plan {
+		p := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if dg := p.SetAttribute(ctx, path.Root("resume_from"), types.StringValue(resumeFrom)); dg.HasError() {
+			t.Fatalf("failed to build plan: %v", dg)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name       string
+		resumeFrom string
+		replaced   []byte
+		want       types.String
+	}{
+		{name: "replacement", resumeFrom: resumeFromLastCommitted, replaced: []byte(`"q1"`), want: types.StringValue("q1")},
+		{name: "new query", resumeFrom: resumeFromLastCommitted, want: types.StringNull()},
+		{name: "not resuming", resumeFrom: resumeFromEarliest, replaced: []byte(`"q1"`), want: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := plan(tt.resumeFrom)
+			if dg := planResumedFrom(ctx, tt.replaced, &p); dg.HasError() {
+				t.Fatalf("planResumedFrom() errors = %v", dg)
+			}
+			var got types.String
+			p.GetAttribute(ctx, path.Root("resumed_from_query_id"), &got)
+			if !got.Equal(tt.want) {
+				t.Errorf("resumed_from_query_id = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,11 +12,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,8 +35,17 @@ import (
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
 )
 
+// Modes for stopping a query on destroy.
+const (
+	stopModeDrain     = "drain"
+	stopModeImmediate = "immediate"
+)
+
 var _ resource.Resource = &QueryResource{}
 var _ resource.ResourceWithConfigure = &QueryResource{}
+var _ resource.ResourceWithModifyPlan = &QueryResource{}
+var _ resource.ResourceWithUpgradeState = &QueryResource{}
+var _ resource.ResourceWithImportState = &QueryResource{}
 
 func NewQueryResource() resource.Resource {
 	return &QueryResource{}
@@ -39,35 +56,99 @@ type QueryResource struct {
 }
 
 type QueryResourceData struct {
+	ID              types.String `tfsdk:"id"`
 	SourceRelations types.List   `tfsdk:"source_relation_fqns"`
-	SinkRelation    types.String `tfsdk:"sink_relation_fqn"`
+	SinkRelations   types.List   `tfsdk:"sink_relation_fqns"`
 	Sql             types.String `tfsdk:"sql"`
 	QueryID         types.String `tfsdk:"query_id"`
 	Name            types.String `tfsdk:"query_name"`
+	Description     types.String `tfsdk:"query_description"`
 	Version         types.Int64  `tfsdk:"query_version"`
 	State           types.String `tfsdk:"state"`
 	Owner           types.String `tfsdk:"owner"`
 	CreatedAt       types.String `tfsdk:"created_at"`
 	UpdatedAt       types.String `tfsdk:"updated_at"`
+
+	TerminatedGracePeriod types.String `tfsdk:"terminated_grace_period"`
+	PurgeOnDestroy        types.Bool   `tfsdk:"purge_on_destroy"`
+	StopMode              types.String `tfsdk:"stop_mode"`
+	ResumeFrom            types.String `tfsdk:"resume_from"`
+	ResumedFromQueryID    types.String `tfsdk:"resumed_from_query_id"`
+	RestartPolicy         types.String `tfsdk:"restart_policy"`
+	MaxRestartAttempts    types.Int64  `tfsdk:"max_restart_attempts"`
+	RestartCount          types.Int64  `tfsdk:"restart_count"`
+
+	StatementID types.String `tfsdk:"statement_id"`
+
+	PinnedVersion types.Int64 `tfsdk:"pinned_version"`
+
+	NotificationTargets types.Set `tfsdk:"notification_targets"`
+
+	Tags    types.Map `tfsdk:"tags"`
+	TagsAll types.Map `tfsdk:"tags_all"`
+
+	UpdateStrategy types.String `tfsdk:"update_strategy"`
+	CatchUp        types.Object `tfsdk:"catch_up"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
+	Database types.String `tfsdk:"database"`
+	Schema   types.String `tfsdk:"schema"`
+	Store    types.String `tfsdk:"store"`
 }
 
 func (d *QueryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Query resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the query",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"source_relation_fqns": schema.ListAttribute{
 				Description: "List of fully qualified source relation names",
 				Required:    true,
 				ElementType: basetypes.StringType{},
+				PlanModifiers: []planmodifier.List{
+					sourcesRequiresReplace,
+				},
 			},
-			"sink_relation_fqn": schema.StringAttribute{
-				Description: "Fully qualified sink relation name",
+			"sink_relation_fqns": schema.ListAttribute{
+				Description: "List of fully qualified sink relation names",
 				Required:    true,
+				ElementType: basetypes.StringType{},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
 			},
 			"sql": schema.StringAttribute{
-				Description: "SQL statement to create the relation",
-				Required:    true,
+				Description: "SQL statement to create the relation. Set to the SQL statement of the pinned version when pinned_version is set. Changes to whitespace or comments only do not replace the query",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("pinned_version")),
+				},
+				PlanModifiers: []planmodifier.String{
+					normalizedSQL{},
+					stringplanmodifier.UseStateForUnknown(),
+					sqlRequiresReplace,
+				},
+			},
+			"pinned_version": schema.Int64Attribute{
+				Description: "Version of the query named query_name to redeploy instead of sql, such as a previous version to roll back to. The versions of a query are listed by the deltastream_query_versions data source. The redeployed query becomes a new version of the query",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("query_name")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					pinnedVersionRequiresReplace,
+				},
 			},
 			"query_id": schema.StringAttribute{
 				Description: "Query ID",
@@ -78,11 +159,17 @@ func (d *QueryResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			},
 			"query_name": schema.StringAttribute{
 				Description: "Query Name",
+				Optional:    true,
 				Computed:    true,
+				Validators:  util.IdentifierValidators,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"query_description": schema.StringAttribute{
+				Description: "Human readable description of the query",
+				Optional:    true,
+			},
 			"query_version": schema.Int64Attribute{
 				Description: "Query version",
 				Computed:    true,
@@ -104,10 +191,150 @@ func (d *QueryResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Description: "Creation date of the query",
 				Computed:    true,
 			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the query, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"updated_at": schema.StringAttribute{
-				Description: "Creation date of the query",
+				Description: "Last update date of the query",
 				Computed:    true,
 			},
+			"terminated_grace_period": schema.StringAttribute{
+				Description: "How long a query may remain terminated before it is treated as deleted and planned for re-creation, as a duration such as 10m. Defaults to 24h",
+				Optional:    true,
+				Validators: []validator.String{
+					util.DurationValidator{},
+				},
+			},
+			"purge_on_destroy": schema.BoolAttribute{
+				Description: "Also remove the query history when the query is destroyed, if supported by the server",
+				Optional:    true,
+			},
+			"stop_mode": schema.StringAttribute{
+				Description: "How the query is stopped when destroyed. drain lets sinks flush and the query savepoint before it stops, immediate terminates it right away. Defaults to immediate",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(stopModeDrain, stopModeImmediate),
+				},
+			},
+			"resume_from": schema.StringAttribute{
+				Description: "Where the sources of the query start reading when the query is created or re-created. last_committed resumes from the positions committed by the query it replaces, falling back to the server default when there is none. Defaults to the server default",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(resumeFromLatest, resumeFromEarliest, resumeFromLastCommitted),
+				},
+			},
+			"resumed_from_query_id": schema.StringAttribute{
+				Description: "ID of the query whose committed positions the query resumed from, when resume_from is last_committed and the query replaced another",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"restart_policy": schema.StringAttribute{
+				Description: "When the query is restarted after it stops. always restarts it whenever it stops, on-failure only when it fails and never leaves it stopped. Removing the attribute keeps the current policy of the query. Defaults to the server default",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(restartPolicyAlways, restartPolicyOnFailure, restartPolicyNever),
+				},
+			},
+			"max_restart_attempts": schema.Int64Attribute{
+				Description: "How many times the query is restarted before it is left stopped. Removing the attribute keeps the current limit of the query. Defaults to the server default",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"restart_count": schema.Int64Attribute{
+				Description: "How many times the query was restarted, if reported by the server",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"notification_targets": schema.SetAttribute{
+				Description: "Names of the notification targets alerted when the query fails. Removing the attribute clears the targets of the query",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(util.IdentifierValidators...),
+				},
+			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the query. Tags can be changed without re-creating the query",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  util.TagValidators,
+			},
+			"tags_all": schema.MapAttribute{
+				Description: "Tags of the query merged with the default_tags of the provider",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"update_strategy": schema.StringAttribute{
+				Description: "How changes to sql, source_relation_fqns and pinned_version are applied. replace terminates the query and creates it again, blue_green launches the new statement as the next version of the query, waits for it to catch up with the previous version and then terminates the previous version. Both versions write to the sinks while the new one catches up. Changes to sink_relation_fqns always replace the query. Defaults to replace",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(updateStrategyReplace, updateStrategyBlueGreen),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Prevent the query from being terminated by a destroy or a replacement. Set it to false and apply before destroying or replacing the query. Defaults to false",
+				Optional:    true,
+			},
+			"catch_up": schema.SingleNestedAttribute{
+				Description: "When the new version of a query rolled out blue/green has caught up and the previous version is terminated",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"max_lag": schema.Int64Attribute{
+						Description: "Largest number of records the new version may be behind the previous version on each source partition, comparing the offsets both commit as reported by DESCRIBE QUERY STATE. Defaults to 0",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"timeout": schema.StringAttribute{
+						Description: "How long to wait for the new version to catch up, as a duration such as 30m, before it is terminated and the previous version kept. Defaults to 30m",
+						Optional:    true,
+						Validators: []validator.String{
+							util.DurationValidator{},
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRoot("update_strategy")),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Name of the Database used to resolve unqualified relation names in sql. Session defaults apply when not set",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "Name of the Schema used to resolve unqualified relation names in sql",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.All(util.IdentifierValidators...),
+					stringvalidator.AlsoRequires(path.MatchRoot("database")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"store": schema.StringAttribute{
+				Description: "Name of the Store used to resolve the entities of relations in sql. Session defaults apply when not set",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
@@ -131,28 +358,90 @@ func (d *QueryResource) Metadata(ctx context.Context, req resource.MetadataReque
 	resp.TypeName = req.ProviderTypeName + "_query"
 }
 
-type statementPlan struct {
-	Ddl     *relationPlan  `json:"ddl,omitempty"`
-	Sink    *relationPlan  `json:"sink,omitempty"`
-	Sources []relationPlan `json:"sources,omitempty"`
+// ImportState adopts an existing query by its ID. The SQL of the query is read from LIST QUERIES by the Read that
+// follows the import, and its source and sink relations from the plan of the SQL.
+func (d *QueryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("query_id"), req, resp)
 }
 
-type relationPlan struct {
-	Fqn        string `json:"fqn"`
-	Type       string `json:"type"`
-	DbName     string `json:"db_name"`
-	SchemaName string `json:"schema_name"`
-	Name       string `json:"name"`
-	StoreName  string `json:"store_name"`
-}
+func (d *QueryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultOwner(ctx, "query", req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ValidateOwner(ctx, resp.Plan, req.State)...)
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultTags(ctx, &resp.Plan)...)
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, req.Plan, resp.RequiresReplace)...)
 
-type artifactDDL struct {
-	Type    string `json:"type"`
-	Name    string `json:"name"`
-	Command string `json:"command"`
-	Summary string `json:"summary"`
+	if req.State.Raw.IsNull() && !req.Plan.Raw.IsNull() {
+		replaced, dg := req.Private.GetKey(ctx, replacedQueryKey)
+		resp.Diagnostics.Append(dg...)
+		resp.Diagnostics.Append(planResumedFrom(ctx, replaced, &resp.Plan)...)
+		return
+	}
+
+	// warn that the sinks stop receiving data while the query is terminated and re-created
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var current QueryResourceData
+	var planned QueryResourceData
+	resp.Diagnostics.Append(req.State.Get(ctx, &current)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a replacement is planned again as a create without the prior state, hand the query it replaces over in
+	// private state so that it can resume from its committed positions
+	if planned.ResumeFrom.ValueString() == resumeFromLastCommitted {
+		b, err := json.Marshal(current.QueryID.ValueString())
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to record replaced query", err)
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, replacedQueryKey, b)...)
+	}
+
+	replacedBy := []string{}
+	if !planned.SourceRelations.Equal(current.SourceRelations) {
+		replacedBy = append(replacedBy, "source_relation_fqns")
+	}
+	if !planned.SinkRelations.Equal(current.SinkRelations) {
+		replacedBy = append(replacedBy, "sink_relation_fqns")
+	}
+	if !planned.Sql.Equal(current.Sql) {
+		replacedBy = append(replacedBy, "sql")
+	}
+	if !planned.PinnedVersion.Equal(current.PinnedVersion) {
+		replacedBy = append(replacedBy, "pinned_version")
+	}
+	if len(replacedBy) == 0 {
+		return
+	}
+
+	// blue/green rollouts keep the sinks fed, only a change of sinks replaces the query
+	if planned.UpdateStrategy.ValueString() == updateStrategyBlueGreen && planned.SinkRelations.Equal(current.SinkRelations) {
+		resp.Diagnostics.Append(planRollout(ctx, &resp.Plan, planned, current)...)
+		return
+	}
+
+	var sinkRelations []string
+	resp.Diagnostics.Append(current.SinkRelations.ElementsAs(ctx, &sinkRelations, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		fmt.Sprintf("Replacing query %s will interrupt its sinks", current.QueryID.ValueString()),
+		fmt.Sprintf("Changes to %s force the query to be terminated and re-created. The following relations stop receiving data until the new query is running:\n  - %s",
+			strings.Join(replacedBy, ", "), strings.Join(sinkRelations, "\n  - ")),
+	)
 }
 
+// statementPlan is the plan DESCRIBE reports for a query statement.
 // Create implements resource.Resource.
 func (d *QueryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var query QueryResourceData
@@ -175,39 +464,130 @@ func (d *QueryResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	defer conn.Close()
 
+	if err := setQueryContext(ctx, conn, query); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set sql context", err)
+		return
+	}
+
+	query, dg := d.validateStatement(ctx, conn, query)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sinkRelations []string
+	resp.Diagnostics.Append(query.SinkRelations.ElementsAs(ctx, &sinkRelations, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "query", "into "+strings.Join(sinkRelations, ", "))...)
+		return
+	}
+
+	previousQueryID := ""
+	if query.ResumeFrom.ValueString() == resumeFromLastCommitted {
+		if previousQueryID = query.ResumedFromQueryID.ValueString(); previousQueryID == "" {
+			resp.Diagnostics.AddWarning("no committed positions to resume from",
+				"The query does not replace another query, it starts from the server default position")
+		}
+	}
+
+	query, dg = d.launch(ctx, conn, query, previousQueryID)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	refreshRestartCount(ctx, conn, &query)
+
+	tflog.Info(ctx, "query created", map[string]any{"name": query.QueryID.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_query", query.manifestName(), query.QueryID.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, query)...)
+}
+
+// manifestName names a query in the manifest by its query_name, falling back to its ID for unnamed queries.
+func (q QueryResourceData) manifestName() string {
+	if q.Name.ValueString() != "" {
+		return q.Name.ValueString()
+	}
+	return q.QueryID.ValueString()
+}
+
+// setQueryContext sets the database, schema and store of the query on the connection, so that the statement compiles
+// the same way regardless of the defaults of the session.
+func setQueryContext(ctx context.Context, conn *sql.Conn, query QueryResourceData) error {
+	return util.SetSqlContext(ctx, conn, query.Database.ValueStringPointer(), query.Schema.ValueStringPointer(), query.Store.ValueStringPointer())
+}
+
+// validateStatement resolves the statement of the pinned version, if any, and checks that the statement is an INSERT
+// INTO reading from and writing to the relations set on the resource.
+func (d *QueryResource) validateStatement(ctx context.Context, conn *sql.Conn, query QueryResourceData) (QueryResourceData, diag.Diagnostics) {
+	var dg diag.Diagnostics
+
+	if !query.PinnedVersion.IsNull() {
+		stmt, err := queryVersionSql(ctx, conn, d.cfg.Organization, query.Name.ValueString(), query.PinnedVersion.ValueInt64())
+		if err != nil {
+			return query, util.LogError(ctx, dg, "failed to read pinned query version", err)
+		}
+		query.Sql = types.StringValue(stmt)
+	}
+
 	row := conn.QueryRowContext(ctx, "DESCRIBE "+query.Sql.ValueString())
 	var kind string
 	var descJson string
 	if err := row.Scan(&kind, &descJson); err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create relation", err)
-		return
+		return query, util.LogError(ctx, dg, "failed to create relation", err)
 	}
 
-	if !util.ArrayContains([]string{kind}, []string{"INSERT_INTO"}) {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("invalid query type: %s", kind))
-		return
+	if !util.ContainsAny([]string{"INSERT_INTO"}, kind) {
+		return query, util.LogError(ctx, dg, "planning error", fmt.Errorf("invalid query type: %s", kind))
 	}
 
-	statementPlan := statementPlan{}
+	statementPlan := util.StatementPlan{}
 	if err := json.Unmarshal([]byte(descJson), &statementPlan); err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to parse query plan", err)
-		return
+		return query, util.LogError(ctx, dg, "failed to parse query plan", err)
 	}
 
 	if statementPlan.Ddl != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("invalid query plan"))
-		return
+		return query, util.LogError(ctx, dg, "planning error", fmt.Errorf("invalid query plan"))
 	}
 
-	if d.cfg.Organization+"."+strings.TrimSpace(query.SinkRelation.ValueString()) != statementPlan.Sink.Fqn {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("sink relation mismatch %s != %s", d.cfg.Organization+"."+query.SinkRelation.ValueString(), statementPlan.Sink.Fqn))
-		return
+	var sinkRelations []string
+	dg.Append(query.SinkRelations.ElementsAs(ctx, &sinkRelations, false)...)
+	if dg.HasError() {
+		return query, dg
+	}
+	planSinks := statementPlan.AllSinks()
+	for _, sink := range planSinks {
+		found := false
+		for _, sinkRelation := range sinkRelations {
+			if d.cfg.Organization+"."+strings.TrimSpace(sinkRelation) == sink.Fqn {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return query, util.LogError(ctx, dg, "planning error", fmt.Errorf("query uses sink relation %s but it is not specified as a sink on the resource", sink.Fqn))
+		}
+	}
+	for _, sinkRelation := range sinkRelations {
+		found := false
+		for _, sink := range planSinks {
+			if d.cfg.Organization+"."+strings.TrimSpace(sinkRelation) == sink.Fqn {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return query, util.LogError(ctx, dg, "planning error", fmt.Errorf("sink relation %s is specified on the resource but is not a sink of the query", sinkRelation))
+		}
 	}
 
 	var sourceRelations []string
-	resp.Diagnostics.Append(query.SourceRelations.ElementsAs(ctx, &sourceRelations, false)...)
-	if resp.Diagnostics.HasError() {
-		return
+	dg.Append(query.SourceRelations.ElementsAs(ctx, &sourceRelations, false)...)
+	if dg.HasError() {
+		return query, dg
 	}
 	for _, source := range statementPlan.Sources {
 		found := false
@@ -218,18 +598,52 @@ func (d *QueryResource) Create(ctx context.Context, req resource.CreateRequest,
 			}
 		}
 		if !found {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("query uses source relation %s but it is not specified as a source on the resource", source.Fqn))
-			return
+			return query, util.LogError(ctx, dg, "planning error", fmt.Errorf("query uses source relation %s but it is not specified as a source on the resource", source.Fqn))
 		}
 	}
+	return query, dg
+}
+
+// launch starts the statement of the query and waits for it to run. previousQueryID is the query whose committed
+// positions the new query resumes from when resume_from is last_committed. A query that fails to start is terminated.
+func (d *QueryResource) launch(ctx context.Context, conn *sql.Conn, query QueryResourceData, previousQueryID string) (QueryResourceData, diag.Diagnostics) {
+	var dg diag.Diagnostics
 
-	artifactDDL := artifactDDL{}
-	row = conn.QueryRowContext(ctx, query.Sql.ValueString())
+	artifactDDL := util.ArtifactDDL{}
+	props := append(resumeProperties(query.ResumeFrom.ValueString(), previousQueryID), restartProperties(query)...)
+	props = append(props, notificationProperties(query)...)
+	tags, tagsDg := util.TagsProperty(ctx, query.TagsAll)
+	dg.Append(tagsDg...)
+	if dg.HasError() {
+		return query, dg
+	}
+	if tags != "" {
+		props = append(props, tags)
+	}
+	launchCtx, statement := util.WithStatementRecorder(ctx)
+	row := conn.QueryRowContext(launchCtx, withQueryProperties(query.Sql.ValueString(), props))
 	if err := row.Scan(&artifactDDL.Type, &artifactDDL.Name, &artifactDDL.Command, &artifactDDL.Summary); err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to launch query", err)
-		return
+		return query, util.LogError(ctx, dg, "failed to launch query", err)
 	}
+	query.StatementID = statement.ID()
 	query.QueryID = types.StringValue(artifactDDL.Name)
+	query.ResumedFromQueryID = types.StringNull()
+	if previousQueryID != "" {
+		query.ResumedFromQueryID = types.StringValue(previousQueryID)
+	}
+
+	if stmt := alterQueryStatement(query, QueryResourceData{}); stmt != "" {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			// the query is running, keep it and leave the name and description to the next apply
+			tflog.Warn(ctx, "failed to set query name and description", map[string]any{
+				"Query ID": query.QueryID.ValueString(),
+				"error":    err.Error(),
+			})
+			dg.AddWarning("query name and description not set",
+				fmt.Sprintf("Query %s was launched but setting its name and description failed, the next apply sets them again: %s", query.QueryID.ValueString(), err))
+			query.Description = types.StringNull()
+		}
+	}
 
 	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*10, retry.NewConstant(time.Second*15)), func(ctx context.Context) (err error) {
 		query, err = d.updateComputed(ctx, conn, query, false)
@@ -251,33 +665,26 @@ func (d *QueryResource) Create(ctx context.Context, req resource.CreateRequest,
 
 		return retry.RetryableError(fmt.Errorf("relation not yet created"))
 	}); err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "query failed to start", err)
+		dg = util.LogError(ctx, dg, "query failed to start", err)
 		if _, derr := conn.ExecContext(ctx, fmt.Sprintf(`TERMINATE QUERY %s;`, query.QueryID.ValueString())); derr != nil {
 			tflog.Error(ctx, "failed to clean up schema", map[string]any{
 				"Query ID": query.QueryID.ValueString(),
 				"error":    derr.Error(),
 			})
 		}
-		return
+		return query, dg
 	}
-
-	tflog.Info(ctx, "query created", map[string]any{"name": query.QueryID.ValueString()})
-	resp.Diagnostics.Append(resp.State.Set(ctx, query)...)
+	return query, dg
 }
 
 func (d *QueryResource) updateComputed(ctx context.Context, conn *sql.Conn, rel QueryResourceData, includeStopped bool) (QueryResourceData, error) {
-	sql := `LIST QUERIES;`
+	stmt := `LIST QUERIES;`
 	if includeStopped {
-		sql = `LIST QUERIES WITH ('all');`
-	}
-
-	rows, err := conn.QueryContext(ctx, sql)
-	if err != nil {
-		return rel, err
+		stmt = `LIST QUERIES WITH ('all');`
 	}
-	defer rows.Close()
 
-	for rows.Next() {
+	found := false
+	if err := util.QueryRows(ctx, conn, stmt, func(rows *sql.Rows) error {
 		var (
 			id            string
 			name          string
@@ -291,20 +698,60 @@ func (d *QueryResource) updateComputed(ctx context.Context, conn *sql.Conn, rel
 		)
 
 		if err := rows.Scan(&id, &name, &version, &intendedState, &actualState, &query, &owner, &createdAt, &updatedAt); err != nil {
-			return rel, err
+			return err
 		}
-		if id == rel.QueryID.ValueString() {
-			rel.QueryID = types.StringValue(id)
-			rel.Name = types.StringValue(name)
-			rel.Version = types.Int64Value(version)
-			rel.State = types.StringValue(actualState)
-			rel.Owner = types.StringValue(owner)
-			rel.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-			rel.UpdatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-			return rel, nil
+		if id != rel.QueryID.ValueString() {
+			return nil
 		}
+		found = true
+		rel.ID = util.ResourceID(d.cfg.Organization, "query", id)
+		rel.QueryID = types.StringValue(id)
+		rel.Name = types.StringValue(name)
+		if rel.Sql.IsNull() {
+			// only imported queries have no SQL in state
+			rel.Sql = types.StringValue(query)
+		}
+		rel.Version = types.Int64Value(version)
+		rel.State = types.StringValue(actualState)
+		rel.Owner = types.StringValue(owner)
+		rel.CreatedAt = util.TimestampValue(createdAt)
+		rel.UpdatedAt = util.TimestampValue(updatedAt)
+		return util.ErrStopRows
+	}); err != nil {
+		return rel, err
+	}
+	if !found {
+		return rel, gods.ErrSQLError{SQLCode: gods.SqlStateInvalidQuery}
 	}
-	return rel, &gods.ErrSQLError{SQLCode: gods.SqlStateInvalidQuery}
+	return rel, nil
+}
+
+// readRelations sets the source and sink relations of a query from the plan of its statement. A statement that cannot
+// be planned is reported as a warning, the relations are then set from the configuration, replacing the query.
+func (d *QueryResource) readRelations(ctx context.Context, conn *sql.Conn, query *QueryResourceData) diag.Diagnostics {
+	var dg diag.Diagnostics
+
+	_, plan, err := util.DescribeStatement(ctx, conn, query.Sql.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, "unable to plan query statement", map[string]any{
+			"Query ID": query.QueryID.ValueString(),
+			"error":    err.Error(),
+		})
+		dg.AddWarning("Unable to read query relations",
+			fmt.Sprintf("The statement of query %s could not be planned to read its source and sink relations: %s. Applying the configuration replaces the query.", query.QueryID.ValueString(), err))
+		return dg
+	}
+
+	sources, sourcesDg := types.ListValueFrom(ctx, types.StringType, util.RelationFqns(d.cfg.Organization, plan.Sources))
+	dg.Append(sourcesDg...)
+	sinks, sinksDg := types.ListValueFrom(ctx, types.StringType, util.RelationFqns(d.cfg.Organization, plan.AllSinks()))
+	dg.Append(sinksDg...)
+	if dg.HasError() {
+		return dg
+	}
+	query.SourceRelations = sources
+	query.SinkRelations = sinks
+	return dg
 }
 
 func (d *QueryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -315,6 +762,15 @@ func (d *QueryResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "query", query.QueryID.ValueString())...)
+		return
+	}
+	if query.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(deletionProtectedError(query.QueryID.ValueString()))
+		return
+	}
+
 	roleName := d.cfg.Role
 	if !query.Owner.IsNull() && !query.Owner.IsUnknown() {
 		roleName = query.Owner.ValueString()
@@ -327,65 +783,255 @@ func (d *QueryResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 	defer conn.Close()
 
-	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`TERMINATE QUERY %s;`, query.QueryID.ValueString())); err != nil {
+	query, positions, dg := d.terminate(ctx, conn, query)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Query committed positions", map[string]any{
+		"Query ID":  query.QueryID.ValueString(),
+		"positions": positions,
+	})
+
+	if query.PurgeOnDestroy.ValueBool() {
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP QUERY %s;`, query.QueryID.ValueString())); err != nil {
+			var sqlErr gods.ErrSQLError
+			if errors.As(err, &sqlErr) && (sqlErr.SQLCode == gods.SqlStateFeatureNotSupported || sqlErr.SQLCode == gods.SqlStateInvalidQuery) {
+				tflog.Warn(ctx, "query history not purged", map[string]any{
+					"Query ID": query.QueryID.ValueString(),
+					"error":    err.Error(),
+				})
+			} else {
+				resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to purge query", err)
+				return
+			}
+		}
+	}
+
+	tflog.Info(ctx, "Query terminated", map[string]any{"name": query.QueryID.ValueString()})
+}
+
+// terminate stops the query with its stop mode and waits until its committed positions reached their final state. A
+// query that no longer exists is not an error, and neither it nor a query that errored has positions to wait for.
+func (d *QueryResource) terminate(ctx context.Context, conn *sql.Conn, query QueryResourceData) (QueryResourceData, queryPositions, diag.Diagnostics) {
+	var dg diag.Diagnostics
+	var err error
+
+	// a drained stop waits for sinks to flush and the final savepoint, allow it more time to reach its final state
+	terminateStmt := fmt.Sprintf(`TERMINATE QUERY %s;`, query.QueryID.ValueString())
+	stopTimeout := time.Minute * 5
+	stateTimeout := time.Minute * 10
+	if query.StopMode.ValueString() == stopModeDrain {
+		terminateStmt = fmt.Sprintf(`TERMINATE QUERY %s WITH ('drain' = true);`, query.QueryID.ValueString())
+		stopTimeout = time.Minute * 30
+		stateTimeout = time.Minute * 30
+	}
+
+	if _, err := conn.ExecContext(ctx, terminateStmt); err != nil {
 		var sqlErr gods.ErrSQLError
-		if !errors.As(err, &sqlErr) || sqlErr.SQLCode != gods.SqlStateInvalidQuery {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to terminate query", err)
-			return
+		switch {
+		case errors.As(err, &sqlErr) && sqlErr.SQLCode == gods.SqlStateInvalidQuery:
+			tflog.Info(ctx, "query no longer exists", map[string]any{"Query ID": query.QueryID.ValueString()})
+			return query, nil, dg
+		case errors.As(err, &sqlErr) && sqlErr.SQLCode == gods.SqlStateFeatureNotSupported && query.StopMode.ValueString() == stopModeDrain:
+			return query, nil, util.LogError(ctx, dg, "failed to drain query", fmt.Errorf("draining queries is not supported by the server, set stop_mode to %s to terminate the query: %w", stopModeImmediate, err))
+		default:
+			return query, nil, util.LogError(ctx, dg, "failed to terminate query", err)
 		}
 	}
 
-	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) error {
-		query, err = d.updateComputed(ctx, conn, query, true)
+	gone := false
+	if err := retry.Do(ctx, retry.WithMaxDuration(stopTimeout, retry.NewExponential(time.Second)), func(ctx context.Context) error {
+		current, err := d.updateComputed(ctx, conn, query, true)
 		if err != nil {
+			if util.IsNotFound("query", err) {
+				gone = true
+				return nil
+			}
 			return err
 		}
+		query = current
 
-		if query.State.ValueString() == "stopped" {
+		switch query.State.ValueString() {
+		case "stopped":
+			return nil
+		case "errored":
+			gone = true
 			return nil
 		}
-
 		return retry.RetryableError(fmt.Errorf("query not yet terminated"))
 	}); err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to terminate query", err)
-		return
+		return query, nil, util.LogError(ctx, dg, "failed to terminate query", err)
+	}
+	if gone {
+		tflog.Info(ctx, "query already stopped on the server, not waiting for its positions", map[string]any{"Query ID": query.QueryID.ValueString(), "state": query.State.ValueString()})
+		return query, nil, dg
 	}
 
-	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*10, retry.NewExponential(time.Second)), func(ctx context.Context) error {
-		sql := fmt.Sprintf(`DESCRIBE QUERY STATE %s;`, query.QueryID.ValueString())
-		rows, err := conn.QueryContext(ctx, sql)
+	var positions queryPositions
+	if err := retry.Do(ctx, retry.WithMaxDuration(stateTimeout, retry.NewExponential(time.Second)), func(ctx context.Context) error {
+		positions, err = describeQueryState(ctx, conn, query.QueryID.ValueString())
+		if util.IsNotFound("query", err) {
+			positions = nil
+			return nil
+		}
 		if err != nil {
 			return retry.RetryableError(fmt.Errorf("unable to lookup query state: %w", err))
 		}
-		defer rows.Close()
-
-		stateReady := true
-		for rows.Next() {
-			var (
-				discard any
-				state   string
-			)
-
-			if err := rows.Scan(&discard, &discard, &discard, &state); err != nil {
-				return fmt.Errorf("unable to read query state: %w", err)
-			}
-			stateReady = stateReady && (state == "completed")
-		}
 
-		if stateReady {
+		if positions.completed() {
 			return nil
 		}
 		return retry.RetryableError(fmt.Errorf("state information not available"))
 	}); err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to terminate query", err)
-		return
+		return query, nil, util.LogError(ctx, dg, "failed to terminate query", err)
 	}
+	return query, positions, dg
+}
 
-	tflog.Info(ctx, "Query terminated", map[string]any{"name": query.QueryID.ValueString()})
+// alterQueryStatement returns the statement applying the name and description of query that differ from prior, or an
+// empty string when neither changed. A removed description is cleared, a removed name is kept by the server.
+func alterQueryStatement(query, prior QueryResourceData) string {
+	props := []string{}
+	if !query.Name.IsNull() && !query.Name.IsUnknown() && !query.Name.Equal(prior.Name) {
+		props = append(props, fmt.Sprintf(`'name' = '%s'`, strings.ReplaceAll(query.Name.ValueString(), "'", "''")))
+	}
+	if !query.Description.IsUnknown() && !query.Description.Equal(prior.Description) {
+		props = append(props, fmt.Sprintf(`'description' = '%s'`, strings.ReplaceAll(query.Description.ValueString(), "'", "''")))
+	}
+	if len(props) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`ALTER QUERY %s SET (%s);`, query.QueryID.ValueString(), strings.Join(props, ", "))
 }
 
 func (d *QueryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("query updates not supported"))
+	var currentQuery QueryResourceData
+	var newQuery QueryResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &newQuery)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentQuery)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "query", currentQuery.QueryID.ValueString())...)
+		return
+	}
+
+	// changes to the statement force replacement, only the name and description can be changed in place
+	if !newQuery.Owner.IsUnknown() && !newQuery.Owner.Equal(currentQuery.Owner) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("query owner cannot be changed"))
+		return
+	}
+
+	roleName := d.cfg.Role
+	if !currentQuery.Owner.IsNull() && !currentQuery.Owner.IsUnknown() {
+		roleName = currentQuery.Owner.ValueString()
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if newQuery.UpdateStrategy.ValueString() == updateStrategyBlueGreen && statementChanged(newQuery, currentQuery) {
+		if err := setQueryContext(ctx, conn, newQuery); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set sql context", err)
+			return
+		}
+		query, dg := d.rollout(ctx, conn, newQuery, currentQuery)
+		resp.Diagnostics.Append(dg...)
+		if query.QueryID.Equal(currentQuery.QueryID) {
+			// the rollout failed and the previous query is still running
+			resp.Diagnostics.Append(resp.State.Set(ctx, currentQuery)...)
+			return
+		}
+		query, err = d.updateComputed(ctx, conn, query, true)
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
+			return
+		}
+		refreshRestartCount(ctx, conn, &query)
+
+		tflog.Info(ctx, "query rolled out", map[string]any{"name": query.QueryID.ValueString(), "previous": currentQuery.QueryID.ValueString()})
+		resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_query", query.manifestName(), query.QueryID.ValueString())...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, query)...)
+		return
+	}
+
+	newQuery.QueryID = currentQuery.QueryID
+	if stmt := alterQueryStatement(newQuery, currentQuery); stmt != "" {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set query name and description", err)
+			return
+		}
+	}
+
+	if !newQuery.RestartPolicy.Equal(currentQuery.RestartPolicy) || !newQuery.MaxRestartAttempts.Equal(currentQuery.MaxRestartAttempts) {
+		if props := restartProperties(newQuery); len(props) > 0 {
+			if _, err := conn.ExecContext(ctx, fmt.Sprintf(`ALTER QUERY %s SET (%s);`, newQuery.QueryID.ValueString(), strings.Join(props, ", "))); err != nil {
+				resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set query restart policy", err)
+				return
+			}
+		}
+		if (newQuery.RestartPolicy.IsNull() && !currentQuery.RestartPolicy.IsNull()) || (newQuery.MaxRestartAttempts.IsNull() && !currentQuery.MaxRestartAttempts.IsNull()) {
+			resp.Diagnostics.AddWarning("query restart policy unchanged",
+				fmt.Sprintf("restart_policy and max_restart_attempts were removed from the configuration, query %s keeps its current restart policy", newQuery.QueryID.ValueString()))
+		}
+	}
+
+	if !newQuery.NotificationTargets.Equal(currentQuery.NotificationTargets) {
+		props := notificationProperties(newQuery)
+		if props == nil {
+			props = []string{`'notification.targets' = ''`}
+		}
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf(`ALTER QUERY %s SET (%s);`, newQuery.QueryID.ValueString(), strings.Join(props, ", "))); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set query notification targets", err)
+			return
+		}
+	}
+
+	if !newQuery.TagsAll.Equal(currentQuery.TagsAll) {
+		stmt, dg := util.SetTagsStatement(ctx, "QUERY", newQuery.QueryID.ValueString(), newQuery.TagsAll)
+		resp.Diagnostics.Append(dg...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set query tags", err)
+			return
+		}
+	}
+
+	currentQuery.Description = newQuery.Description
+	currentQuery.TerminatedGracePeriod = newQuery.TerminatedGracePeriod
+	currentQuery.PurgeOnDestroy = newQuery.PurgeOnDestroy
+	currentQuery.StopMode = newQuery.StopMode
+	currentQuery.ResumeFrom = newQuery.ResumeFrom
+	currentQuery.RestartPolicy = newQuery.RestartPolicy
+	currentQuery.MaxRestartAttempts = newQuery.MaxRestartAttempts
+	currentQuery.NotificationTargets = newQuery.NotificationTargets
+	currentQuery.Tags = newQuery.Tags
+	currentQuery.TagsAll = newQuery.TagsAll
+	currentQuery.DeletionProtection = newQuery.DeletionProtection
+	currentQuery, err = d.updateComputed(ctx, conn, currentQuery, true)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
+		return
+	}
+	refreshRestartCount(ctx, conn, &currentQuery)
+
+	tflog.Info(ctx, "query updated", map[string]any{"name": currentQuery.QueryID.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_query", currentQuery.manifestName(), currentQuery.QueryID.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, currentQuery)...)
 }
 
 func (d *QueryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -409,16 +1055,64 @@ func (d *QueryResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 	defer conn.Close()
 
+	recorded := query
 	query, err = d.updateComputed(ctx, conn, query, true)
 	if err != nil {
-		var godsErr gods.ErrSQLError
-		if errors.As(err, &godsErr) && godsErr.SQLCode == gods.SqlStateInvalidQuery {
-			resp.State.RemoveResource(ctx)
+		if util.RemoveIfNotFound(ctx, &resp.State, "query", err) {
 			return
 		}
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
 		return
 	}
+	resp.Diagnostics.Append(d.cfg.ReportDrift("query", query.manifestName(), path.Root("owner"), recorded.Owner, query.Owner)...)
+	if query.SourceRelations.IsNull() || query.SinkRelations.IsNull() {
+		// only imported queries have no relations in state
+		resp.Diagnostics.Append(d.readRelations(ctx, conn, &query)...)
+	}
+
+	if terminatedBeyondGrace(query, time.Now()) {
+		tflog.Info(ctx, "query terminated, removing from state", map[string]any{"name": query.QueryID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	refreshRestartCount(ctx, conn, &query)
+	resp.Diagnostics.Append(d.cfg.ReadTags(ctx, conn, fmt.Sprintf(`DESCRIBE QUERY %s;`, query.QueryID.ValueString()), &query.Tags, &query.TagsAll)...)
+
+	// the committed positions are only kept for diagnostics, failing to read them does not fail the refresh
+	if positions, err := describeQueryState(ctx, conn, query.QueryID.ValueString()); err != nil {
+		tflog.Warn(ctx, "unable to read query committed positions", map[string]any{
+			"Query ID": query.QueryID.ValueString(),
+			"error":    err.Error(),
+		})
+	} else if b, err := json.Marshal(positions); err == nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, committedPositionsKey, b)...)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, query)...)
 }
+
+// defaultTerminatedGracePeriod is how long a query may remain terminated when terminated_grace_period is not set.
+const defaultTerminatedGracePeriod = 24 * time.Hour
+
+// terminatedBeyondGrace reports whether a query that is still listed by LIST QUERIES WITH ('all') was stopped
+// longer ago than its grace period, in which case it is treated as deleted. A query whose stop time cannot be read is
+// kept.
+func terminatedBeyondGrace(query QueryResourceData, now time.Time) bool {
+	if query.State.ValueString() != "stopped" {
+		return false
+	}
+
+	grace := defaultTerminatedGracePeriod
+	if !query.TerminatedGracePeriod.IsNull() && !query.TerminatedGracePeriod.IsUnknown() {
+		if d, err := time.ParseDuration(query.TerminatedGracePeriod.ValueString()); err == nil {
+			grace = d
+		}
+	}
+
+	stoppedAt, err := time.Parse(time.RFC3339Nano, query.UpdatedAt.ValueString())
+	if err != nil {
+		return false
+	}
+	return now.Sub(stoppedAt) >= grace
+}
@@ -0,0 +1,208 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ resource.Resource = &QuerySavepointResource{}
+var _ resource.ResourceWithConfigure = &QuerySavepointResource{}
+
+func NewQuerySavepointResource() resource.Resource {
+	return &QuerySavepointResource{}
+}
+
+// QuerySavepointResource records the positions a running query committed when it is created, so that a configuration
+// can snapshot the state of a query before the changes depending on it are applied. Changing triggers takes a new one.
+type QuerySavepointResource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+type QuerySavepointResourceData struct {
+	ID        types.String `tfsdk:"id"`
+	QueryID   types.String `tfsdk:"query_id"`
+	Triggers  types.Map    `tfsdk:"triggers"`
+	Timeout   types.String `tfsdk:"timeout"`
+	TakenAt   types.String `tfsdk:"taken_at"`
+	Positions types.List   `tfsdk:"positions"`
+}
+
+func (d *QuerySavepointResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Query savepoint resource. Waits until a running query committed a position on every partition of its sources and records them, place it before the changes that risk the state of the query with `depends_on`. DeltaStream has no statement to take a savepoint on demand, the recorded positions are the ones the query last committed, which a query created with `resume_from` set to `last_committed` resumes from. Destroying the resource only removes it from state.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Savepoint",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"query_id": schema.StringAttribute{
+				Description: "ID of the Query to take a savepoint of",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that take a new savepoint whenever they change, for example the SQL of the query about to be replaced",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "How long to wait for the query to commit a position on every source, as a duration such as 10m. Defaults to 10m",
+				Optional:    true,
+				Validators: []validator.String{
+					util.DurationValidator{},
+				},
+			},
+			"taken_at": schema.StringAttribute{
+				Description: "Time the positions were recorded",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"positions": schema.ListAttribute{
+				Description: "Positions committed by the query, one per source partition. Each position maps the columns reported by DESCRIBE QUERY STATE, in snake case, to their value",
+				Computed:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (d *QuerySavepointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+func (d *QuerySavepointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_savepoint"
+}
+
+func (d *QuerySavepointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var savepoint QuerySavepointResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &savepoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultSavepointTimeout
+	if !savepoint.Timeout.IsNull() && !savepoint.Timeout.IsUnknown() {
+		// validated by util.DurationValidator
+		timeout, _ = time.ParseDuration(savepoint.Timeout.ValueString())
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "query savepoint", savepoint.QueryID.ValueString())...)
+		return
+	}
+
+	positions, err := committedPositions(ctx, conn, savepoint.QueryID.ValueString(), timeout)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to take query savepoint", err)
+		return
+	}
+
+	positionList, dg := types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, positions)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	savepoint.TakenAt = util.TimestampValue(time.Now())
+	savepoint.ID = util.ResourceID(d.cfg.Organization, "query_savepoint", savepoint.QueryID.ValueString(), savepoint.TakenAt.ValueString())
+	savepoint.Positions = positionList
+
+	tflog.Info(ctx, "Query savepoint taken", map[string]any{"query_id": savepoint.QueryID.ValueString(), "positions": positions})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_query_savepoint", savepoint.QueryID.ValueString(), savepoint.QueryID.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, savepoint)...)
+}
+
+// Update only records the new timeout, every other change takes a new savepoint.
+func (d *QuerySavepointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var savepoint QuerySavepointResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &savepoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "query savepoint", savepoint.QueryID.ValueString())...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, savepoint)...)
+}
+
+// Read keeps the recorded positions, they are a snapshot and do not follow the query.
+func (d *QuerySavepointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var savepoint QuerySavepointResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &savepoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, savepoint)...)
+}
+
+// Delete only removes the savepoint from state.
+func (d *QuerySavepointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var savepoint QuerySavepointResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &savepoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "query savepoint", savepoint.QueryID.ValueString())...)
+		return
+	}
+
+	tflog.Info(ctx, "Query savepoint removed from state", map[string]any{"query_id": savepoint.QueryID.ValueString(), "taken_at": savepoint.TakenAt.ValueString()})
+}
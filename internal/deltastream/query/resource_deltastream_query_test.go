@@ -0,0 +1,230 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const testOrganization = "00000000-0000-0000-0000-000000000001"
+
+func TestQueryUpdateComputed(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^LIST QUERIES WITH \('all'\);$`,
+		Columns: []mockserver.Column{
+			{Name: "id", Type: "VARCHAR"},
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "version", Type: "BIGINT"},
+			{Name: "intended_state", Type: "VARCHAR"},
+			{Name: "actual_state", Type: "VARCHAR"},
+			{Name: "query", Type: "VARCHAR"},
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+			{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("other", "other_query", "1", "running", "running", "INSERT INTO b SELECT * FROM a;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+			mockserver.Row("q1", "pageviews_query", "3", "running", "running", "INSERT INTO b SELECT * FROM a;", "sysadmin", "2024-01-02 03:04:05.5Z", "2024-02-03 04:05:06.123456789Z"),
+		},
+	}})
+
+	d := &QueryResource{cfg: &config.DeltaStreamProviderCfg{Organization: testOrganization}}
+	query, err := d.updateComputed(ctx, conn, QueryResourceData{QueryID: types.StringValue("q1")}, true)
+	if err != nil {
+		t.Fatalf("updateComputed() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"id":         testOrganization + "/query/q1",
+		"query_name": "pageviews_query",
+		"state":      "running",
+		"owner":      "sysadmin",
+		"created_at": "2024-01-02T03:04:05.5Z",
+		"updated_at": "2024-02-03T04:05:06.123456789Z",
+	}
+	actual := map[string]string{
+		"id":         query.ID.ValueString(),
+		"query_name": query.Name.ValueString(),
+		"state":      query.State.ValueString(),
+		"owner":      query.Owner.ValueString(),
+		"created_at": query.CreatedAt.ValueString(),
+		"updated_at": query.UpdatedAt.ValueString(),
+	}
+	for k, v := range expected {
+		if actual[k] != v {
+			t.Errorf("%s = %q, want %q", k, actual[k], v)
+		}
+	}
+	if query.Version.ValueInt64() != 3 {
+		t.Errorf("query_version = %d, want 3", query.Version.ValueInt64())
+	}
+
+	if _, err := d.updateComputed(ctx, conn, QueryResourceData{QueryID: types.StringValue("missing")}, true); err == nil {
+		t.Errorf("updateComputed() of a missing query succeeded")
+	}
+}
+
+func TestQueryReadRelations(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE INSERT INTO b SELECT \* FROM a JOIN c`,
+		Columns:   []mockserver.Column{{Name: "kind", Type: "VARCHAR"}, {Name: "plan", Type: "VARCHAR"}},
+		Rows: [][]*string{mockserver.Row("INSERT_INTO", `{"sink":{"fqn":"`+testOrganization+`.db.public.b"},`+
+			`"sources":[{"fqn":"`+testOrganization+`.db.public.a"},{"fqn":"`+testOrganization+`.db.public.c"}]}`)},
+	}, {
+		Statement: `^DESCRIBE INSERT INTO missing`,
+		SqlState:  string(gods.SqlStateInvalidRelation),
+		Message:   "relation missing does not exist",
+	}})
+
+	d := &QueryResource{cfg: &config.DeltaStreamProviderCfg{Organization: testOrganization}}
+	query := QueryResourceData{
+		QueryID:         types.StringValue("q1"),
+		Sql:             types.StringValue("INSERT INTO b SELECT * FROM a JOIN c ON a.id = c.id;"),
+		SourceRelations: types.ListNull(types.StringType),
+		SinkRelations:   types.ListNull(types.StringType),
+	}
+	if dg := d.readRelations(ctx, conn, &query); dg.HasError() || dg.WarningsCount() > 0 {
+		t.Fatalf("readRelations() = %v", dg)
+	}
+	var sources, sinks []string
+	query.SourceRelations.ElementsAs(ctx, &sources, false)
+	query.SinkRelations.ElementsAs(ctx, &sinks, false)
+	if strings.Join(sources, ",") != "db.public.a,db.public.c" {
+		t.Errorf("source_relation_fqns = %v, want [db.public.a db.public.c]", sources)
+	}
+	if strings.Join(sinks, ",") != "db.public.b" {
+		t.Errorf("sink_relation_fqns = %v, want [db.public.b]", sinks)
+	}
+
+	unplanned := QueryResourceData{
+		QueryID:         types.StringValue("q2"),
+		Sql:             types.StringValue("INSERT INTO missing SELECT * FROM a;"),
+		SourceRelations: types.ListNull(types.StringType),
+		SinkRelations:   types.ListNull(types.StringType),
+	}
+	if dg := d.readRelations(ctx, conn, &unplanned); dg.HasError() || dg.WarningsCount() != 1 {
+		t.Errorf("readRelations() of a statement that cannot be planned = %v, want one warning", dg)
+	}
+	if !unplanned.SourceRelations.IsNull() || !unplanned.SinkRelations.IsNull() {
+		t.Errorf("readRelations() of a statement that cannot be planned set the relations")
+	}
+}
+
+func TestTerminatedBeyondGrace(t *testing.T) {
+	now := time.Date(2024, 11, 12, 10, 0, 0, 0, time.UTC)
+	stoppedAt := util.TimestampValue(now.Add(-5 * time.Minute))
+
+	tests := []struct {
+		name      string
+		state     string
+		stoppedAt types.String
+		grace     types.String
+		want      bool
+	}{
+		{name: "running", state: "running", stoppedAt: stoppedAt, grace: types.StringNull(), want: false},
+		{name: "stopped within default grace", state: "stopped", stoppedAt: stoppedAt, grace: types.StringNull(), want: false},
+		{name: "stopped beyond default grace", state: "stopped", stoppedAt: util.TimestampValue(now.Add(-25 * time.Hour)), grace: types.StringNull(), want: true},
+		{name: "stopped within grace", state: "stopped", stoppedAt: stoppedAt, grace: types.StringValue("10m"), want: false},
+		{name: "stopped beyond grace", state: "stopped", stoppedAt: stoppedAt, grace: types.StringValue("1m"), want: true},
+		{name: "stop time unknown", state: "stopped", stoppedAt: types.StringNull(), grace: types.StringValue("1m"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := QueryResourceData{
+				State:                 types.StringValue(tt.state),
+				UpdatedAt:             tt.stoppedAt,
+				TerminatedGracePeriod: tt.grace,
+			}
+			if got := terminatedBeyondGrace(query, now); got != tt.want {
+				t.Errorf("terminatedBeyondGrace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerminateStaleQuery(t *testing.T) {
+	ctx := context.Background()
+	server, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^TERMINATE QUERY gone;$`,
+		SqlState:  string(gods.SqlStateInvalidQuery),
+		Message:   "query not found",
+	}, {
+		Statement: `^TERMINATE QUERY failed;$`,
+	}, {
+		Statement: `^LIST QUERIES WITH \('all'\);$`,
+		Columns: []mockserver.Column{
+			{Name: "id", Type: "VARCHAR"},
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "version", Type: "BIGINT"},
+			{Name: "intended_state", Type: "VARCHAR"},
+			{Name: "actual_state", Type: "VARCHAR"},
+			{Name: "query", Type: "VARCHAR"},
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+			{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("failed", "pageviews_query", "1", "running", "errored", "INSERT INTO b SELECT * FROM a;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+		},
+	}})
+
+	d := &QueryResource{cfg: &config.DeltaStreamProviderCfg{Organization: testOrganization}}
+	for _, id := range []string{"gone", "failed"} {
+		start := time.Now()
+		_, positions, dg := d.terminate(ctx, conn, QueryResourceData{QueryID: types.StringValue(id), StopMode: types.StringValue(stopModeImmediate)})
+		if dg.HasError() {
+			t.Fatalf("terminate(%s) diagnostics = %v", id, dg)
+		}
+		if positions != nil {
+			t.Errorf("terminate(%s) positions = %v, want none", id, positions)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second*5 {
+			t.Errorf("terminate(%s) took %s, expected the stale query to be skipped", id, elapsed)
+		}
+	}
+	for _, stmt := range server.Statements() {
+		if strings.HasPrefix(stmt, "DESCRIBE QUERY STATE") {
+			t.Errorf("unexpected statement %s", stmt)
+		}
+	}
+}
+
+func TestAlterQueryStatement(t *testing.T) {
+	named := QueryResourceData{QueryID: types.StringValue("q1"), Name: types.StringValue("copy"), Description: types.StringNull()}
+	described := named
+	described.Description = types.StringValue("copies pageviews")
+
+	tests := []struct {
+		name  string
+		query QueryResourceData
+		prior QueryResourceData
+		want  string
+	}{
+		{name: "launch without description", query: named, prior: QueryResourceData{}, want: `ALTER QUERY q1 SET ('name' = 'copy');`},
+		{name: "launch with description", query: described, prior: QueryResourceData{}, want: `ALTER QUERY q1 SET ('name' = 'copy', 'description' = 'copies pageviews');`},
+		{name: "unchanged", query: described, prior: described, want: ""},
+		{name: "description added", query: described, prior: named, want: `ALTER QUERY q1 SET ('description' = 'copies pageviews');`},
+		{name: "description removed", query: named, prior: described, want: `ALTER QUERY q1 SET ('description' = '');`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alterQueryStatement(tt.query, tt.prior); got != tt.want {
+				t.Errorf("alterQueryStatement() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
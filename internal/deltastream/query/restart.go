@@ -0,0 +1,90 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Policies for restarting a query after it fails or stops.
+const (
+	restartPolicyAlways    = "always"
+	restartPolicyOnFailure = "on-failure"
+	restartPolicyNever     = "never"
+)
+
+// restartProperties returns the query properties applying the configured restart policy, or nil when none is
+// configured.
+func restartProperties(query QueryResourceData) []string {
+	var props []string
+	if !query.RestartPolicy.IsNull() && !query.RestartPolicy.IsUnknown() {
+		props = append(props, fmt.Sprintf(`'restart.policy' = '%s'`, query.RestartPolicy.ValueString()))
+	}
+	if !query.MaxRestartAttempts.IsNull() && !query.MaxRestartAttempts.IsUnknown() {
+		props = append(props, fmt.Sprintf(`'restart.max_attempts' = %d`, query.MaxRestartAttempts.ValueInt64()))
+	}
+	return props
+}
+
+// refreshRestartCount updates the restart count of the query. The count is only reported for observability, failing to
+// read it keeps the last known count instead of failing the operation.
+func refreshRestartCount(ctx context.Context, conn *sql.Conn, query *QueryResourceData) {
+	count, err := describeRestartCount(ctx, conn, query.QueryID.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, "unable to read query restart count", map[string]any{
+			"Query ID": query.QueryID.ValueString(),
+			"error":    err.Error(),
+		})
+		if query.RestartCount.IsUnknown() {
+			query.RestartCount = types.Int64Null()
+		}
+		return
+	}
+	query.RestartCount = count
+}
+
+// describeRestartCount returns how many times the query was restarted, or null when the server does not report it.
+func describeRestartCount(ctx context.Context, conn *sql.Conn, queryID string) (types.Int64, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`DESCRIBE QUERY %s;`, queryID))
+	if err != nil {
+		return types.Int64Null(), err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return types.Int64Null(), err
+	}
+	if !rows.Next() {
+		return types.Int64Null(), rows.Err()
+	}
+
+	values := make([]sql.NullString, len(cols))
+	dest := make([]any, len(cols))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return types.Int64Null(), err
+	}
+
+	for i, col := range cols {
+		if strings.ReplaceAll(strings.ToLower(col), " ", "_") != "restart_count" || !values[i].Valid {
+			continue
+		}
+		count, err := strconv.ParseInt(values[i].String, 10, 64)
+		if err != nil {
+			return types.Int64Null(), fmt.Errorf("invalid restart count %q: %w", values[i].String, err)
+		}
+		return types.Int64Value(count), nil
+	}
+	return types.Int64Null(), nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRestartProperties(t *testing.T) {
+	tests := []struct {
+		name  string
+		query QueryResourceData
+		want  []string
+	}{
+		{
+			name:  "not configured",
+			query: QueryResourceData{RestartPolicy: types.StringNull(), MaxRestartAttempts: types.Int64Null()},
+		},
+		{
+			name:  "policy only",
+			query: QueryResourceData{RestartPolicy: types.StringValue(restartPolicyNever), MaxRestartAttempts: types.Int64Null()},
+			want:  []string{`'restart.policy' = 'never'`},
+		},
+		{
+			name:  "policy and attempts",
+			query: QueryResourceData{RestartPolicy: types.StringValue(restartPolicyOnFailure), MaxRestartAttempts: types.Int64Value(3)},
+			want:  []string{`'restart.policy' = 'on-failure'`, `'restart.max_attempts' = 3`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := restartProperties(tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("restartProperties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sethvargo/go-retry"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const defaultSavepointTimeout = time.Minute * 10
+
+// querySavepoint holds the positions a version of a query committed on its sources, a query created with resume_from
+// set to last_committed resumes from them.
+type querySavepoint struct {
+	QueryID   string
+	Version   int64
+	State     string
+	Positions queryPositions
+}
+
+// listQuerySavepoints returns the positions committed by every version of the query, from the oldest version to the
+// latest. DeltaStream keeps the committed positions of a query once it is terminated, versions whose state the
+// server no longer reports are left out.
+func listQuerySavepoints(ctx context.Context, conn *sql.Conn, organization, queryID string) ([]querySavepoint, error) {
+	versions, err := listQueryVersionsOf(ctx, conn, organization, queryID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("query %s not found", queryID)
+	}
+
+	savepoints := []querySavepoint{}
+	for _, v := range versions {
+		positions, err := describeQueryState(ctx, conn, v.QueryID.ValueString())
+		if util.IsNotFound("query", err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		savepoints = append(savepoints, querySavepoint{
+			QueryID:   v.QueryID.ValueString(),
+			Version:   v.Version.ValueInt64(),
+			State:     v.State.ValueString(),
+			Positions: positions,
+		})
+	}
+	return savepoints, nil
+}
+
+// committedPositions waits until the query committed a position on every partition of its sources and returns
+// them. DeltaStream has no statement to take a savepoint on demand, a running query commits its positions on its own.
+func committedPositions(ctx context.Context, conn *sql.Conn, queryID string, timeout time.Duration) (queryPositions, error) {
+	var positions queryPositions
+	if err := retry.Do(ctx, retry.WithMaxDuration(timeout, retry.NewExponential(time.Second)), func(ctx context.Context) error {
+		p, err := describeQueryState(ctx, conn, queryID)
+		if err != nil {
+			return retry.RetryableError(fmt.Errorf("unable to lookup query state: %w", err))
+		}
+		if len(p) == 0 || len(p.offsets()) < len(p) {
+			return retry.RetryableError(fmt.Errorf("query %s has not committed a position on every source yet", queryID))
+		}
+		positions = p
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestCommittedPositions(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE QUERY STATE q1;$`,
+		Columns:   queryStateColumns,
+		Rows: [][]*string{
+			mockserver.Row("db.public.pageviews", "0", "42", "running"),
+			mockserver.Row("db.public.pageviews", "1", "7", "running"),
+		},
+	}, {
+		Statement: `^DESCRIBE QUERY STATE q2;$`,
+		Columns:   queryStateColumns,
+		Rows: [][]*string{
+			mockserver.Row("db.public.pageviews", "0", "42", "running"),
+			append(append(mockserver.Row("db.public.pageviews", "1"), nil), mockserver.Row("running")...),
+		},
+	}})
+
+	positions, err := committedPositions(ctx, conn, "q1", time.Second*5)
+	if err != nil {
+		t.Fatalf("committedPositions(q1) error = %v", err)
+	}
+	if len(positions) != 2 || positions[1]["offset"] != "7" {
+		t.Errorf("committedPositions(q1) = %v, want both partitions", positions)
+	}
+
+	if _, err := committedPositions(ctx, conn, "q2", time.Second*2); err == nil {
+		t.Errorf("committedPositions(q2) expected an error while a partition has no committed offset")
+	}
+}
+
+func TestListQuerySavepoints(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^LIST QUERIES WITH \('all'\);$`,
+		Columns: []mockserver.Column{
+			{Name: "id", Type: "VARCHAR"},
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "version", Type: "BIGINT"},
+			{Name: "intended_state", Type: "VARCHAR"},
+			{Name: "actual_state", Type: "VARCHAR"},
+			{Name: "query", Type: "VARCHAR"},
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+			{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("q3", "pageviews_query", "3", "running", "running", "INSERT INTO b SELECT * FROM a WHERE x > 2;", "sysadmin", "2024-01-03 00:00:00Z", "2024-01-03 00:00:00Z"),
+			mockserver.Row("other", "other_query", "1", "running", "running", "INSERT INTO d SELECT * FROM c;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+			mockserver.Row("q2", "pageviews_query", "2", "terminated", "terminated", "INSERT INTO b SELECT * FROM a WHERE x > 1;", "sysadmin", "2024-01-02 00:00:00Z", "2024-01-03 00:00:00Z"),
+			mockserver.Row("q1", "pageviews_query", "1", "terminated", "terminated", "INSERT INTO b SELECT * FROM a;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-02 00:00:00Z"),
+		},
+	}, {
+		Statement: `^DESCRIBE QUERY STATE q1;$`,
+		SqlState:  string(gods.SqlStateInvalidQuery),
+		Message:   "query q1 not found",
+	}, {
+		Statement: `^DESCRIBE QUERY STATE q[23];$`,
+		Columns:   queryStateColumns,
+		Rows:      [][]*string{mockserver.Row("db.public.pageviews", "0", "42", "completed")},
+	}})
+
+	savepoints, err := listQuerySavepoints(ctx, conn, testOrganization, "q3")
+	if err != nil {
+		t.Fatalf("listQuerySavepoints() error = %v", err)
+	}
+	if len(savepoints) != 2 || savepoints[0].QueryID != "q2" || savepoints[1].Version != 3 || savepoints[0].Positions[0]["offset"] != "42" {
+		t.Errorf("listQuerySavepoints() = %+v, want the positions of q2 and q3", savepoints)
+	}
+
+	if _, err := listQuerySavepoints(ctx, conn, testOrganization, "missing"); err == nil {
+		t.Errorf("listQuerySavepoints(missing) expected an error")
+	}
+}
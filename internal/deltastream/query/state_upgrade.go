@@ -0,0 +1,114 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// QueryResourceDataV0 is the state of a query at schema version 0, before a query could write to several sinks.
+type QueryResourceDataV0 struct {
+	ID              types.String `tfsdk:"id"`
+	SourceRelations types.List   `tfsdk:"source_relation_fqns"`
+	SinkRelation    types.String `tfsdk:"sink_relation_fqn"`
+	Sql             types.String `tfsdk:"sql"`
+	QueryID         types.String `tfsdk:"query_id"`
+	Name            types.String `tfsdk:"query_name"`
+	Version         types.Int64  `tfsdk:"query_version"`
+	Owner           types.String `tfsdk:"owner"`
+	State           types.String `tfsdk:"state"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+	UpdatedAt       types.String `tfsdk:"updated_at"`
+}
+
+// querySchemaV0 is the schema of a query at version 0. It is frozen, attributes added to the query since then must
+// not be added here or version 0 states no longer decode.
+func querySchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                   schema.StringAttribute{Computed: true},
+			"source_relation_fqns": schema.ListAttribute{Required: true, ElementType: basetypes.StringType{}},
+			"sink_relation_fqn":    schema.StringAttribute{Required: true},
+			"sql":                  schema.StringAttribute{Required: true},
+			"query_id":             schema.StringAttribute{Computed: true},
+			"query_name":           schema.StringAttribute{Computed: true},
+			"query_version":        schema.Int64Attribute{Computed: true},
+			"owner":                schema.StringAttribute{Optional: true, Computed: true},
+			"state":                schema.StringAttribute{Computed: true},
+			"created_at":           schema.StringAttribute{Computed: true},
+			"updated_at":           schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+func (d *QueryResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   querySchemaV0(),
+			StateUpgrader: upgradeQueryStateV0,
+		},
+	}
+}
+
+// upgradeQueryStateV0 moves the single sink of a version 0 query into sink_relation_fqns, attributes that did not
+// exist at version 0 are left null and refreshed by the next read.
+func upgradeQueryStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior QueryResourceDataV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sinkRelations, dg := types.ListValueFrom(ctx, types.StringType, []string{prior.SinkRelation.ValueString()})
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, QueryResourceData{
+		ID:              prior.ID,
+		SourceRelations: prior.SourceRelations,
+		SinkRelations:   sinkRelations,
+		Sql:             prior.Sql,
+		QueryID:         prior.QueryID,
+		Name:            prior.Name,
+		Description:     types.StringNull(),
+		Version:         prior.Version,
+		State:           prior.State,
+		Owner:           prior.Owner,
+		CreatedAt:       prior.CreatedAt,
+		UpdatedAt:       prior.UpdatedAt,
+
+		TerminatedGracePeriod: types.StringNull(),
+		PurgeOnDestroy:        types.BoolNull(),
+		StopMode:              types.StringNull(),
+		ResumeFrom:            types.StringNull(),
+		ResumedFromQueryID:    types.StringNull(),
+		RestartPolicy:         types.StringNull(),
+		MaxRestartAttempts:    types.Int64Null(),
+		RestartCount:          types.Int64Null(),
+
+		StatementID:   types.StringNull(),
+		PinnedVersion: types.Int64Null(),
+
+		NotificationTargets: types.SetNull(types.StringType),
+
+		Tags:    types.MapNull(types.StringType),
+		TagsAll: types.MapNull(types.StringType),
+
+		UpdateStrategy: types.StringNull(),
+		CatchUp:        types.ObjectNull(catchUpAttributeTypes),
+
+		DeletionProtection: types.BoolNull(),
+
+		Database: types.StringNull(),
+		Schema:   types.StringNull(),
+		Store:    types.StringNull(),
+	})...)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const queryStateV0 = `{
+	"id": "q1",
+	"source_relation_fqns": ["db.public.pageviews"],
+	"sink_relation_fqn": "db.public.pageviews_copy",
+	"sql": "INSERT INTO pageviews_copy SELECT * FROM pageviews;",
+	"query_id": "q1",
+	"query_name": "copy",
+	"query_version": 2,
+	"owner": "sysadmin",
+	"state": "running",
+	"created_at": "2024-01-01T00:00:00Z",
+	"updated_at": "2024-01-01T00:00:00Z"
+}`
+
+func TestUpgradeQueryStateV0(t *testing.T) {
+	ctx := context.Background()
+	d := &QueryResource{}
+	upgrader, ok := d.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatalf("no upgrader for version 0")
+	}
+
+	raw, err := tfprotov6.RawState{JSON: []byte(queryStateV0)}.Unmarshal(upgrader.PriorSchema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("failed to decode version 0 state: %v", err)
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	d.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	req := resource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: raw}}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("StateUpgrader() errors = %v", resp.Diagnostics)
+	}
+
+	var query QueryResourceData
+	if dg := resp.State.Get(ctx, &query); dg.HasError() {
+		t.Fatalf("failed to read upgraded state: %v", dg)
+	}
+	var sinks []string
+	query.SinkRelations.ElementsAs(ctx, &sinks, false)
+	if len(sinks) != 1 || sinks[0] != "db.public.pageviews_copy" {
+		t.Errorf("sink_relation_fqns = %v, want [db.public.pageviews_copy]", sinks)
+	}
+	if query.QueryID.ValueString() != "q1" || query.Version.ValueInt64() != 2 || query.Owner.ValueString() != "sysadmin" {
+		t.Errorf("upgraded query = %+v, want the version 0 values", query)
+	}
+	if !query.Database.IsNull() || !query.Tags.IsNull() || !query.CatchUp.IsNull() {
+		t.Errorf("attributes added after version 0 should be null, got database %s, tags %s, catch_up %s", query.Database, query.Tags, query.CatchUp)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sqlToken is a significant token of a statement: a word, a quoted string or identifier, or a punctuation character.
+// depth is the number of parentheses the token is nested in.
+type sqlToken struct {
+	text       string
+	start, end int
+	depth      int
+}
+
+// tokenizeSQL splits a statement into its significant tokens, skipping whitespace and comments. It is the only lexer
+// of the package, the statements of queries are rewritten and compared on its tokens. Quotes are escaped by doubling
+// them inside quoted strings and identifiers, and by a backslash inside quoted strings. A comment or quoted token
+// that is not terminated runs to the end of the statement.
+func tokenizeSQL(stmt string) []sqlToken {
+	tokens := []sqlToken{}
+	depth := 0
+	isWord := func(r rune) bool { return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) }
+	for i := 0; i < len(stmt); {
+		c, size := utf8.DecodeRuneInString(stmt[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case strings.HasPrefix(stmt[i:], "--"):
+			if n := strings.IndexByte(stmt[i:], '\n'); n >= 0 {
+				i += n + 1
+			} else {
+				i = len(stmt)
+			}
+		case strings.HasPrefix(stmt[i:], "/*"):
+			if n := strings.Index(stmt[i+2:], "*/"); n >= 0 {
+				i += n + 4
+			} else {
+				i = len(stmt)
+			}
+		case c == '\'' || c == '"':
+			end := i + 1
+			for end < len(stmt) {
+				if c == '\'' && stmt[end] == '\\' {
+					end += 2
+					continue
+				}
+				if rune(stmt[end]) == c {
+					if end+1 < len(stmt) && rune(stmt[end+1]) == c {
+						end += 2
+						continue
+					}
+					end++
+					break
+				}
+				end++
+			}
+			end = min(end, len(stmt))
+			tokens = append(tokens, sqlToken{text: stmt[i:end], start: i, end: end, depth: depth})
+			i = end
+		case isWord(c):
+			end := i + size
+			for end < len(stmt) {
+				r, n := utf8.DecodeRuneInString(stmt[end:])
+				if !isWord(r) {
+					break
+				}
+				end += n
+			}
+			tokens = append(tokens, sqlToken{text: stmt[i:end], start: i, end: end, depth: depth})
+			i = end
+		default:
+			if c == ')' && depth > 0 {
+				depth--
+			}
+			tokens = append(tokens, sqlToken{text: stmt[i : i+size], start: i, end: i + size, depth: depth})
+			if c == '(' {
+				depth++
+			}
+			i += size
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,86 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenizeSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		stmt string
+		want []string
+	}{
+		{
+			name: "words and punctuation",
+			stmt: "INSERT INTO sink SELECT a.b, COUNT(*) FROM source;",
+			want: []string{"INSERT", "INTO", "sink", "SELECT", "a", ".", "b", ",", "COUNT", "(", "*", ")", "FROM", "source", ";"},
+		},
+		{
+			name: "comments",
+			stmt: "SELECT -- line comment\n 1 /* block\ncomment */ FROM t -- trailing",
+			want: []string{"SELECT", "1", "FROM", "t"},
+		},
+		{
+			name: "doubled quotes",
+			stmt: `SELECT 'it''s', "a ""b""" FROM t`,
+			want: []string{"SELECT", `'it''s'`, ",", `"a ""b"""`, "FROM", "t"},
+		},
+		{
+			name: "backslash escaped quote",
+			stmt: `SELECT 'it\'s -- not a comment' FROM t`,
+			want: []string{"SELECT", `'it\'s -- not a comment'`, "FROM", "t"},
+		},
+		{
+			name: "backslash in an identifier",
+			stmt: `SELECT "a\" FROM t`,
+			want: []string{"SELECT", `"a\"`, "FROM", "t"},
+		},
+		{
+			name: "comment markers in literals",
+			stmt: `SELECT '/* x */', "--y" FROM t`,
+			want: []string{"SELECT", "'/* x */'", ",", `"--y"`, "FROM", "t"},
+		},
+		{
+			name: "unterminated literal",
+			stmt: `SELECT 'abc\`,
+			want: []string{"SELECT", `'abc\`},
+		},
+		{
+			name: "unterminated comment",
+			stmt: "SELECT 1 /* open",
+			want: []string{"SELECT", "1"},
+		},
+		{
+			name: "non ascii",
+			stmt: "SELECT * FROM voilà",
+			want: []string{"SELECT", "*", "FROM", "voilà"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := tokenizeSQL(tt.stmt)
+			got := make([]string, 0, len(tokens))
+			for _, tok := range tokens {
+				if tt.stmt[tok.start:tok.end] != tok.text {
+					t.Errorf("token %q spans %q", tok.text, tt.stmt[tok.start:tok.end])
+				}
+				got = append(got, tok.text)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenizeSQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	depths := []int{}
+	for _, tok := range tokenizeSQL("f(a, (b)) c") {
+		depths = append(depths, tok.depth)
+	}
+	if want := []int{0, 0, 1, 1, 1, 2, 1, 0, 0}; !reflect.DeepEqual(depths, want) {
+		t.Errorf("depths = %v, want %v", depths, want)
+	}
+}
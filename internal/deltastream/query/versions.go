@@ -0,0 +1,102 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// listQueryVersions returns every version of the named query, including the versions that are no longer running,
+// ordered from the oldest to the latest.
+func listQueryVersions(ctx context.Context, conn *sql.Conn, organization, name string) ([]QueryDataSourceData, error) {
+	return listQueryVersionsWhere(ctx, conn, organization, func(id, queryName string) bool { return queryName == name })
+}
+
+// listQueryVersionsOf returns every version of the query the query ID is a version of, ordered from the oldest to the
+// latest. It returns no version when no query has the ID.
+func listQueryVersionsOf(ctx context.Context, conn *sql.Conn, organization, queryID string) ([]QueryDataSourceData, error) {
+	all, err := listQueryVersionsWhere(ctx, conn, organization, func(id, queryName string) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range all {
+		if q.QueryID.ValueString() != queryID {
+			continue
+		}
+		versions := []QueryDataSourceData{}
+		for _, v := range all {
+			if v.Name.Equal(q.Name) {
+				versions = append(versions, v)
+			}
+		}
+		return versions, nil
+	}
+	return []QueryDataSourceData{}, nil
+}
+
+// listQueryVersionsWhere returns the queries LIST QUERIES reports, including the ones that are no longer running, that
+// match, ordered by version.
+func listQueryVersionsWhere(ctx context.Context, conn *sql.Conn, organization string, match func(id, name string) bool) ([]QueryDataSourceData, error) {
+	versions := []QueryDataSourceData{}
+	if err := util.QueryRows(ctx, conn, `LIST QUERIES WITH ('all');`, func(rows *sql.Rows) error {
+		var (
+			id            string
+			queryName     string
+			version       int64
+			intendedState string
+			actualState   string
+			query         string
+			owner         string
+			createdAt     time.Time
+			updatedAt     time.Time
+		)
+		if err := rows.Scan(&id, &queryName, &version, &intendedState, &actualState, &query, &owner, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+		if !match(id, queryName) {
+			return nil
+		}
+		versions = append(versions, QueryDataSourceData{
+			ID:        util.ResourceID(organization, "query", id),
+			QueryID:   types.StringValue(id),
+			Name:      types.StringValue(queryName),
+			Version:   types.Int64Value(version),
+			State:     types.StringValue(actualState),
+			Sql:       types.StringValue(query),
+			Owner:     types.StringValue(owner),
+			CreatedAt: util.TimestampValue(createdAt),
+			UpdatedAt: util.TimestampValue(updatedAt),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Version.ValueInt64() < versions[j].Version.ValueInt64()
+	})
+	return versions, nil
+}
+
+// queryVersionSql returns the SQL statement of a version of the named query.
+func queryVersionSql(ctx context.Context, conn *sql.Conn, organization, name string, version int64) (string, error) {
+	versions, err := listQueryVersions(ctx, conn, organization, name)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range versions {
+		if v.Version.ValueInt64() == version {
+			return v.Sql.ValueString(), nil
+		}
+	}
+	return "", fmt.Errorf("query %s has no version %d", name, version)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestListQueryVersions(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^LIST QUERIES WITH \('all'\);$`,
+		Columns: []mockserver.Column{
+			{Name: "id", Type: "VARCHAR"},
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "version", Type: "BIGINT"},
+			{Name: "intended_state", Type: "VARCHAR"},
+			{Name: "actual_state", Type: "VARCHAR"},
+			{Name: "query", Type: "VARCHAR"},
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+			{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("q3", "pageviews_query", "3", "running", "running", "INSERT INTO b SELECT * FROM a WHERE x > 2;", "sysadmin", "2024-01-03 00:00:00Z", "2024-01-03 00:00:00Z"),
+			mockserver.Row("other", "other_query", "1", "running", "running", "INSERT INTO d SELECT * FROM c;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+			mockserver.Row("q1", "pageviews_query", "1", "terminated", "terminated", "INSERT INTO b SELECT * FROM a;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-02 00:00:00Z"),
+		},
+	}})
+
+	versions, err := listQueryVersions(ctx, conn, testOrganization, "pageviews_query")
+	if err != nil {
+		t.Fatalf("listQueryVersions() error = %v", err)
+	}
+	if len(versions) != 2 || versions[0].QueryID.ValueString() != "q1" || versions[1].QueryID.ValueString() != "q3" {
+		t.Fatalf("expected versions q1 and q3 in order, got %+v", versions)
+	}
+	if versions[0].State.ValueString() != "terminated" {
+		t.Errorf("State = %s, want terminated", versions[0].State)
+	}
+
+	stmt, err := queryVersionSql(ctx, conn, testOrganization, "pageviews_query", 1)
+	if err != nil {
+		t.Fatalf("queryVersionSql() error = %v", err)
+	}
+	if stmt != "INSERT INTO b SELECT * FROM a;" {
+		t.Errorf("queryVersionSql() = %q", stmt)
+	}
+	if _, err := queryVersionSql(ctx, conn, testOrganization, "pageviews_query", 2); err == nil {
+		t.Error("expected an error for a missing version")
+	}
+}
@@ -5,6 +5,7 @@ package region
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
@@ -41,6 +42,7 @@ func (d *RegionDataSource) Configure(ctx context.Context, req datasource.Configu
 }
 
 type RegionDataSourceData struct {
+	ID     types.String `tfsdk:"id"`
 	Name   types.String `tfsdk:"name"`
 	Cloud  types.String `tfsdk:"cloud"`
 	Region types.String `tfsdk:"region"`
@@ -55,6 +57,10 @@ func getRegionSchema() schema.Schema {
 		MarkdownDescription: "Region resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Region",
+				Computed:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Name of the Region",
 				Required:    true,
@@ -91,28 +97,25 @@ func (d *RegionDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, `LIST REGIONS;`)
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list regions", err)
-		return
-	}
-	defer rows.Close()
-
 	found := false
-	for rows.Next() {
+	if err := util.QueryRows(ctx, conn, `LIST REGIONS;`, func(rows *sql.Rows) error {
 		var name string
 		var cloud string
 		var region string
 		if err := rows.Scan(&name, &cloud, &region); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read region", err)
-			return
+			return err
 		}
-		if name == dsRegion.Name.ValueString() {
-			found = true
-			dsRegion.Cloud = basetypes.NewStringValue(cloud)
-			dsRegion.Region = basetypes.NewStringValue(region)
-			break
+		if name != dsRegion.Name.ValueString() {
+			return nil
 		}
+		found = true
+		dsRegion.ID = util.ResourceID(d.cfg.Organization, "region", name)
+		dsRegion.Cloud = basetypes.NewStringValue(cloud)
+		dsRegion.Region = basetypes.NewStringValue(region)
+		return util.ErrStopRows
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list regions", err)
+		return
 	}
 
 	if !found {
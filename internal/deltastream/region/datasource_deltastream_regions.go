@@ -5,6 +5,7 @@ package region
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
@@ -54,6 +55,7 @@ func (d *RegionsDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 			},
 		},
 	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["items"].(schema.ListNestedAttribute), "name")
 }
 
 func (d *RegionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -62,6 +64,8 @@ func (d *RegionsDataSource) Metadata(ctx context.Context, req datasource.Metadat
 
 type SecretsDatasourceData struct {
 	Items types.List `tfsdk:"items"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
 }
 
 func (d *RegionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -79,32 +83,31 @@ func (d *RegionsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, `LIST REGIONS;`)
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list region", err)
-		return
-	}
-	defer rows.Close()
-
 	items := []RegionDataSourceData{}
-	for rows.Next() {
+	if err := util.QueryRows(ctx, conn, `LIST REGIONS;`, func(rows *sql.Rows) error {
 		var name string
 		var cloud string
 		var region string
 		if err := rows.Scan(&name, &cloud, &region); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read region", err)
-			return
+			return err
 		}
 		items = append(items, RegionDataSourceData{
+			ID:     util.ResourceID(d.cfg.Organization, "region", name),
 			Name:   types.StringValue(name),
 			Cloud:  types.StringValue(cloud),
 			Region: types.StringValue(region),
 		})
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list region", err)
+		return
 	}
 
 	var dg diag.Diagnostics
 	regions.Items, dg = types.ListValueFrom(ctx, regions.Items.ElementType(ctx), items)
 	resp.Diagnostics.Append(dg...)
+	regions.ItemsByName, dg = util.ItemsByName(ctx, regions.Items, "name")
+	resp.Diagnostics.Append(dg...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &regions)...)
 }
@@ -0,0 +1,253 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package region
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sethvargo/go-retry"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ resource.Resource = &RegionResource{}
+var _ resource.ResourceWithConfigure = &RegionResource{}
+
+func NewRegionResource() resource.Resource {
+	return &RegionResource{}
+}
+
+// RegionResource enables a data plane region for the organization. Destroying the resource disables the region
+// again, which the server refuses while stores or queries still run in it.
+type RegionResource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+type RegionResourceData struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Cloud  types.String `tfsdk:"cloud"`
+	Region types.String `tfsdk:"region"`
+
+	StatementID types.String `tfsdk:"statement_id"`
+}
+
+func (d *RegionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Region resource. Enables a data plane region for the organization, where the DeltaStream deployment supports managing regions. " +
+			"The provider reads the region back from `LIST REGIONS`, which does not report whether a region is enabled: a region that is disabled outside of Terraform " +
+			"but still listed is not detected as drift, and creating the resource only checks that the region is listed once `ENABLE REGION` succeeded.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Region",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the Region",
+				Required:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cloud": schema.StringAttribute{
+				Description: "Cloud provider of the Region",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "Cloud provider region",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that enabled the Region, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (d *RegionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+func (d *RegionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_region"
+}
+
+// regionManagementError replaces the error of a deployment that does not let organizations manage their regions with
+// one saying so, any other error is returned as is.
+func regionManagementError(err error) error {
+	var sqlErr gods.ErrSQLError
+	if errors.As(err, &sqlErr) && sqlErr.SQLCode == gods.SqlStateFeatureNotSupported {
+		return fmt.Errorf("the DeltaStream deployment does not support managing regions, contact DeltaStream support to enable a region: %w", err)
+	}
+	return err
+}
+
+// Create implements resource.Resource.
+func (d *RegionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var region RegionResourceData
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &region)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "region", region.Name.ValueString())...)
+		return
+	}
+	enableCtx, statement := util.WithStatementRecorder(ctx)
+	if _, err := conn.ExecContext(enableCtx, fmt.Sprintf(`ENABLE REGION "%s";`, region.Name.ValueString())); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to enable region", regionManagementError(err))
+		return
+	}
+	region.StatementID = statement.ID()
+
+	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
+		region, err = d.updateComputed(ctx, conn, region)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to enable region", err)
+		return
+	}
+	tflog.Info(ctx, "Region enabled", map[string]any{"name": region.Name.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_region", region.Name.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, region)...)
+}
+
+// updateComputed reads the region from LIST REGIONS. The listing has no state column, so a listed region is assumed to
+// be enabled and only a region missing from it is reported as not found.
+func (d *RegionResource) updateComputed(ctx context.Context, conn *sql.Conn, region RegionResourceData) (RegionResourceData, error) {
+	found := false
+	if err := util.QueryRows(ctx, conn, `LIST REGIONS;`, func(rows *sql.Rows) error {
+		var name string
+		var cloud string
+		var cloudRegion string
+		if err := rows.Scan(&name, &cloud, &cloudRegion); err != nil {
+			return err
+		}
+		if name != region.Name.ValueString() {
+			return nil
+		}
+		found = true
+		region.ID = util.ResourceID(d.cfg.Organization, "region", name)
+		region.Cloud = types.StringValue(cloud)
+		region.Region = types.StringValue(cloudRegion)
+		return util.ErrStopRows
+	}); err != nil {
+		return region, err
+	}
+	if !found {
+		return region, gods.ErrSQLError{SQLCode: gods.SqlStateInvalidRegion}
+	}
+	return region, nil
+}
+
+func (d *RegionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var region RegionResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &region)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "region", region.Name.ValueString())...)
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DISABLE REGION "%s";`, region.Name.ValueString())); err != nil {
+		if !util.IsNotFound("region", err) {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to disable region", regionManagementError(err))
+			return
+		}
+	}
+	tflog.Info(ctx, "Region disabled", map[string]any{"name": region.Name.ValueString()})
+}
+
+func (d *RegionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("region updates not supported"))
+}
+
+func (d *RegionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var region RegionResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &region)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	region, err = d.updateComputed(ctx, conn, region)
+	if err != nil {
+		if util.RemoveIfNotFound(ctx, &resp.State, "region", err) {
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read region state", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, region)...)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package region
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const testOrganization = "00000000-0000-0000-0000-000000000001"
+
+func TestRegionUpdateComputed(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^LIST REGIONS;$`,
+		Columns:   []mockserver.Column{{Name: "name", Type: "VARCHAR"}, {Name: "cloud", Type: "VARCHAR"}, {Name: "region", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("AWS us-east-1", "aws", "us-east-1"), mockserver.Row("AWS eu-west-1", "aws", "eu-west-1")},
+	}})
+
+	d := &RegionResource{cfg: &config.DeltaStreamProviderCfg{Organization: testOrganization}}
+	region, err := d.updateComputed(ctx, conn, RegionResourceData{Name: types.StringValue("AWS eu-west-1")})
+	if err != nil {
+		t.Fatalf("updateComputed() error = %v", err)
+	}
+	if region.Cloud.ValueString() != "aws" || region.Region.ValueString() != "eu-west-1" || region.ID.IsNull() {
+		t.Errorf("updateComputed() = %+v", region)
+	}
+
+	if _, err := d.updateComputed(ctx, conn, RegionResourceData{Name: types.StringValue("GCP us-central1")}); !util.IsNotFound("region", err) {
+		t.Errorf("updateComputed() error = %v, want region not found", err)
+	}
+}
+
+func TestRegionManagementError(t *testing.T) {
+	unsupported := gods.ErrSQLError{SQLCode: gods.SqlStateFeatureNotSupported}
+	if err := regionManagementError(unsupported); !errors.Is(err, unsupported) || !strings.Contains(err.Error(), "does not support managing regions") {
+		t.Errorf("regionManagementError() = %v", err)
+	}
+
+	other := errors.New("connection reset")
+	if err := regionManagementError(other); err != other {
+		t.Errorf("regionManagementError() = %v, want %v", err, other)
+	}
+}
@@ -42,6 +42,7 @@ func (d *RelationDataSource) Configure(ctx context.Context, req datasource.Confi
 }
 
 type RelationDataSourceData struct {
+	ID        types.String `tfsdk:"id"`
 	Database  types.String `tfsdk:"database"`
 	Schema    types.String `tfsdk:"schema"`
 	Name      types.String `tfsdk:"name"`
@@ -51,6 +52,15 @@ type RelationDataSourceData struct {
 	State     types.String `tfsdk:"state"`
 	CreatedAt types.String `tfsdk:"created_at"`
 	UpdatedAt types.String `tfsdk:"updated_at"`
+
+	PrimaryKey      types.List   `tfsdk:"primary_key"`
+	TimestampColumn types.String `tfsdk:"timestamp_column"`
+	EventTimeFormat types.String `tfsdk:"event_time_format"`
+
+	RawDescribeJSON types.String `tfsdk:"raw_describe_json"`
+
+	RetentionMs    types.Int64 `tfsdk:"retention_ms"`
+	RetentionBytes types.Int64 `tfsdk:"retention_bytes"`
 }
 
 func (d *RelationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -58,6 +68,10 @@ func (d *RelationDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		MarkdownDescription: "Relation resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Relation",
+				Computed:    true,
+			},
 			"database": schema.StringAttribute{
 				Description: "Name of the Database",
 				Required:    true,
@@ -91,7 +105,32 @@ func (d *RelationDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				Computed:    true,
 			},
 			"updated_at": schema.StringAttribute{
-				Description: "Creation date of the relation",
+				Description: "Last update date of the relation",
+				Computed:    true,
+			},
+			"primary_key": schema.ListAttribute{
+				Description: "Primary key columns of the relation",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"timestamp_column": schema.StringAttribute{
+				Description: "Column used as the event time of the relation",
+				Computed:    true,
+			},
+			"event_time_format": schema.StringAttribute{
+				Description: "Format of the event time column",
+				Computed:    true,
+			},
+			"raw_describe_json": schema.StringAttribute{
+				Description: "Result of DESCRIBE RELATION as a JSON object of the column names to their values, verbatim. Use jsondecode to read details the data source does not model yet",
+				Computed:    true,
+			},
+			"retention_ms": schema.Int64Attribute{
+				Description: "How long, in milliseconds, the topic of the relation retains records, -1 for no limit. Null when the relation is not backed by a Kafka topic",
+				Computed:    true,
+			},
+			"retention_bytes": schema.Int64Attribute{
+				Description: "How many bytes each partition of the topic of the relation retains, -1 for no limit. Null when the relation is not backed by a Kafka topic",
 				Computed:    true,
 			},
 		},
@@ -137,11 +176,33 @@ func (d *RelationDataSource) Read(ctx context.Context, req datasource.ReadReques
 	if err := row.Scan(&kind, &owner, &state, &createdAt, &updatedAt); err != nil {
 	}
 	rel.FQN = types.StringValue(fmt.Sprintf("%s.%s.%s", rel.Database.ValueString(), rel.Schema.ValueString(), rel.Name.ValueString()))
+	rel.ID = util.ResourceID(d.cfg.Organization, "relation", rel.FQN.ValueString())
 	rel.Owner = types.StringValue(owner)
 	rel.Type = types.StringValue(kind)
 	rel.State = types.StringValue(state)
-	rel.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-	rel.UpdatedAt = types.StringValue(createdAt.Format(time.RFC3339))
+	rel.CreatedAt = util.TimestampValue(createdAt)
+	rel.UpdatedAt = util.TimestampValue(updatedAt)
+
+	metadata, err := describeRelation(ctx, conn, rel.FQN.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to describe relation", err)
+		return
+	}
+	rel.PrimaryKey = metadata.PrimaryKey
+	rel.TimestampColumn = metadata.TimestampColumn
+	rel.EventTimeFormat = metadata.EventTimeFormat
+	rel.RawDescribeJSON = metadata.RawDescribeJSON
+
+	retention := nullRetention()
+	if !metadata.Store.IsNull() && !metadata.Topic.IsNull() {
+		retention, err = describeTopicRetention(ctx, conn, metadata.Store.ValueString(), metadata.Topic.ValueString())
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read relation retention", err)
+			return
+		}
+	}
+	rel.RetentionMs = retention.Ms
+	rel.RetentionBytes = retention.Bytes
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &rel)...)
 }
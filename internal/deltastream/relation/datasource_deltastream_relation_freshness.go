@@ -0,0 +1,259 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RelationFreshnessDataSource{}
+var _ datasource.DataSourceWithConfigure = &RelationFreshnessDataSource{}
+
+const (
+	defaultFreshnessSampleSize = 10
+	defaultFreshnessTimeout    = 30 * time.Second
+)
+
+func NewRelationFreshnessDataSource() datasource.DataSource {
+	return &RelationFreshnessDataSource{}
+}
+
+// RelationFreshnessDataSource samples the records flowing through a relation for a bounded amount of time. It is
+// intended for check blocks asserting that a pipeline produces data after apply.
+type RelationFreshnessDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *RelationFreshnessDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "provider error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type RelationFreshnessDataSourceData struct {
+	ID              types.String `tfsdk:"id"`
+	Database        types.String `tfsdk:"database"`
+	Schema          types.String `tfsdk:"schema"`
+	Name            types.String `tfsdk:"name"`
+	FQN             types.String `tfsdk:"fqn"`
+	SampleSize      types.Int64  `tfsdk:"sample_size"`
+	Timeout         types.String `tfsdk:"timeout"`
+	TimestampColumn types.String `tfsdk:"timestamp_column"`
+	SampledRows     types.Int64  `tfsdk:"sampled_rows"`
+	LastEventTime   types.String `tfsdk:"last_event_time"`
+}
+
+func (d *RelationFreshnessDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Samples the records of a relation for a bounded amount of time and reports the latest event time seen. Use it in `check` blocks to validate that a pipeline is flowing.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Relation",
+				Computed:    true,
+			},
+			"database": schema.StringAttribute{
+				Description: "Name of the Database",
+				Required:    true,
+			},
+			"schema": schema.StringAttribute{
+				Description: "Name of the Schema",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the Relation",
+				Required:    true,
+			},
+			"fqn": schema.StringAttribute{
+				Description: "Fully qualified name of the Relation",
+				Computed:    true,
+			},
+			"sample_size": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of records to read, defaults to %d", defaultFreshnessSampleSize),
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"timeout": schema.StringAttribute{
+				Description: fmt.Sprintf("Maximum time to wait for records, such as 30s or 2m, defaults to %s", defaultFreshnessTimeout),
+				Optional:    true,
+				Validators:  []validator.String{util.DurationValidator{}},
+			},
+			"timestamp_column": schema.StringAttribute{
+				Description: "Column used as the event time of the relation",
+				Computed:    true,
+			},
+			"sampled_rows": schema.Int64Attribute{
+				Description: "Number of records read before the sample size or the timeout was reached",
+				Computed:    true,
+			},
+			"last_event_time": schema.StringAttribute{
+				Description: "Latest event time among the sampled records, unset when no record was read or the relation has no timestamp column",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *RelationFreshnessDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_relation_freshness"
+}
+
+func (d *RelationFreshnessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	freshness := RelationFreshnessDataSourceData{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, &freshness)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sampleSize := int64(defaultFreshnessSampleSize)
+	if !freshness.SampleSize.IsNull() {
+		sampleSize = freshness.SampleSize.ValueInt64()
+	}
+	timeout := defaultFreshnessTimeout
+	if !freshness.Timeout.IsNull() {
+		d, err := time.ParseDuration(freshness.Timeout.ValueString())
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "invalid timeout", err)
+			return
+		}
+		timeout = d
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	freshness.FQN = types.StringValue(fmt.Sprintf("%s.%s.%s", freshness.Database.ValueString(), freshness.Schema.ValueString(), freshness.Name.ValueString()))
+	freshness.ID = util.ResourceID(d.cfg.Organization, "relation", freshness.FQN.ValueString())
+
+	metadata, err := describeRelation(ctx, conn, freshness.FQN.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to describe relation", err)
+		return
+	}
+	freshness.TimestampColumn = metadata.TimestampColumn
+
+	sampleCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rows, err := conn.QueryContext(sampleCtx, fmt.Sprintf(`SELECT * FROM %s;`, freshness.FQN.ValueString()))
+	if err != nil {
+		if sampleCtx.Err() != nil {
+			resp.Diagnostics.AddError("timed out sampling relation", fmt.Sprintf("relation %s did not start streaming within %s", freshness.FQN.ValueString(), timeout))
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to sample relation", err)
+		return
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read columns", err)
+		return
+	}
+	tsIndex := -1
+	if !metadata.TimestampColumn.IsNull() {
+		for i, col := range cols {
+			if strings.EqualFold(col, metadata.TimestampColumn.ValueString()) {
+				tsIndex = i
+				break
+			}
+		}
+	}
+
+	var (
+		sampled   int64
+		lastEvent time.Time
+	)
+	for sampled < sampleSize && rows.Next() {
+		// the driver may report a row when the sampling deadline interrupts it
+		if sampleCtx.Err() != nil {
+			break
+		}
+
+		values := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read relation record", err)
+			return
+		}
+		sampled++
+
+		if tsIndex >= 0 {
+			if t, ok := eventTime(values[tsIndex]); ok && t.After(lastEvent) {
+				lastEvent = t
+			}
+		}
+	}
+	if err := rows.Err(); err != nil && sampleCtx.Err() == nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to sample relation", err)
+		return
+	}
+
+	tflog.Info(ctx, "Relation sampled", map[string]any{
+		"fqn":          freshness.FQN.ValueString(),
+		"sampled_rows": sampled,
+	})
+
+	freshness.SampledRows = types.Int64Value(sampled)
+	freshness.LastEventTime = types.StringNull()
+	if !lastEvent.IsZero() {
+		freshness.LastEventTime = util.TimestampValue(lastEvent)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &freshness)...)
+}
+
+// eventTimeLayouts are the textual timestamp formats accepted for event time columns.
+var eventTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999"}
+
+// eventTime converts an event time column value to a time. Numeric values are epoch milliseconds.
+func eventTime(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case int64:
+		return time.UnixMilli(t).UTC(), true
+	case *big.Int:
+		if !t.IsInt64() {
+			return time.Time{}, false
+		}
+		return time.UnixMilli(t.Int64()).UTC(), true
+	case string:
+		for _, layout := range eventTimeLayouts {
+			if parsed, err := time.Parse(layout, t); err == nil {
+				return parsed, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
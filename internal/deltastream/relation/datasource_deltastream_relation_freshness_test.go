@@ -0,0 +1,38 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestEventTime(t *testing.T) {
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value any
+		ok    bool
+	}{
+		{name: "time", value: want, ok: true},
+		{name: "epoch millis", value: want.UnixMilli(), ok: true},
+		{name: "bigint", value: big.NewInt(want.UnixMilli()), ok: true},
+		{name: "rfc3339", value: "2024-01-02T03:04:05Z", ok: true},
+		{name: "sql timestamp", value: "2024-01-02 03:04:05", ok: true},
+		{name: "invalid", value: "yesterday", ok: false},
+		{name: "null", value: nil, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := eventTime(tt.value)
+			if ok != tt.ok {
+				t.Fatalf("eventTime(%v) ok = %v, want %v", tt.value, ok, tt.ok)
+			}
+			if ok && !got.Equal(want) {
+				t.Errorf("eventTime(%v) = %v, want %v", tt.value, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ datasource.DataSource = &RelationQueriesDataSource{}
+var _ datasource.DataSourceWithConfigure = &RelationQueriesDataSource{}
+
+func NewRelationQueriesDataSource() datasource.DataSource {
+	return &RelationQueriesDataSource{}
+}
+
+// RelationQueriesDataSource lists the queries writing to and reading from a relation, to order destroys and analyse
+// the impact of changing the relation.
+type RelationQueriesDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *RelationQueriesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "provider error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type RelationQueriesDataSourceData struct {
+	ID             types.String `tfsdk:"id"`
+	FQN            types.String `tfsdk:"fqn"`
+	IncludeStopped types.Bool   `tfsdk:"include_stopped"`
+	Producers      types.List   `tfsdk:"producers"`
+	Consumers      types.List   `tfsdk:"consumers"`
+}
+
+type RelationQueryData struct {
+	QueryID types.String `tfsdk:"query_id"`
+	Name    types.String `tfsdk:"name"`
+	State   types.String `tfsdk:"state"`
+}
+
+func (RelationQueryData) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"query_id": types.StringType,
+		"name":     types.StringType,
+		"state":    types.StringType,
+	}
+}
+
+func (d *RelationQueriesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	queryAttributes := map[string]schema.Attribute{
+		"query_id": schema.StringAttribute{
+			Description: "Query ID",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Name of the query",
+			Computed:    true,
+		},
+		"state": schema.StringAttribute{
+			Description: "Actual state of the query",
+			Computed:    true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the queries writing to and reading from a relation. Use it to order the destruction of queries before the relations they use, or to find the queries affected by a change to a relation.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Relation",
+				Computed:    true,
+			},
+			"fqn": schema.StringAttribute{
+				Description: "Fully qualified name of the Relation, such as db.public.pageviews",
+				Required:    true,
+			},
+			"include_stopped": schema.BoolAttribute{
+				Description: "Include queries that are no longer running",
+				Optional:    true,
+			},
+			"producers": schema.ListNestedAttribute{
+				Description: "Queries writing to the relation",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: queryAttributes,
+				},
+			},
+			"consumers": schema.ListNestedAttribute{
+				Description: "Queries reading from the relation",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: queryAttributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *RelationQueriesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_relation_queries"
+}
+
+func (d *RelationQueriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	data := RelationQueriesDataSourceData{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	producers, consumers, err := relationQueries(ctx, conn, d.cfg.Organization, data.FQN.ValueString(), data.IncludeStopped.ValueBool())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list queries", err)
+		return
+	}
+
+	data.ID = util.ResourceID(d.cfg.Organization, "relation", data.FQN.ValueString())
+	var dg diag.Diagnostics
+	data.Producers, dg = relationQueryList(ctx, producers)
+	resp.Diagnostics.Append(dg...)
+	data.Consumers, dg = relationQueryList(ctx, consumers)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func relationQueryList(ctx context.Context, queries []relationQuery) (types.List, diag.Diagnostics) {
+	items := make([]RelationQueryData, 0, len(queries))
+	for _, q := range queries {
+		items = append(items, RelationQueryData{
+			QueryID: types.StringValue(q.ID),
+			Name:    types.StringValue(q.Name),
+			State:   types.StringValue(q.State),
+		})
+	}
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: RelationQueryData{}.AttributeTypes()}, items)
+}
@@ -5,6 +5,7 @@ package relation
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -46,6 +47,8 @@ type RelationsDataSourceData struct {
 	Database  types.String `tfsdk:"database"`
 	Schema    types.String `tfsdk:"schema"`
 	Relations types.List   `tfsdk:"relations"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
 }
 
 func (d *RelationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -66,6 +69,10 @@ func (d *RelationsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Global identifier of the Relation",
+							Computed:    true,
+						},
 						"database": schema.StringAttribute{
 							Description: "Name of the Database",
 							Computed:    true,
@@ -99,7 +106,20 @@ func (d *RelationsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 							Computed:    true,
 						},
 						"updated_at": schema.StringAttribute{
-							Description: "Creation date of the relation",
+							Description: "Last update date of the relation",
+							Computed:    true,
+						},
+						"primary_key": schema.ListAttribute{
+							Description: "Primary key columns of the relation",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"timestamp_column": schema.StringAttribute{
+							Description: "Column used as the event time of the relation",
+							Computed:    true,
+						},
+						"event_time_format": schema.StringAttribute{
+							Description: "Format of the event time column",
 							Computed:    true,
 						},
 					},
@@ -107,6 +127,7 @@ func (d *RelationsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 			},
 		},
 	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["relations"].(schema.ListNestedAttribute), "name")
 }
 
 func (d *RelationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -128,13 +149,6 @@ func (d *RelationsDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`SELECT name, relation_type, "owner", "state", created_at, updated_at FROM deltastream.sys."relations" WHERE database_name = '%s' AND schema_name = '%s';`, rels.Database.ValueString(), rels.Schema.ValueString()))
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to load relations", err)
-		return
-	}
-	defer rows.Close()
-
 	var (
 		name      string
 		kind      string
@@ -145,29 +159,46 @@ func (d *RelationsDataSource) Read(ctx context.Context, req datasource.ReadReque
 	)
 
 	relList := []RelationDataSourceData{}
-	for rows.Next() {
+	if err := util.QueryRows(ctx, conn, fmt.Sprintf(`SELECT name, relation_type, "owner", "state", created_at, updated_at FROM deltastream.sys."relations" WHERE database_name = '%s' AND schema_name = '%s';`, rels.Database.ValueString(), rels.Schema.ValueString()), func(rows *sql.Rows) error {
 		rel := RelationDataSourceData{
 			Database: rels.Database,
 			Schema:   rels.Schema,
 		}
 		if err := rows.Scan(&name, &kind, &owner, &state, &createdAt, &updatedAt); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read relation", err)
-			return
+			return err
 		}
 
 		rel.Name = types.StringValue(name)
 		rel.FQN = types.StringValue(fmt.Sprintf("%s.%s.%s", rel.Database.ValueString(), rel.Schema.ValueString(), name))
+		rel.ID = util.ResourceID(d.cfg.Organization, "relation", rel.FQN.ValueString())
 		rel.Owner = types.StringValue(owner)
 		rel.Type = types.StringValue(kind)
 		rel.State = types.StringValue(state)
-		rel.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-		rel.UpdatedAt = types.StringValue(createdAt.Format(time.RFC3339))
+		rel.CreatedAt = util.TimestampValue(createdAt)
+		rel.UpdatedAt = util.TimestampValue(updatedAt)
 		relList = append(relList, rel)
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to load relations", err)
+		return
+	}
+
+	for i, rel := range relList {
+		metadata, err := describeRelation(ctx, conn, rel.FQN.ValueString())
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to describe relation", err)
+			return
+		}
+		relList[i].PrimaryKey = metadata.PrimaryKey
+		relList[i].TimestampColumn = metadata.TimestampColumn
+		relList[i].EventTimeFormat = metadata.EventTimeFormat
 	}
 
 	var dg diag.Diagnostics
 	rels.Relations, dg = basetypes.NewListValueFrom(ctx, rels.Relations.ElementType(ctx), relList)
 	resp.Diagnostics.Append(dg...)
+	rels.ItemsByName, dg = util.ItemsByName(ctx, rels.Relations, "name")
+	resp.Diagnostics.Append(dg...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &rels)...)
 }
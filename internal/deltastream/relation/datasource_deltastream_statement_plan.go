@@ -0,0 +1,247 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &StatementPlanDataSource{}
+var _ datasource.DataSourceWithConfigure = &StatementPlanDataSource{}
+
+func NewStatementPlanDataSource() datasource.DataSource {
+	return &StatementPlanDataSource{}
+}
+
+type StatementPlanDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *StatementPlanDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "provider error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type StatementPlanDataSourceData struct {
+	ID       types.String `tfsdk:"id"`
+	Sql      types.String `tfsdk:"sql"`
+	Database types.String `tfsdk:"database"`
+	Schema   types.String `tfsdk:"schema"`
+	Store    types.String `tfsdk:"store"`
+	Kind     types.String `tfsdk:"kind"`
+	Ddl      types.Object `tfsdk:"ddl"`
+	Sinks    types.List   `tfsdk:"sinks"`
+	Sources  types.List   `tfsdk:"sources"`
+}
+
+type StatementPlanRelationData struct {
+	FQN      types.String `tfsdk:"fqn"`
+	Type     types.String `tfsdk:"type"`
+	Database types.String `tfsdk:"database"`
+	Schema   types.String `tfsdk:"schema"`
+	Name     types.String `tfsdk:"name"`
+	Store    types.String `tfsdk:"store"`
+}
+
+func (StatementPlanRelationData) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"fqn":      types.StringType,
+		"type":     types.StringType,
+		"database": types.StringType,
+		"schema":   types.StringType,
+		"name":     types.StringType,
+		"store":    types.StringType,
+	}
+}
+
+func statementPlanRelationAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"fqn": schema.StringAttribute{
+			Description: "Fully qualified name of the Relation",
+			Computed:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "Type of the Relation",
+			Computed:    true,
+		},
+		"database": schema.StringAttribute{
+			Description: "Name of the Database",
+			Computed:    true,
+		},
+		"schema": schema.StringAttribute{
+			Description: "Name of the Schema",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Name of the Relation",
+			Computed:    true,
+		},
+		"store": schema.StringAttribute{
+			Description: "Name of the Store backing the Relation",
+			Computed:    true,
+		},
+	}
+}
+
+func (d *StatementPlanDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Planning output of a SQL statement. The statement is described but not executed",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the statement plan",
+				Computed:    true,
+			},
+			"sql": schema.StringAttribute{
+				Description: "SQL statement to plan",
+				Required:    true,
+			},
+			"database": schema.StringAttribute{
+				Description: "Name of the Database used to resolve unqualified names",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+			},
+			"schema": schema.StringAttribute{
+				Description: "Name of the Schema used to resolve unqualified names",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+			},
+			"store": schema.StringAttribute{
+				Description: "Name of the Store used when the statement does not specify one",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+			},
+			"kind": schema.StringAttribute{
+				Description: "Kind of statement, such as CREATE_STREAM or INSERT_INTO",
+				Computed:    true,
+			},
+			"ddl": schema.SingleNestedAttribute{
+				Description: "Relation created by the statement",
+				Computed:    true,
+				Attributes:  statementPlanRelationAttributes(),
+			},
+			"sinks": schema.ListNestedAttribute{
+				Description: "Relations written by the statement",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: statementPlanRelationAttributes(),
+				},
+			},
+			"sources": schema.ListNestedAttribute{
+				Description: "Relations read by the statement",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: statementPlanRelationAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *StatementPlanDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_statement_plan"
+}
+
+func (d *StatementPlanDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	plan := StatementPlanDataSourceData{}
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if err := util.SetSqlContext(ctx, conn, plan.Database.ValueStringPointer(), plan.Schema.ValueStringPointer(), plan.Store.ValueStringPointer()); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set sql context", err)
+		return
+	}
+
+	row := conn.QueryRowContext(ctx, "DESCRIBE "+plan.Sql.ValueString())
+	var kind string
+	var descJson string
+	if err := row.Scan(&kind, &descJson); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to describe statement", err)
+		return
+	}
+
+	stmtPlan := util.StatementPlan{}
+	if err := json.Unmarshal([]byte(descJson), &stmtPlan); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to parse statement plan", err)
+		return
+	}
+
+	sum := sha256.Sum256([]byte(plan.Sql.ValueString()))
+	plan.ID = util.ResourceID(d.cfg.Organization, "statement_plan", hex.EncodeToString(sum[:]))
+	plan.Kind = types.StringValue(kind)
+
+	var dg diag.Diagnostics
+	plan.Ddl = types.ObjectNull(StatementPlanRelationData{}.AttributeTypes())
+	if stmtPlan.Ddl != nil {
+		plan.Ddl, dg = types.ObjectValueFrom(ctx, StatementPlanRelationData{}.AttributeTypes(), d.toRelationData(*stmtPlan.Ddl))
+		resp.Diagnostics.Append(dg...)
+	}
+
+	sinks := []StatementPlanRelationData{}
+	for _, sink := range stmtPlan.Sinks {
+		sinks = append(sinks, d.toRelationData(sink))
+	}
+	if stmtPlan.Sink != nil && len(stmtPlan.Sinks) == 0 {
+		sinks = append(sinks, d.toRelationData(*stmtPlan.Sink))
+	}
+	plan.Sinks, dg = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: StatementPlanRelationData{}.AttributeTypes()}, sinks)
+	resp.Diagnostics.Append(dg...)
+
+	sources := []StatementPlanRelationData{}
+	for _, source := range stmtPlan.Sources {
+		sources = append(sources, d.toRelationData(source))
+	}
+	plan.Sources, dg = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: StatementPlanRelationData{}.AttributeTypes()}, sources)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// toRelationData converts a relation of the statement plan, dropping the organization prefix from its FQN so it
+// matches the fqn attribute of relation resources.
+func (d *StatementPlanDataSource) toRelationData(rel util.RelationPlan) StatementPlanRelationData {
+	return StatementPlanRelationData{
+		FQN:      types.StringValue(strings.TrimPrefix(rel.Fqn, d.cfg.Organization+".")),
+		Type:     types.StringValue(rel.Type),
+		Database: types.StringValue(rel.DbName),
+		Schema:   types.StringValue(rel.SchemaName),
+		Name:     types.StringValue(rel.Name),
+		Store:    types.StringValue(rel.StoreName),
+	}
+}
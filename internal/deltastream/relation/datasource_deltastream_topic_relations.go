@@ -0,0 +1,245 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ datasource.DataSource = &TopicRelationsDataSource{}
+var _ datasource.DataSourceWithConfigure = &TopicRelationsDataSource{}
+
+func NewTopicRelationsDataSource() datasource.DataSource {
+	return &TopicRelationsDataSource{}
+}
+
+// TopicRelationsDataSource lists the relations defined on a topic of a store, to import or reference the relations
+// of topics that existed before their relations were managed.
+type TopicRelationsDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *TopicRelationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "provider error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type TopicRelationsDataSourceData struct {
+	Store     types.String `tfsdk:"store"`
+	Topic     types.String `tfsdk:"topic"`
+	Database  types.String `tfsdk:"database"`
+	Relations types.List   `tfsdk:"relations"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
+}
+
+type TopicRelationData struct {
+	ID       types.String `tfsdk:"id"`
+	Database types.String `tfsdk:"database"`
+	Schema   types.String `tfsdk:"schema"`
+	Name     types.String `tfsdk:"name"`
+	FQN      types.String `tfsdk:"fqn"`
+	Type     types.String `tfsdk:"type"`
+	Owner    types.String `tfsdk:"owner"`
+}
+
+func (TopicRelationData) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":       types.StringType,
+		"database": types.StringType,
+		"schema":   types.StringType,
+		"name":     types.StringType,
+		"fqn":      types.StringType,
+		"type":     types.StringType,
+		"owner":    types.StringType,
+	}
+}
+
+func (d *TopicRelationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the relations defined on a topic of a store. Use it to import or reference the relations of topics that were created before the relations were managed. The relations are filtered by the server, servers that cannot filter relations by topic have every relation described instead, set database to limit the relations looked at.",
+
+		Attributes: map[string]schema.Attribute{
+			"store": schema.StringAttribute{
+				Description: "Name of the Store",
+				Required:    true,
+			},
+			"topic": schema.StringAttribute{
+				Description: "Name of the topic",
+				Required:    true,
+			},
+			"database": schema.StringAttribute{
+				Description: "Only look at the relations of this Database",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+			},
+			"relations": schema.ListNestedAttribute{
+				Description: "Relations defined on the topic",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Global identifier of the Relation",
+							Computed:    true,
+						},
+						"database": schema.StringAttribute{
+							Description: "Name of the Database",
+							Computed:    true,
+						},
+						"schema": schema.StringAttribute{
+							Description: "Name of the Schema",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the Relation",
+							Computed:    true,
+						},
+						"fqn": schema.StringAttribute{
+							Description: "Fully qualified name of the Relation",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the Relation",
+							Computed:    true,
+						},
+						"owner": schema.StringAttribute{
+							Description: "Owning role of the Relation",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["relations"].(schema.ListNestedAttribute), "fqn")
+}
+
+func (d *TopicRelationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_topic_relations"
+}
+
+func (d *TopicRelationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	data := TopicRelationsDataSourceData{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	relations, skipped, err := topicRelations(ctx, conn, d.cfg.Organization, data.Database.ValueString(), data.Store.ValueString(), data.Topic.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to load relations", err)
+		return
+	}
+	if len(skipped) > 0 {
+		resp.Diagnostics.AddWarning("Unable to describe relations",
+			fmt.Sprintf("The following relations could not be described and are left out of the relations of the topic: %s", strings.Join(skipped, ", ")))
+	}
+
+	var dg diag.Diagnostics
+	data.Relations, dg = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: TopicRelationData{}.AttributeTypes()}, relations)
+	resp.Diagnostics.Append(dg...)
+	data.ItemsByName, dg = util.ItemsByName(ctx, data.Relations, "fqn")
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// topicRelations returns the relations of the database, or of every database when database is empty, that are bound
+// to the topic of the store. The relations are filtered by the server, when the server cannot filter them by topic
+// every relation is described instead. Relations that cannot be described are left out and returned in skipped.
+func topicRelations(ctx context.Context, conn *sql.Conn, organization, database, store, topic string) (relations []TopicRelationData, skipped []string, err error) {
+	quote := func(v string) string { return "'" + strings.ReplaceAll(v, "'", "''") + "'" }
+	where := []string{}
+	if database != "" {
+		where = append(where, "database_name = "+quote(database))
+	}
+
+	filtered := append([]string{"store_name = " + quote(store), "topic_name = " + quote(topic)}, where...)
+	relations, err = listTopicRelations(ctx, conn, organization, filtered)
+	if err == nil {
+		return relations, nil, nil
+	}
+	tflog.Debug(ctx, "unable to filter relations by topic, describing every relation", map[string]any{"error": err.Error()})
+
+	candidates, err := listTopicRelations(ctx, conn, organization, where)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	relations = []TopicRelationData{}
+	for _, rel := range candidates {
+		metadata, err := describeRelation(ctx, conn, rel.FQN.ValueString())
+		if err != nil {
+			tflog.Warn(ctx, "unable to describe relation", map[string]any{"name": rel.FQN.ValueString(), "error": err.Error()})
+			skipped = append(skipped, rel.FQN.ValueString())
+			continue
+		}
+		if metadata.Store.ValueString() == store && metadata.Topic.ValueString() == topic {
+			relations = append(relations, rel)
+		}
+	}
+	return relations, skipped, nil
+}
+
+// listTopicRelations returns the relations of deltastream.sys.relations matching every condition of where.
+func listTopicRelations(ctx context.Context, conn *sql.Conn, organization string, where []string) ([]TopicRelationData, error) {
+	stmt := `SELECT database_name, schema_name, name, relation_type, "owner" FROM deltastream.sys."relations"`
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	relations := []TopicRelationData{}
+	if err := util.QueryRows(ctx, conn, stmt+";", func(rows *sql.Rows) error {
+		var databaseName, schemaName, name, kind, owner string
+		if err := rows.Scan(&databaseName, &schemaName, &name, &kind, &owner); err != nil {
+			return err
+		}
+		fqn := fmt.Sprintf("%s.%s.%s", databaseName, schemaName, name)
+		relations = append(relations, TopicRelationData{
+			ID:       util.ResourceID(organization, "relation", fqn),
+			Database: types.StringValue(databaseName),
+			Schema:   types.StringValue(schemaName),
+			Name:     types.StringValue(name),
+			FQN:      types.StringValue(fqn),
+			Type:     types.StringValue(kind),
+			Owner:    types.StringValue(owner),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestTopicRelations(t *testing.T) {
+	ctx := context.Background()
+	describe := func(fqn, store, topic string) mockserver.Fixture {
+		return mockserver.Fixture{
+			Statement: `^DESCRIBE RELATION ` + fqn + `;$`,
+			Columns: []mockserver.Column{
+				{Name: "Store", Type: "VARCHAR"},
+				{Name: "Properties", Type: "VARCHAR"},
+			},
+			Rows: [][]*string{mockserver.Row(store, `{"topic": "`+topic+`"}`)},
+		}
+	}
+	relationColumns := []mockserver.Column{
+		{Name: "database_name", Type: "VARCHAR"},
+		{Name: "schema_name", Type: "VARCHAR"},
+		{Name: "name", Type: "VARCHAR"},
+		{Name: "relation_type", Type: "VARCHAR"},
+		{Name: "owner", Type: "VARCHAR"},
+	}
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{
+		{
+			Statement: `FROM deltastream\.sys\."relations" WHERE store_name = 'kafka' AND topic_name = 'pageviews' AND database_name = 'db2';$`,
+			Columns:   relationColumns,
+			Rows:      [][]*string{mockserver.Row("db2", "public", "pageviews", "stream", "sysadmin")},
+		},
+		{
+			Statement: `FROM deltastream\.sys\."relations" WHERE database_name = 'db1';$`,
+			Columns:   relationColumns,
+			Rows: [][]*string{
+				mockserver.Row("db1", "public", "pageviews", "stream", "sysadmin"),
+				mockserver.Row("db1", "public", "pageviews_by_user", "changelog", "data_eng"),
+				mockserver.Row("db1", "public", "users", "stream", "sysadmin"),
+				mockserver.Row("db1", "public", "pageviews_msk", "stream", "sysadmin"),
+				mockserver.Row("db1", "public", "broken", "stream", "sysadmin"),
+			},
+		},
+		describe(`db1\.public\.pageviews`, "kafka", "pageviews"),
+		describe(`db1\.public\.pageviews_by_user`, "kafka", "pageviews"),
+		describe(`db1\.public\.users`, "kafka", "users"),
+		describe(`db1\.public\.pageviews_msk`, "msk", "pageviews"),
+	})
+
+	relations, skipped, err := topicRelations(ctx, conn, testOrganization, "db1", "kafka", "pageviews")
+	if err != nil {
+		t.Fatalf("topicRelations() error = %v", err)
+	}
+	got := map[string]string{}
+	for _, rel := range relations {
+		got[rel.FQN.ValueString()] = rel.Type.ValueString() + "/" + rel.Owner.ValueString()
+	}
+	want := map[string]string{
+		"db1.public.pageviews":         "stream/sysadmin",
+		"db1.public.pageviews_by_user": "changelog/data_eng",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("topicRelations() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(skipped, []string{"db1.public.broken"}) {
+		t.Errorf("topicRelations() skipped = %v, want the relation that cannot be described", skipped)
+	}
+
+	relations, skipped, err = topicRelations(ctx, conn, testOrganization, "db2", "kafka", "pageviews")
+	if err != nil {
+		t.Fatalf("topicRelations(db2) error = %v", err)
+	}
+	if len(relations) != 1 || relations[0].FQN.ValueString() != "db2.public.pageviews" || len(skipped) != 0 {
+		t.Errorf("topicRelations(db2) = %v, %v, want the relation filtered by the server", relations, skipped)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// relationQuery is a query reading from or writing to a relation.
+type relationQuery struct {
+	ID    string
+	Name  string
+	State string
+}
+
+// dependentQueries returns the running queries that read from or write to the relation.
+func dependentQueries(ctx context.Context, conn *sql.Conn, organization, fqn string) ([]string, error) {
+	producers, consumers, err := relationQueries(ctx, conn, organization, fqn, false)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	dependents := []string{}
+	for _, q := range append(producers, consumers...) {
+		if seen[q.ID] {
+			continue
+		}
+		seen[q.ID] = true
+		dependents = append(dependents, fmt.Sprintf("%s (%s)", q.Name, q.ID))
+	}
+	return dependents, nil
+}
+
+// relationQueries returns the queries writing to the relation (producers) and reading from it (consumers). Each
+// query is re-planned with DESCRIBE to find its sources and sinks, queries that can no longer be planned are skipped.
+// Only running queries are considered unless all is set.
+func relationQueries(ctx context.Context, conn *sql.Conn, organization, fqn string, all bool) (producers, consumers []relationQuery, err error) {
+	stmt := `LIST QUERIES;`
+	if all {
+		stmt = `LIST QUERIES WITH ('all');`
+	}
+	type listedQuery struct {
+		relationQuery
+		sql string
+	}
+	queries := []listedQuery{}
+	if err := util.QueryRows(ctx, conn, stmt, func(rows *sql.Rows) error {
+		var (
+			id            string
+			name          string
+			version       int64
+			intendedState string
+			actualState   string
+			query         string
+			owner         string
+			createdAt     time.Time
+			updatedAt     time.Time
+		)
+		if err := rows.Scan(&id, &name, &version, &intendedState, &actualState, &query, &owner, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+		queries = append(queries, listedQuery{relationQuery: relationQuery{ID: id, Name: name, State: actualState}, sql: query})
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	orgFqn := organization + "." + fqn
+	matches := func(rels ...util.RelationPlan) bool {
+		for _, rel := range rels {
+			if rel.Fqn == orgFqn || rel.Fqn == fqn {
+				return true
+			}
+		}
+		return false
+	}
+
+	producers = []relationQuery{}
+	consumers = []relationQuery{}
+	for _, q := range queries {
+		_, plan, err := util.DescribeStatement(ctx, conn, q.sql)
+		if err != nil {
+			tflog.Debug(ctx, "failed to describe query", map[string]any{"query_id": q.ID, "error": err.Error()})
+			continue
+		}
+
+		if matches(plan.AllSinks()...) {
+			producers = append(producers, q.relationQuery)
+		}
+		if matches(plan.Sources...) {
+			consumers = append(consumers, q.relationQuery)
+		}
+	}
+	return producers, consumers, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestRelationQueries(t *testing.T) {
+	ctx := context.Background()
+	describe := func(statement, plan string) mockserver.Fixture {
+		return mockserver.Fixture{
+			Statement: `^DESCRIBE ` + statement,
+			Columns:   []mockserver.Column{{Name: "kind", Type: "VARCHAR"}, {Name: "plan", Type: "VARCHAR"}},
+			Rows:      [][]*string{mockserver.Row("INSERT_INTO", plan)},
+		}
+	}
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{
+		{
+			Statement: `^LIST QUERIES;$`,
+			Columns: []mockserver.Column{
+				{Name: "id", Type: "VARCHAR"},
+				{Name: "name", Type: "VARCHAR"},
+				{Name: "version", Type: "BIGINT"},
+				{Name: "intended_state", Type: "VARCHAR"},
+				{Name: "actual_state", Type: "VARCHAR"},
+				{Name: "query", Type: "VARCHAR"},
+				{Name: "owner", Type: "VARCHAR"},
+				{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+				{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+			},
+			Rows: [][]*string{
+				mockserver.Row("q1", "producer", "1", "running", "running", "INSERT INTO pageviews SELECT * FROM clicks;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+				mockserver.Row("q2", "consumer", "1", "running", "errored", "INSERT INTO users SELECT * FROM pageviews;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+				mockserver.Row("q3", "unrelated", "1", "running", "running", "INSERT INTO users SELECT * FROM clicks;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+			},
+		},
+		describe(`INSERT INTO pageviews`, `{"sink":{"fqn":"db1.public.pageviews"},"sources":[{"fqn":"db1.public.clicks"}]}`),
+		describe(`INSERT INTO users SELECT \* FROM pageviews`, `{"sinks":[{"fqn":"db1.public.users"}],"sources":[{"fqn":"`+testOrganization+`.db1.public.pageviews"}]}`),
+		describe(`INSERT INTO users SELECT \* FROM clicks`, `{"sinks":[{"fqn":"db1.public.users"}],"sources":[{"fqn":"db1.public.clicks"}]}`),
+	})
+
+	producers, consumers, err := relationQueries(ctx, conn, testOrganization, "db1.public.pageviews", false)
+	if err != nil {
+		t.Fatalf("relationQueries() error = %v", err)
+	}
+	if want := []relationQuery{{ID: "q1", Name: "producer", State: "running"}}; !reflect.DeepEqual(producers, want) {
+		t.Errorf("producers = %+v, want %+v", producers, want)
+	}
+	if want := []relationQuery{{ID: "q2", Name: "consumer", State: "errored"}}; !reflect.DeepEqual(consumers, want) {
+		t.Errorf("consumers = %+v, want %+v", consumers, want)
+	}
+
+	dependents, err := dependentQueries(ctx, conn, testOrganization, "db1.public.pageviews")
+	if err != nil {
+		t.Fatalf("dependentQueries() error = %v", err)
+	}
+	if want := []string{"producer (q1)", "consumer (q2)"}; !reflect.DeepEqual(dependents, want) {
+		t.Errorf("dependentQueries() = %v, want %v", dependents, want)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// relationMetadata holds the key and event time settings of a relation as reported by DESCRIBE RELATION.
+type relationMetadata struct {
+	PrimaryKey      types.List
+	TimestampColumn types.String
+	EventTimeFormat types.String
+
+	// Store and Topic are the store and topic the relation is bound to, when reported.
+	Store types.String
+	Topic types.String
+
+	// RawDescribeJSON is the DESCRIBE RELATION row, verbatim.
+	RawDescribeJSON types.String
+}
+
+func describeRelation(ctx context.Context, conn *sql.Conn, fqn string) (relationMetadata, error) {
+	row, err := util.Describe(ctx, conn, fmt.Sprintf(`DESCRIBE RELATION %s;`, fqn))
+	if errors.Is(err, sql.ErrNoRows) {
+		return relationMetadata{}, fmt.Errorf("relation %s not found", fqn)
+	}
+	if err != nil {
+		return relationMetadata{}, err
+	}
+
+	metadata, err := metadataFromDetails(row.Map())
+	if err != nil {
+		return relationMetadata{}, err
+	}
+
+	raw, err := row.JSON()
+	if err != nil {
+		return relationMetadata{}, err
+	}
+	metadata.RawDescribeJSON = types.StringValue(raw)
+	return metadata, nil
+}
+
+// metadataFromDetails builds the relation metadata from the DESCRIBE RELATION columns, keyed by their snake cased
+// names. Timestamp settings missing from the columns are looked up in the relation properties.
+func metadataFromDetails(details map[string]string) (relationMetadata, error) {
+	properties := map[string]any{}
+	if v := details["properties"]; v != "" {
+		if err := json.Unmarshal([]byte(v), &properties); err != nil {
+			return relationMetadata{}, fmt.Errorf("failed to parse relation properties: %w", err)
+		}
+	}
+	lookup := func(column, property string) types.String {
+		if v := details[column]; v != "" {
+			return types.StringValue(v)
+		}
+		if v, ok := properties[property].(string); ok && v != "" {
+			return types.StringValue(v)
+		}
+		return types.StringNull()
+	}
+
+	primaryKey, err := parseKeyColumns(details["primary_key"])
+	if err != nil {
+		return relationMetadata{}, err
+	}
+	keyValues := make([]attr.Value, 0, len(primaryKey))
+	for _, k := range primaryKey {
+		keyValues = append(keyValues, types.StringValue(k))
+	}
+
+	return relationMetadata{
+		PrimaryKey:      types.ListValueMust(types.StringType, keyValues),
+		TimestampColumn: lookup("timestamp_column", "timestamp"),
+		EventTimeFormat: lookup("timestamp_format", "timestamp.format"),
+		Store:           lookup("store", "store"),
+		Topic:           lookup("topic", "topic"),
+	}, nil
+}
+
+// parseKeyColumns accepts a JSON array or a comma separated list of column names.
+func parseKeyColumns(v string) ([]string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(v, "[") {
+		keys := []string{}
+		if err := json.Unmarshal([]byte(v), &keys); err != nil {
+			return nil, fmt.Errorf("failed to parse primary key: %w", err)
+		}
+		return keys, nil
+	}
+
+	keys := []string{}
+	for _, k := range strings.Split(v, ",") {
+		keys = append(keys, strings.Trim(strings.TrimSpace(k), `"`))
+	}
+	return keys, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMetadataFromDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		details map[string]string
+		want    relationMetadata
+	}{
+		{
+			name:    "columns",
+			details: map[string]string{"primary_key": "id, region", "timestamp_column": "ts", "timestamp_format": "iso8601"},
+			want: relationMetadata{
+				PrimaryKey:      types.ListValueMust(types.StringType, []attr.Value{types.StringValue("id"), types.StringValue("region")}),
+				TimestampColumn: types.StringValue("ts"),
+				EventTimeFormat: types.StringValue("iso8601"),
+			},
+		},
+		{
+			name:    "properties",
+			details: map[string]string{"primary_key": `["id"]`, "store": "kafka", "properties": `{"timestamp": "ts", "timestamp.format": "unix_millis", "topic": "pageviews"}`},
+			want: relationMetadata{
+				PrimaryKey:      types.ListValueMust(types.StringType, []attr.Value{types.StringValue("id")}),
+				TimestampColumn: types.StringValue("ts"),
+				EventTimeFormat: types.StringValue("unix_millis"),
+				Store:           types.StringValue("kafka"),
+				Topic:           types.StringValue("pageviews"),
+			},
+		},
+		{
+			name:    "none",
+			details: map[string]string{},
+			want: relationMetadata{
+				PrimaryKey:      types.ListValueMust(types.StringType, []attr.Value{}),
+				TimestampColumn: types.StringNull(),
+				EventTimeFormat: types.StringNull(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := metadataFromDetails(tt.details)
+			if err != nil {
+				t.Fatalf("metadataFromDetails() error = %v", err)
+			}
+			if !got.PrimaryKey.Equal(tt.want.PrimaryKey) {
+				t.Errorf("PrimaryKey = %s, want %s", got.PrimaryKey, tt.want.PrimaryKey)
+			}
+			if !got.TimestampColumn.Equal(tt.want.TimestampColumn) {
+				t.Errorf("TimestampColumn = %s, want %s", got.TimestampColumn, tt.want.TimestampColumn)
+			}
+			if !got.EventTimeFormat.Equal(tt.want.EventTimeFormat) {
+				t.Errorf("EventTimeFormat = %s, want %s", got.EventTimeFormat, tt.want.EventTimeFormat)
+			}
+			if !got.Store.Equal(tt.want.Store) || !got.Topic.Equal(tt.want.Topic) {
+				t.Errorf("Store, Topic = %s, %s, want %s, %s", got.Store, got.Topic, tt.want.Store, tt.want.Topic)
+			}
+		})
+	}
+}
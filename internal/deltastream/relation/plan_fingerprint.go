@@ -0,0 +1,130 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// statementPlanKey is the private state key holding the fingerprint of the plan a relation was created from.
+const statementPlanKey = "statement_plan"
+
+// planFingerprint records what the statement of a relation resolved to when it was created, along with the SQL
+// context it was planned in so that it can be planned again the same way.
+type planFingerprint struct {
+	Database *string `json:"database,omitempty"`
+	Schema   *string `json:"schema,omitempty"`
+	Store    *string `json:"store,omitempty"`
+
+	Sink    util.RelationPlan   `json:"sink"`
+	Sources []util.RelationPlan `json:"sources,omitempty"`
+}
+
+// newPlanFingerprint returns the fingerprint of a relation statement plan planned in the given context.
+func newPlanFingerprint(dbName, schemaName, storeName *string, plan util.StatementPlan) planFingerprint {
+	f := planFingerprint{Database: dbName, Schema: schemaName, Store: storeName}
+	if plan.Ddl != nil {
+		f.Sink = *plan.Ddl
+	}
+	f.Sources = append(f.Sources, plan.Sources...)
+	sort.Slice(f.Sources, func(i, j int) bool { return f.Sources[i].Fqn < f.Sources[j].Fqn })
+	return f
+}
+
+func planNames(plans []util.RelationPlan) string {
+	names := make([]string, 0, len(plans))
+	for _, p := range plans {
+		names = append(names, p.String())
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// differences lists how the relations the statement resolves to now differ from the recorded ones.
+func (f planFingerprint) differences(current planFingerprint) []string {
+	var diffs []string
+	if f.Sink.Fqn != current.Sink.Fqn || f.Sink.StoreName != current.Sink.StoreName {
+		diffs = append(diffs, fmt.Sprintf("relation: %s, now %s", f.Sink.String(), current.Sink.String()))
+	}
+	if planNames(f.Sources) != planNames(current.Sources) {
+		diffs = append(diffs, fmt.Sprintf("sources: %s, now %s", planNames(f.Sources), planNames(current.Sources)))
+	}
+	return diffs
+}
+
+// replanRelation describes the statement of a relation again in the context its fingerprint recorded.
+func replanRelation(ctx context.Context, conn *sql.Conn, rel RelationResourceData, recorded planFingerprint) (planFingerprint, error) {
+	if err := util.SetSqlContext(ctx, conn, recorded.Database, recorded.Schema, recorded.Store); err != nil {
+		return planFingerprint{}, err
+	}
+	if err := setSessionProperties(ctx, conn, rel.WithProperties); err != nil {
+		return planFingerprint{}, err
+	}
+
+	_, descJson, err := describeStatement(ctx, conn, rel.Sql.ValueString())
+	if err != nil {
+		return planFingerprint{}, err
+	}
+	plan := util.StatementPlan{}
+	if err := json.Unmarshal([]byte(descJson), &plan); err != nil {
+		return planFingerprint{}, err
+	}
+	return newPlanFingerprint(recorded.Database, recorded.Schema, recorded.Store, plan), nil
+}
+
+// verifyPlanFingerprint warns when the statement of a relation no longer resolves to the relations it was created
+// from, such as after a source was re-created in another store. The statement is planned again on a dedicated
+// connection, discarded afterwards, so the recorded SQL context and session properties do not leak into the pool.
+// A statement that cannot be planned again is reported as a warning as well, the relation itself is left as is.
+func verifyPlanFingerprint(ctx context.Context, cfg *config.DeltaStreamProviderCfg, rel RelationResourceData, private []byte) (dg diag.Diagnostics) {
+	if len(private) == 0 {
+		return
+	}
+	var recorded planFingerprint
+	if err := json.Unmarshal(private, &recorded); err != nil {
+		tflog.Warn(ctx, "ignoring invalid relation plan fingerprint", map[string]any{"error": err.Error()})
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, cfg.Db, cfg.SessionID, cfg.Organization, cfg.Role)
+	if err != nil {
+		dg.AddWarning(fmt.Sprintf("Unable to verify the statement of relation %s", rel.FQN.ValueString()),
+			fmt.Sprintf("Failed to connect to plan the SQL of the relation again: %s", err))
+		return
+	}
+	defer util.DiscardConnection(conn)
+
+	current, err := replanRelation(ctx, conn, rel, recorded)
+	if err != nil {
+		tflog.Warn(ctx, "unable to plan relation statement again", map[string]any{
+			"name":  rel.FQN.ValueString(),
+			"error": err.Error(),
+		})
+		dg.AddWarning(fmt.Sprintf("Unable to verify the statement of relation %s", rel.FQN.ValueString()),
+			fmt.Sprintf("The SQL of the relation could not be planned again to check that it still resolves to the relations it was created from: %s", err))
+		return
+	}
+
+	if diffs := recorded.differences(current); len(diffs) > 0 {
+		dg.AddWarning(
+			fmt.Sprintf("Statement of relation %s resolves differently", rel.FQN.ValueString()),
+			fmt.Sprintf("The SQL of the relation no longer resolves to the relations it was created from, the existing relation is left as is. Replace the relation to apply the new resolution:\n  - %s",
+				strings.Join(diffs, "\n  - ")),
+		)
+	}
+	return
+}
@@ -0,0 +1,72 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+func TestVerifyPlanFingerprint(t *testing.T) {
+	ctx := context.Background()
+	_, db := mockserver.NewDB(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE CREATE STREAM pv_copy`,
+		Columns:   []mockserver.Column{{Name: "kind", Type: "VARCHAR"}, {Name: "plan", Type: "VARCHAR"}},
+		Rows: [][]*string{mockserver.Row("CREATE_STREAM",
+			`{"ddl":{"fqn":"db1.public.pv_copy","store_name":"kafka_b"},"sources":[{"fqn":"db1.public.pageviews","store_name":"kafka_b"}]}`)},
+	}})
+	cfg := &config.DeltaStreamProviderCfg{Db: db, Organization: testOrganization, Role: "sysadmin"}
+
+	rel := RelationResourceData{
+		FQN:            types.StringValue("db1.public.pv_copy"),
+		Sql:            types.StringValue(`CREATE STREAM pv_copy AS SELECT * FROM pageviews;`),
+		WithProperties: types.MapNull(types.StringType),
+	}
+	fingerprint := func(store string) []byte {
+		db := "db1"
+		b, err := json.Marshal(newPlanFingerprint(&db, nil, nil, util.StatementPlan{
+			Ddl:     &util.RelationPlan{Fqn: "db1.public.pv_copy", StoreName: store},
+			Sources: []util.RelationPlan{{Fqn: "db1.public.pageviews", StoreName: store}},
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	if dg := verifyPlanFingerprint(ctx, cfg, rel, fingerprint("kafka_b")); len(dg) != 0 {
+		t.Errorf("expected no diagnostics for an unchanged plan, got %v", dg)
+	}
+
+	dg := verifyPlanFingerprint(ctx, cfg, rel, fingerprint("kafka_a"))
+	if len(dg) != 1 || dg.HasError() {
+		t.Fatalf("expected a warning for a changed plan, got %v", dg)
+	}
+	for _, want := range []string{"db1.public.pv_copy in store kafka_a, now db1.public.pv_copy in store kafka_b", "sources: db1.public.pageviews in store kafka_a"} {
+		if !strings.Contains(dg[0].Detail(), want) {
+			t.Errorf("warning %q does not contain %q", dg[0].Detail(), want)
+		}
+	}
+
+	if dg := verifyPlanFingerprint(ctx, cfg, rel, nil); len(dg) != 0 {
+		t.Errorf("expected relations without a fingerprint to be skipped, got %v", dg)
+	}
+	rel.Sql = types.StringValue(`CREATE STREAM other AS SELECT * FROM pageviews;`)
+	dg = verifyPlanFingerprint(ctx, cfg, rel, fingerprint("kafka_a"))
+	if len(dg) != 1 || dg.HasError() || !strings.Contains(dg[0].Summary(), "Unable to verify") {
+		t.Errorf("expected a warning for a statement that cannot be planned, got %v", dg)
+	}
+
+	if stats := db.Stats(); stats.OpenConnections != 0 {
+		t.Errorf("open connections after verification = %d, want 0", stats.OpenConnections)
+	}
+}
@@ -5,16 +5,28 @@ package relation
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sethvargo/go-retry"
@@ -26,6 +38,8 @@ import (
 
 var _ resource.Resource = &RelationResource{}
 var _ resource.ResourceWithConfigure = &RelationResource{}
+var _ resource.ResourceWithModifyPlan = &RelationResource{}
+var _ resource.ResourceWithImportState = &RelationResource{}
 
 func NewRelationResource() resource.Resource {
 	return &RelationResource{}
@@ -36,18 +50,39 @@ type RelationResource struct {
 }
 
 type RelationResourceData struct {
+	ID       types.String `tfsdk:"id"`
 	Database types.String `tfsdk:"database"`
 	Schema   types.String `tfsdk:"schema"`
 	Name     types.String `tfsdk:"name"`
 	Store    types.String `tfsdk:"store"`
 	Sql      types.String `tfsdk:"sql"`
 
+	WithProperties types.Map `tfsdk:"with_properties"`
+
 	FQN       types.String `tfsdk:"fqn"`
 	Type      types.String `tfsdk:"type"`
 	State     types.String `tfsdk:"state"`
 	Owner     types.String `tfsdk:"owner"`
 	CreatedAt types.String `tfsdk:"created_at"`
 	UpdatedAt types.String `tfsdk:"updated_at"`
+
+	StatementID types.String `tfsdk:"statement_id"`
+
+	RenameTo types.String `tfsdk:"rename_to"`
+
+	RetentionMs    types.Int64 `tfsdk:"retention_ms"`
+	RetentionBytes types.Int64 `tfsdk:"retention_bytes"`
+
+	Tags    types.Map `tfsdk:"tags"`
+	TagsAll types.Map `tfsdk:"tags_all"`
+}
+
+func (r RelationResourceData) retention() topicRetention {
+	return topicRetention{Ms: r.RetentionMs, Bytes: r.RetentionBytes}
+}
+
+func (r *RelationResourceData) setRetention(retention topicRetention) {
+	r.RetentionMs, r.RetentionBytes = retention.Ms, retention.Bytes
 }
 
 func (d *RelationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -55,24 +90,60 @@ func (d *RelationResource) Schema(ctx context.Context, req resource.SchemaReques
 		MarkdownDescription: "Relation resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Relation",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"database": schema.StringAttribute{
-				Description: "Name of the Database",
-				Required:    true,
+				Description: "Name of the Database. Derived from the statement when not set, otherwise the statement must create the relation in this Database",
+				Optional:    true,
+				Computed:    true,
 				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"schema": schema.StringAttribute{
-				Description: "Name of the Schema",
-				Required:    true,
+				Description: "Name of the Schema. Derived from the statement when not set, otherwise the statement must create the relation in this Schema",
+				Optional:    true,
+				Computed:    true,
 				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"store": schema.StringAttribute{
-				Description: "Name of the Store",
-				Required:    true,
+				Description: "Name of the Store. Defaults to the store the statement resolves, set it to the default_store of a deltastream_schema to use the default store of the Schema",
+				Optional:    true,
+				Computed:    true,
 				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					util.RequiresReplaceUnlessImported(),
+				},
 			},
 			"sql": schema.StringAttribute{
-				Description: "SQL statement to create the relation",
+				Description: "SQL statement to create the relation. The statement of an imported relation is taken from the configuration",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					util.RequiresReplaceUnlessImported(),
+				},
+			},
+			"with_properties": schema.MapAttribute{
+				Description: "Properties set in the session the relation is created in, before the statement is planned and executed. Use them for settings the statement cannot express. The provider does not check the keys, the server rejects the ones it does not support when the relation is created. The settings apply to a dedicated connection that is discarded once the relation is created",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(sessionPropertyKey, "must be a dotted property name")),
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
 			},
 			"owner": schema.StringAttribute{
 				Description: "Owning role of the relation",
@@ -80,6 +151,29 @@ func (d *RelationResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:    true,
 				Validators:  util.IdentifierValidators,
 			},
+			"rename_to": schema.StringAttribute{
+				Description: "Name to rename the relation to, in place, after it is created by the statement. Changing it renames the relation without re-creating it or the data of its entity. Unsetting it keeps the current name",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+			},
+			"retention_ms": schema.Int64Attribute{
+				Description: "How long, in milliseconds, the topic of the relation retains records, -1 for no limit. Defaults to the retention of the topic. Changing it updates the topic in place, it can only be set on relations backed by a Kafka topic",
+				Optional:    true,
+				Computed:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(-1)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"retention_bytes": schema.Int64Attribute{
+				Description: "How many bytes each partition of the topic of the relation retains, -1 for no limit. Defaults to the retention of the topic. Changing it updates the topic in place, it can only be set on relations backed by a Kafka topic",
+				Optional:    true,
+				Computed:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(-1)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 
 			"name": schema.StringAttribute{
 				Description: "Name of the Relation",
@@ -111,8 +205,26 @@ func (d *RelationResource) Schema(ctx context.Context, req resource.SchemaReques
 				Description: "Creation date of the relation",
 				Computed:    true,
 			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the Relation. Tags can be changed without replacing the Relation",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  util.TagValidators,
+			},
+			"tags_all": schema.MapAttribute{
+				Description: "Tags of the Relation merged with the default_tags of the provider",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the relation, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"updated_at": schema.StringAttribute{
-				Description: "Creation date of the relation",
+				Description: "Last update date of the relation",
 				Computed:    true,
 			},
 		},
@@ -138,26 +250,116 @@ func (d *RelationResource) Metadata(ctx context.Context, req resource.MetadataRe
 	resp.TypeName = req.ProviderTypeName + "_relation"
 }
 
-type statementPlan struct {
-	Ddl     *relationPlan  `json:"ddl,omitempty"`
-	Sink    *relationPlan  `json:"sink,omitempty"`
-	Sources []relationPlan `json:"sources,omitempty"`
-}
+// ImportState adopts an existing relation from its fully qualified name, database_name.schema_name.relation_name. The
+// statement that created the relation is not reported by the server, it is taken from the configuration when the
+// relation is first updated.
+func (d *RelationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError("invalid import ID", fmt.Sprintf("invalid import ID %q, expected database_name.schema_name.relation_name", req.ID))
+		return
+	}
 
-type relationPlan struct {
-	Fqn        string `json:"fqn"`
-	Type       string `json:"type"`
-	DbName     string `json:"db_name"`
-	SchemaName string `json:"schema_name"`
-	Name       string `json:"name"`
-	StoreName  string `json:"store_name"`
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fqn"), req.ID)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, util.ImportedKey, []byte("true"))...)
 }
 
-type artifactDDL struct {
-	Type    string `json:"type"`
-	Name    string `json:"name"`
-	Command string `json:"command"`
-	Summary string `json:"summary"`
+func (d *RelationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultOwner(ctx, "relation", req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ValidateOwner(ctx, resp.Plan, req.State)...)
+	resp.Diagnostics.Append(d.cfg.ValidateIdentifierCase(ctx, resp.Plan, req.State, "rename_to")...)
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultTags(ctx, &resp.Plan)...)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned RelationResourceData
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the relation is renamed to the name the provider creates objects under
+	renameTo := planned.RenameTo
+	if !renameTo.IsNull() && !renameTo.IsUnknown() {
+		renameTo = types.StringValue(d.cfg.ObjectName(renameTo.ValueString()))
+	}
+
+	if req.State.Raw.IsNull() {
+		if !renameTo.IsNull() {
+			planned.Name = renameTo
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, planned)...)
+		}
+		return
+	}
+
+	var current RelationResourceData
+	resp.Diagnostics.Append(req.State.Get(ctx, &current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a rename changes the name, fully qualified name and ID of the relation in place
+	if !renameTo.IsNull() && !renameTo.Equal(current.Name) {
+		planned.Name = renameTo
+		planned.FQN = types.StringUnknown()
+		planned.ID = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, planned)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// warn about queries that break when the relation is dropped and re-created, imported relations adopt the
+	// statement and store they are configured with
+	adopted := util.Imported(ctx, req.Private)
+
+	replacedBy := []string{}
+	for attr, changed := range map[string]bool{
+		"database":        !planned.Database.IsUnknown() && !planned.Database.Equal(current.Database),
+		"schema":          !planned.Schema.IsUnknown() && !planned.Schema.Equal(current.Schema),
+		"store":           !planned.Store.IsUnknown() && !planned.Store.Equal(current.Store) && !(adopted && current.Store.IsNull()),
+		"sql":             !planned.Sql.Equal(current.Sql) && !(adopted && current.Sql.IsNull()),
+		"with_properties": !planned.WithProperties.Equal(current.WithProperties),
+	} {
+		if changed {
+			replacedBy = append(replacedBy, attr)
+		}
+	}
+	if len(replacedBy) == 0 {
+		return
+	}
+	sort.Strings(replacedBy)
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to determine queries affected by relation replacement", err.Error())
+		return
+	}
+	defer conn.Close()
+
+	queries, err := dependentQueries(ctx, conn, d.cfg.Organization, current.FQN.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to determine queries affected by relation replacement", err.Error())
+		return
+	}
+	if len(queries) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		fmt.Sprintf("Replacing relation %s will break running queries", current.FQN.ValueString()),
+		fmt.Sprintf("Changes to %s force the relation to be dropped and re-created. The following running queries read from or write to it and will fail:\n  - %s",
+			strings.Join(replacedBy, ", "), strings.Join(queries, "\n  - ")),
+	)
 }
 
 // Create implements resource.Resource.
@@ -180,27 +382,58 @@ func (d *RelationResource) Create(ctx context.Context, req resource.CreateReques
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
 		return
 	}
-	defer conn.Close()
+	// the session properties of the relation must not leak into the operations of other resources
+	defer util.DiscardConnection(conn)
+
+	// database, schema and store are derived from the statement plan when they are not set
+	var dbName, schemaName *string
+	if !relation.Database.IsUnknown() {
+		dbName = relation.Database.ValueStringPointer()
+	}
+	if !relation.Schema.IsUnknown() {
+		schemaName = relation.Schema.ValueStringPointer()
+	}
+	var storeName *string
+	if !relation.Store.IsUnknown() {
+		storeName = relation.Store.ValueStringPointer()
+	}
 
-	if err := util.SetSqlContext(ctx, conn, relation.Database.ValueStringPointer(), relation.Schema.ValueStringPointer(), relation.Store.ValueStringPointer()); err != nil {
+	if storeName != nil {
+		if err := util.WaitForStoreReady(ctx, conn, *storeName); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "store not ready", err)
+			return
+		}
+	}
+
+	if err := util.SetSqlContext(ctx, conn, dbName, schemaName, storeName); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set sql context", err)
 		return
 	}
 
-	row := conn.QueryRowContext(ctx, "DESCRIBE "+relation.Sql.ValueString())
-	var kind string
-	var descJson string
-	if err := row.Scan(&kind, &descJson); err != nil {
+	if err := setSessionProperties(ctx, conn, relation.WithProperties); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set session properties", err)
+		return
+	}
+
+	kind, descJson, err := describeStatement(ctx, conn, relation.Sql.ValueString())
+	if err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create relation", err)
 		return
 	}
+	// the relation is created on this connection in the context the statement was planned against
+	session, err := util.PinSqlContext(conn)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read sql context", err)
+		return
+	}
+	tflog.Debug(ctx, "relation statement planned", map[string]any{"kind": kind, "plan hash": planHash(descJson)})
 
-	if !util.ArrayContains([]string{kind}, []string{"CREATE_STREAM", "CREATE_CHANGELOG"}) {
+	if !util.ContainsAny([]string{"CREATE_STREAM", "CREATE_CHANGELOG"}, kind) {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("invalid relation type: %s", kind))
 		return
 	}
 
-	statementPlan := statementPlan{}
+	statementPlan := util.StatementPlan{}
 	if err := json.Unmarshal([]byte(descJson), &statementPlan); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to parse relation plan", err)
 		return
@@ -211,53 +444,151 @@ func (d *RelationResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	if statementPlan.Ddl.DbName != relation.Database.ValueString() {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("database name mismatch, statement would create relation in %s instead of %s", statementPlan.Ddl.DbName, relation.Database.ValueString()))
+	if dbName != nil && statementPlan.Ddl.DbName != *dbName {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("database name mismatch, statement would create relation in %s instead of %s", statementPlan.Ddl.DbName, *dbName))
 		return
 	}
 
-	if statementPlan.Ddl.SchemaName != relation.Schema.ValueString() {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("schema name mismatch, statement would create relation in %s instead of %s", statementPlan.Ddl.SchemaName, relation.Schema.ValueString()))
+	if schemaName != nil && statementPlan.Ddl.SchemaName != *schemaName {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("schema name mismatch, statement would create relation in %s instead of %s", statementPlan.Ddl.SchemaName, *schemaName))
 		return
 	}
+	relation.Database = types.StringValue(statementPlan.Ddl.DbName)
+	relation.Schema = types.StringValue(statementPlan.Ddl.SchemaName)
 
-	if statementPlan.Ddl.StoreName != relation.Store.ValueString() {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("store name mismatch, statement would use store %s instead of %s", statementPlan.Ddl.StoreName, relation.Store.ValueString()))
+	if storeName != nil && statementPlan.Ddl.StoreName != *storeName {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", fmt.Errorf("store name mismatch, statement would use store %s instead of %s", statementPlan.Ddl.StoreName, *storeName))
 		return
 	}
+	if storeName == nil {
+		if err := util.WaitForStoreReady(ctx, conn, statementPlan.Ddl.StoreName); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "store not ready", err)
+			return
+		}
+	}
+	relation.Store = types.StringValue(statementPlan.Ddl.StoreName)
 
-	artifactDDL := artifactDDL{}
-	row = conn.QueryRowContext(ctx, relation.Sql.ValueString())
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "relation", statementPlan.Ddl.Fqn)...)
+		return
+	}
+
+	if err := verifyStatementPlan(ctx, conn, session, relation.Sql.ValueString(), descJson); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "planning error", err)
+		return
+	}
+
+	artifactDDL := util.ArtifactDDL{}
+	createCtx, statement := util.WithStatementRecorder(ctx)
+	row := conn.QueryRowContext(createCtx, relation.Sql.ValueString())
 	if err := row.Scan(&artifactDDL.Type, &artifactDDL.Name, &artifactDDL.Command, &artifactDDL.Summary); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create relation", err)
 		return
 	}
+	relation.StatementID = statement.ID()
 	relation.FQN = types.StringValue(artifactDDL.Name)
 
-	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
-		relation, err = d.updateComputed(ctx, conn, relation)
+	if b, err := json.Marshal(newPlanFingerprint(dbName, schemaName, storeName, statementPlan)); err == nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, statementPlanKey, b)...)
+	}
+
+	// the rename, retention and tags below must not run against a relation dropped because it did not become ready
+	relation, err = d.awaitCreated(ctx, conn, relation, time.Minute*5)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "relation not ready", err)
+		return
+	}
+
+	if renameTo := d.cfg.ObjectName(relation.RenameTo.ValueString()); !relation.RenameTo.IsNull() && renameTo != relation.Name.ValueString() {
+		relation, err = d.rename(ctx, conn, relation, renameTo)
 		if err != nil {
-			return err
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to rename relation", err)
+			relation.setRetention(nullRetention())
+			resp.Diagnostics.Append(resp.State.Set(ctx, relation)...)
+			return
 		}
+	}
 
-		if relation.State.ValueString() != "created" {
-			return retry.RetryableError(fmt.Errorf("relation not yet created"))
-		}
+	retention, err := setRelationRetention(ctx, conn, relation.FQN.ValueString(), relation.retention())
+	if err != nil {
+		relation.setRetention(retention)
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set relation retention", err)
+		resp.Diagnostics.Append(resp.State.Set(ctx, relation)...)
+		return
+	}
+	relation.setRetention(retention.reportedOr(relation.retention()))
 
-		return nil
-	}); err != nil {
-		if _, derr := conn.ExecContext(ctx, fmt.Sprintf(`DROP RELATION %s;`, relation.FQN.ValueString())); derr != nil {
-			tflog.Error(ctx, "failed to clean up schema", map[string]any{
-				"name":  relation.FQN.ValueString(),
-				"error": derr.Error(),
-			})
+	// relations are created from the configured statement, the tags are set once the relation exists
+	if len(relation.TagsAll.Elements()) > 0 {
+		if err := setRelationTags(ctx, conn, relation.FQN.ValueString(), relation.TagsAll); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set relation tags", err)
+			relation.TagsAll = types.MapNull(types.StringType)
+			resp.Diagnostics.Append(resp.State.Set(ctx, relation)...)
+			return
 		}
 	}
 
 	tflog.Info(ctx, "Relation created", map[string]any{"name": relation.FQN.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_relation", relation.FQN.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, relation)...)
 }
 
+// sessionPropertyKey matches the keys with_properties accepts, they are quoted as is in the SET statements.
+var sessionPropertyKey = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+
+// setSessionProperties runs a SET statement per property on the connection, in key order. The connection must be
+// discarded rather than returned to the pool once the statement relying on the properties ran.
+func setSessionProperties(ctx context.Context, conn *sql.Conn, properties types.Map) error {
+	if properties.IsNull() || properties.IsUnknown() {
+		return nil
+	}
+
+	props := map[string]string{}
+	if dg := properties.ElementsAs(ctx, &props, false); dg.HasError() {
+		return fmt.Errorf("invalid properties: %v", dg)
+	}
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf(`SET '%s' = '%s';`, k, strings.ReplaceAll(props[k], "'", "''"))); err != nil {
+			return fmt.Errorf("failed to set %s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// describeStatement returns the kind and the JSON plan of a statement.
+func describeStatement(ctx context.Context, conn *sql.Conn, statement string) (kind string, descJson string, err error) {
+	err = conn.QueryRowContext(ctx, "DESCRIBE "+statement).Scan(&kind, &descJson)
+	return kind, descJson, err
+}
+
+// verifyStatementPlan checks, right before a statement runs, that the session is still in the context the statement
+// was described in and that describing it again yields the same plan as descJson.
+func verifyStatementPlan(ctx context.Context, conn *sql.Conn, session *util.SqlSession, statement, descJson string) error {
+	if err := session.Verify(); err != nil {
+		return err
+	}
+	_, current, err := describeStatement(ctx, conn, statement)
+	if err != nil {
+		return fmt.Errorf("failed to describe statement: %w", err)
+	}
+	if planHash(current) != planHash(descJson) {
+		return fmt.Errorf("statement plan changed since it was validated, re-run the apply")
+	}
+	return session.Verify()
+}
+
+// planHash returns the hash of a JSON statement plan.
+func planHash(descJson string) string {
+	sum := sha256.Sum256([]byte(descJson))
+	return hex.EncodeToString(sum[:])
+}
+
 func (d *RelationResource) updateComputed(ctx context.Context, conn *sql.Conn, rel RelationResourceData) (RelationResourceData, error) {
 	row := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT name, relation_type, "owner", "state", created_at, updated_at FROM deltastream.sys."relations" WHERE database_name || '.' || schema_name || '.' || name = '%s';`, rel.FQN.ValueString()))
 	if err := row.Err(); err != nil {
@@ -275,15 +606,78 @@ func (d *RelationResource) updateComputed(ctx context.Context, conn *sql.Conn, r
 	if err := row.Scan(&name, &kind, &owner, &state, &createdAt, &updatedAt); err != nil {
 		return rel, err
 	}
+	rel.ID = util.ResourceID(d.cfg.Organization, "relation", rel.FQN.ValueString())
 	rel.Name = types.StringValue(name)
 	rel.Owner = types.StringValue(owner)
 	rel.Type = types.StringValue(kind)
 	rel.State = types.StringValue(state)
-	rel.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-	rel.UpdatedAt = types.StringValue(createdAt.Format(time.RFC3339))
+	rel.CreatedAt = util.TimestampValue(createdAt)
+	rel.UpdatedAt = util.TimestampValue(updatedAt)
 	return rel, nil
 }
 
+// awaitCreated waits for the relation to reach the created state. A relation that does not become ready within
+// timeout is dropped, the returned error then means the relation no longer exists.
+func (d *RelationResource) awaitCreated(ctx context.Context, conn *sql.Conn, relation RelationResourceData, timeout time.Duration) (RelationResourceData, error) {
+	if err := retry.Do(ctx, retry.WithMaxDuration(timeout, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
+		relation, err = d.updateComputed(ctx, conn, relation)
+		if err != nil {
+			return err
+		}
+
+		if relation.State.ValueString() != "created" {
+			return retry.RetryableError(fmt.Errorf("relation not yet created"))
+		}
+
+		return nil
+	}); err != nil {
+		if _, derr := conn.ExecContext(ctx, fmt.Sprintf(`DROP RELATION %s;`, relation.FQN.ValueString())); derr != nil {
+			tflog.Error(ctx, "failed to clean up relation", map[string]any{
+				"name":  relation.FQN.ValueString(),
+				"error": derr.Error(),
+			})
+		}
+		return relation, err
+	}
+	return relation, nil
+}
+
+// setRelationTags replaces the tags of the relation with tags.
+func setRelationTags(ctx context.Context, conn *sql.Conn, fqn string, tags types.Map) error {
+	stmt, dg := util.SetTagsStatement(ctx, "RELATION", fqn, tags)
+	if dg.HasError() {
+		return fmt.Errorf("invalid tags: %v", dg)
+	}
+	_, err := conn.ExecContext(ctx, stmt)
+	return err
+}
+
+// renameStatement returns the statement renaming the relation fqn of the given type, stream or changelog, to name.
+func renameStatement(kind, fqn, name string) string {
+	return fmt.Sprintf(`ALTER %s %s RENAME TO "%s";`, strings.ToUpper(kind), fqn, name)
+}
+
+// rename renames the relation in place and waits for it to be listed under its new name.
+func (d *RelationResource) rename(ctx context.Context, conn *sql.Conn, rel RelationResourceData, name string) (RelationResourceData, error) {
+	if _, err := conn.ExecContext(ctx, renameStatement(rel.Type.ValueString(), rel.FQN.ValueString(), name)); err != nil {
+		return rel, err
+	}
+	tflog.Info(ctx, "Relation renamed", map[string]any{"from": rel.FQN.ValueString(), "to": name})
+
+	renamed := rel
+	renamed.FQN = types.StringValue(strings.Join([]string{rel.Database.ValueString(), rel.Schema.ValueString(), name}, "."))
+	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
+		renamed, err = d.updateComputed(ctx, conn, renamed)
+		if errors.Is(err, sql.ErrNoRows) {
+			return retry.RetryableError(fmt.Errorf("relation not yet renamed"))
+		}
+		return err
+	}); err != nil {
+		return rel, err
+	}
+	return renamed, nil
+}
+
 func (d *RelationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var relation RelationResourceData
 
@@ -292,6 +686,11 @@ func (d *RelationResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "relation", relation.FQN.ValueString())...)
+		return
+	}
+
 	roleName := d.cfg.Role
 	if !relation.Owner.IsNull() && !relation.Owner.IsUnknown() {
 		roleName = relation.Owner.ValueString()
@@ -349,6 +748,11 @@ func (d *RelationResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "relation", currentRelation.FQN.ValueString())...)
+		return
+	}
+
 	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
 	if err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
@@ -356,6 +760,16 @@ func (d *RelationResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 	defer conn.Close()
 
+	// an imported relation adopts the statement and store it is configured with on its first update
+	if util.Imported(ctx, req.Private) {
+		currentRelation.Sql = newRelation.Sql
+		if currentRelation.Store.IsNull() && !newRelation.Store.IsUnknown() {
+			currentRelation.Store = newRelation.Store
+		}
+		newRelation.Store = currentRelation.Store
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, util.ImportedKey, nil)...)
+	}
+
 	// all changes to database other than ownership are disallowed
 	if !newRelation.Database.Equal(currentRelation.Database) || !newRelation.Schema.Equal(currentRelation.Schema) || !newRelation.Store.Equal(currentRelation.Store) {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "invalid update", fmt.Errorf("database, schema and store names cannot be changed"))
@@ -372,6 +786,36 @@ func (d *RelationResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	if renameTo := d.cfg.ObjectName(newRelation.RenameTo.ValueString()); !newRelation.RenameTo.IsNull() && renameTo != currentRelation.Name.ValueString() {
+		currentRelation, err = d.rename(ctx, conn, currentRelation, renameTo)
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to rename relation", err)
+			return
+		}
+	}
+	currentRelation.RenameTo = newRelation.RenameTo
+
+	retention, err := setRelationRetention(ctx, conn, currentRelation.FQN.ValueString(), newRelation.retention())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set relation retention", err)
+		return
+	}
+	currentRelation.setRetention(retention.reportedOr(newRelation.retention()))
+
+	if !newRelation.TagsAll.Equal(currentRelation.TagsAll) {
+		if err := setRelationTags(ctx, conn, currentRelation.FQN.ValueString(), newRelation.TagsAll); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set relation tags", err)
+			return
+		}
+	}
+	currentRelation.Tags = newRelation.Tags
+	currentRelation.TagsAll = newRelation.TagsAll
+
+	fingerprint, dg := req.Private.GetKey(ctx, statementPlanKey)
+	resp.Diagnostics.Append(dg...)
+	resp.Diagnostics.Append(verifyPlanFingerprint(ctx, d.cfg, currentRelation, fingerprint)...)
+
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_relation", currentRelation.FQN.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, currentRelation)...)
 }
 
@@ -391,16 +835,34 @@ func (d *RelationResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 	defer conn.Close()
 
+	recorded := relation
 	relation, err = d.updateComputed(ctx, conn, relation)
 	if err != nil {
-		var godsErr gods.ErrSQLError
-		if errors.As(err, &godsErr) && godsErr.SQLCode == gods.SqlStateInvalidRelation {
-			resp.State.RemoveResource(ctx)
+		if util.RemoveIfNotFound(ctx, &resp.State, "relation", err) {
 			return
 		}
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
 		return
 	}
+	resp.Diagnostics.Append(d.cfg.ReportDrift("relation", relation.FQN.ValueString(), path.Root("owner"), recorded.Owner, relation.Owner)...)
+	resp.Diagnostics.Append(d.cfg.ReportDrift("relation", relation.FQN.ValueString(), path.Root("type"), recorded.Type, relation.Type)...)
+
+	// retention is informational, a topic that cannot be described keeps the recorded limits
+	if retention, err := relationRetention(ctx, conn, relation.FQN.ValueString()); err != nil {
+		tflog.Warn(ctx, "failed to read relation retention", map[string]any{
+			"name":  relation.FQN.ValueString(),
+			"error": err.Error(),
+		})
+		resp.Diagnostics.AddWarning(fmt.Sprintf("Unable to read retention of relation %s", relation.FQN.ValueString()),
+			fmt.Sprintf("The retention of the topic of the relation could not be read, the recorded retention_ms and retention_bytes are kept: %s", err))
+	} else {
+		relation.setRetention(retention.reportedOr(relation.retention()))
+	}
+	resp.Diagnostics.Append(d.cfg.ReadTags(ctx, conn, fmt.Sprintf(`DESCRIBE RELATION %s;`, relation.FQN.ValueString()), &relation.Tags, &relation.TagsAll)...)
+
+	fingerprint, dg := req.Private.GetKey(ctx, statementPlanKey)
+	resp.Diagnostics.Append(dg...)
+	resp.Diagnostics.Append(verifyPlanFingerprint(ctx, d.cfg, relation, fingerprint)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, relation)...)
 }
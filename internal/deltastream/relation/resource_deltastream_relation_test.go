@@ -0,0 +1,161 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+)
+
+const testOrganization = "00000000-0000-0000-0000-000000000001"
+
+func TestRelationUpdateComputed(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `FROM deltastream\.sys\."relations" WHERE .* = 'db1\.public\.pageviews';$`,
+		Columns: []mockserver.Column{
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "relation_type", Type: "VARCHAR"},
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "state", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+			{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("pageviews", "stream", "sysadmin", "created", "2024-01-02 03:04:05Z", "2024-02-03 04:05:06.25Z"),
+		},
+	}})
+
+	d := &RelationResource{cfg: &config.DeltaStreamProviderCfg{Organization: testOrganization}}
+	rel, err := d.updateComputed(ctx, conn, RelationResourceData{FQN: types.StringValue("db1.public.pageviews")})
+	if err != nil {
+		t.Fatalf("updateComputed() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"id":         testOrganization + "/relation/db1.public.pageviews",
+		"name":       "pageviews",
+		"type":       "stream",
+		"owner":      "sysadmin",
+		"state":      "created",
+		"created_at": "2024-01-02T03:04:05Z",
+		"updated_at": "2024-02-03T04:05:06.25Z",
+	}
+	actual := map[string]string{
+		"id":         rel.ID.ValueString(),
+		"name":       rel.Name.ValueString(),
+		"type":       rel.Type.ValueString(),
+		"owner":      rel.Owner.ValueString(),
+		"state":      rel.State.ValueString(),
+		"created_at": rel.CreatedAt.ValueString(),
+		"updated_at": rel.UpdatedAt.ValueString(),
+	}
+	for k, v := range expected {
+		if actual[k] != v {
+			t.Errorf("%s = %q, want %q", k, actual[k], v)
+		}
+	}
+}
+
+func TestRelationAwaitCreated(t *testing.T) {
+	ctx := context.Background()
+	server, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `FROM deltastream\.sys\."relations" WHERE .* = 'db1\.public\.pageviews';$`,
+		SqlState:  string(gods.SqlStateInvalidRelation),
+		Message:   "relation is in an invalid state",
+	}, {
+		Statement: `^DROP RELATION db1\.public\.pageviews;$`,
+	}})
+
+	d := &RelationResource{cfg: &config.DeltaStreamProviderCfg{Organization: testOrganization}}
+	if _, err := d.awaitCreated(ctx, conn, RelationResourceData{FQN: types.StringValue("db1.public.pageviews")}, time.Second); err == nil {
+		t.Fatalf("awaitCreated() expected an error for a relation that is not ready")
+	}
+
+	statements := server.Statements()
+	if last := statements[len(statements)-1]; !strings.HasPrefix(last, "DROP RELATION db1.public.pageviews;") {
+		t.Errorf("last statement = %q, want the relation dropped", last)
+	}
+}
+
+func TestSetSessionProperties(t *testing.T) {
+	ctx := context.Background()
+	server, conn := mockserver.NewConn(t, []mockserver.Fixture{{Statement: `^SET `}})
+
+	props := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"compute.size":        types.StringValue("large"),
+		"compute.parallelism": types.StringValue("4"),
+	})
+	if err := setSessionProperties(ctx, conn, props); err != nil {
+		t.Fatalf("setSessionProperties() error = %v", err)
+	}
+	if err := setSessionProperties(ctx, conn, types.MapNull(types.StringType)); err != nil {
+		t.Fatalf("setSessionProperties(null) error = %v", err)
+	}
+
+	statements := []string{}
+	for _, s := range server.Statements() {
+		if strings.HasPrefix(s, "SET ") {
+			statements = append(statements, s)
+		}
+	}
+	want := []string{`SET 'compute.parallelism' = '4';`, `SET 'compute.size' = 'large';`}
+	if !reflect.DeepEqual(statements, want) {
+		t.Errorf("statements = %v, want %v", statements, want)
+	}
+}
+
+func TestRelationRename(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{
+		{Statement: `^ALTER CHANGELOG db1\.public\.pageviews RENAME TO "page_views";$`},
+		{
+			Statement: `FROM deltastream\.sys\."relations" WHERE .* = 'db1\.public\.page_views';$`,
+			Columns: []mockserver.Column{
+				{Name: "name", Type: "VARCHAR"},
+				{Name: "relation_type", Type: "VARCHAR"},
+				{Name: "owner", Type: "VARCHAR"},
+				{Name: "state", Type: "VARCHAR"},
+				{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+				{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+			},
+			Rows: [][]*string{
+				mockserver.Row("page_views", "changelog", "sysadmin", "created", "2024-01-02 03:04:05Z", "2024-02-03 04:05:06Z"),
+			},
+		},
+	})
+
+	d := &RelationResource{cfg: &config.DeltaStreamProviderCfg{Organization: testOrganization}}
+	rel, err := d.rename(ctx, conn, RelationResourceData{
+		Database: types.StringValue("db1"),
+		Schema:   types.StringValue("public"),
+		Name:     types.StringValue("pageviews"),
+		Type:     types.StringValue("changelog"),
+		FQN:      types.StringValue("db1.public.pageviews"),
+	}, "page_views")
+	if err != nil {
+		t.Fatalf("rename() error = %v", err)
+	}
+
+	if got, want := rel.FQN.ValueString(), "db1.public.page_views"; got != want {
+		t.Errorf("fqn = %q, want %q", got, want)
+	}
+	if got, want := rel.Name.ValueString(), "page_views"; got != want {
+		t.Errorf("name = %q, want %q", got, want)
+	}
+	if got, want := rel.ID.ValueString(), testOrganization+"/relation/db1.public.page_views"; got != want {
+		t.Errorf("id = %q, want %q", got, want)
+	}
+}
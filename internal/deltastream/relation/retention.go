@@ -0,0 +1,142 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// topicRetention is the retention of the topic a relation is bound to. Both limits are null when the relation is not
+// bound to a topic or its store does not report topic configurations, -1 means unlimited.
+type topicRetention struct {
+	Ms    types.Int64
+	Bytes types.Int64
+}
+
+func nullRetention() topicRetention {
+	return topicRetention{Ms: types.Int64Null(), Bytes: types.Int64Null()}
+}
+
+// set reports whether any retention limit is known and not null.
+func (r topicRetention) set() bool {
+	return !r.Ms.IsNull() && !r.Ms.IsUnknown() || !r.Bytes.IsNull() && !r.Bytes.IsUnknown()
+}
+
+// reportedOr returns the reported retention limits, falling back to the limits of prior that the topic does not
+// report. Prior limits that are unknown fall back to null.
+func (r topicRetention) reportedOr(prior topicRetention) topicRetention {
+	pick := func(reported, prior types.Int64) types.Int64 {
+		if !reported.IsNull() {
+			return reported
+		}
+		if prior.IsUnknown() {
+			return types.Int64Null()
+		}
+		return prior
+	}
+	return topicRetention{Ms: pick(r.Ms, prior.Ms), Bytes: pick(r.Bytes, prior.Bytes)}
+}
+
+// describeTopicRetention reads the retention of a topic from the configurations reported by DESCRIBE ENTITY.
+func describeTopicRetention(ctx context.Context, conn *sql.Conn, store, topic string) (topicRetention, error) {
+	row, err := util.Describe(ctx, conn, fmt.Sprintf(`DESCRIBE ENTITY "%s" IN STORE "%s";`, topic, store))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nullRetention(), nil
+	}
+	if err != nil {
+		return nullRetention(), err
+	}
+
+	configs := map[string]string{}
+	for i, col := range row.Columns {
+		if strings.ToLower(col) == "configs" && row.Values[i].Valid {
+			if err := json.Unmarshal([]byte(row.Values[i].String), &configs); err != nil {
+				return nullRetention(), fmt.Errorf("failed to parse configurations of topic %s: %w", topic, err)
+			}
+		}
+	}
+
+	retention := nullRetention()
+	for key, dst := range map[string]*types.Int64{"retention.ms": &retention.Ms, "retention.bytes": &retention.Bytes} {
+		v, ok := configs[key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nullRetention(), fmt.Errorf("invalid %s of topic %s: %w", key, topic, err)
+		}
+		*dst = types.Int64Value(n)
+	}
+	return retention, nil
+}
+
+// relationRetention returns the retention of the topic of a relation.
+func relationRetention(ctx context.Context, conn *sql.Conn, fqn string) (topicRetention, error) {
+	metadata, err := describeRelation(ctx, conn, fqn)
+	if err != nil {
+		return nullRetention(), err
+	}
+	if metadata.Store.IsNull() || metadata.Topic.IsNull() {
+		return nullRetention(), nil
+	}
+	return describeTopicRetention(ctx, conn, metadata.Store.ValueString(), metadata.Topic.ValueString())
+}
+
+// retentionProperties returns the UPDATE ENTITY properties setting the planned retention limits that differ from the
+// current ones.
+func retentionProperties(planned, current topicRetention) []string {
+	props := []string{}
+	for _, p := range []struct {
+		key              string
+		planned, current types.Int64
+	}{
+		{"retention.ms", planned.Ms, current.Ms},
+		{"retention.bytes", planned.Bytes, current.Bytes},
+	} {
+		if p.planned.IsNull() || p.planned.IsUnknown() || p.planned.Equal(p.current) {
+			continue
+		}
+		props = append(props, fmt.Sprintf("'kafka.topic.%s' = %d", p.key, p.planned.ValueInt64()))
+	}
+	return props
+}
+
+// setRelationRetention updates the topic of a relation to the planned retention limits and returns the retention the
+// topic reports afterwards.
+func setRelationRetention(ctx context.Context, conn *sql.Conn, fqn string, planned topicRetention) (topicRetention, error) {
+	metadata, err := describeRelation(ctx, conn, fqn)
+	if err != nil {
+		return nullRetention(), err
+	}
+	if metadata.Store.IsNull() || metadata.Topic.IsNull() {
+		if !planned.set() {
+			return nullRetention(), nil
+		}
+		return nullRetention(), fmt.Errorf("relation %s is not bound to a topic, retention_ms and retention_bytes can only be set on relations backed by a Kafka topic", fqn)
+	}
+
+	current, err := describeTopicRetention(ctx, conn, metadata.Store.ValueString(), metadata.Topic.ValueString())
+	if err != nil {
+		return current, err
+	}
+	props := retentionProperties(planned, current)
+	if len(props) == 0 {
+		return current, nil
+	}
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`UPDATE ENTITY "%s" IN STORE "%s" WITH (%s);`, metadata.Topic.ValueString(), metadata.Store.ValueString(), strings.Join(props, ", "))); err != nil {
+		return current, fmt.Errorf("failed to set retention of topic %s: %w", metadata.Topic.ValueString(), err)
+	}
+	return describeTopicRetention(ctx, conn, metadata.Store.ValueString(), metadata.Topic.ValueString())
+}
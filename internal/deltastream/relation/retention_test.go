@@ -0,0 +1,113 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package relation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestRetentionProperties(t *testing.T) {
+	current := topicRetention{Ms: types.Int64Value(604800000), Bytes: types.Int64Value(-1)}
+	tests := []struct {
+		name     string
+		planned  topicRetention
+		expected []string
+	}{
+		{name: "unchanged", planned: current, expected: []string{}},
+		{name: "not configured", planned: topicRetention{Ms: types.Int64Unknown(), Bytes: types.Int64Unknown()}, expected: []string{}},
+		{
+			name:     "ms changed",
+			planned:  topicRetention{Ms: types.Int64Value(86400000), Bytes: types.Int64Unknown()},
+			expected: []string{"'kafka.topic.retention.ms' = 86400000"},
+		},
+		{
+			name:     "both changed",
+			planned:  topicRetention{Ms: types.Int64Value(-1), Bytes: types.Int64Value(1073741824)},
+			expected: []string{"'kafka.topic.retention.ms' = -1", "'kafka.topic.retention.bytes' = 1073741824"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if props := retentionProperties(tt.planned, current); !reflect.DeepEqual(props, tt.expected) {
+				t.Errorf("retentionProperties() = %v, want %v", props, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetentionReportedOr(t *testing.T) {
+	prior := topicRetention{Ms: types.Int64Value(86400000), Bytes: types.Int64Unknown()}
+	tests := []struct {
+		name     string
+		reported topicRetention
+		expected topicRetention
+	}{
+		{name: "reported", reported: topicRetention{Ms: types.Int64Value(604800000), Bytes: types.Int64Value(-1)}, expected: topicRetention{Ms: types.Int64Value(604800000), Bytes: types.Int64Value(-1)}},
+		{name: "not reported", reported: nullRetention(), expected: topicRetention{Ms: types.Int64Value(86400000), Bytes: types.Int64Null()}},
+		{name: "partially reported", reported: topicRetention{Ms: types.Int64Null(), Bytes: types.Int64Value(1024)}, expected: topicRetention{Ms: types.Int64Value(86400000), Bytes: types.Int64Value(1024)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reported.reportedOr(prior); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("reportedOr() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetRelationRetention(t *testing.T) {
+	ctx := context.Background()
+	server, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE RELATION db1\.public\.pageviews;$`,
+		Columns:   []mockserver.Column{{Name: "Name", Type: "VARCHAR"}, {Name: "Store", Type: "VARCHAR"}, {Name: "Topic", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("pageviews", "kafka_store", "pageviews")},
+	}, {
+		Statement: `^DESCRIBE RELATION db1\.public\.orders;$`,
+		Columns:   []mockserver.Column{{Name: "Name", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("orders")},
+	}, {
+		Statement: `^DESCRIBE ENTITY "pageviews" IN STORE "kafka_store";$`,
+		Columns:   []mockserver.Column{{Name: "Name", Type: "VARCHAR"}, {Name: "Configs", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("pageviews", `{"cleanup.policy":"delete","retention.ms":"604800000","retention.bytes":"-1"}`)},
+	}, {
+		Statement: `^UPDATE ENTITY "pageviews" IN STORE "kafka_store" WITH \('kafka\.topic\.retention\.ms' = 86400000\);$`,
+	}})
+
+	retention, err := relationRetention(ctx, conn, "db1.public.pageviews")
+	if err != nil {
+		t.Fatalf("relationRetention() error = %v", err)
+	}
+	if expected := (topicRetention{Ms: types.Int64Value(604800000), Bytes: types.Int64Value(-1)}); retention != expected {
+		t.Errorf("relationRetention() = %v, want %v", retention, expected)
+	}
+
+	if _, err := setRelationRetention(ctx, conn, "db1.public.pageviews", topicRetention{Ms: types.Int64Value(86400000), Bytes: types.Int64Unknown()}); err != nil {
+		t.Fatalf("setRelationRetention() error = %v", err)
+	}
+	updated := false
+	for _, stmt := range server.Statements() {
+		updated = updated || stmt == `UPDATE ENTITY "pageviews" IN STORE "kafka_store" WITH ('kafka.topic.retention.ms' = 86400000);`
+	}
+	if !updated {
+		t.Errorf("expected the retention of the topic to be updated, statements: %v", server.Statements())
+	}
+
+	// relations that are not bound to a topic have no retention, and it cannot be set on them
+	retention, err = relationRetention(ctx, conn, "db1.public.orders")
+	if err != nil {
+		t.Fatalf("relationRetention() error = %v", err)
+	}
+	if retention != nullRetention() {
+		t.Errorf("relationRetention() = %v, want null retention", retention)
+	}
+	if _, err := setRelationRetention(ctx, conn, "db1.public.orders", topicRetention{Ms: types.Int64Value(86400000), Bytes: types.Int64Unknown()}); err == nil {
+		t.Error("expected setting the retention of a relation without topic to fail")
+	}
+}
@@ -5,13 +5,16 @@ package schema
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -40,10 +43,80 @@ func (d *SchemaDataSource) Configure(ctx context.Context, req datasource.Configu
 	d.cfg = cfg
 }
 
-type SchemaDatasourceData = SchemaResourceData
+type SchemaDatasourceData struct {
+	ID             types.String `tfsdk:"id"`
+	Database       types.String `tfsdk:"database"`
+	Name           types.String `tfsdk:"name"`
+	Owner          types.String `tfsdk:"owner"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	IncludeObjects types.Bool   `tfsdk:"include_objects"`
+	Objects        types.List   `tfsdk:"objects"`
+}
+
+type SchemaObjectData struct {
+	Name      types.String `tfsdk:"name"`
+	FQN       types.String `tfsdk:"fqn"`
+	Type      types.String `tfsdk:"type"`
+	Owner     types.String `tfsdk:"owner"`
+	State     types.String `tfsdk:"state"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+func (SchemaObjectData) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":       types.StringType,
+		"fqn":        types.StringType,
+		"type":       types.StringType,
+		"owner":      types.StringType,
+		"state":      types.StringType,
+		"created_at": types.StringType,
+		"updated_at": types.StringType,
+	}
+}
 
 func (d *SchemaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = getSchemaSchema()
+	resp.Schema.Attributes["include_objects"] = schema.BoolAttribute{
+		Description: "Whether to list the objects contained in the Schema",
+		Optional:    true,
+	}
+	resp.Schema.Attributes["objects"] = schema.ListNestedAttribute{
+		Description: "Relations in the Schema, only set when include_objects is true",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Description: "Name of the Relation",
+					Computed:    true,
+				},
+				"fqn": schema.StringAttribute{
+					Description: "Fully qualified name of the Relation",
+					Computed:    true,
+				},
+				"type": schema.StringAttribute{
+					Description: "Type of the Relation",
+					Computed:    true,
+				},
+				"owner": schema.StringAttribute{
+					Description: "Owning role of the Relation",
+					Computed:    true,
+				},
+				"state": schema.StringAttribute{
+					Description: "State of the Relation",
+					Computed:    true,
+				},
+				"created_at": schema.StringAttribute{
+					Description: "Creation date of the Relation",
+					Computed:    true,
+				},
+				"updated_at": schema.StringAttribute{
+					Description: "Last update date of the Relation",
+					Computed:    true,
+				},
+			},
+		},
+	}
 }
 
 func getSchemaSchema() schema.Schema {
@@ -51,6 +124,10 @@ func getSchemaSchema() schema.Schema {
 		MarkdownDescription: "Schema resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Schema",
+				Computed:    true,
+			},
 			"database": schema.StringAttribute{
 				Description: "Name of the Database",
 				Required:    true,
@@ -94,29 +171,26 @@ func (d *SchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`LIST SCHEMAS IN DATABASE "%s";`, schema.Database.ValueString()))
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list schemas", err)
-		return
-	}
-	defer rows.Close()
-
 	found := false
-	for rows.Next() {
+	if err := util.QueryRows(ctx, conn, fmt.Sprintf(`LIST SCHEMAS IN DATABASE "%s";`, schema.Database.ValueString()), func(rows *sql.Rows) error {
 		var discard any
 		var name string
 		var owner string
 		var createdAt time.Time
 		if err := rows.Scan(&name, &discard, &owner, &createdAt); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read schema", err)
-			return
+			return err
 		}
-		if name == schema.Name.ValueString() {
-			found = true
-			schema.Owner = types.StringValue(owner)
-			schema.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-			break
+		if name != schema.Name.ValueString() {
+			return nil
 		}
+		found = true
+		schema.ID = util.ResourceID(d.cfg.Organization, "schema", schema.Database.ValueString(), name)
+		schema.Owner = types.StringValue(owner)
+		schema.CreatedAt = util.TimestampValue(createdAt)
+		return util.ErrStopRows
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list schemas", err)
+		return
 	}
 
 	if !found {
@@ -124,5 +198,52 @@ func (d *SchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	schema.Objects = types.ListNull(types.ObjectType{AttrTypes: SchemaObjectData{}.AttributeTypes()})
+	if schema.IncludeObjects.ValueBool() {
+		objects, err := listObjects(ctx, conn, schema.Database.ValueString(), schema.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list schema objects", err)
+			return
+		}
+
+		var dg diag.Diagnostics
+		schema.Objects, dg = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: SchemaObjectData{}.AttributeTypes()}, objects)
+		resp.Diagnostics.Append(dg...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &schema)...)
 }
+
+// listObjects returns the relations of a schema.
+func listObjects(ctx context.Context, conn *sql.Conn, databaseName, schemaName string) ([]SchemaObjectData, error) {
+	objects := []SchemaObjectData{}
+	if err := util.QueryRows(ctx, conn, fmt.Sprintf(`SELECT name, relation_type, "owner", "state", created_at, updated_at FROM deltastream.sys."relations" WHERE database_name = '%s' AND schema_name = '%s';`, databaseName, schemaName), func(rows *sql.Rows) error {
+		var (
+			name      string
+			kind      string
+			owner     string
+			state     string
+			createdAt time.Time
+			updatedAt time.Time
+		)
+		if err := rows.Scan(&name, &kind, &owner, &state, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+		objects = append(objects, SchemaObjectData{
+			Name:      types.StringValue(name),
+			FQN:       types.StringValue(fmt.Sprintf("%s.%s.%s", databaseName, schemaName, name)),
+			Type:      types.StringValue(kind),
+			Owner:     types.StringValue(owner),
+			State:     types.StringValue(state),
+			CreatedAt: util.TimestampValue(createdAt),
+			UpdatedAt: util.TimestampValue(updatedAt),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
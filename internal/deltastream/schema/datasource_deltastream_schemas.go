@@ -5,6 +5,7 @@ package schema
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -60,15 +61,27 @@ func (d *SchemasDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 			},
 		},
 	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["items"].(schema.ListNestedAttribute), "name")
 }
 
 func (d *SchemasDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_schemas"
 }
 
+// SchemaItemData is a schema listed by the schemas data source.
+type SchemaItemData struct {
+	ID        types.String `tfsdk:"id"`
+	Database  types.String `tfsdk:"database"`
+	Name      types.String `tfsdk:"name"`
+	Owner     types.String `tfsdk:"owner"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
 type SchemasDatasourceData struct {
 	Database types.String `tfsdk:"database"`
 	Items    types.List   `tfsdk:"items"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
 }
 
 func (d *SchemasDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -86,34 +99,34 @@ func (d *SchemasDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`LIST SCHEMAS IN DATABASE "%s";`, schemas.Database.ValueString()))
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list schemas", err)
-		return
-	}
-	defer rows.Close()
-
-	items := []SchemaDatasourceData{}
-	for rows.Next() {
+	items := []SchemaItemData{}
+	if err := util.QueryRows(ctx, conn, fmt.Sprintf(`LIST SCHEMAS IN DATABASE "%s";`, schemas.Database.ValueString()), func(rows *sql.Rows) error {
 		var discard any
 		var name string
 		var owner string
 		var createdAt time.Time
 		if err := rows.Scan(&name, &discard, &owner, &createdAt); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read schemas", err)
-			return
+			return err
 		}
-		items = append(items, SchemaDatasourceData{
+		item := SchemaItemData{
+			ID:        util.ResourceID(d.cfg.Organization, "schema", schemas.Database.ValueString(), name),
 			Database:  schemas.Database,
 			Name:      types.StringValue(name),
 			Owner:     types.StringValue(owner),
-			CreatedAt: types.StringValue(createdAt.Format(time.RFC3339)),
-		})
+			CreatedAt: util.TimestampValue(createdAt),
+		}
+		items = append(items, item)
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list schemas", err)
+		return
 	}
 
 	var dg diag.Diagnostics
 	schemas.Items, dg = types.ListValueFrom(ctx, schemas.Items.ElementType(ctx), items)
 	resp.Diagnostics.Append(dg...)
+	schemas.ItemsByName, dg = util.ItemsByName(ctx, schemas.Items, "name")
+	resp.Diagnostics.Append(dg...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &schemas)...)
 }
@@ -0,0 +1,58 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+)
+
+func TestSchemasDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	_, db := mockserver.NewDB(t, []mockserver.Fixture{{
+		Statement: `^LIST SCHEMAS IN DATABASE "analytics";$`,
+		Columns: []mockserver.Column{
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "is_default", Type: "BOOLEAN"},
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+		},
+		Rows: [][]*string{mockserver.Row("public", "true", "sysadmin", "2024-01-01 00:00:00Z")},
+	}})
+
+	d := &SchemasDataSource{cfg: &config.DeltaStreamProviderCfg{Db: db, Organization: "00000000-0000-0000-0000-000000000001", Role: "sysadmin"}}
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["database"] = tftypes.NewValue(tftypes.String, "analytics")
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, nil)}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, values)}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() errors = %v", resp.Diagnostics)
+	}
+
+	var schemas SchemasDatasourceData
+	resp.Diagnostics.Append(resp.State.Get(ctx, &schemas)...)
+	items := []SchemaItemData{}
+	resp.Diagnostics.Append(schemas.Items.ElementsAs(ctx, &items, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("failed to read items: %v", resp.Diagnostics)
+	}
+	if len(items) != 1 || items[0].Name.ValueString() != "public" || items[0].Owner.ValueString() != "sysadmin" {
+		t.Errorf("items = %v, want the public schema", items)
+	}
+}
@@ -9,11 +9,15 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"text/template"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sethvargo/go-retry"
@@ -25,6 +29,8 @@ import (
 
 var _ resource.Resource = &SchemaResource{}
 var _ resource.ResourceWithConfigure = &SchemaResource{}
+var _ resource.ResourceWithModifyPlan = &SchemaResource{}
+var _ resource.ResourceWithImportState = &SchemaResource{}
 
 func NewSchemaResource() resource.Resource {
 	return &SchemaResource{}
@@ -35,10 +41,17 @@ type SchemaResource struct {
 }
 
 type SchemaResourceData struct {
-	Database  types.String `tfsdk:"database"`
-	Name      types.String `tfsdk:"name"`
-	Owner     types.String `tfsdk:"owner"`
-	CreatedAt types.String `tfsdk:"created_at"`
+	ID           types.String `tfsdk:"id"`
+	Database     types.String `tfsdk:"database"`
+	Name         types.String `tfsdk:"name"`
+	Owner        types.String `tfsdk:"owner"`
+	DefaultStore types.String `tfsdk:"default_store"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+
+	Tags    types.Map `tfsdk:"tags"`
+	TagsAll types.Map `tfsdk:"tags_all"`
+
+	StatementID types.String `tfsdk:"statement_id"`
 }
 
 func (d *SchemaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -46,6 +59,13 @@ func (d *SchemaResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		MarkdownDescription: "Schema resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Schema",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"database": schema.StringAttribute{
 				Description: "Name of the Database",
 				Required:    true,
@@ -62,10 +82,33 @@ func (d *SchemaResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:    true,
 				Validators:  util.IdentifierValidators,
 			},
+			"default_store": schema.StringAttribute{
+				Description: "Default store for relations in the Schema. DeltaStream does not record it, relations use it by setting their store to this attribute",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+			},
 			"created_at": schema.StringAttribute{
 				Description: "Creation date of the schema",
 				Computed:    true,
 			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the Schema. Tags can be changed without replacing the Schema",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  util.TagValidators,
+			},
+			"tags_all": schema.MapAttribute{
+				Description: "Tags of the Schema merged with the default_tags of the provider",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the Schema, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -89,7 +132,30 @@ func (d *SchemaResource) Metadata(ctx context.Context, req resource.MetadataRequ
 	resp.TypeName = req.ProviderTypeName + "_schema"
 }
 
-const createStatement = `CREATE SCHEMA "{{.Name}}" IN DATABASE "{{.Database}}";`
+// ImportState adopts an existing schema from an import ID of the form database_name.schema_name.
+func (d *SchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	database, name, ok := strings.Cut(req.ID, ".")
+	if !ok || database == "" || name == "" || strings.Contains(name, ".") {
+		resp.Diagnostics.AddError("invalid import ID", fmt.Sprintf("invalid import ID %q, expected database_name.schema_name", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), database)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func (d *SchemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultOwner(ctx, "schema", req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ValidateOwner(ctx, resp.Plan, req.State)...)
+	resp.Diagnostics.Append(d.cfg.ValidateIdentifierCase(ctx, resp.Plan, req.State, "name")...)
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultTags(ctx, &resp.Plan)...)
+}
+
+const createStatement = `CREATE SCHEMA "{{.Name}}" IN DATABASE "{{.Database}}"{{if .Tags}} WITH ({{.Tags}}){{end}};`
 
 // Create implements resource.Resource.
 func (d *SchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -113,15 +179,32 @@ func (d *SchemaResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	defer conn.Close()
 
+	tags, dg := util.TagsProperty(ctx, schema.TagsAll)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	b := bytes.NewBuffer(nil)
 	template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
-		"Database": schema.Database.ValueString(),
-		"Name":     schema.Name.ValueString(),
+		"Database": d.cfg.ObjectName(schema.Database.ValueString()),
+		"Name":     d.cfg.ObjectName(schema.Name.ValueString()),
+		"Tags":     tags,
 	})
-	if _, err := conn.ExecContext(ctx, b.String()); err != nil {
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "schema", schema.Database.ValueString()+"."+schema.Name.ValueString())...)
+		return
+	}
+	createCtx, statement := util.WithStatementRecorder(ctx)
+	// schemas created in parallel in one database contend for the lock of the database
+	if err := util.RetryCreateOnConflict(createCtx, "schema", func(ctx context.Context) error {
+		_, err := conn.ExecContext(ctx, b.String())
+		return err
+	}); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create schema", err)
 		return
 	}
+	schema.StatementID = statement.ID()
 
 	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
 		schema, err = d.updateComputed(ctx, conn, schema)
@@ -134,7 +217,7 @@ func (d *SchemaResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 		return nil
 	}); err != nil {
-		if _, derr := conn.ExecContext(ctx, fmt.Sprintf(`DROP SCHEMA "%s"."%s";`, schema.Database.ValueString(), schema.Name.ValueString())); derr != nil {
+		if derr := dropSchema(ctx, conn, d.cfg.ObjectName(schema.Database.ValueString()), d.cfg.ObjectName(schema.Name.ValueString())); derr != nil {
 			tflog.Error(ctx, "failed to clean up schema", map[string]any{
 				"name":  schema.Name.ValueString(),
 				"error": derr.Error(),
@@ -145,31 +228,43 @@ func (d *SchemaResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	tflog.Info(ctx, "Schema created", map[string]any{"name": schema.Name.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_schema", schema.Database.ValueString()+"."+schema.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, schema)...)
 }
 
 func (d *SchemaResource) updateComputed(ctx context.Context, conn *sql.Conn, sch SchemaResourceData) (SchemaResourceData, error) {
-	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`LIST SCHEMAS IN DATABASE "%s";`, sch.Database.ValueString()))
-	if err != nil {
-		return sch, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
+	found := false
+	if err := util.QueryRows(ctx, conn, fmt.Sprintf(`LIST SCHEMAS IN DATABASE "%s";`, d.cfg.ObjectName(sch.Database.ValueString())), func(rows *sql.Rows) error {
 		var discard any
 		var name string
 		var owner string
 		var createdAt time.Time
 		if err := rows.Scan(&name, &discard, &owner, &createdAt); err != nil {
-			return sch, err
+			return err
 		}
-		if name == sch.Name.ValueString() {
-			sch.Owner = types.StringValue(owner)
-			sch.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-			return sch, nil
+		if name != d.cfg.ObjectName(sch.Name.ValueString()) {
+			return nil
 		}
+		found = true
+		sch.ID = util.ResourceID(d.cfg.Organization, "schema", sch.Database.ValueString(), name)
+		sch.Owner = types.StringValue(owner)
+		sch.CreatedAt = util.TimestampValue(createdAt)
+		return util.ErrStopRows
+	}); err != nil {
+		return sch, err
+	}
+	if !found {
+		return SchemaResourceData{}, gods.ErrSQLError{SQLCode: gods.SqlStateInvalidSchema}
 	}
-	return SchemaResourceData{}, &gods.ErrSQLError{SQLCode: gods.SqlStateInvalidSchema}
+	return sch, nil
+}
+
+// dropSchema drops a schema, retrying while the drop conflicts with schemas created or dropped alongside it.
+func dropSchema(ctx context.Context, conn *sql.Conn, database, name string) error {
+	return util.RetryDropOnConflict(ctx, "schema", func(ctx context.Context) error {
+		_, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP SCHEMA "%s"."%s";`, database, name))
+		return err
+	})
 }
 
 func (d *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -180,6 +275,11 @@ func (d *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "schema", schema.Database.ValueString()+"."+schema.Name.ValueString())...)
+		return
+	}
+
 	roleName := d.cfg.Role
 	if !schema.Owner.IsNull() && !schema.Owner.IsUnknown() {
 		roleName = schema.Owner.ValueString()
@@ -192,7 +292,7 @@ func (d *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 	defer conn.Close()
 
-	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP SCHEMA "%s"."%s";`, schema.Database.ValueString(), schema.Name.ValueString())); err != nil {
+	if err := dropSchema(ctx, conn, d.cfg.ObjectName(schema.Database.ValueString()), d.cfg.ObjectName(schema.Name.ValueString())); err != nil {
 		var sqlErr gods.ErrSQLError
 		if !errors.As(err, &sqlErr) || (sqlErr.SQLCode != gods.SqlStateInvalidDatabase && sqlErr.SQLCode != gods.SqlStateInvalidSchema) {
 			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to delete schema", err)
@@ -203,7 +303,58 @@ func (d *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (d *SchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("schema updates not supported"))
+	var currentSchema SchemaResourceData
+	var newSchema SchemaResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &newSchema)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentSchema)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "schema", currentSchema.Database.ValueString()+"."+currentSchema.Name.ValueString())...)
+		return
+	}
+
+	// only the default store binding and the tags can be changed in place
+	if !newSchema.Database.Equal(currentSchema.Database) || !newSchema.Name.Equal(currentSchema.Name) || (!newSchema.Owner.IsUnknown() && !newSchema.Owner.Equal(currentSchema.Owner)) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("schema updates not supported"))
+		return
+	}
+
+	if !newSchema.TagsAll.Equal(currentSchema.TagsAll) {
+		roleName := d.cfg.Role
+		if !currentSchema.Owner.IsNull() && !currentSchema.Owner.IsUnknown() {
+			roleName = currentSchema.Owner.ValueString()
+		}
+
+		ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+			return
+		}
+		defer conn.Close()
+
+		stmt, dg := util.SetTagsStatement(ctx, "SCHEMA", fmt.Sprintf(`"%s"."%s"`, d.cfg.ObjectName(currentSchema.Database.ValueString()), d.cfg.ObjectName(currentSchema.Name.ValueString())), newSchema.TagsAll)
+		resp.Diagnostics.Append(dg...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set schema tags", err)
+			return
+		}
+	}
+
+	currentSchema.DefaultStore = newSchema.DefaultStore
+	currentSchema.Tags = newSchema.Tags
+	currentSchema.TagsAll = newSchema.TagsAll
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_schema", currentSchema.Database.ValueString()+"."+currentSchema.Name.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, currentSchema)...)
 }
 
 func (d *SchemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -227,16 +378,17 @@ func (d *SchemaResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 	defer conn.Close()
 
+	recorded := schema
 	schema, err = d.updateComputed(ctx, conn, schema)
 	if err != nil {
-		var sqlErr gods.ErrSQLError
-		if errors.As(err, &sqlErr) && sqlErr.SQLCode == gods.SqlStateInvalidSchema {
-			resp.State.RemoveResource(ctx)
+		if util.RemoveIfNotFound(ctx, &resp.State, "schema", err) {
 			return
 		}
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
 		return
 	}
+	resp.Diagnostics.Append(d.cfg.ReportDrift("schema", schema.Database.ValueString()+"."+schema.Name.ValueString(), path.Root("owner"), recorded.Owner, schema.Owner)...)
+	resp.Diagnostics.Append(d.cfg.ReadTags(ctx, conn, fmt.Sprintf(`DESCRIBE SCHEMA "%s"."%s";`, d.cfg.ObjectName(schema.Database.ValueString()), d.cfg.ObjectName(schema.Name.ValueString())), &schema.Tags, &schema.TagsAll)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, schema)...)
 }
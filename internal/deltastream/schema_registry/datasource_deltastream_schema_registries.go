@@ -5,6 +5,7 @@ package schemaregistry
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -29,6 +30,7 @@ type SchemaRegistriesDataSource struct {
 }
 
 type SchemaRegistryDatasourceDataItem struct {
+	ID        types.String `tfsdk:"id"`
 	Name      types.String `tfsdk:"name"`
 	Type      types.String `tfsdk:"type"`
 	Owner     types.String `tfsdk:"owner"`
@@ -39,6 +41,8 @@ type SchemaRegistryDatasourceDataItem struct {
 
 type SchemaRegistriesDatasourceData struct {
 	Items types.List `tfsdk:"items"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
 }
 
 func (d *SchemaRegistriesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -65,6 +69,10 @@ func (d *SchemaRegistriesDataSource) Schema(ctx context.Context, req datasource.
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Global identifier of the schema registry",
+							Computed:    true,
+						},
 						"name": schema.StringAttribute{
 							Description: "Name of the schema registry",
 							Computed:    true,
@@ -98,6 +106,7 @@ func (d *SchemaRegistriesDataSource) Schema(ctx context.Context, req datasource.
 			},
 		},
 	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["items"].(schema.ListNestedAttribute), "name")
 }
 
 func (d *SchemaRegistriesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -119,15 +128,8 @@ func (d *SchemaRegistriesDataSource) Read(ctx context.Context, req datasource.Re
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, `LIST SCHEMA_REGISTRIES;`)
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list schema registry", err)
-		return
-	}
-	defer rows.Close()
-
 	items := []SchemaRegistryDatasourceDataItem{}
-	for rows.Next() {
+	if err := util.QueryRows(ctx, conn, `LIST SCHEMA_REGISTRIES;`, func(rows *sql.Rows) error {
 		var discard any
 		var name string
 		// var accessRegion string
@@ -137,22 +139,28 @@ func (d *SchemaRegistriesDataSource) Read(ctx context.Context, req datasource.Re
 		var createdAt time.Time
 		var updatedAt time.Time
 		if err := rows.Scan(&name, &kind, &state, &discard, &owner, &createdAt, &updatedAt); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read schema registry", err)
-			return
+			return err
 		}
 		items = append(items, SchemaRegistryDatasourceDataItem{
+			ID:        util.ResourceID(d.cfg.Organization, "schema_registry", name),
 			Name:      types.StringValue(name),
 			Type:      types.StringValue(kind),
 			State:     types.StringValue(state),
 			Owner:     types.StringValue(owner),
-			CreatedAt: types.StringValue(createdAt.Format(time.RFC3339)),
-			UpdatedAt: types.StringValue(createdAt.Format(time.RFC3339)),
+			CreatedAt: util.TimestampValue(createdAt),
+			UpdatedAt: util.TimestampValue(updatedAt),
 		})
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list schema registry", err)
+		return
 	}
 
 	var dg diag.Diagnostics
 	schemaRegistries.Items, dg = types.ListValueFrom(ctx, schemaRegistries.Items.ElementType(ctx), items)
 	resp.Diagnostics.Append(dg...)
+	schemaRegistries.ItemsByName, dg = util.ItemsByName(ctx, schemaRegistries.Items, "name")
+	resp.Diagnostics.Append(dg...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &schemaRegistries)...)
 }
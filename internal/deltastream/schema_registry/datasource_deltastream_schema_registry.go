@@ -5,6 +5,7 @@ package schemaregistry
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -23,6 +25,17 @@ func NewSchemaRegistryDataSource() datasource.DataSource {
 	return &SchemaRegistryDataSource{}
 }
 
+type SchemaRegistryDatasourceData struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Owner     types.String `tfsdk:"owner"`
+	State     types.String `tfsdk:"state"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	Stores    types.List   `tfsdk:"stores"`
+}
+
 type SchemaRegistryDataSource struct {
 	cfg *config.DeltaStreamProviderCfg
 }
@@ -46,6 +59,10 @@ func (d *SchemaRegistryDataSource) Schema(ctx context.Context, req datasource.Sc
 		MarkdownDescription: "Schema registry datasource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the schema registry",
+				Computed:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Name of the schema registry",
 				Required:    true,
@@ -74,6 +91,11 @@ func (d *SchemaRegistryDataSource) Schema(ctx context.Context, req datasource.Sc
 				Description: "Last update date of the schema registry",
 				Computed:    true,
 			},
+			"stores": schema.ListAttribute{
+				Description: "Names of the stores the schema registry is attached to",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -83,7 +105,7 @@ func (d *SchemaRegistryDataSource) Metadata(ctx context.Context, req datasource.
 }
 
 func (d *SchemaRegistryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	sr := SchemaRegistryDatasourceDataItem{}
+	sr := SchemaRegistryDatasourceData{}
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &sr)...)
 	if resp.Diagnostics.HasError() {
@@ -97,15 +119,8 @@ func (d *SchemaRegistryDataSource) Read(ctx context.Context, req datasource.Read
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, `LIST SCHEMA_REGISTRIES;`)
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list schema registry", err)
-		return
-	}
-	defer rows.Close()
-
 	found := false
-	for rows.Next() {
+	if err := util.QueryRows(ctx, conn, `LIST SCHEMA_REGISTRIES;`, func(rows *sql.Rows) error {
 		var discard any
 		var name string
 		// var accessRegion string
@@ -115,18 +130,22 @@ func (d *SchemaRegistryDataSource) Read(ctx context.Context, req datasource.Read
 		var createdAt time.Time
 		var updatedAt time.Time
 		if err := rows.Scan(&name, &kind, &state, &discard, &owner, &createdAt, &updatedAt); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read schema registry", err)
-			return
+			return err
 		}
-		if name == sr.Name.ValueString() {
-			found = true
-			sr.Type = types.StringValue(kind)
-			sr.State = types.StringValue(state)
-			sr.Owner = types.StringValue(owner)
-			sr.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-			sr.UpdatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-			break
+		if name != sr.Name.ValueString() {
+			return nil
 		}
+		found = true
+		sr.ID = util.ResourceID(d.cfg.Organization, "schema_registry", name)
+		sr.Type = types.StringValue(kind)
+		sr.State = types.StringValue(state)
+		sr.Owner = types.StringValue(owner)
+		sr.CreatedAt = util.TimestampValue(createdAt)
+		sr.UpdatedAt = util.TimestampValue(updatedAt)
+		return util.ErrStopRows
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list schema registry", err)
+		return
 	}
 
 	if !found {
@@ -134,5 +153,17 @@ func (d *SchemaRegistryDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
+	stores, err := util.StoresUsingSchemaRegistry(ctx, conn, sr.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list stores using schema registry", err)
+		return
+	}
+	var dg diag.Diagnostics
+	sr.Stores, dg = types.ListValueFrom(ctx, types.StringType, stores)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &sr)...)
 }
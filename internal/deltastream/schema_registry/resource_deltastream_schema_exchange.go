@@ -0,0 +1,405 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ resource.Resource = &SchemaExchangeResource{}
+var _ resource.ResourceWithConfigure = &SchemaExchangeResource{}
+var _ resource.ResourceWithModifyPlan = &SchemaExchangeResource{}
+
+const schemaTypeAvro = "AVRO"
+
+// createdVersionKey is the private state key recording whether the resource registered its version of the subject,
+// only versions it registered are deleted on destroy.
+const createdVersionKey = "created_version"
+
+// schemaTypes are the formats of the schemas a subject can be registered with.
+var schemaTypes = []string{schemaTypeAvro, "PROTOBUF", "JSON"}
+
+// compatibilityLevels are the compatibility levels of Confluent schema registries.
+var compatibilityLevels = []string{"BACKWARD", "BACKWARD_TRANSITIVE", "FORWARD", "FORWARD_TRANSITIVE", "FULL", "FULL_TRANSITIVE", "NONE"}
+
+func NewSchemaExchangeResource() resource.Resource {
+	return &SchemaExchangeResource{}
+}
+
+type SchemaExchangeResource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+type SchemaExchangeResourceData struct {
+	ID             types.String `tfsdk:"id"`
+	SchemaRegistry types.String `tfsdk:"schema_registry"`
+	Subject        types.String `tfsdk:"subject"`
+	SchemaType     types.String `tfsdk:"schema_type"`
+	Schema         types.String `tfsdk:"schema"`
+	Compatibility  types.String `tfsdk:"compatibility"`
+	Username       types.String `tfsdk:"username"`
+	Password       types.String `tfsdk:"password"`
+	SchemaID       types.Int64  `tfsdk:"schema_id"`
+	Version        types.Int64  `tfsdk:"version"`
+}
+
+func (d *SchemaExchangeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Schema exchange resource. Registers the schema of a subject in a Confluent schema registry attached to DeltaStream, so that the consumers of the topics queries write to find the schema before the first record is produced. The registry is reached at the URI DeltaStream reports for it, changing the schema registers a new version of the subject and destroying the resource deletes the version it registered. A schema that was already registered under the subject is adopted and left in place on destroy.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the subject",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"schema_registry": schema.StringAttribute{
+				Description: "Name of the DeltaStream schema registry the subject is registered in",
+				Required:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subject": schema.StringAttribute{
+				Description: "Subject to register the schema under, such as orders-value",
+				Required:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema_type": schema.StringAttribute{
+				Description: "Format of the schema, one of " + strings.Join(schemaTypes, ", ") + ". Default: AVRO",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(schemaTypeAvro),
+				Validators:  []validator.String{stringvalidator.OneOf(schemaTypes...)},
+			},
+			"schema": schema.StringAttribute{
+				Description: "Schema text. Changing it registers a new version of the subject",
+				Required:    true,
+			},
+			"compatibility": schema.StringAttribute{
+				Description: "Compatibility level of the subject, one of " + strings.Join(compatibilityLevels, ", ") + ". The global level of the registry applies when not set",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(compatibilityLevels...)},
+			},
+			"username": schema.StringAttribute{
+				Description: "Username, or API key, to authenticate with the schema registry. DeltaStream does not expose the credentials of the registries attached to it",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("password")),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "Password, or API secret, to authenticate with the schema registry",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("username")),
+				},
+			},
+			"schema_id": schema.Int64Attribute{
+				Description: "Global ID the registry assigned to the schema",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Description: "Version of the subject the schema is registered as",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (d *SchemaExchangeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+func (d *SchemaExchangeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema_exchange"
+}
+
+// ModifyPlan plans a new version of the subject when its schema changes.
+func (d *SchemaExchangeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned, current SchemaExchangeResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !planned.Schema.Equal(current.Schema) || !planned.SchemaType.Equal(current.SchemaType) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("schema_id"), types.Int64Unknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), types.Int64Unknown())...)
+	}
+}
+
+// client returns a client of the registry the subject is registered in, at the URI DeltaStream reports for it.
+func (d *SchemaExchangeResource) client(ctx context.Context, exchange SchemaExchangeResourceData) (*subjectClient, error) {
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	details, err := describeSchemaRegistry(ctx, conn, exchange.SchemaRegistry.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	return newSubjectClient(details["uris"], exchange.Username.ValueString(), exchange.Password.ValueString())
+}
+
+// register sets the compatibility level of the subject and registers its schema, compatibility is set first so that
+// the schema is checked against the planned level. created is false when the schema was already registered.
+func register(ctx context.Context, client *subjectClient, exchange SchemaExchangeResourceData, setCompatibility bool) (SchemaExchangeResourceData, bool, error) {
+	subject := exchange.Subject.ValueString()
+	if setCompatibility {
+		if err := client.setCompatibility(ctx, subject, exchange.Compatibility.ValueString()); err != nil {
+			return exchange, false, fmt.Errorf("failed to set compatibility: %w", err)
+		}
+	}
+
+	registered, created, err := client.register(ctx, subject, exchange.SchemaType.ValueString(), exchange.Schema.ValueString())
+	if err != nil {
+		return exchange, false, err
+	}
+	exchange.SchemaID = types.Int64Value(registered.ID)
+	exchange.Version = types.Int64Value(registered.Version)
+	return exchange, created, nil
+}
+
+// createdVersion encodes whether the resource registered its version for the createdVersionKey private state key.
+func createdVersion(created bool) []byte {
+	if created {
+		return []byte("true")
+	}
+	return []byte("false")
+}
+
+// Create implements resource.Resource.
+func (d *SchemaExchangeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var exchange SchemaExchangeResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &exchange)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client(ctx, exchange)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect to schema registry", err)
+		return
+	}
+	exchange.ID = util.ResourceID(d.cfg.Organization, "schema_exchange", exchange.SchemaRegistry.ValueString(), exchange.Subject.ValueString())
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "schema exchange", exchange.Subject.ValueString())...)
+		return
+	}
+
+	exchange, created, err := register(ctx, client, exchange, !exchange.Compatibility.IsNull())
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to register schema", err)
+		return
+	}
+
+	tflog.Info(ctx, "Schema registered", map[string]any{
+		"subject": exchange.Subject.ValueString(),
+		"version": exchange.Version.ValueInt64(),
+		"created": created,
+	})
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, createdVersionKey, createdVersion(created))...)
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_schema_exchange", exchange.manifestName())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, exchange)...)
+}
+
+func (d *SchemaExchangeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var exchange SchemaExchangeResourceData
+	var current SchemaExchangeResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &exchange)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "schema exchange", current.Subject.ValueString())...)
+		return
+	}
+
+	client, err := d.client(ctx, exchange)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect to schema registry", err)
+		return
+	}
+
+	setCompatibility := !exchange.Compatibility.Equal(current.Compatibility)
+	if exchange.Schema.Equal(current.Schema) && exchange.SchemaType.Equal(current.SchemaType) {
+		if setCompatibility {
+			if err := client.setCompatibility(ctx, exchange.Subject.ValueString(), exchange.Compatibility.ValueString()); err != nil {
+				resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set compatibility", err)
+				return
+			}
+		}
+		exchange.SchemaID = current.SchemaID
+		exchange.Version = current.Version
+	} else {
+		var created bool
+		exchange, created, err = register(ctx, client, exchange, setCompatibility)
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to register schema", err)
+			return
+		}
+		tflog.Info(ctx, "Schema registered", map[string]any{
+			"subject": exchange.Subject.ValueString(),
+			"version": exchange.Version.ValueInt64(),
+			"created": created,
+		})
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, createdVersionKey, createdVersion(created))...)
+	}
+
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_schema_exchange", exchange.manifestName())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, exchange)...)
+}
+
+// manifestName is the name the exchange is recorded under in the manifest, the subject within its schema registry.
+func (exchange SchemaExchangeResourceData) manifestName() string {
+	return exchange.SchemaRegistry.ValueString() + "." + exchange.Subject.ValueString()
+}
+
+func (d *SchemaExchangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var exchange SchemaExchangeResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &exchange)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "schema exchange", exchange.Subject.ValueString())...)
+		return
+	}
+
+	created, dg := req.Private.GetKey(ctx, createdVersionKey)
+	resp.Diagnostics.Append(dg...)
+	if string(created) != "true" {
+		tflog.Info(ctx, "schema version was not registered by the resource, leaving it in place", map[string]any{
+			"subject": exchange.Subject.ValueString(),
+			"version": exchange.Version.ValueInt64(),
+		})
+		return
+	}
+
+	client, err := d.client(ctx, exchange)
+	if err != nil {
+		if util.IsNotFound("schema_registry", err) {
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect to schema registry", err)
+		return
+	}
+
+	if err := client.deleteVersion(ctx, exchange.Subject.ValueString(), exchange.Version.ValueInt64()); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to delete schema", err)
+		return
+	}
+	tflog.Info(ctx, "Schema deleted", map[string]any{
+		"subject": exchange.Subject.ValueString(),
+		"version": exchange.Version.ValueInt64(),
+	})
+}
+
+// Read checks that the version of the subject the resource registered still exists and still holds the schema. The
+// schema text is kept as configured while it defines the same schema as the version, registries may return it
+// normalized.
+func (d *SchemaExchangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var exchange SchemaExchangeResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &exchange)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client(ctx, exchange)
+	if err != nil {
+		if util.RemoveIfNotFound(ctx, &resp.State, "schema_registry", err) {
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect to schema registry", err)
+		return
+	}
+
+	registered, err := client.version(ctx, exchange.Subject.ValueString(), exchange.Version.ValueInt64())
+	if err != nil {
+		if isRegistryNotFound(err) {
+			tflog.Info(ctx, "schema version not found, removing from state", map[string]any{"error": err.Error()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read schema", err)
+		return
+	}
+	exchange.SchemaID = types.Int64Value(registered.ID)
+	exchange.SchemaType = types.StringValue(registered.SchemaType)
+	if !sameSchema(registered.SchemaType, registered.Schema, exchange.Schema.ValueString()) {
+		exchange.Schema = types.StringValue(registered.Schema)
+	}
+
+	// the level is only tracked when it is managed, the global level applies otherwise
+	if !exchange.Compatibility.IsNull() {
+		level, err := client.compatibility(ctx, exchange.Subject.ValueString())
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read compatibility", err)
+			return
+		}
+		exchange.Compatibility = types.StringNull()
+		if level != "" {
+			exchange.Compatibility = types.StringValue(level)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, exchange)...)
+}
@@ -9,13 +9,23 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 	"text/template"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -28,6 +38,9 @@ import (
 
 var _ resource.Resource = &SchemaRegistryResource{}
 var _ resource.ResourceWithConfigure = &SchemaRegistryResource{}
+var _ resource.ResourceWithModifyPlan = &SchemaRegistryResource{}
+var _ resource.ResourceWithImportState = &SchemaRegistryResource{}
+var _ resource.ResourceWithConfigValidators = &SchemaRegistryResource{}
 
 func NewSchemaRegistryResource() resource.Resource {
 	return &SchemaRegistryResource{}
@@ -43,13 +56,30 @@ type ConfluentProperties struct {
 	Password types.String `tfsdk:"password"`
 }
 
+func (ConfluentProperties) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":     types.StringType,
+		"username": types.StringType,
+		"password": types.StringType,
+	}
+}
+
 type ConfluentCloudProperties struct {
 	Uris   types.String `tfsdk:"uris"`
 	Key    types.String `tfsdk:"key"`
 	Secret types.String `tfsdk:"secret"`
 }
 
+func (ConfluentCloudProperties) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":   types.StringType,
+		"key":    types.StringType,
+		"secret": types.StringType,
+	}
+}
+
 type SchemaRegistryResourceData struct {
+	ID             types.String `tfsdk:"id"`
 	Name           types.String `tfsdk:"name"`
 	Type           types.String `tfsdk:"type"`
 	AccessRegion   types.String `tfsdk:"access_region"`
@@ -59,6 +89,15 @@ type SchemaRegistryResourceData struct {
 	State          types.String `tfsdk:"state"`
 	UpdatedAt      types.String `tfsdk:"updated_at"`
 	CreatedAt      types.String `tfsdk:"created_at"`
+	Uris           types.String `tfsdk:"uris"`
+	AuthMode       types.String `tfsdk:"auth_mode"`
+
+	StatementID types.String `tfsdk:"statement_id"`
+
+	Properties types.Map `tfsdk:"properties"`
+
+	Tags    types.Map `tfsdk:"tags"`
+	TagsAll types.Map `tfsdk:"tags_all"`
 }
 
 func (d *SchemaRegistryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -66,6 +105,13 @@ func (d *SchemaRegistryResource) Schema(ctx context.Context, req resource.Schema
 		MarkdownDescription: "Schema registry resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the schema registry",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
 				Description: "Name of the schema registry",
 				Required:    true,
@@ -79,8 +125,13 @@ func (d *SchemaRegistryResource) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 			"access_region": schema.StringAttribute{
-				Description: "Region the schema registry will be used in",
-				Required:    true,
+				Description: "Region the schema registry will be used in. Defaults to the default_access_region of the provider",
+				Optional:    true,
+				Computed:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"confluent": schema.SingleNestedAttribute{
 				Description: "Confluent specific configuration",
@@ -88,6 +139,9 @@ func (d *SchemaRegistryResource) Schema(ctx context.Context, req resource.Schema
 					"uris": schema.StringAttribute{
 						Description: "List of host:port URIs to connect to the schema registry",
 						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"username": schema.StringAttribute{
 						Description: "Username to use when authenticating with confluent schema registry",
@@ -108,6 +162,9 @@ func (d *SchemaRegistryResource) Schema(ctx context.Context, req resource.Schema
 					"uris": schema.StringAttribute{
 						Description: "List of host:port URIs to connect to the schema registry",
 						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"key": schema.StringAttribute{
 						Description: "Key to use when authenticating with confluent cloud schema registry",
@@ -140,6 +197,43 @@ func (d *SchemaRegistryResource) Schema(ctx context.Context, req resource.Schema
 				Description: "Creation date of the schema registry",
 				Computed:    true,
 			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the schema registry, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"uris": schema.StringAttribute{
+				Description: "URIs the schema registry is configured with, as reported by the server",
+				Computed:    true,
+			},
+			"auth_mode": schema.StringAttribute{
+				Description: "Authentication mode of the schema registry, as reported by the server",
+				Computed:    true,
+			},
+			"properties": schema.MapAttribute{
+				Description: "Additional properties appended verbatim to the WITH clause of CREATE SCHEMA_REGISTRY. These properties are not validated by the provider, except that they cannot set the properties managed by other attributes",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOf("type", "uris", "access_region", "tags")),
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the schema registry. Tags can be changed without replacing the schema registry",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  util.TagValidators,
+			},
+			"tags_all": schema.MapAttribute{
+				Description: "Tags of the schema registry merged with the default_tags of the provider",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -162,6 +256,32 @@ func (d *SchemaRegistryResource) Metadata(ctx context.Context, req resource.Meta
 	resp.TypeName = req.ProviderTypeName + "_schema_registry"
 }
 
+// ConfigValidators requires a single schema registry type and the credentials of that type to be set together, so that
+// an incomplete configuration fails at plan time rather than when creating the schema registry.
+func (d *SchemaRegistryResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("confluent"), path.MatchRoot("confluent_cloud")),
+		resourcevalidator.RequiredTogether(path.MatchRoot("confluent").AtName("username"), path.MatchRoot("confluent").AtName("password")),
+		resourcevalidator.RequiredTogether(path.MatchRoot("confluent_cloud").AtName("key"), path.MatchRoot("confluent_cloud").AtName("secret")),
+	}
+}
+
+func (d *SchemaRegistryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (d *SchemaRegistryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultOwner(ctx, "schema_registry", req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultAccessRegion(ctx, req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ValidateOwner(ctx, resp.Plan, req.State)...)
+	resp.Diagnostics.Append(d.cfg.ValidateIdentifierCase(ctx, resp.Plan, req.State, "name")...)
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultTags(ctx, &resp.Plan)...)
+}
+
 const createStatement = `CREATE SCHEMA_REGISTRY "{{.Name}}" WITH(
 	{{- if eq .Type "CONFLUENT" -}}
 		'type' = CONFLUENT, 'access_region' = "{{.AccessRegion}}", 'uris' = '{{.Confluent.Uris.ValueString}}'
@@ -173,6 +293,9 @@ const createStatement = `CREATE SCHEMA_REGISTRY "{{.Name}}" WITH(
 		'type' = CONFLUENT_CLOUD, 'access_region' = "{{.AccessRegion}}", 'uris' = '{{.ConfluentCloud.Uris.ValueString}}',
 		'confluent_cloud.key' = '{{.ConfluentCloud.Key.ValueString}}', 'confluent_cloud.secret' = '{{.ConfluentCloud.Secret.ValueString}}'
 	{{- end -}}
+	{{- range .Properties }},
+		{{ . }}
+	{{- end }}
 );`
 
 // Create implements resource.Resource.
@@ -212,18 +335,43 @@ func (d *SchemaRegistryResource) Create(ctx context.Context, req resource.Create
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "invalid schema registry", fmt.Errorf("must specify atleast one schema registry type properties"))
 	}
 
+	properties, dg := util.RenderProperties(ctx, "", sr.Properties)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(properties) > 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root("properties"), "unvalidated schema registry properties", "properties are passed to DeltaStream as is and are not validated by the provider")
+	}
+	tags, dg := util.TagsProperty(ctx, sr.TagsAll)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if tags != "" {
+		properties = append(properties, tags)
+	}
+
+	planned := sr
 	b := bytes.NewBuffer(nil)
 	template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
-		"Name":           sr.Name.ValueString(),
+		"Name":           d.cfg.ObjectName(sr.Name.ValueString()),
 		"Type":           srtype,
 		"AccessRegion":   sr.AccessRegion.ValueString(),
 		"Confluent":      confluentProperties,
 		"ConfluentCloud": conflientCloudProperties,
+		"Properties":     properties,
 	})
-	if _, err := conn.ExecContext(ctx, b.String()); err != nil {
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "schema registry", sr.Name.ValueString())...)
+		return
+	}
+	createCtx, statement := util.WithStatementRecorder(ctx)
+	if _, err := conn.ExecContext(createCtx, b.String()); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create schema registry", err)
 		return
 	}
+	sr.StatementID = statement.ID()
 
 	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
 		sr, err = d.updateComputed(ctx, conn, sr)
@@ -239,7 +387,7 @@ func (d *SchemaRegistryResource) Create(ctx context.Context, req resource.Create
 		}
 		return nil
 	}); err != nil {
-		if _, derr := conn.ExecContext(ctx, `DROP SCHEMA_REGISTRY "`+sr.Name.ValueString()+`";`); derr != nil {
+		if _, derr := conn.ExecContext(ctx, `DROP SCHEMA_REGISTRY "`+d.cfg.ObjectName(sr.Name.ValueString())+`";`); derr != nil {
 			tflog.Error(ctx, "failed to clean up schema registry", map[string]any{
 				"name":  sr.Name.ValueString(),
 				"error": derr.Error(),
@@ -249,18 +397,18 @@ func (d *SchemaRegistryResource) Create(ctx context.Context, req resource.Create
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create schema registry", err)
 		return
 	}
+	// keep the configured properties, the server may report the URIs in a normalized form
+	sr.Confluent = planned.Confluent
+	sr.ConfluentCloud = planned.ConfluentCloud
+
 	tflog.Info(ctx, "Schema registry created", map[string]any{"name": sr.Name.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_schema_registry", sr.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, sr)...)
 }
 
 func (d *SchemaRegistryResource) updateComputed(ctx context.Context, conn *sql.Conn, sr SchemaRegistryResourceData) (SchemaRegistryResourceData, error) {
-	rows, err := conn.QueryContext(ctx, `LIST SCHEMA_REGISTRIES;`)
-	if err != nil {
-		return sr, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
+	found := false
+	if err := util.QueryRows(ctx, conn, `LIST SCHEMA_REGISTRIES;`, func(rows *sql.Rows) error {
 		var discard any
 		var name string
 		var srtype string
@@ -269,18 +417,145 @@ func (d *SchemaRegistryResource) updateComputed(ctx context.Context, conn *sql.C
 		var updatedAt time.Time
 		var createdAt time.Time
 		if err := rows.Scan(&name, &srtype, &state, &discard, &owner, &createdAt, &updatedAt); err != nil {
-			return sr, err
+			return err
+		}
+		if name != d.cfg.ObjectName(sr.Name.ValueString()) {
+			return nil
+		}
+		found = true
+		sr.ID = util.ResourceID(d.cfg.Organization, "schema_registry", name)
+		sr.State = types.StringValue(state)
+		sr.Type = types.StringValue(srtype)
+		sr.Owner = types.StringValue(owner)
+		sr.CreatedAt = util.TimestampValue(createdAt)
+		sr.UpdatedAt = util.TimestampValue(updatedAt)
+		return util.ErrStopRows
+	}); err != nil {
+		return sr, err
+	}
+	if !found {
+		return SchemaRegistryResourceData{}, gods.ErrSQLError{SQLCode: gods.SqlStateInvalidSchemaRegistry}
+	}
+	return d.updateDetails(ctx, conn, sr)
+}
+
+// updateDetails reads the connection details of the schema registry. The configured URIs are kept while the server
+// reports the same endpoints, possibly in another form, and are replaced by the reported ones once they point
+// elsewhere so endpoint changes made outside of Terraform show up as drift. Credentials are never returned by the
+// server and are kept from the prior state.
+func (d *SchemaRegistryResource) updateDetails(ctx context.Context, conn *sql.Conn, sr SchemaRegistryResourceData) (SchemaRegistryResourceData, error) {
+	details, err := describeSchemaRegistry(ctx, conn, d.cfg.ObjectName(sr.Name.ValueString()))
+	if err != nil {
+		return sr, err
+	}
+
+	uris := details["uris"]
+	sr.Uris = types.StringNull()
+	if uris != "" {
+		sr.Uris = types.StringValue(uris)
+	}
+	sr.AuthMode = types.StringNull()
+	if v := details["auth_mode"]; v != "" {
+		sr.AuthMode = types.StringValue(v)
+	}
+	if uris == "" {
+		return sr, nil
+	}
+
+	switch strings.ToLower(strings.ReplaceAll(sr.Type.ValueString(), "_", "")) {
+	case "confluent":
+		confluent := ConfluentProperties{Username: types.StringNull(), Password: types.StringNull()}
+		if !sr.Confluent.IsNull() && !sr.Confluent.IsUnknown() {
+			if dg := sr.Confluent.As(ctx, &confluent, basetypes.ObjectAsOptions{}); dg.HasError() {
+				return sr, fmt.Errorf("failed to read confluent properties")
+			}
 		}
-		if name == sr.Name.ValueString() {
-			sr.State = types.StringValue(state)
-			sr.Type = types.StringValue(srtype)
-			sr.Owner = types.StringValue(owner)
-			sr.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-			sr.UpdatedAt = types.StringValue(updatedAt.Format(time.RFC3339))
-			return sr, nil
+		confluent.Uris = reconcileUris(confluent.Uris, uris)
+		obj, dg := types.ObjectValueFrom(ctx, confluent.AttributeTypes(), confluent)
+		if dg.HasError() {
+			return sr, fmt.Errorf("failed to set confluent properties")
 		}
+		sr.Confluent = obj
+	case "confluentcloud":
+		confluentCloud := ConfluentCloudProperties{Key: types.StringNull(), Secret: types.StringNull()}
+		if !sr.ConfluentCloud.IsNull() && !sr.ConfluentCloud.IsUnknown() {
+			if dg := sr.ConfluentCloud.As(ctx, &confluentCloud, basetypes.ObjectAsOptions{}); dg.HasError() {
+				return sr, fmt.Errorf("failed to read confluent cloud properties")
+			}
+		}
+		confluentCloud.Uris = reconcileUris(confluentCloud.Uris, uris)
+		obj, dg := types.ObjectValueFrom(ctx, confluentCloud.AttributeTypes(), confluentCloud)
+		if dg.HasError() {
+			return sr, fmt.Errorf("failed to set confluent cloud properties")
+		}
+		sr.ConfluentCloud = obj
+	}
+	return sr, nil
+}
+
+// reconcileUris returns the recorded URIs when they name the same endpoints as the reported ones, and the reported
+// URIs otherwise.
+func reconcileUris(recorded types.String, reported string) types.String {
+	if !recorded.IsNull() && !recorded.IsUnknown() && slices.Equal(normalizeUris(recorded.ValueString()), normalizeUris(reported)) {
+		return recorded
 	}
-	return SchemaRegistryResourceData{}, &gods.ErrSQLError{SQLCode: gods.SqlStateInvalidSchemaRegistry}
+	return types.StringValue(reported)
+}
+
+// normalizeUris splits a comma separated list of URIs, ignoring surrounding spaces, trailing slashes, the case of
+// the scheme and host, and the order of the URIs.
+func normalizeUris(uris string) []string {
+	normalized := []string{}
+	for _, uri := range strings.Split(uris, ",") {
+		uri = strings.TrimSuffix(strings.TrimSpace(uri), "/")
+		if uri == "" {
+			continue
+		}
+		if u, err := url.Parse(uri); err == nil && u.Host != "" {
+			u.Scheme = strings.ToLower(u.Scheme)
+			u.Host = strings.ToLower(u.Host)
+			uri = u.String()
+		}
+		normalized = append(normalized, uri)
+	}
+	slices.Sort(normalized)
+	return normalized
+}
+
+// describeSchemaRegistry returns the DESCRIBE SCHEMA_REGISTRY columns keyed by their snake cased names.
+func describeSchemaRegistry(ctx context.Context, conn *sql.Conn, name string) (map[string]string, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`DESCRIBE SCHEMA_REGISTRY "%s";`, name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	details := map[string]string{}
+	if !rows.Next() {
+		return details, rows.Err()
+	}
+
+	values := make([]sql.NullString, len(cols))
+	dest := make([]any, len(cols))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	for i, col := range cols {
+		if values[i].Valid {
+			details[strings.ReplaceAll(strings.ToLower(col), " ", "_")] = values[i].String
+		}
+	}
+	if details["uris"] == "" {
+		details["uris"] = details["uri"]
+	}
+	return details, nil
 }
 
 func (d *SchemaRegistryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -291,6 +566,11 @@ func (d *SchemaRegistryResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "schema registry", sr.Name.ValueString())...)
+		return
+	}
+
 	roleName := d.cfg.Role
 	if !sr.Owner.IsNull() && !sr.Owner.IsUnknown() {
 		roleName = sr.Owner.ValueString()
@@ -303,18 +583,83 @@ func (d *SchemaRegistryResource) Delete(ctx context.Context, req resource.Delete
 	}
 	defer conn.Close()
 
-	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP SCHEMA_REGISTRY "%s";`, sr.Name.ValueString())); err != nil {
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP SCHEMA_REGISTRY "%s";`, d.cfg.ObjectName(sr.Name.ValueString()))); err != nil {
 		var sqlErr gods.ErrSQLError
-		if !errors.As(err, &sqlErr) || sqlErr.SQLCode != gods.SqlStateInvalidSchemaRegistry {
+		switch {
+		case errors.As(err, &sqlErr) && sqlErr.SQLCode == gods.SqlStateInvalidSchemaRegistry:
+		case errors.As(err, &sqlErr) && sqlErr.SQLCode == gods.SqlStateDependentObjectsStillExist:
+			resp.Diagnostics.AddError("Schema registry is in use", fmt.Sprintf("schema registry %s is still used by one or more stores and cannot be dropped: %s. Remove schema_registry_name from the stores using it, or delete those stores first.", sr.Name.ValueString(), sqlErr.Message))
+			return
+		default:
 			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to drop schema registry", err)
 			return
 		}
 	}
+
+	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
+		if _, err = d.updateComputed(ctx, conn, sr); err != nil {
+			var godsErr gods.ErrSQLError
+			if errors.As(err, &godsErr) && godsErr.SQLCode == gods.SqlStateInvalidSchemaRegistry {
+				return nil
+			}
+			return retry.RetryableError(err)
+		}
+		return retry.RetryableError(fmt.Errorf("timed out waiting for schema registry to be deleted"))
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to delete schema registry", err)
+		return
+	}
 	tflog.Info(ctx, "Schema registry deleted", map[string]any{"name": sr.Name.ValueString()})
 }
 
+// Update only supports changing the tags of the schema registry.
 func (d *SchemaRegistryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("schema registry updates not supported"))
+	var plan, sr SchemaRegistryResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &sr)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Name.Equal(sr.Name) || !plan.AccessRegion.Equal(sr.AccessRegion) || !plan.Confluent.Equal(sr.Confluent) ||
+		!plan.ConfluentCloud.Equal(sr.ConfluentCloud) || !plan.Owner.Equal(sr.Owner) || !plan.Properties.Equal(sr.Properties) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("schema registry updates not supported, only the tags can be changed"))
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "schema registry", sr.Name.ValueString())...)
+		return
+	}
+
+	if !plan.TagsAll.Equal(sr.TagsAll) {
+		roleName := d.cfg.Role
+		if !sr.Owner.IsNull() && !sr.Owner.IsUnknown() {
+			roleName = sr.Owner.ValueString()
+		}
+
+		ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+			return
+		}
+		defer conn.Close()
+
+		stmt, dg := util.SetTagsStatement(ctx, "SCHEMA_REGISTRY", `"`+d.cfg.ObjectName(sr.Name.ValueString())+`"`, plan.TagsAll)
+		resp.Diagnostics.Append(dg...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set schema registry tags", err)
+			return
+		}
+	}
+	sr.Tags = plan.Tags
+	sr.TagsAll = plan.TagsAll
+
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_schema_registry", sr.Name.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, sr)...)
 }
 
 func (d *SchemaRegistryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -338,16 +683,18 @@ func (d *SchemaRegistryResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 	defer conn.Close()
 
+	recorded := sr
 	sr, err = d.updateComputed(ctx, conn, sr)
 	if err != nil {
-		var godsErr gods.ErrSQLError
-		if errors.As(err, &godsErr) && godsErr.SQLCode == gods.SqlStateInvalidSchemaRegistry {
-			resp.State.RemoveResource(ctx)
+		if util.RemoveIfNotFound(ctx, &resp.State, "schema_registry", err) {
 			return
 		}
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
 		return
 	}
+	resp.Diagnostics.Append(d.cfg.ReportDrift("schema registry", sr.Name.ValueString(), path.Root("owner"), recorded.Owner, sr.Owner)...)
+	resp.Diagnostics.Append(d.cfg.ReportDrift("schema registry", sr.Name.ValueString(), path.Root("type"), recorded.Type, sr.Type)...)
+	resp.Diagnostics.Append(d.cfg.ReadTags(ctx, conn, fmt.Sprintf(`DESCRIBE SCHEMA_REGISTRY "%s";`, d.cfg.ObjectName(sr.Name.ValueString())), &sr.Tags, &sr.TagsAll)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, sr)...)
 }
@@ -0,0 +1,70 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+func TestCreateStatementProperties(t *testing.T) {
+	ctx := context.Background()
+	properties, dg := util.RenderProperties(ctx, "", types.MapValueMust(types.StringType, map[string]attr.Value{
+		"confluent.ssl.truststore": types.StringValue("ca"),
+	}))
+	if dg.HasError() {
+		t.Fatalf("RenderProperties() diagnostics = %v", dg)
+	}
+
+	b := bytes.NewBuffer(nil)
+	if err := template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
+		"Name":         "sr",
+		"Type":         "CONFLUENT",
+		"AccessRegion": "AWS us-east-1",
+		"Confluent": ConfluentProperties{
+			Uris:     types.StringValue("https://registry:8081"),
+			Username: types.StringNull(),
+			Password: types.StringNull(),
+		},
+		"Properties": properties,
+	}); err != nil {
+		t.Fatalf("failed to render statement: %v", err)
+	}
+
+	want := `'uris' = 'https://registry:8081',
+		'confluent.ssl.truststore' = 'ca'
+);`
+	if !strings.HasSuffix(b.String(), want) {
+		t.Errorf("statement %q does not end with %q", b.String(), want)
+	}
+}
+
+func TestReconcileUris(t *testing.T) {
+	tests := []struct {
+		name     string
+		recorded types.String
+		reported string
+		want     types.String
+	}{
+		{name: "same", recorded: types.StringValue("https://registry:8081"), reported: "https://registry:8081", want: types.StringValue("https://registry:8081")},
+		{name: "normalized by the server", recorded: types.StringValue("https://Registry:8081/, https://b:8081"), reported: "https://b:8081,https://registry:8081", want: types.StringValue("https://Registry:8081/, https://b:8081")},
+		{name: "changed outside of terraform", recorded: types.StringValue("https://registry:8081"), reported: "https://other:8081", want: types.StringValue("https://other:8081")},
+		{name: "imported", recorded: types.StringNull(), reported: "https://registry:8081", want: types.StringValue("https://registry:8081")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reconcileUris(tt.recorded, tt.reported); !got.Equal(tt.want) {
+				t.Errorf("reconcileUris() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+
+// subjectClient registers and looks up the schemas of subjects through the REST API of a Confluent schema registry.
+// DeltaStream has no statements managing the subjects of the schema registries attached to it.
+type subjectClient struct {
+	baseURL  string
+	username string
+	password string
+	http     *http.Client
+}
+
+// newSubjectClient returns a client of the first of the comma separated uris DeltaStream reports for a schema
+// registry. URIs without a scheme are reached over HTTPS.
+func newSubjectClient(uris, username, password string) (*subjectClient, error) {
+	uri := strings.TrimSpace(strings.Split(uris, ",")[0])
+	if uri == "" {
+		return nil, fmt.Errorf("schema registry has no URI")
+	}
+	if !strings.Contains(uri, "://") {
+		uri = "https://" + uri
+	}
+	if _, err := url.Parse(uri); err != nil {
+		return nil, fmt.Errorf("invalid schema registry URI %q: %w", uri, err)
+	}
+	return &subjectClient{
+		baseURL:  strings.TrimSuffix(uri, "/"),
+		username: username,
+		password: password,
+		http:     &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+// registryError is an error response of the schema registry.
+type registryError struct {
+	StatusCode int    `json:"-"`
+	Code       int    `json:"error_code"`
+	Message    string `json:"message"`
+}
+
+func (e *registryError) Error() string {
+	return fmt.Sprintf("schema registry error %d (HTTP %d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// isRegistryNotFound reports whether err means the subject, version or configuration requested does not exist.
+func isRegistryNotFound(err error) bool {
+	var regErr *registryError
+	return errors.As(err, &regErr) && regErr.StatusCode == http.StatusNotFound
+}
+
+func (c *subjectClient) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", schemaRegistryContentType)
+	if in != nil {
+		req.Header.Set("Content-Type", schemaRegistryContentType)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		regErr := &registryError{StatusCode: resp.StatusCode}
+		if json.Unmarshal(data, regErr) != nil || regErr.Message == "" {
+			regErr.Message = strings.TrimSpace(string(data))
+		}
+		return regErr
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// registeredSchema is a version of the schema of a subject.
+type registeredSchema struct {
+	Subject    string `json:"subject"`
+	ID         int64  `json:"id"`
+	Version    int64  `json:"version"`
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+// schemaRequest is the body of schema registrations and lookups. The registry omits the type of Avro schemas.
+type schemaRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+func newSchemaRequest(schemaType, schema string) schemaRequest {
+	req := schemaRequest{Schema: schema}
+	if schemaType != schemaTypeAvro {
+		req.SchemaType = schemaType
+	}
+	return req
+}
+
+func subjectPath(subject string) string {
+	return "/subjects/" + url.PathEscape(subject)
+}
+
+// register registers schema under subject and returns its version. When the schema is already registered under
+// subject the existing version is returned and created is false.
+func (c *subjectClient) register(ctx context.Context, subject, schemaType, schema string) (registered registeredSchema, created bool, err error) {
+	req := newSchemaRequest(schemaType, schema)
+	registered, err = c.lookup(ctx, subject, req)
+	if err == nil || !isRegistryNotFound(err) {
+		return registered, false, err
+	}
+
+	if err := c.do(ctx, http.MethodPost, subjectPath(subject)+"/versions", req, nil); err != nil {
+		return registeredSchema{}, false, err
+	}
+	registered, err = c.lookup(ctx, subject, req)
+	return registered, err == nil, err
+}
+
+// lookup returns the version schema is registered as under subject.
+func (c *subjectClient) lookup(ctx context.Context, subject string, req schemaRequest) (registeredSchema, error) {
+	var registered registeredSchema
+	if err := c.do(ctx, http.MethodPost, subjectPath(subject), req, &registered); err != nil {
+		return registeredSchema{}, err
+	}
+	return registered, nil
+}
+
+// sameSchema reports whether two schema texts define the same schema. Avro and JSON schemas are compared as JSON
+// documents, ignoring formatting and the order of object keys, Protobuf schemas ignoring surrounding whitespace.
+func sameSchema(schemaType, a, b string) bool {
+	if schemaType == "PROTOBUF" {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	var docA, docB any
+	if json.Unmarshal([]byte(a), &docA) != nil || json.Unmarshal([]byte(b), &docB) != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return reflect.DeepEqual(docA, docB)
+}
+
+// version returns a version of the schema of subject.
+func (c *subjectClient) version(ctx context.Context, subject string, version int64) (registeredSchema, error) {
+	var registered registeredSchema
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s/versions/%d", subjectPath(subject), version), nil, &registered)
+	if registered.SchemaType == "" {
+		registered.SchemaType = schemaTypeAvro
+	}
+	return registered, err
+}
+
+// deleteVersion soft deletes a version of the schema of subject. Versions that no longer exist are ignored.
+func (c *subjectClient) deleteVersion(ctx context.Context, subject string, version int64) error {
+	err := c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/versions/%d", subjectPath(subject), version), nil, nil)
+	if isRegistryNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// compatibility returns the compatibility level set on subject, or an empty string when the subject uses the
+// global level.
+func (c *subjectClient) compatibility(ctx context.Context, subject string) (string, error) {
+	var config struct {
+		CompatibilityLevel string `json:"compatibilityLevel"`
+	}
+	err := c.do(ctx, http.MethodGet, "/config/"+url.PathEscape(subject), nil, &config)
+	if isRegistryNotFound(err) {
+		return "", nil
+	}
+	return config.CompatibilityLevel, err
+}
+
+// setCompatibility sets the compatibility level of subject, an empty level reverts the subject to the global level.
+func (c *subjectClient) setCompatibility(ctx context.Context, subject, level string) error {
+	if level == "" {
+		err := c.do(ctx, http.MethodDelete, "/config/"+url.PathEscape(subject), nil, nil)
+		if isRegistryNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return c.do(ctx, http.MethodPut, "/config/"+url.PathEscape(subject), map[string]string{"compatibility": level}, nil)
+}
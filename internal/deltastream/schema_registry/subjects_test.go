@@ -0,0 +1,133 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubjectClient(t *testing.T) {
+	ctx := context.Background()
+	var compatibility string
+	var registered schemaRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "key" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", schemaRegistryContentType)
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "PUT /config/orders%2Fvalue":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			compatibility = body["compatibility"]
+			_ = json.NewEncoder(w).Encode(body)
+		case "GET /config/orders%2Fvalue":
+			if compatibility == "" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error_code":40408,"message":"Subject does not have subject-level compatibility configured"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"compatibilityLevel": compatibility})
+		case "POST /subjects/orders%2Fvalue/versions":
+			_ = json.NewDecoder(r.Body).Decode(&registered)
+			_, _ = w.Write([]byte(`{"id":7}`))
+		case "POST /subjects/orders%2Fvalue":
+			if registered.Schema == "" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error_code":40403,"message":"Schema not found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(registeredSchema{Subject: "orders/value", ID: 7, Version: 3, Schema: registered.Schema, SchemaType: registered.SchemaType})
+		case "GET /subjects/orders%2Fvalue/versions/3":
+			_ = json.NewEncoder(w).Encode(registeredSchema{Subject: "orders/value", ID: 7, Version: 3, Schema: registered.Schema})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":40402,"message":"Version not found."}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := newSubjectClient(server.URL+"/, https://standby:8081", "key", "secret")
+	if err != nil {
+		t.Fatalf("newSubjectClient() error = %v", err)
+	}
+
+	if level, err := client.compatibility(ctx, "orders/value"); err != nil || level != "" {
+		t.Errorf("compatibility() = %q, %v, want the global level", level, err)
+	}
+	if err := client.setCompatibility(ctx, "orders/value", "BACKWARD"); err != nil {
+		t.Fatalf("setCompatibility() error = %v", err)
+	}
+	if level, err := client.compatibility(ctx, "orders/value"); err != nil || level != "BACKWARD" {
+		t.Errorf("compatibility() = %q, %v, want BACKWARD", level, err)
+	}
+
+	got, created, err := client.register(ctx, "orders/value", schemaTypeAvro, `{"type":"string"}`)
+	if err != nil {
+		t.Fatalf("register() error = %v", err)
+	}
+	if got.ID != 7 || got.Version != 3 || !created || registered.SchemaType != "" {
+		t.Errorf("register() = %+v, %v, sent %+v", got, created, registered)
+	}
+	if got, created, err := client.register(ctx, "orders/value", schemaTypeAvro, `{"type":"string"}`); err != nil || created || got.Version != 3 {
+		t.Errorf("register() of a registered schema = %+v, %v, %v, want the existing version", got, created, err)
+	}
+
+	version, err := client.version(ctx, "orders/value", 3)
+	if err != nil || version.SchemaType != schemaTypeAvro {
+		t.Errorf("version() = %+v, %v, want an Avro schema", version, err)
+	}
+	if _, err := client.version(ctx, "orders/value", 4); !isRegistryNotFound(err) {
+		t.Errorf("version() error = %v, want not found", err)
+	}
+	if err := client.deleteVersion(ctx, "orders/value", 4); err != nil {
+		t.Errorf("deleteVersion() of a missing version error = %v", err)
+	}
+
+	client.password = "wrong"
+	if _, err := client.version(ctx, "orders/value", 3); err == nil || isRegistryNotFound(err) {
+		t.Errorf("version() error = %v, want unauthorized", err)
+	}
+}
+
+func TestSameSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		a, b       string
+		want       bool
+	}{
+		{name: "formatting", schemaType: schemaTypeAvro, a: `{"type": "record", "name": "o", "fields": []}`, b: `{"fields":[],"name":"o","type":"record"}`, want: true},
+		{name: "changed", schemaType: schemaTypeAvro, a: `{"type":"string"}`, b: `{"type":"long"}`, want: false},
+		{name: "protobuf", schemaType: "PROTOBUF", a: "syntax = \"proto3\";\n", b: `syntax = "proto3";`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameSchema(tt.schemaType, tt.a, tt.b); got != tt.want {
+				t.Errorf("sameSchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSubjectClient(t *testing.T) {
+	for uris, want := range map[string]string{
+		"psrc-123.us-east-2.aws.confluent.cloud":        "https://psrc-123.us-east-2.aws.confluent.cloud",
+		"http://registry:8081/, http://standby:8081":    "http://registry:8081",
+		" https://registry:8081,https://standby:8081  ": "https://registry:8081",
+	} {
+		client, err := newSubjectClient(uris, "", "")
+		if err != nil || client.baseURL != want {
+			t.Errorf("newSubjectClient(%q) = %v, %v, want %s", uris, client, err, want)
+		}
+	}
+	if _, err := newSubjectClient("", "", ""); err == nil {
+		t.Error("expected an error for a registry without URI")
+	}
+}
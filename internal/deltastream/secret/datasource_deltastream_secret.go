@@ -5,6 +5,7 @@ package secret
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -41,6 +42,7 @@ func (d *SecretDataSource) Configure(ctx context.Context, req datasource.Configu
 }
 
 type SecretDatasourceData struct {
+	ID           types.String `tfsdk:"id"`
 	Name         types.String `tfsdk:"name"`
 	Type         types.String `tfsdk:"type"`
 	Description  types.String `tfsdk:"description"`
@@ -60,6 +62,10 @@ func getSecretSchema() schema.Schema {
 		MarkdownDescription: "Secret resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Secret",
+				Computed:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Name of the Secret",
 				Required:    true,
@@ -116,15 +122,8 @@ func (d *SecretDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, `LIST SECRETS;`)
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list secrets", err)
-		return
-	}
-	defer rows.Close()
-
 	found := false
-	for rows.Next() {
+	if err := util.QueryRows(ctx, conn, `LIST SECRETS;`, func(rows *sql.Rows) error {
 		var name string
 		var stype string
 		var description string
@@ -134,20 +133,24 @@ func (d *SecretDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		var createdAt time.Time
 		var updatedAt time.Time
 		if err := rows.Scan(&name, &stype, &description, &region, &status, &owner, &createdAt, &updatedAt); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read secret", err)
-			return
+			return err
 		}
-		if name == secret.Name.ValueString() {
-			found = true
-			secret.Type = types.StringValue(stype)
-			secret.Description = types.StringValue(description)
-			secret.AccessRegion = types.StringValue(region)
-			secret.Status = types.StringValue(status)
-			secret.Owner = types.StringValue(owner)
-			secret.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-			secret.UpdatedAt = types.StringValue(updatedAt.Format(time.RFC3339))
-			break
+		if name != secret.Name.ValueString() {
+			return nil
 		}
+		found = true
+		secret.ID = util.ResourceID(d.cfg.Organization, "secret", name)
+		secret.Type = types.StringValue(stype)
+		secret.Description = types.StringValue(description)
+		secret.AccessRegion = types.StringValue(region)
+		secret.Status = types.StringValue(status)
+		secret.Owner = types.StringValue(owner)
+		secret.CreatedAt = util.TimestampValue(createdAt)
+		secret.UpdatedAt = util.TimestampValue(updatedAt)
+		return util.ErrStopRows
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list secrets", err)
+		return
 	}
 
 	if !found {
@@ -5,6 +5,7 @@ package secret
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -55,6 +56,7 @@ func (d *SecretsDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 			},
 		},
 	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["items"].(schema.ListNestedAttribute), "name")
 }
 
 func (d *SecretsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -63,6 +65,8 @@ func (d *SecretsDataSource) Metadata(ctx context.Context, req datasource.Metadat
 
 type SecretsDatasourceData struct {
 	Items types.List `tfsdk:"items"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
 }
 
 func (d *SecretsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -80,15 +84,8 @@ func (d *SecretsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, `LIST SECRETS;`)
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list secrets", err)
-		return
-	}
-	defer rows.Close()
-
 	items := []SecretDatasourceData{}
-	for rows.Next() {
+	if err := util.QueryRows(ctx, conn, `LIST SECRETS;`, func(rows *sql.Rows) error {
 		var name string
 		var stype string
 		var description string
@@ -98,24 +95,30 @@ func (d *SecretsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		var createdAt time.Time
 		var updatedAt time.Time
 		if err := rows.Scan(&name, &stype, &description, &region, &status, &owner, &createdAt, &updatedAt); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read secret", err)
-			return
+			return err
 		}
 		items = append(items, SecretDatasourceData{
+			ID:           util.ResourceID(d.cfg.Organization, "secret", name),
 			Name:         types.StringValue(name),
 			Type:         types.StringValue(stype),
 			Description:  types.StringValue(description),
 			AccessRegion: types.StringValue(region),
 			Owner:        types.StringValue(owner),
 			Status:       types.StringValue(status),
-			CreatedAt:    types.StringValue(createdAt.Format(time.RFC3339)),
-			UpdatedAt:    types.StringValue(updatedAt.Format(time.RFC3339)),
+			CreatedAt:    util.TimestampValue(createdAt),
+			UpdatedAt:    util.TimestampValue(updatedAt),
 		})
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list secrets", err)
+		return
 	}
 
 	var dg diag.Diagnostics
 	secrets.Items, dg = types.ListValueFrom(ctx, secrets.Items.ElementType(ctx), items)
 	resp.Diagnostics.Append(dg...)
+	secrets.ItemsByName, dg = util.ItemsByName(ctx, secrets.Items, "name")
+	resp.Diagnostics.Append(dg...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &secrets)...)
 }
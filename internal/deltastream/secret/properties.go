@@ -0,0 +1,97 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// managedProperties are the secret properties set from attributes other than custom_properties.
+var managedProperties = map[string]bool{
+	"type":          true,
+	"description":   true,
+	"secret_string": true,
+	"access_region": true,
+	"tags":          true,
+}
+
+// describeSecretProperties returns the custom properties DESCRIBE SECRET reports for a secret. ok is false when the
+// server does not report the properties of secrets.
+func describeSecretProperties(ctx context.Context, conn *sql.Conn, name string) (props map[string]string, ok bool, err error) {
+	describe, err := util.Describe(ctx, conn, fmt.Sprintf(`DESCRIBE SECRET "%s";`, name))
+	if err != nil {
+		return nil, false, err
+	}
+	column, ok := describe.Column("properties")
+	if !ok {
+		return nil, false, nil
+	}
+
+	props = map[string]string{}
+	if !column.Valid || column.String == "" {
+		return props, true, nil
+	}
+	raw := map[string]any{}
+	if err := json.Unmarshal([]byte(column.String), &raw); err != nil {
+		return nil, false, fmt.Errorf("failed to decode properties of secret %s: %w", name, err)
+	}
+	for k, v := range raw {
+		if managedProperties[k] {
+			continue
+		}
+		if s, isString := v.(string); isString {
+			props[k] = s
+		} else {
+			props[k] = fmt.Sprint(v)
+		}
+	}
+	return props, true, nil
+}
+
+// masked reports whether the server hid the value of a sensitive property.
+func masked(v string) bool {
+	return v != "" && strings.Trim(v, "*") == ""
+}
+
+// reconcileCustomProperties merges the properties reported by the server into the recorded custom properties.
+// Changed and added properties show up as drift, masked values keep the recorded value since the actual one cannot be
+// compared. Masked properties that were not recorded are left out, there is no value to compare them with and
+// recording the mask would show as drift on every plan. Secrets without custom properties on either side keep a null
+// map.
+func reconcileCustomProperties(ctx context.Context, recorded types.Map, reported map[string]string) (types.Map, diag.Diagnostics) {
+	current := map[string]string{}
+	if !recorded.IsNull() && !recorded.IsUnknown() {
+		if dg := recorded.ElementsAs(ctx, &current, false); dg.HasError() {
+			return recorded, dg
+		}
+	}
+	if len(reported) == 0 && recorded.IsNull() {
+		return recorded, nil
+	}
+
+	props := make(map[string]string, len(reported))
+	for k, v := range reported {
+		if masked(v) {
+			prior, ok := current[k]
+			if !ok {
+				continue
+			}
+			v = prior
+		}
+		props[k] = v
+	}
+	if len(props) == 0 && recorded.IsNull() {
+		return recorded, nil
+	}
+	return types.MapValueFrom(ctx, types.StringType, props)
+}
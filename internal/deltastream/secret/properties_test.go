@@ -0,0 +1,76 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestDescribeSecretProperties(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE SECRET "api";$`,
+		Columns:   []mockserver.Column{{Name: "Name", Type: "VARCHAR"}, {Name: "Properties", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("api", `{"type":"generic_string","access_region":"AWS us-east-1","endpoint":"https://api","port":443}`)},
+	}, {
+		Statement: `^DESCRIBE SECRET "legacy";$`,
+		Columns:   []mockserver.Column{{Name: "Name", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("legacy")},
+	}})
+
+	props, ok, err := describeSecretProperties(ctx, conn, "api")
+	if err != nil || !ok {
+		t.Fatalf("describeSecretProperties(api) = %v, %v", ok, err)
+	}
+	if want := map[string]string{"endpoint": "https://api", "port": "443"}; !reflect.DeepEqual(props, want) {
+		t.Errorf("describeSecretProperties(api) = %v, want %v", props, want)
+	}
+
+	if _, ok, err := describeSecretProperties(ctx, conn, "legacy"); err != nil || ok {
+		t.Errorf("describeSecretProperties(legacy) = %v, %v, want not reported", ok, err)
+	}
+}
+
+func TestReconcileCustomProperties(t *testing.T) {
+	ctx := context.Background()
+	mapOf := func(m map[string]string) types.Map {
+		v, dg := types.MapValueFrom(ctx, types.StringType, m)
+		if dg.HasError() {
+			t.Fatalf("failed to build map: %v", dg)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name     string
+		recorded types.Map
+		reported map[string]string
+		want     types.Map
+	}{
+		{name: "unchanged", recorded: mapOf(map[string]string{"endpoint": "a"}), reported: map[string]string{"endpoint": "a"}, want: mapOf(map[string]string{"endpoint": "a"})},
+		{name: "changed outside of terraform", recorded: mapOf(map[string]string{"endpoint": "a"}), reported: map[string]string{"endpoint": "b", "extra": "c"}, want: mapOf(map[string]string{"endpoint": "b", "extra": "c"})},
+		{name: "masked value kept", recorded: mapOf(map[string]string{"password": "hunter2"}), reported: map[string]string{"password": "****"}, want: mapOf(map[string]string{"password": "hunter2"})},
+		{name: "masked value not recorded", recorded: mapOf(map[string]string{"endpoint": "a"}), reported: map[string]string{"endpoint": "a", "token": "****"}, want: mapOf(map[string]string{"endpoint": "a"})},
+		{name: "only masked values", recorded: types.MapNull(types.StringType), reported: map[string]string{"token": "****"}, want: types.MapNull(types.StringType)},
+		{name: "none on either side", recorded: types.MapNull(types.StringType), reported: map[string]string{}, want: types.MapNull(types.StringType)},
+		{name: "added outside of terraform", recorded: types.MapNull(types.StringType), reported: map[string]string{"extra": "c"}, want: mapOf(map[string]string{"extra": "c"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dg := reconcileCustomProperties(ctx, tt.recorded, tt.reported)
+			if dg.HasError() {
+				t.Fatalf("reconcileCustomProperties() errors = %v", dg)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("reconcileCustomProperties() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
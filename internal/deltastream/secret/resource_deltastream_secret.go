@@ -13,8 +13,12 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,6 +31,7 @@ import (
 
 var _ resource.Resource = &SecretResource{}
 var _ resource.ResourceWithConfigure = &SecretResource{}
+var _ resource.ResourceWithModifyPlan = &SecretResource{}
 
 func NewSecretResource() resource.Resource {
 	return &SecretResource{}
@@ -37,6 +42,7 @@ type SecretResource struct {
 }
 
 type SecretResourceData struct {
+	ID               types.String `tfsdk:"id"`
 	Name             types.String `tfsdk:"name"`
 	Type             types.String `tfsdk:"type"`
 	Description      types.String `tfsdk:"description"`
@@ -47,6 +53,11 @@ type SecretResourceData struct {
 	Status           types.String `tfsdk:"status"`
 	CreatedAt        types.String `tfsdk:"created_at"`
 	UpdatedAt        types.String `tfsdk:"updated_at"`
+
+	StatementID types.String `tfsdk:"statement_id"`
+
+	Tags    types.Map `tfsdk:"tags"`
+	TagsAll types.Map `tfsdk:"tags_all"`
 }
 
 func (d *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -54,6 +65,13 @@ func (d *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		MarkdownDescription: "Secret resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Secret",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
 				Description: "Name of the Secret",
 				Required:    true,
@@ -71,6 +89,7 @@ func (d *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"access_region": schema.StringAttribute{
 				Description: "Region the secret will be used in",
 				Required:    true,
+				Validators:  util.IdentifierValidators,
 			},
 			"owner": schema.StringAttribute{
 				Description: "Owning role of the Secret",
@@ -83,9 +102,10 @@ func (d *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:    true,
 			},
 			"custom_properties": schema.MapAttribute{
-				Description: "Custom properties of the Secret",
+				Description: "Custom properties of the Secret. Refreshed from the properties the server reports, so that changes made outside of Terraform show up as drift. Values the server masks keep their configured value, masked properties that are not configured are ignored",
 				ElementType: types.StringType,
 				Optional:    true,
+				Sensitive:   true,
 			},
 			"status": schema.StringAttribute{
 				Description: "Status of the Secret",
@@ -99,6 +119,24 @@ func (d *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Description: "Creation date of the Secret",
 				Computed:    true,
 			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the Secret. Tags can be changed without replacing the Secret",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  util.TagValidators,
+			},
+			"tags_all": schema.MapAttribute{
+				Description: "Tags of the Secret merged with the default_tags of the provider",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the Secret, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -121,11 +159,23 @@ func (d *SecretResource) Metadata(ctx context.Context, req resource.MetadataRequ
 	resp.TypeName = req.ProviderTypeName + "_secret"
 }
 
+func (d *SecretResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultOwner(ctx, "secret", req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ValidateOwner(ctx, resp.Plan, req.State)...)
+	resp.Diagnostics.Append(d.cfg.ValidateIdentifierCase(ctx, resp.Plan, req.State, "name")...)
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultTags(ctx, &resp.Plan)...)
+}
+
 const createStatement = `CREATE SECRET "{{.Name}}" WITH( 
 	'type' = {{.Type}}, 
 	{{ if .Description }}'description' = '{{.Description}}',{{ end }}
 	{{ if .SecretString }}'secret_string' = '{{.SecretString}}',{{ end }}
 	{{ range $k, $v := .CustomProperties }}'{{$k}}' = '{{$v}}',{{ end }}
+	{{ if .Tags }}{{.Tags}},{{ end }}
 	'access_region' = "{{.AccessRegion}}"
 );`
 
@@ -159,19 +209,32 @@ func (d *SecretResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
+	tags, dg := util.TagsProperty(ctx, secret.TagsAll)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	b := bytes.NewBuffer(nil)
 	template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
-		"Name":             secret.Name.ValueString(),
+		"Name":             d.cfg.ObjectName(secret.Name.ValueString()),
 		"Type":             secret.Type.ValueString(),
 		"AccessRegion":     secret.AccessRegion.ValueString(),
 		"Description":      secret.Description.ValueString(),
 		"SecretString":     secret.StringValue.ValueString(),
 		"CustomProperties": customProps,
+		"Tags":             tags,
 	})
-	if _, err := conn.ExecContext(ctx, b.String()); err != nil {
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "secret", secret.Name.ValueString())...)
+		return
+	}
+	createCtx, statement := util.WithStatementRecorder(ctx)
+	if _, err := conn.ExecContext(createCtx, b.String()); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create secret", err)
 		return
 	}
+	secret.StatementID = statement.ID()
 
 	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
 		secret, err = d.updateComputed(ctx, conn, secret)
@@ -187,7 +250,7 @@ func (d *SecretResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 		return nil
 	}); err != nil {
-		if _, derr := conn.ExecContext(ctx, `DROP SECRET "`+secret.Name.ValueString()+`";`); derr != nil {
+		if _, derr := conn.ExecContext(ctx, `DROP SECRET "`+d.cfg.ObjectName(secret.Name.ValueString())+`";`); derr != nil {
 			tflog.Error(ctx, "failed to clean up secret", map[string]any{
 				"name":  secret.Name.ValueString(),
 				"error": derr.Error(),
@@ -198,17 +261,13 @@ func (d *SecretResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	tflog.Info(ctx, "Secret created", map[string]any{"name": secret.Name.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_secret", secret.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, secret)...)
 }
 
 func (d *SecretResource) updateComputed(ctx context.Context, conn *sql.Conn, db SecretResourceData) (SecretResourceData, error) {
-	rows, err := conn.QueryContext(ctx, `LIST SECRETS;`)
-	if err != nil {
-		return db, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
+	found := false
+	if err := util.QueryRows(ctx, conn, `LIST SECRETS;`, func(rows *sql.Rows) error {
 		var discard any
 		var name string
 		var status string
@@ -216,17 +275,25 @@ func (d *SecretResource) updateComputed(ctx context.Context, conn *sql.Conn, db
 		var updatedAt time.Time
 		var createdAt time.Time
 		if err := rows.Scan(&name, &discard, &discard, &discard, &status, &owner, &createdAt, &updatedAt); err != nil {
-			return db, err
+			return err
 		}
-		if name == db.Name.ValueString() {
-			db.Status = types.StringValue(status)
-			db.Owner = types.StringValue(owner)
-			db.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-			db.UpdatedAt = types.StringValue(updatedAt.Format(time.RFC3339))
-			return db, nil
+		if name != d.cfg.ObjectName(db.Name.ValueString()) {
+			return nil
 		}
+		found = true
+		db.ID = util.ResourceID(d.cfg.Organization, "secret", name)
+		db.Status = types.StringValue(status)
+		db.Owner = types.StringValue(owner)
+		db.CreatedAt = util.TimestampValue(createdAt)
+		db.UpdatedAt = util.TimestampValue(updatedAt)
+		return util.ErrStopRows
+	}); err != nil {
+		return db, err
+	}
+	if !found {
+		return SecretResourceData{}, gods.ErrSQLError{SQLCode: gods.SqlStateInvalidSecret}
 	}
-	return SecretResourceData{}, &gods.ErrSQLError{SQLCode: gods.SqlStateInvalidSecret}
+	return db, nil
 }
 
 func (d *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -237,6 +304,11 @@ func (d *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "secret", secret.Name.ValueString())...)
+		return
+	}
+
 	roleName := d.cfg.Role
 	if !secret.Owner.IsNull() && !secret.Owner.IsUnknown() {
 		roleName = secret.Owner.ValueString()
@@ -249,7 +321,7 @@ func (d *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 	defer conn.Close()
 
-	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP SECRET "%s";`, secret.Name.ValueString())); err != nil {
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP SECRET "%s";`, d.cfg.ObjectName(secret.Name.ValueString()))); err != nil {
 		var sqlErr gods.ErrSQLError
 		if !errors.As(err, &sqlErr) || sqlErr.SQLCode != gods.SqlStateInvalidSecret {
 			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to drop secret", err)
@@ -259,8 +331,65 @@ func (d *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	tflog.Info(ctx, "Secret deleted", map[string]any{"name": secret.Name.ValueString()})
 }
 
+// Update only changes the tags of the secret, any other change is not supported.
 func (d *SecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("secret updates not supported"))
+	var currentSecret SecretResourceData
+	var newSecret SecretResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &newSecret)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentSecret)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "secret", currentSecret.Name.ValueString())...)
+		return
+	}
+
+	changed := func(planned, current attr.Value) bool { return !planned.IsUnknown() && !planned.Equal(current) }
+	if changed(newSecret.Type, currentSecret.Type) || changed(newSecret.Description, currentSecret.Description) ||
+		changed(newSecret.AccessRegion, currentSecret.AccessRegion) || changed(newSecret.Owner, currentSecret.Owner) ||
+		changed(newSecret.StringValue, currentSecret.StringValue) || changed(newSecret.CustomProperties, currentSecret.CustomProperties) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("secret updates not supported"))
+		return
+	}
+
+	roleName := d.cfg.Role
+	if !currentSecret.Owner.IsNull() && !currentSecret.Owner.IsUnknown() {
+		roleName = currentSecret.Owner.ValueString()
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if !newSecret.TagsAll.Equal(currentSecret.TagsAll) {
+		stmt, dg := util.SetTagsStatement(ctx, "SECRET", `"`+d.cfg.ObjectName(currentSecret.Name.ValueString())+`"`, newSecret.TagsAll)
+		resp.Diagnostics.Append(dg...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set secret tags", err)
+			return
+		}
+	}
+
+	currentSecret.Tags = newSecret.Tags
+	currentSecret.TagsAll = newSecret.TagsAll
+	currentSecret, err = d.updateComputed(ctx, conn, currentSecret)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
+		return
+	}
+
+	tflog.Info(ctx, "Secret updated", map[string]any{"name": currentSecret.Name.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_secret", currentSecret.Name.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, currentSecret)...)
 }
 
 func (d *SecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -281,14 +410,25 @@ func (d *SecretResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	Secret, err = d.updateComputed(ctx, conn, Secret)
 	if err != nil {
-		var godsErr gods.ErrSQLError
-		if errors.As(err, &godsErr) && godsErr.SQLCode == gods.SqlStateInvalidSecret {
-			resp.State.RemoveResource(ctx)
+		if util.RemoveIfNotFound(ctx, &resp.State, "secret", err) {
 			return
 		}
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
 		return
 	}
 
+	// the custom properties are kept as recorded when the server does not report them
+	if props, ok, err := describeSecretProperties(ctx, conn, d.cfg.ObjectName(Secret.Name.ValueString())); err != nil {
+		tflog.Warn(ctx, "unable to read secret properties", map[string]any{
+			"name":  Secret.Name.ValueString(),
+			"error": err.Error(),
+		})
+	} else if ok {
+		var dg diag.Diagnostics
+		Secret.CustomProperties, dg = reconcileCustomProperties(ctx, Secret.CustomProperties, props)
+		resp.Diagnostics.Append(dg...)
+	}
+	resp.Diagnostics.Append(d.cfg.ReadTags(ctx, conn, fmt.Sprintf(`DESCRIBE SECRET "%s";`, d.cfg.ObjectName(Secret.Name.ValueString())), &Secret.Tags, &Secret.TagsAll)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, Secret)...)
 }
@@ -0,0 +1,263 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ resource.Resource = &SecretVersionResource{}
+var _ resource.ResourceWithConfigure = &SecretVersionResource{}
+var _ resource.ResourceWithModifyPlan = &SecretVersionResource{}
+
+func NewSecretVersionResource() resource.Resource {
+	return &SecretVersionResource{}
+}
+
+// SecretVersionResource sets the value of an existing secret. Each new value comes with a higher version, so
+// rotations are explicit in the configuration and recorded in state.
+type SecretVersionResource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+type SecretVersionResourceData struct {
+	ID          types.String `tfsdk:"id"`
+	Secret      types.String `tfsdk:"secret"`
+	Version     types.Int64  `tfsdk:"version"`
+	StringValue types.String `tfsdk:"string_value"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+}
+
+func (d *SecretVersionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Secret version resource. Sets the value of an existing secret, each new value must come with a higher version. Destroying the resource leaves the secret and its current value in place.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Secret version",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret": schema.StringAttribute{
+				Description: "Name of the Secret",
+				Required:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Description: "Version of the secret value. Must be increased whenever string_value changes",
+				Required:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"string_value": schema.StringAttribute{
+				Description: "Secret value",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "Last update date of the Secret",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SecretVersionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+func (d *SecretVersionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_version"
+}
+
+func (d *SecretVersionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var current SecretVersionResourceData
+	var planned SecretVersionResourceData
+	resp.Diagnostics.Append(req.State.Get(ctx, &current)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planned.Version.IsUnknown() || planned.StringValue.IsUnknown() || !planned.Secret.Equal(current.Secret) {
+		return
+	}
+
+	switch {
+	case planned.Version.ValueInt64() < current.Version.ValueInt64():
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "Secret version cannot decrease",
+			fmt.Sprintf("version is %d in state, the new version must be higher", current.Version.ValueInt64()))
+	case planned.Version.ValueInt64() == current.Version.ValueInt64() && !planned.StringValue.Equal(current.StringValue):
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "Secret version not increased",
+			fmt.Sprintf("string_value changed but version is still %d, increase version to rotate the secret", current.Version.ValueInt64()))
+	}
+}
+
+func (d *SecretVersionResource) setValue(ctx context.Context, conn *sql.Conn, version SecretVersionResourceData) (SecretVersionResourceData, error) {
+	stmt := fmt.Sprintf(`ALTER SECRET "%s" WITH ('secret_string' = '%s');`, d.cfg.ObjectName(version.Secret.ValueString()), strings.ReplaceAll(version.StringValue.ValueString(), "'", "''"))
+	if _, err := conn.ExecContext(ctx, stmt); err != nil {
+		return version, err
+	}
+	return d.updateComputed(ctx, conn, version)
+}
+
+func (d *SecretVersionResource) updateComputed(ctx context.Context, conn *sql.Conn, version SecretVersionResourceData) (SecretVersionResourceData, error) {
+	secret, err := (&SecretResource{cfg: d.cfg}).updateComputed(ctx, conn, SecretResourceData{Name: version.Secret})
+	if err != nil {
+		return version, err
+	}
+	version.ID = util.ResourceID(d.cfg.Organization, "secret_version", version.Secret.ValueString())
+	version.UpdatedAt = secret.UpdatedAt
+	return version, nil
+}
+
+// Create implements resource.Resource.
+func (d *SecretVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var version SecretVersionResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &version)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "secret version", version.Secret.ValueString())...)
+		return
+	}
+
+	version, err = d.setValue(ctx, conn, version)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set secret value", err)
+		return
+	}
+
+	tflog.Info(ctx, "Secret version set", map[string]any{"name": version.Secret.ValueString(), "version": version.Version.ValueInt64()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_secret_version", version.Secret.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, version)...)
+}
+
+func (d *SecretVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var current SecretVersionResourceData
+	var version SecretVersionResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &current)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &version)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "secret version", current.Secret.ValueString())...)
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	// only a new version rotates the secret, the value is unchanged otherwise
+	rotated := version.Version.ValueInt64() != current.Version.ValueInt64()
+	if rotated {
+		version, err = d.setValue(ctx, conn, version)
+	} else {
+		version, err = d.updateComputed(ctx, conn, version)
+	}
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set secret value", err)
+		return
+	}
+	if rotated {
+		resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_secret_version", version.Secret.ValueString())...)
+	}
+
+	tflog.Info(ctx, "Secret version set", map[string]any{"name": version.Secret.ValueString(), "version": version.Version.ValueInt64()})
+	resp.Diagnostics.Append(resp.State.Set(ctx, version)...)
+}
+
+func (d *SecretVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var version SecretVersionResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &version)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	version, err = d.updateComputed(ctx, conn, version)
+	if err != nil {
+		if util.RemoveIfNotFound(ctx, &resp.State, "secret", err) {
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, version)...)
+}
+
+// Delete only forgets the version, the secret keeps its current value.
+func (d *SecretVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var version SecretVersionResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &version)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "secret version", version.Secret.ValueString())...)
+		return
+	}
+
+	tflog.Info(ctx, "Secret version removed from state", map[string]any{"name": version.Secret.ValueString(), "version": version.Version.ValueInt64()})
+}
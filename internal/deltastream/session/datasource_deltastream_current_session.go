@@ -0,0 +1,205 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &CurrentSessionDataSource{}
+var _ datasource.DataSourceWithConfigure = &CurrentSessionDataSource{}
+
+func NewCurrentSessionDataSource() datasource.DataSource {
+	return &CurrentSessionDataSource{}
+}
+
+// CurrentSessionDataSource reports the organization and role the provider runs statements as, so that
+// configurations can check them in preconditions before anything is changed.
+type CurrentSessionDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *CurrentSessionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type CurrentSessionDataSourceData struct {
+	ID             types.String `tfsdk:"id"`
+	Organization   types.String `tfsdk:"organization"`
+	Role           types.String `tfsdk:"role"`
+	SessionID      types.String `tfsdk:"session_id"`
+	AvailableRoles types.List   `tfsdk:"available_roles"`
+	Privileges     types.List   `tfsdk:"privileges"`
+}
+
+func (d *CurrentSessionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Current session data source. Reports the organization and role the provider runs statements as, with the privileges of the role. Use it in preconditions or `check` blocks to fail early when a configuration is applied with unexpected credentials.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the session role",
+				Computed:    true,
+			},
+			"organization": schema.StringAttribute{
+				Description: "ID of the organization statements run in, as reported by the server",
+				Computed:    true,
+			},
+			"role": schema.StringAttribute{
+				Description: "Role statements run as, as reported by the server. Resources with an owner run their statements as the owner instead",
+				Computed:    true,
+			},
+			"session_id": schema.StringAttribute{
+				Description: "Session ID statements are tagged with, null when none is configured",
+				Computed:    true,
+			},
+			"available_roles": schema.ListAttribute{
+				Description: "Names of the roles the user can run statements as, sorted. Every role of the organization is checked by running a statement as it, roles the server rejects are left out",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"privileges": schema.ListAttribute{
+				Description: "Privileges of the role, as listed by DESCRIBE ROLE. Each privilege maps the columns reported by the server, in snake case, to their value",
+				Computed:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+func (d *CurrentSessionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_session"
+}
+
+func (d *CurrentSessionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	state := CurrentSessionDataSourceData{}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	privileges, err := rolePrivileges(ctx, conn, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to describe role", err)
+		return
+	}
+
+	// the server reports the context the statement ran in with its result
+	session, err := util.PinSqlContext(conn)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read sql context", err)
+		return
+	}
+	organization, role := session.Organization(), session.Role()
+	if organization == "" {
+		organization = d.cfg.Organization
+	}
+	if role == "" {
+		role = d.cfg.Role
+	}
+
+	roleNames, err := d.availableRoles(ctx)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list roles", err)
+		return
+	}
+
+	state.ID = util.ResourceID(organization, "role", role)
+	state.Organization = types.StringValue(organization)
+	state.Role = types.StringValue(role)
+	state.SessionID = types.StringPointerValue(d.cfg.SessionID)
+	var dg diag.Diagnostics
+	state.AvailableRoles, dg = types.ListValueFrom(ctx, types.StringType, roleNames)
+	resp.Diagnostics.Append(dg...)
+	state.Privileges, dg = types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, privileges)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// rolePrivileges returns the rows of DESCRIBE ROLE, keyed by their snake cased column names.
+func rolePrivileges(ctx context.Context, conn *sql.Conn, role string) ([]map[string]string, error) {
+	rows, err := util.DescribeRows(ctx, conn, fmt.Sprintf(`DESCRIBE ROLE "%s";`, role))
+	if err != nil {
+		return nil, err
+	}
+	privileges := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		privileges = append(privileges, row.Map())
+	}
+	return privileges, nil
+}
+
+// availableRoles returns the sorted names of the roles of the organization the user is granted. LIST ROLES reports
+// every role of the organization, so each one is checked by running it as the role of a statement: the server rejects
+// roles the user is not granted.
+func (d *CurrentSessionDataSource) availableRoles(ctx context.Context) ([]string, error) {
+	roles, err := d.cfg.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(roles))
+	for name := range roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	available := []string{}
+	for _, name := range names {
+		granted, err := roleGranted(ctx, d.cfg, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check role %s: %w", name, err)
+		}
+		if granted {
+			available = append(available, name)
+		}
+	}
+	return available, nil
+}
+
+// roleGranted runs a statement as the role, it reports false when the server refuses the role.
+func roleGranted(ctx context.Context, cfg *config.DeltaStreamProviderCfg, role string) (bool, error) {
+	ctx, conn, err := util.GetConnection(ctx, cfg.Db, cfg.SessionID, cfg.Organization, role)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if err := util.QueryRows(ctx, conn, `LIST ROLES;`, func(rows *sql.Rows) error { return util.ErrStopRows }); err != nil {
+		var sqlErr gods.ErrSQLError
+		if errors.As(err, &sqlErr) && (sqlErr.SQLCode == gods.SqlStateInsufficientPrivilege || sqlErr.SQLCode == gods.SqlStateInvalidRole) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
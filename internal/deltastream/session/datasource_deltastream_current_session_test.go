@@ -0,0 +1,77 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const testOrganization = "00000000-0000-0000-0000-000000000001"
+
+func TestRolePrivileges(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE ROLE "sysadmin";$`,
+		Columns: []mockserver.Column{
+			{Name: "Privilege", Type: "VARCHAR"},
+			{Name: "Object Type", Type: "VARCHAR"},
+			{Name: "Object Name", Type: "VARCHAR", Nullable: true},
+		},
+		Rows: [][]*string{
+			mockserver.Row("CREATE_DATABASE", "organization", testOrganization),
+			append(mockserver.Row("CREATE_STORE", "organization"), nil),
+		},
+	}})
+
+	privileges, err := rolePrivileges(ctx, conn, "sysadmin")
+	if err != nil {
+		t.Fatalf("rolePrivileges() error = %v", err)
+	}
+	want := []map[string]string{
+		{"privilege": "CREATE_DATABASE", "object_type": "organization", "object_name": testOrganization},
+		{"privilege": "CREATE_STORE", "object_type": "organization"},
+	}
+	if !reflect.DeepEqual(privileges, want) {
+		t.Errorf("rolePrivileges() = %v, want %v", privileges, want)
+	}
+
+	session, err := util.PinSqlContext(conn)
+	if err != nil {
+		t.Fatalf("PinSqlContext() error = %v", err)
+	}
+	if session.Role() != "sysadmin" || session.Organization() != testOrganization {
+		t.Errorf("session context = %s/%s, want %s/sysadmin", session.Organization(), session.Role(), testOrganization)
+	}
+}
+
+func TestAvailableRoles(t *testing.T) {
+	ctx := context.Background()
+	roles := []mockserver.Column{{Name: "Name", Type: "VARCHAR"}}
+	_, db := mockserver.NewDB(t, []mockserver.Fixture{{
+		Statement: `^LIST ROLES;$`,
+		Role:      "useradmin",
+		SqlState:  string(gods.SqlStateInsufficientPrivilege),
+		Message:   "role useradmin is not granted",
+	}, {
+		Statement: `^LIST ROLES;$`,
+		Columns:   roles,
+		Rows:      [][]*string{mockserver.Row("sysadmin"), mockserver.Row("useradmin"), mockserver.Row("public")},
+	}})
+	d := &CurrentSessionDataSource{cfg: &config.DeltaStreamProviderCfg{Db: db, Organization: testOrganization, Role: "sysadmin"}}
+
+	got, err := d.availableRoles(ctx)
+	if err != nil {
+		t.Fatalf("availableRoles() error = %v", err)
+	}
+	if want := []string{"public", "sysadmin"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("availableRoles() = %v, want %v", got, want)
+	}
+}
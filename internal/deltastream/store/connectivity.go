@@ -0,0 +1,66 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sethvargo/go-retry"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const (
+	// connectivityMaxDuration bounds the wait for the store state to settle after a successful connectivity test.
+	connectivityMaxDuration = 30 * time.Second
+	connectivityBaseBackoff = time.Second
+)
+
+var errStoreErrored = errors.New("store is still errored")
+
+// testStoreConnectivity makes the server connect to the store by listing its top level entities. The store state
+// is only updated when the backend probes the store, listing entities forces such a probe so that expired
+// credentials are reported during refresh instead of when a query next fails.
+func testStoreConnectivity(ctx context.Context, conn *sql.Conn, storeName string) error {
+	return util.QueryRows(ctx, conn, fmt.Sprintf(`LIST ENTITIES IN STORE "%s";`, storeName), func(rows *sql.Rows) error {
+		return nil
+	})
+}
+
+// verifyConnectivity tests the connectivity of the store and records the result. After a successful test it waits
+// briefly for an errored store to be reported ready again, the previous state is kept if it does not settle in time.
+func (d *StoreResource) verifyConnectivity(ctx context.Context, conn *sql.Conn, store StoreResourceData) (StoreResourceData, error) {
+	if err := testStoreConnectivity(ctx, conn, store.Name.ValueString()); err != nil {
+		store.ConnectivityError = types.StringValue(err.Error())
+		return store, nil
+	}
+	store.ConnectivityError = types.StringNull()
+
+	if store.State.ValueString() != "errored" {
+		return store, nil
+	}
+	backoff := retry.WithMaxDuration(connectivityMaxDuration, retry.NewExponential(connectivityBaseBackoff))
+	err := retry.Do(ctx, backoff, func(ctx context.Context) error {
+		refreshed, err := d.updateComputed(ctx, conn, store)
+		if err != nil {
+			return err
+		}
+		store = refreshed
+		if store.State.ValueString() == "errored" {
+			tflog.Debug(ctx, "store still errored after connectivity test, retrying", map[string]any{"store": store.Name.ValueString()})
+			return retry.RetryableError(errStoreErrored)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStoreErrored) {
+		return store, err
+	}
+	return store, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestVerifyConnectivity(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{
+		{
+			Statement: `^LIST ENTITIES IN STORE "reachable";$`,
+			Columns:   []mockserver.Column{{Name: "name", Type: "VARCHAR"}, {Name: "is_leaf", Type: "BOOLEAN"}},
+			Rows:      [][]*string{mockserver.Row("pageviews", "true")},
+		},
+		{
+			Statement: `^LIST ENTITIES IN STORE "expired";$`,
+			SqlState:  "XX000",
+			Message:   "SASL authentication failed",
+		},
+	})
+
+	d := &StoreResource{}
+	store, err := d.verifyConnectivity(ctx, conn, StoreResourceData{
+		Name:              types.StringValue("reachable"),
+		State:             types.StringValue("ready"),
+		ConnectivityError: types.StringValue("stale"),
+	})
+	if err != nil {
+		t.Fatalf("verifyConnectivity() error = %v", err)
+	}
+	if !store.ConnectivityError.IsNull() {
+		t.Errorf("connectivity_error = %s, want null", store.ConnectivityError)
+	}
+
+	store, err = d.verifyConnectivity(ctx, conn, StoreResourceData{
+		Name:  types.StringValue("expired"),
+		State: types.StringValue("ready"),
+	})
+	if err != nil {
+		t.Fatalf("verifyConnectivity() error = %v", err)
+	}
+	if !strings.Contains(store.ConnectivityError.ValueString(), "SASL authentication failed") {
+		t.Errorf("connectivity_error = %s, want the server error", store.ConnectivityError)
+	}
+	if store.State.ValueString() != "ready" {
+		t.Errorf("state = %s, want the last known state", store.State)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const (
+	// storeReadyBaseBackoff is the first delay of the readiness poll when CREATE STORE gave no hint.
+	storeReadyBaseBackoff = time.Second
+	// storeReadyMaxHint caps the delay hinted by CREATE STORE, so that a bogus hint cannot stall the apply.
+	storeReadyMaxHint = time.Minute
+)
+
+// storeReadyHint returns the delay CREATE STORE hinted before polling the new store, from a retry_after column with
+// the number of seconds provisioning is expected to take. It returns 0 when the statement gave no hint, the store is
+// then looked up right away.
+func storeReadyHint(rows []util.DescribeRow) time.Duration {
+	if len(rows) == 0 {
+		return 0
+	}
+	v, ok := rows[0].Column("retry_after")
+	if !ok || !v.Valid {
+		return 0
+	}
+	seconds, err := strconv.ParseFloat(v.String, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return min(time.Duration(seconds*float64(time.Second)), storeReadyMaxHint)
+}
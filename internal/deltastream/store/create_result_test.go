@@ -0,0 +1,47 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const testOrganization = "00000000-0000-0000-0000-000000000001"
+
+func TestStoreReadyHint(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^CREATE STORE "provisioning"`,
+		Columns: []mockserver.Column{
+			{Name: "status", Type: "VARCHAR"},
+			{Name: "Retry_After", Type: "BIGINT"},
+		},
+		Rows: [][]*string{mockserver.Row("provisioning", "30")},
+	}, {
+		Statement: `^CREATE STORE "silent"`,
+	}, {
+		Statement: `^CREATE STORE "hinted"`,
+		Columns:   []mockserver.Column{{Name: "retry_after", Type: "BIGINT"}},
+		Rows:      [][]*string{mockserver.Row("3600")},
+	}})
+
+	for name, want := range map[string]time.Duration{
+		"provisioning": 30 * time.Second,
+		"silent":       0,
+		"hinted":       storeReadyMaxHint,
+	} {
+		rows, err := util.DescribeRows(ctx, conn, `CREATE STORE "`+name+`" WITH('type' = KAFKA);`)
+		if err != nil {
+			t.Fatalf("failed to create store %s: %v", name, err)
+		}
+		if got := storeReadyHint(rows); got != want {
+			t.Errorf("storeReadyHint(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
@@ -6,12 +6,15 @@ package store
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -48,9 +51,27 @@ func (d *EntitiesDataSource) Metadata(ctx context.Context, req datasource.Metada
 }
 
 type EntitiesDataSourceData struct {
-	Store         types.String `tfsdk:"store"`
-	ParentPath    types.List   `tfsdk:"parent_path"`
-	ChildEntities types.List   `tfsdk:"child_entities"`
+	ID         types.String `tfsdk:"id"`
+	Store      types.String `tfsdk:"store"`
+	ParentPath types.List   `tfsdk:"parent_path"`
+	Entities   types.List   `tfsdk:"entities"`
+
+	ItemsByName   types.Map  `tfsdk:"items_by_name"`
+	ChildEntities types.List `tfsdk:"child_entities"`
+}
+
+type EntityItem struct {
+	Name   types.String `tfsdk:"name"`
+	IsLeaf types.Bool   `tfsdk:"is_leaf"`
+	Type   types.String `tfsdk:"type"`
+}
+
+func (EntityItem) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":    types.StringType,
+		"is_leaf": types.BoolType,
+		"type":    types.StringType,
+	}
 }
 
 func (d *EntitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -58,6 +79,10 @@ func (d *EntitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		MarkdownDescription: "Entities in a store",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the parent entity",
+				Computed:    true,
+			},
 			"store": schema.StringAttribute{
 				Description: "Name of the Store",
 				Required:    true,
@@ -68,13 +93,35 @@ func (d *EntitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				Optional:    true,
 				ElementType: types.StringType,
 			},
-			"child_entities": schema.ListAttribute{
+			"entities": schema.ListNestedAttribute{
 				Description: "Child entities",
 				Computed:    true,
-				ElementType: types.StringType,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the entity",
+							Computed:    true,
+						},
+						"is_leaf": schema.BoolAttribute{
+							Description: "Specifies if the entity is a leaf entity that holds data, such as a topic or table, rather than a namespace of other entities",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the entity, such as topic, stream, table or namespace",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"child_entities": schema.ListAttribute{
+				Description:        "Child entities",
+				DeprecationMessage: "Use entities instead. child_entities will be removed in the next release.",
+				Computed:           true,
+				ElementType:        types.StringType,
 			},
 		},
 	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["entities"].(schema.ListNestedAttribute), "name")
 }
 
 const listEntitiesStatement = `LIST ENTITIES 
@@ -116,27 +163,71 @@ func (d *EntitiesDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	rows, err := conn.QueryContext(ctx, b.String())
+	storeType, err := getStoreType(ctx, d.cfg, conn, entityData.Store.ValueString())
 	if err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list store entities", err)
 		return
 	}
-	defer rows.Close()
 
 	items := []string{}
-	for rows.Next() {
-		var name string
-		var isLeaf bool
-		if err := rows.Scan(&name, &isLeaf); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read topics", err)
-			return
+	entities := []EntityItem{}
+	if err := util.QueryRows(ctx, conn, b.String(), func(rows *sql.Rows) error {
+		cols, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		if len(cols) < 2 {
+			return fmt.Errorf("unexpected columns %v", cols)
+		}
+		values, err := rowsToMap(rows)
+		if err != nil {
+			return err
 		}
+		name := values[cols[0]]
+		isLeaf := values[cols[1]] == "true"
+		kind, ok := values["type"]
+		if !ok || kind == "" {
+			kind = entityType(storeType, isLeaf)
+		}
+
 		items = append(items, name)
+		entities = append(entities, EntityItem{
+			Name:   types.StringValue(name),
+			IsLeaf: types.BoolValue(isLeaf),
+			Type:   types.StringValue(kind),
+		})
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to list store entities", err)
+		return
 	}
 
+	entityData.ID = util.ResourceID(d.cfg.Organization, "entity", append([]string{entityData.Store.ValueString()}, parentPath...)...)
+
 	var dg diag.Diagnostics
+	entityData.Entities, dg = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: EntityItem{}.AttributeTypes()}, entities)
+	resp.Diagnostics.Append(dg...)
+	entityData.ItemsByName, dg = util.ItemsByName(ctx, entityData.Entities, "name")
+	resp.Diagnostics.Append(dg...)
 	entityData.ChildEntities, dg = types.ListValueFrom(ctx, types.StringType, items)
 	resp.Diagnostics.Append(dg...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &entityData)...)
 }
+
+// entityType derives the kind of an entity from the type of the store holding
+// it, for servers that do not report it in LIST ENTITIES.
+func entityType(storeType string, isLeaf bool) string {
+	if !isLeaf {
+		return "namespace"
+	}
+
+	switch strings.ToLower(storeType) {
+	case "kafka", "confluentkafka":
+		return "topic"
+	case "kinesis":
+		return "stream"
+	default:
+		return "table"
+	}
+}
@@ -50,6 +50,7 @@ func (d *EntityDataDataSource) Metadata(ctx context.Context, req datasource.Meta
 }
 
 type EntityDataDataSourceData struct {
+	ID            types.String `tfsdk:"id"`
 	Store         types.String `tfsdk:"store"`
 	EntityPath    types.List   `tfsdk:"entity_path"`
 	NumRows       types.Int64  `tfsdk:"num_rows"`
@@ -62,6 +63,10 @@ func (d *EntityDataDataSource) Schema(ctx context.Context, req datasource.Schema
 		MarkdownDescription: "Entities in a store",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Entity",
+				Computed:    true,
+			},
 			"store": schema.StringAttribute{
 				Description: "Name of the Store",
 				Required:    true,
@@ -167,6 +172,12 @@ func (d *EntityDataDataSource) Read(ctx context.Context, req datasource.ReadRequ
 			break
 		}
 	}
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read entity data", err)
+		return
+	}
+
+	entityData.ID = util.ResourceID(d.cfg.Organization, "entity", append([]string{entityData.Store.ValueString()}, entityPath...)...)
 
 	var dg diag.Diagnostics
 	entityData.Rows, dg = types.ListValueFrom(ctx, types.StringType, items)
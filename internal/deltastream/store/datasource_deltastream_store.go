@@ -8,15 +8,16 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/store/models"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
 	"sigs.k8s.io/yaml"
 
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -34,89 +35,8 @@ type StoreDataSource struct {
 	cfg *config.DeltaStreamProviderCfg
 }
 
-type KafkaDatasourceProperties struct {
-	Uris                    types.String `tfsdk:"uris"`
-	SchemaRegistryName      types.String `tfsdk:"schema_registry_name"`
-	TlsDisabled             types.Bool   `tfsdk:"tls_disabled"`
-	TlsVerifyServerHostname types.Bool   `tfsdk:"tls_verify_server_hostname"`
-}
-
-func (KafkaDatasourceProperties) AttributeTypes() map[string]attr.Type {
-	return map[string]attr.Type{
-		"uris":                       types.StringType,
-		"schema_registry_name":       types.StringType,
-		"tls_disabled":               types.BoolType,
-		"tls_verify_server_hostname": types.BoolType,
-	}
-}
-
-type ConfluentKafkaDatasourceProperties struct {
-	Uris               types.String `tfsdk:"uris"`
-	SchemaRegistryName types.String `tfsdk:"schema_registry_name"`
-}
-
-func (ConfluentKafkaDatasourceProperties) AttributeTypes() map[string]attr.Type {
-	return map[string]attr.Type{
-		"uris":                 types.StringType,
-		"schema_registry_name": types.StringType,
-	}
-}
-
-type KinesisDatasourceProperties struct {
-	Uris               types.String `tfsdk:"uris"`
-	SchemaRegistryName types.String `tfsdk:"schema_registry_name"`
-}
-
-func (KinesisDatasourceProperties) AttributeTypes() map[string]attr.Type {
-	return map[string]attr.Type{
-		"uris":                 types.StringType,
-		"schema_registry_name": types.StringType,
-	}
-}
-
-type SnowflakeDatasourceProperties struct {
-	Uris          types.String `tfsdk:"uris"`
-	AccountId     types.String `tfsdk:"account_id"`
-	WarehouseName types.String `tfsdk:"warehouse_name"`
-	RoleName      types.String `tfsdk:"role_name"`
-}
-
-func (SnowflakeDatasourceProperties) AttributeTypes() map[string]attr.Type {
-	return map[string]attr.Type{
-		"uris":           types.StringType,
-		"account_id":     types.StringType,
-		"warehouse_name": types.StringType,
-		"role_name":      types.StringType,
-	}
-}
-
-type DatabricksDatasourceProperties struct {
-	Uris          types.String `tfsdk:"uris"`
-	WarehouseId   types.String `tfsdk:"warehouse_id"`
-	CloudS3Bucket types.String `tfsdk:"cloud_s3_bucket"`
-	CloudRegion   types.String `tfsdk:"cloud_region"`
-}
-
-func (DatabricksDatasourceProperties) AttributeTypes() map[string]attr.Type {
-	return map[string]attr.Type{
-		"uris":            types.StringType,
-		"warehouse_id":    types.StringType,
-		"cloud_s3_bucket": types.StringType,
-		"cloud_region":    types.StringType,
-	}
-}
-
-type PostgresDatasourceProperties struct {
-	Uris types.String `tfsdk:"uris"`
-}
-
-func (PostgresDatasourceProperties) AttributeTypes() map[string]attr.Type {
-	return map[string]attr.Type{
-		"uris": types.StringType,
-	}
-}
-
 type StoreDatasourceData struct {
+	ID             types.String `tfsdk:"id"`
 	Name           types.String `tfsdk:"name"`
 	AccessRegion   types.String `tfsdk:"access_region"`
 	Type           types.String `tfsdk:"type"`
@@ -130,6 +50,10 @@ type StoreDatasourceData struct {
 	Postgres       types.Object `tfsdk:"postgres"`
 	UpdatedAt      types.String `tfsdk:"updated_at"`
 	CreatedAt      types.String `tfsdk:"created_at"`
+
+	SchemaRegistryName types.String `tfsdk:"schema_registry_name"`
+
+	RawDescribeJSON types.String `tfsdk:"raw_describe_json"`
 }
 
 func (d *StoreDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -151,6 +75,10 @@ func (d *StoreDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 		MarkdownDescription: "Store resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Store",
+				Computed:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Name of the Store",
 				Required:    true,
@@ -271,6 +199,10 @@ func (d *StoreDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 						Description: "List of host:port URIs to connect to the store",
 						Computed:    true,
 					},
+					"username": schema.StringAttribute{
+						Description: "Username used when authenticating with the Postgres database",
+						Computed:    true,
+					},
 				},
 				Optional: true,
 			},
@@ -291,6 +223,14 @@ func (d *StoreDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Description: "Last update date of the Store",
 				Computed:    true,
 			},
+			"schema_registry_name": schema.StringAttribute{
+				Description: "Name of the schema registry attached to the Store, regardless of the Store type",
+				Computed:    true,
+			},
+			"raw_describe_json": schema.StringAttribute{
+				Description: "Result of DESCRIBE STORE as a JSON object of the column names to their values, verbatim. Use jsondecode to read details the data source does not model yet",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -317,7 +257,7 @@ func (d *StoreDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	row := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT "region", type, status, "owner", created_at, updated_at FROM deltastream.sys."stores" WHERE name = '%s';`, store.Name.ValueString()))
 	if row.Err() != nil {
 		if errors.Is(row.Err(), sql.ErrNoRows) {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read store details", &gods.ErrSQLError{SQLCode: gods.SqlStateInvalidStore})
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read store details", gods.ErrSQLError{SQLCode: gods.SqlStateInvalidStore})
 			return
 		}
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read store details", row.Err())
@@ -335,78 +275,96 @@ func (d *StoreDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	store.ID = util.ResourceID(d.cfg.Organization, "store", store.Name.ValueString())
 	store.Type = types.StringValue(kind)
 	store.AccessRegion = types.StringValue(accessRegion)
 	store.State = types.StringValue(state)
 	store.Owner = types.StringValue(owner)
-	store.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-	store.UpdatedAt = types.StringValue(updatedAt.Format(time.RFC3339))
-	store.Owner = types.StringValue(owner)
-	store.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-
-	row = conn.QueryRowContext(ctx, fmt.Sprintf(`DESCRIBE STORE "%s";`, store.Name.ValueString()))
-	var metadataJSON string
-	var uri string
-	var detailsJSON string
-	var tlsEnabled bool
-	var verifyHostname bool
-	var schemaRegistryName *string
-	if err := row.Scan(&metadataJSON, &uri, &detailsJSON, &tlsEnabled, &verifyHostname, &schemaRegistryName); err != nil {
+	store.CreatedAt = util.TimestampValue(createdAt)
+	store.UpdatedAt = util.TimestampValue(updatedAt)
+
+	describe, err := util.Describe(ctx, conn, fmt.Sprintf(`DESCRIBE STORE "%s";`, store.Name.ValueString()))
+	if err == nil && len(describe.Values) < 6 {
+		err = fmt.Errorf("unexpected DESCRIBE STORE columns: %v", describe.Columns)
+	}
+	if err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read store details", err)
 		return
 	}
+	rawDescribe, err := describe.JSON()
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read store details", err)
+		return
+	}
+	store.RawDescribeJSON = types.StringValue(rawDescribe)
+
+	uri := describe.Values[1].String
+	detailsJSON := describe.Values[2].String
+	tlsEnabled, _ := strconv.ParseBool(describe.Values[3].String)
+	verifyHostname, _ := strconv.ParseBool(describe.Values[4].String)
+	var schemaRegistryName *string
+	if describe.Values[5].Valid {
+		schemaRegistryName = &describe.Values[5].String
+	}
+
+	store.SchemaRegistryName = types.StringPointerValue(schemaRegistryName)
+
+	details := map[string]any{}
+	if detailsJSON != "" {
+		if err := yaml.Unmarshal([]byte(detailsJSON), &details); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to unmarshal store details", err)
+			return
+		}
+	}
 
 	var dg diag.Diagnostics
 	switch strings.ToLower(store.Type.ValueString()) {
 	case "kafka":
-		store.Kafka, dg = types.ObjectValueFrom(ctx, KafkaDatasourceProperties{}.AttributeTypes(), KafkaDatasourceProperties{
+		store.Kafka, dg = models.DatasourceObject(ctx, models.Kafka{
 			Uris:                    types.StringValue(uri),
-			SchemaRegistryName:      types.StringPointerValue(schemaRegistryName),
+			SchemaRegistry:          types.StringPointerValue(schemaRegistryName),
 			TlsDisabled:             types.BoolValue(!tlsEnabled),
 			TlsVerifyServerHostname: types.BoolValue(verifyHostname),
 		})
 	case "confluentkafka":
-		store.ConfluentKafka, dg = types.ObjectValueFrom(ctx, ConfluentKafkaDatasourceProperties{}.AttributeTypes(), ConfluentKafkaDatasourceProperties{
-			Uris:               types.StringValue(uri),
-			SchemaRegistryName: types.StringPointerValue(schemaRegistryName),
+		store.ConfluentKafka, dg = models.DatasourceObject(ctx, models.ConfluentKafka{
+			Uris:           types.StringValue(uri),
+			SchemaRegistry: types.StringPointerValue(schemaRegistryName),
 		})
 	case "kinesis":
-		store.Kinesis, dg = types.ObjectValueFrom(ctx, KinesisDatasourceProperties{}.AttributeTypes(), KinesisDatasourceProperties{
-			Uris:               types.StringValue(uri),
-			SchemaRegistryName: types.StringPointerValue(schemaRegistryName),
+		store.Kinesis, dg = models.DatasourceObject(ctx, models.Kinesis{
+			Uris:           types.StringValue(uri),
+			SchemaRegistry: types.StringPointerValue(schemaRegistryName),
 		})
 	case "snowflake":
-		details := map[string]any{}
-		if err := yaml.Unmarshal([]byte(detailsJSON), &details); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to unmarshal databricks details", err)
-			return
-		}
-
-		store.Snowflake, dg = types.ObjectValueFrom(ctx, SnowflakeDatasourceProperties{}.AttributeTypes(), SnowflakeDatasourceProperties{
+		store.Snowflake, dg = models.DatasourceObject(ctx, models.Snowflake{
 			Uris:          types.StringValue(uri),
-			AccountId:     types.StringValue(details["account_id"].(string)),
-			WarehouseName: types.StringValue(details["warehouse_name"].(string)),
-			RoleName:      types.StringValue(details["role_name"].(string)),
+			AccountId:     detailString(details, "account_id"),
+			WarehouseName: detailString(details, "warehouse_name"),
+			RoleName:      detailString(details, "role_name"),
 		})
 	case "databricks":
-		details := map[string]any{}
-		if err := yaml.Unmarshal([]byte(detailsJSON), &details); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to unmarshal databricks details", err)
-			return
-		}
-
-		store.Databricks, dg = types.ObjectValueFrom(ctx, DatabricksDatasourceProperties{}.AttributeTypes(), DatabricksDatasourceProperties{
+		store.Databricks, dg = models.DatasourceObject(ctx, models.Databricks{
 			Uris:          types.StringValue(uri),
-			WarehouseId:   types.StringValue(details["sql_warehouse_id"].(string)),
-			CloudS3Bucket: types.StringValue(details["cloud_provider_bucket"].(string)),
-			CloudRegion:   types.StringValue(details["cloud_provider_region"].(string)),
+			WarehouseId:   detailString(details, "sql_warehouse_id"),
+			CloudS3Bucket: detailString(details, "cloud_provider_bucket"),
+			CloudRegion:   detailString(details, "cloud_provider_region"),
 		})
-	case "postgres":
-		store.Postgres, dg = types.ObjectValueFrom(ctx, PostgresDatasourceProperties{}.AttributeTypes(), PostgresDatasourceProperties{
-			Uris: types.StringValue(uri),
+	case "postgres", "postgresql":
+		store.Postgres, dg = models.DatasourceObject(ctx, models.Postgres{
+			Uris:     types.StringValue(uri),
+			Username: detailString(details, "username"),
 		})
 	}
 	resp.Diagnostics.Append(dg...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &store)...)
 }
+
+// detailString returns a string from the details DeltaStream describes a Store with, or null when it is not set.
+func detailString(details map[string]any, key string) types.String {
+	if v, ok := details[key].(string); ok {
+		return types.StringValue(v)
+	}
+	return types.StringNull()
+}
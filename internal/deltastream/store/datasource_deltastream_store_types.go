@@ -0,0 +1,155 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ datasource.DataSource = &StoreTypesDataSource{}
+
+func NewStoreTypesDataSource() datasource.DataSource {
+	return &StoreTypesDataSource{}
+}
+
+// StoreTypesDataSource lists the store types supported by this provider version. The properties are derived from
+// the deltastream_store resource schema so they stay in sync with what the resource accepts.
+type StoreTypesDataSource struct{}
+
+// storeTypeAttributes maps the deltastream_store attribute configuring a store type to the type used in CREATE STORE.
+var storeTypeAttributes = map[string]string{
+	"kafka":           "KAFKA",
+	"confluent_kafka": "CONFLUENT_KAFKA",
+	"kinesis":         "KINESIS",
+	"snowflake":       "SNOWFLAKE",
+	"databricks":      "DATABRICKS",
+	"postgres":        "POSTGRESQL",
+}
+
+type StoreTypesDatasourceDataItem struct {
+	Type               types.String `tfsdk:"type"`
+	Attribute          types.String `tfsdk:"attribute"`
+	RequiredProperties types.List   `tfsdk:"required_properties"`
+	OptionalProperties types.List   `tfsdk:"optional_properties"`
+}
+
+type StoreTypesDatasourceData struct {
+	Items types.List `tfsdk:"items"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
+}
+
+func (d *StoreTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Store types supported by the provider",
+
+		Attributes: map[string]schema.Attribute{
+			"items": schema.ListNestedAttribute{
+				Description: "List of store types",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Type of the Store",
+							Computed:    true,
+						},
+						"attribute": schema.StringAttribute{
+							Description: "Attribute of the deltastream_store resource configuring this type of Store",
+							Computed:    true,
+						},
+						"required_properties": schema.ListAttribute{
+							Description: "Properties that must be set for this type of Store",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"optional_properties": schema.ListAttribute{
+							Description: "Properties that may be set for this type of Store",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["items"].(schema.ListNestedAttribute), "type")
+}
+
+func (d *StoreTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_store_types"
+}
+
+func (d *StoreTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	storeTypes := StoreTypesDatasourceData{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, &storeTypes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	(&StoreResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	resp.Diagnostics.Append(schemaResp.Diagnostics...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributes := make([]string, 0, len(storeTypeAttributes))
+	for name := range storeTypeAttributes {
+		attributes = append(attributes, name)
+	}
+	sort.Strings(attributes)
+
+	items := []StoreTypesDatasourceDataItem{}
+	for _, name := range attributes {
+		nested, ok := schemaResp.Schema.Attributes[name].(resourceschema.SingleNestedAttribute)
+		if !ok {
+			continue
+		}
+
+		required := []string{}
+		optional := []string{}
+		for property, a := range nested.Attributes {
+			switch {
+			case a.IsRequired():
+				required = append(required, property)
+			case a.IsOptional():
+				optional = append(optional, property)
+			}
+		}
+		sort.Strings(required)
+		sort.Strings(optional)
+
+		item := StoreTypesDatasourceDataItem{
+			Type:      types.StringValue(storeTypeAttributes[name]),
+			Attribute: types.StringValue(name),
+		}
+		var dg diag.Diagnostics
+		item.RequiredProperties, dg = types.ListValueFrom(ctx, types.StringType, required)
+		resp.Diagnostics.Append(dg...)
+		item.OptionalProperties, dg = types.ListValueFrom(ctx, types.StringType, optional)
+		resp.Diagnostics.Append(dg...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		items = append(items, item)
+	}
+
+	var dg diag.Diagnostics
+	storeTypes.Items, dg = types.ListValueFrom(ctx, storeTypes.Items.ElementType(ctx), items)
+	resp.Diagnostics.Append(dg...)
+	storeTypes.ItemsByName, dg = util.ItemsByName(ctx, storeTypes.Items, "type")
+	resp.Diagnostics.Append(dg...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &storeTypes)...)
+}
@@ -5,6 +5,7 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -29,6 +30,7 @@ type StoresDataSource struct {
 }
 
 type StoresDatasourceDataItem struct {
+	ID           types.String `tfsdk:"id"`
 	Name         types.String `tfsdk:"name"`
 	AccessRegion types.String `tfsdk:"access_region"`
 	Type         types.String `tfsdk:"type"`
@@ -36,10 +38,14 @@ type StoresDatasourceDataItem struct {
 	State        types.String `tfsdk:"state"`
 	UpdatedAt    types.String `tfsdk:"updated_at"`
 	CreatedAt    types.String `tfsdk:"created_at"`
+
+	SchemaRegistryName types.String `tfsdk:"schema_registry_name"`
 }
 
 type StoresDatasourceData struct {
 	Items types.List `tfsdk:"items"`
+
+	ItemsByName types.Map `tfsdk:"items_by_name"`
 }
 
 func (d *StoresDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -66,6 +72,10 @@ func (d *StoresDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Global identifier of the Store",
+							Computed:    true,
+						},
 						"name": schema.StringAttribute{
 							Description: "Name of the Store",
 							Computed:    true,
@@ -94,11 +104,16 @@ func (d *StoresDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 							Description: "Last update date of the Store",
 							Computed:    true,
 						},
+						"schema_registry_name": schema.StringAttribute{
+							Description: "Name of the schema registry attached to the Store",
+							Computed:    true,
+						},
 					},
 				},
 			},
 		},
 	}
+	resp.Schema.Attributes["items_by_name"] = util.ItemsByNameAttribute(resp.Schema.Attributes["items"].(schema.ListNestedAttribute), "name")
 }
 
 func (d *StoresDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -120,12 +135,6 @@ func (d *StoresDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(ctx, `SELECT "name", "region", type, status, "owner", created_at, updated_at FROM deltastream.sys."stores";`)
-	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read stores", err)
-	}
-	defer rows.Close()
-
 	var name string
 	var accessRegion string
 	var kind string
@@ -135,25 +144,40 @@ func (d *StoresDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	var updatedAt time.Time
 
 	items := []StoresDatasourceDataItem{}
-	for rows.Next() {
+	if err := util.QueryRows(ctx, conn, `SELECT "name", "region", type, status, "owner", created_at, updated_at FROM deltastream.sys."stores";`, func(rows *sql.Rows) error {
 		if err := rows.Scan(&name, &accessRegion, &kind, &state, &owner, &createdAt, &updatedAt); err != nil {
-			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read stores", err)
-			return
+			return err
 		}
 		items = append(items, StoresDatasourceDataItem{
+			ID:           util.ResourceID(d.cfg.Organization, "store", name),
 			Name:         types.StringValue(name),
 			Type:         types.StringValue(kind),
 			AccessRegion: types.StringValue(accessRegion),
 			State:        types.StringValue(state),
 			Owner:        types.StringValue(owner),
-			CreatedAt:    types.StringValue(createdAt.Format(time.RFC3339)),
-			UpdatedAt:    types.StringValue(createdAt.Format(time.RFC3339)),
+			CreatedAt:    util.TimestampValue(createdAt),
+			UpdatedAt:    util.TimestampValue(updatedAt),
 		})
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read stores", err)
+		return
+	}
+
+	for i := range items {
+		schemaRegistryName, err := util.StoreSchemaRegistry(ctx, conn, items[i].Name.ValueString())
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read store details", err)
+			return
+		}
+		items[i].SchemaRegistryName = types.StringPointerValue(schemaRegistryName)
 	}
 
 	var dg diag.Diagnostics
 	stores.Items, dg = types.ListValueFrom(ctx, stores.Items.ElementType(ctx), items)
 	resp.Diagnostics.Append(dg...)
+	stores.ItemsByName, dg = util.ItemsByName(ctx, stores.Items, "name")
+	resp.Diagnostics.Append(dg...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stores)...)
 }
@@ -0,0 +1,68 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// configuredURIs returns the type attribute of the store and the URIs it is configured with, ok is false when the
+// URIs are not known.
+func configuredURIs(store StoreResourceData) (attribute string, uris types.String, ok bool) {
+	for attribute, obj := range map[string]types.Object{
+		"kafka":           store.Kafka,
+		"confluent_kafka": store.ConfleuntKafka,
+		"kinesis":         store.Kinesis,
+		"snowflake":       store.Snowflake,
+		"databricks":      store.Databricks,
+		"postgres":        store.Postgres,
+	} {
+		if obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		uris, isString := obj.Attributes()["uris"].(types.String)
+		return attribute, uris, isString && !uris.IsNull() && !uris.IsUnknown()
+	}
+	return "", types.StringNull(), false
+}
+
+// sameURIs compares two comma separated lists of URIs regardless of their order, schemes and trailing slashes.
+func sameURIs(a, b string) bool {
+	x, y := bootstrapServers(a), bootstrapServers(b)
+	slices.Sort(x)
+	slices.Sort(y)
+	return slices.Equal(x, y)
+}
+
+// reportURIDrift warns when the URIs DESCRIBE STORE reports differ from the ones the store is configured with. The
+// URIs are create-only and never refreshed, a store repointed outside of Terraform is otherwise not noticed.
+func (d *StoreResource) reportURIDrift(ctx context.Context, conn *sql.Conn, store StoreResourceData) (dg diag.Diagnostics) {
+	attribute, uris, ok := configuredURIs(store)
+	if !ok {
+		return
+	}
+
+	describe, err := util.Describe(ctx, conn, fmt.Sprintf(`DESCRIBE STORE "%s";`, store.Name.ValueString()))
+	if err == nil && len(describe.Values) < 2 {
+		err = fmt.Errorf("unexpected DESCRIBE STORE columns: %v", describe.Columns)
+	}
+	if err != nil {
+		dg.AddWarning("Unable to check store URIs for changes made outside of Terraform", err.Error())
+		return
+	}
+	current := describe.Values[1].String
+	if sameURIs(uris.ValueString(), current) {
+		return
+	}
+	return d.cfg.ReportDrift("store", store.Name.ValueString(), path.Root(attribute).AtName("uris"), uris, types.StringValue(current))
+}
@@ -0,0 +1,46 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/store/models"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+)
+
+func TestReportURIDrift(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE STORE "kafka";$`,
+		Columns:   []mockserver.Column{{Name: "Type", Type: "VARCHAR"}, {Name: "Uri", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("KAFKA", "b2:9092,b1:9092")},
+	}})
+
+	kafkaStore := func(uris string) StoreResourceData {
+		obj, dg := models.ResourceObject(ctx, models.Kafka{
+			Uris:                 types.StringValue(uris),
+			ClientProperties:     types.MapNull(types.StringType),
+			AdditionalProperties: types.MapNull(types.StringType),
+		})
+		if dg.HasError() {
+			t.Fatalf("failed to build kafka: %v", dg)
+		}
+		return StoreResourceData{Name: types.StringValue("kafka"), Kafka: obj}
+	}
+
+	d := &StoreResource{cfg: &config.DeltaStreamProviderCfg{StrictDriftChecks: true}}
+	if dg := d.reportURIDrift(ctx, conn, kafkaStore("SASL_SSL://b1:9092, b2:9092")); len(dg) != 0 {
+		t.Errorf("reportURIDrift() = %v, want no drift for the same brokers", dg)
+	}
+	dg := d.reportURIDrift(ctx, conn, kafkaStore("b1:9092,b3:9092"))
+	if dg.WarningsCount() != 1 || !strings.Contains(dg[0].Detail(), `to "b2:9092,b1:9092"`) {
+		t.Errorf("reportURIDrift() = %v, want a warning with the reported URIs", dg)
+	}
+}
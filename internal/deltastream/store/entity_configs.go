@@ -0,0 +1,123 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizeConfigKey returns the form topic configuration keys are compared in. Brokers report keys lower cased, such
+// as cleanup.policy for "Cleanup.Policy". Values are compared as is, a value the broker reports differently is drift.
+func normalizeConfigKey(k string) string {
+	return strings.ToLower(strings.TrimSpace(k))
+}
+
+// normalizedKeys returns the topic configurations keyed by their normalized keys.
+func normalizedKeys(configs map[string]string) map[string]string {
+	normalized := make(map[string]string, len(configs))
+	for k, v := range configs {
+		normalized[normalizeConfigKey(k)] = v
+	}
+	return normalized
+}
+
+// configsEquivalent reports whether two topic configurations set keys that normalize the same to the same values.
+func configsEquivalent(a, b map[string]string) bool {
+	na, nb := normalizedKeys(a), normalizedKeys(b)
+	if len(na) != len(nb) {
+		return false
+	}
+	for k, v := range na {
+		if w, ok := nb[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
+
+// refreshConfigs returns the configured topic configurations updated with the values the server reports. Keys the
+// server reports in their normalized form keep the configured spelling, so that only actual drift shows in plans.
+func refreshConfigs(configs, allConfigs map[string]string) map[string]string {
+	reported := normalizedKeys(allConfigs)
+	refreshed := make(map[string]string, len(configs))
+	for k, v := range configs {
+		if actual, ok := reported[normalizeConfigKey(k)]; ok {
+			v = actual
+		}
+		refreshed[k] = v
+	}
+	return refreshed
+}
+
+// kafkaConfigProperties returns the WITH clause entries of topic configurations, ordered by key.
+func kafkaConfigProperties(configs map[string]string) []string {
+	keys := make([]string, 0, len(configs))
+	for k := range configs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	properties := make([]string, 0, len(keys))
+	for _, k := range keys {
+		properties = append(properties, fmt.Sprintf("'kafka.topic.%s' = '%s'", k, strings.ReplaceAll(configs[k], "'", "''")))
+	}
+	return properties
+}
+
+func stringMap(m types.Map) (map[string]string, bool) {
+	if m.IsNull() || m.IsUnknown() {
+		return nil, false
+	}
+	out := map[string]string{}
+	for k, v := range m.Elements() {
+		s, ok := v.(types.String)
+		if !ok || s.IsUnknown() {
+			return nil, false
+		}
+		out[k] = s.ValueString()
+	}
+	return out, true
+}
+
+// normalizedConfigs keeps the configs of a topic in state when the planned configs only differ from them by the
+// normalization the server applies to keys, so that respelling a key does not replace the topic.
+type normalizedConfigs struct{}
+
+var _ planmodifier.Map = normalizedConfigs{}
+
+func (normalizedConfigs) Description(ctx context.Context) string {
+	return "Configuration keys that only differ by case or surrounding spaces are not changes"
+}
+
+func (m normalizedConfigs) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (normalizedConfigs) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	planned, ok := stringMap(req.PlanValue)
+	if !ok {
+		return
+	}
+	current, ok := stringMap(req.StateValue)
+	if !ok || !configsEquivalent(planned, current) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// stringMapValue converts a Go map into a map of strings.
+func stringMapValue(m map[string]string) types.Map {
+	values := make(map[string]attr.Value, len(m))
+	for k, v := range m {
+		values[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, values)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRefreshConfigs(t *testing.T) {
+	configs := map[string]string{"Cleanup.Policy": "compact,delete", "retention.ms": "604800000", "segment.bytes": "1"}
+	allConfigs := map[string]string{"cleanup.policy": "compact,delete", "retention.ms": "86400000", "segment.ms": "1000"}
+
+	got := refreshConfigs(configs, allConfigs)
+	want := map[string]string{"Cleanup.Policy": "compact,delete", "retention.ms": "86400000", "segment.bytes": "1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("refreshConfigs() = %v, want %v", got, want)
+	}
+}
+
+func TestNormalizedConfigs(t *testing.T) {
+	state := stringMapValue(map[string]string{"cleanup.policy": "compact,delete"})
+	for _, c := range []struct {
+		name string
+		plan map[string]string
+		kept bool
+	}{
+		{name: "respelled key", plan: map[string]string{"Cleanup.Policy": "compact,delete"}, kept: true},
+		{name: "respelled value", plan: map[string]string{"cleanup.policy": "Compact, Delete"}},
+		{name: "changed", plan: map[string]string{"cleanup.policy": "delete"}},
+		{name: "added", plan: map[string]string{"cleanup.policy": "compact,delete", "retention.ms": "1"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			plan := stringMapValue(c.plan)
+			resp := &planmodifier.MapResponse{PlanValue: plan}
+			normalizedConfigs{}.PlanModifyMap(context.Background(), planmodifier.MapRequest{PlanValue: plan, StateValue: state}, resp)
+			if got := resp.PlanValue.Equal(state); got != c.kept {
+				t.Errorf("plan kept state = %v, want %v", got, c.kept)
+			}
+		})
+	}
+
+	resp := &planmodifier.MapResponse{PlanValue: types.MapUnknown(types.StringType)}
+	normalizedConfigs{}.PlanModifyMap(context.Background(), planmodifier.MapRequest{PlanValue: resp.PlanValue, StateValue: state}, resp)
+	if !resp.PlanValue.IsUnknown() {
+		t.Errorf("unknown plan was replaced with %v", resp.PlanValue)
+	}
+}
+
+func TestKafkaConfigProperties(t *testing.T) {
+	got := kafkaConfigProperties(map[string]string{"retention.ms": "1", "cleanup.policy": "it's"})
+	want := []string{`'kafka.topic.cleanup.policy' = 'it''s'`, `'kafka.topic.retention.ms' = '1'`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("kafkaConfigProperties() = %v, want %v", got, want)
+	}
+}
+
+func TestCommentProperty(t *testing.T) {
+	if got, want := commentProperty("orders' topic"), `'comment' = 'orders'' topic'`; got != want {
+		t.Errorf("commentProperty() = %s, want %s", got, want)
+	}
+}
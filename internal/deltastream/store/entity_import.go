@@ -0,0 +1,78 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// parseEntityImportID splits an import ID of the form store_name:entity/path into the store and the entity path.
+func parseEntityImportID(id string) (string, []string, error) {
+	store, entityPath, ok := strings.Cut(id, ":")
+	if !ok || store == "" || entityPath == "" {
+		return "", nil, fmt.Errorf("invalid import ID %q, expected store_name:entity/path", id)
+	}
+	segments := strings.Split(entityPath, "/")
+	for _, s := range segments {
+		if s == "" {
+			return "", nil, fmt.Errorf("invalid import ID %q, entity path has an empty segment", id)
+		}
+	}
+	return store, segments, nil
+}
+
+// ImportState adopts an existing entity. The properties of the entity are read from DESCRIBE ENTITY by the Read that
+// follows the import.
+func (d *EntityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	store, entityPath, err := parseEntityImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("store"), store)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entity_path"), entityPath)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, util.ImportedKey, []byte("true"))...)
+}
+
+// configsRequireReplace replaces the topic when its configs change. Configs of an imported topic are not recorded in
+// state, setting them to the values the topic already has adopts them without replacing the topic.
+func configsRequireReplace() planmodifier.Map {
+	return mapplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = true
+		if !req.StateValue.IsNull() || !util.Imported(ctx, req.Private) {
+			return
+		}
+		planned, ok := stringMap(req.PlanValue)
+		if !ok {
+			return
+		}
+		var all types.Map
+		if resp.Diagnostics.Append(req.State.GetAttribute(ctx, req.Path.ParentPath().AtName("all_configs"), &all)...); resp.Diagnostics.HasError() {
+			return
+		}
+		allConfigs, ok := stringMap(all)
+		if !ok {
+			return
+		}
+		reported := normalizedKeys(allConfigs)
+		current := map[string]string{}
+		for k := range planned {
+			if v, ok := reported[normalizeConfigKey(k)]; ok {
+				current[k] = v
+			}
+		}
+		resp.RequiresReplace = !configsEquivalent(planned, current)
+	}, "Changing the configs of a topic requires replacement, unless an imported topic already has the configured values", "Changing the configs of a topic requires replacement, unless an imported topic already has the configured values")
+}
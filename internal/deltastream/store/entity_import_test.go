@@ -0,0 +1,37 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEntityImportID(t *testing.T) {
+	tests := []struct {
+		id       string
+		store    string
+		path     []string
+		expected bool
+	}{
+		{id: "kafka_store:pageviews", store: "kafka_store", path: []string{"pageviews"}, expected: true},
+		{id: "snowflake_store:DB/PUBLIC/PAGEVIEWS", store: "snowflake_store", path: []string{"DB", "PUBLIC", "PAGEVIEWS"}, expected: true},
+		{id: "kafka_store:topic:with:colons", store: "kafka_store", path: []string{"topic:with:colons"}, expected: true},
+		{id: "pageviews"},
+		{id: ":pageviews"},
+		{id: "kafka_store:"},
+		{id: "snowflake_store:DB//PAGEVIEWS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			store, path, err := parseEntityImportID(tt.id)
+			if (err == nil) != tt.expected {
+				t.Fatalf("parseEntityImportID(%q) error = %v", tt.id, err)
+			}
+			if store != tt.store || !reflect.DeepEqual(path, tt.path) {
+				t.Errorf("parseEntityImportID(%q) = %q, %v, want %q, %v", tt.id, store, path, tt.store, tt.path)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// tailEntity runs a PRINT ENTITY statement and collects the records it streams until limit records were read or
+// timeout elapsed, whichever comes first. Each record is returned as a JSON object keyed by column name.
+func tailEntity(ctx context.Context, conn *sql.Conn, statement string, limit int, timeout time.Duration) ([]string, error) {
+	tailCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	records := []string{}
+	// reaching the timeout ends the tail, it only fails the read when the caller's context is done too
+	timedOut := func() bool {
+		return errors.Is(tailCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	}
+
+	rows, err := conn.QueryContext(tailCtx, statement)
+	if err != nil {
+		if timedOut() {
+			return records, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	for len(records) < limit && rows.Next() {
+		values := make([]any, len(cols))
+		dest := make([]any, len(cols))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		record := make(map[string]any, len(cols))
+		for i, col := range cols {
+			if b, ok := values[i].([]byte); ok {
+				values[i] = string(b)
+			}
+			record[col] = values[i]
+		}
+		b, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, string(b))
+	}
+	if err := rows.Err(); err != nil && !timedOut() {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestTailEntity(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^PRINT ENTITY`,
+		Columns: []mockserver.Column{
+			{Name: "key", Type: "VARCHAR"},
+			{Name: "value", Type: "VARCHAR"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("u1", `{"page":"home"}`),
+			mockserver.Row("u2", `{"page":"cart"}`),
+			mockserver.Row("u3", `{"page":"checkout"}`),
+		},
+	}})
+
+	records, err := tailEntity(ctx, conn, `PRINT ENTITY "pageviews" IN STORE "kafka";`, 2, time.Second*5)
+	if err != nil {
+		t.Fatalf("tailEntity() error = %v", err)
+	}
+	want := []string{`{"key":"u1","value":"{\"page\":\"home\"}"}`, `{"key":"u2","value":"{\"page\":\"cart\"}"}`}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("tailEntity() = %v, want %v", records, want)
+	}
+}
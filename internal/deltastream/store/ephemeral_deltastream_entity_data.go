@@ -0,0 +1,164 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultTailRecords  = 10
+	defaultTailDuration = time.Second * 10
+)
+
+var _ ephemeral.EphemeralResource = &EntityDataEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &EntityDataEphemeralResource{}
+
+func NewEntityDataEphemeralResource() ephemeral.EphemeralResource {
+	return &EntityDataEphemeralResource{}
+}
+
+// EntityDataEphemeralResource tails the records of an entity. Being ephemeral, the records are never written to the
+// plan or the state, which makes it safe to look at production data from terraform console.
+type EntityDataEphemeralResource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *EntityDataEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "provider error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+func (d *EntityDataEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_data"
+}
+
+type EntityDataEphemeralResourceData struct {
+	Store         types.String `tfsdk:"store"`
+	EntityPath    types.List   `tfsdk:"entity_path"`
+	MaxRecords    types.Int64  `tfsdk:"max_records"`
+	Duration      types.String `tfsdk:"duration"`
+	FromBeginning types.Bool   `tfsdk:"from_beginning"`
+	Records       types.List   `tfsdk:"records"`
+}
+
+func (d *EntityDataEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tails the records of an entity in a store for troubleshooting. The records are never persisted to the plan or the state.",
+
+		Attributes: map[string]schema.Attribute{
+			"store": schema.StringAttribute{
+				Description: "Name of the Store",
+				Required:    true,
+				Validators:  util.IdentifierValidators,
+			},
+			"entity_path": schema.ListAttribute{
+				Description: "Path to entity",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"max_records": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of records to read. Defaults to %d", defaultTailRecords),
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"duration": schema.StringAttribute{
+				Description: fmt.Sprintf("How long to wait for records, as a duration such as 30s. Defaults to %s", defaultTailDuration),
+				Optional:    true,
+			},
+			"from_beginning": schema.BoolAttribute{
+				Description: "Read from the beginning of the entity instead of the records produced after the read starts",
+				Optional:    true,
+			},
+			"records": schema.ListAttribute{
+				Description: "Records read, each a JSON object keyed by column name",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *EntityDataEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	entityData := EntityDataEphemeralResourceData{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, &entityData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int64(defaultTailRecords)
+	if !entityData.MaxRecords.IsNull() && !entityData.MaxRecords.IsUnknown() {
+		limit = entityData.MaxRecords.ValueInt64()
+	}
+	timeout := defaultTailDuration
+	if !entityData.Duration.IsNull() && !entityData.Duration.IsUnknown() {
+		t, err := time.ParseDuration(entityData.Duration.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("duration"), "Invalid duration", err.Error())
+			return
+		}
+		timeout = t
+	}
+
+	entityPath := []string{}
+	resp.Diagnostics.Append(entityData.EntityPath.ElementsAs(ctx, &entityPath, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	b := bytes.NewBuffer(nil)
+	if err := template.Must(template.New("").Parse(printEntityStatement)).Execute(b, map[string]any{
+		"StoreName":     entityData.Store.ValueString(),
+		"EntityPath":    entityPath,
+		"FromBeginning": entityData.FromBeginning.ValueBool(),
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to print entities", err)
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	records, err := tailEntity(ctx, conn, b.String(), int(limit), timeout)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read entity data", err)
+		return
+	}
+
+	recordList, dg := types.ListValueFrom(ctx, types.StringType, records)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entityData.Records = recordList
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &entityData)...)
+}
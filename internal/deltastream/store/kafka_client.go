@@ -0,0 +1,136 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/store/models"
+)
+
+// kafkaSaslMechanisms maps the SASL hash functions of DeltaStream to the sasl_mechanism values of the Kafka
+// providers. NONE has no mechanism.
+var kafkaSaslMechanisms = map[string]string{
+	"PLAIN":       "plain",
+	"SHA256":      "scram-sha256",
+	"SHA512":      "scram-sha512",
+	"AWS_MSK_IAM": "aws-iam",
+}
+
+// kafkaClient holds the client settings of a Kafka store, named after the provider configuration of the Kafka
+// providers so that topics managed with them reach the brokers the same way DeltaStream does.
+type kafkaClient struct {
+	BootstrapServers types.List   `tfsdk:"bootstrap_servers"`
+	SaslMechanism    types.String `tfsdk:"sasl_mechanism"`
+	TlsEnabled       types.Bool   `tfsdk:"tls_enabled"`
+	SkipTlsVerify    types.Bool   `tfsdk:"skip_tls_verify"`
+}
+
+func (kafkaClient) attributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"bootstrap_servers": types.ListType{ElemType: types.StringType},
+		"sasl_mechanism":    types.StringType,
+		"tls_enabled":       types.BoolType,
+		"skip_tls_verify":   types.BoolType,
+	}
+}
+
+func kafkaClientAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Client settings of a kafka or confluent_kafka store, named after the provider configuration of the Kafka providers so that they can be passed to them as is. Credentials are left out, pass the ones configured on the store. Null for other store types",
+		Computed:    true,
+		Attributes: map[string]schema.Attribute{
+			"bootstrap_servers": schema.ListAttribute{
+				Description: "host:port of the brokers, without their protocol and in the order they are configured",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"sasl_mechanism": schema.StringAttribute{
+				Description: "SASL mechanism, one of plain, scram-sha256, scram-sha512 or aws-iam. Null when the store does not use SASL",
+				Computed:    true,
+			},
+			"tls_enabled": schema.BoolAttribute{
+				Description: "Whether the brokers are reached over TLS",
+				Computed:    true,
+			},
+			"skip_tls_verify": schema.BoolAttribute{
+				Description: "Whether the hostname of the brokers is not verified against their certificate",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// bootstrapServers splits the uris of a store into the host:port of its brokers, dropping their protocol and
+// duplicates.
+func bootstrapServers(uris string) []string {
+	servers := []string{}
+	seen := map[string]bool{}
+	for _, uri := range strings.Split(uris, ",") {
+		uri = strings.TrimSpace(uri)
+		if i := strings.Index(uri, "://"); i >= 0 {
+			uri = uri[i+3:]
+		}
+		uri = strings.TrimSuffix(uri, "/")
+		if uri == "" || seen[uri] {
+			continue
+		}
+		seen[uri] = true
+		servers = append(servers, uri)
+	}
+	return servers
+}
+
+// storeKafkaClient derives the kafka_client attribute from the configuration of a store. The TLS settings left
+// unset take the defaults Create applies.
+func storeKafkaClient(ctx context.Context, store StoreResourceData) (types.Object, diag.Diagnostics) {
+	var dg diag.Diagnostics
+	attrTypes := kafkaClient{}.attributeTypes()
+
+	var uris, saslHashFunc types.String
+	client := kafkaClient{TlsEnabled: types.BoolValue(true), SkipTlsVerify: types.BoolValue(false)}
+	switch {
+	case !store.Kafka.IsNull() && !store.Kafka.IsUnknown():
+		var kafka models.Kafka
+		if dg = models.FromObject(ctx, store.Kafka, &kafka); dg.HasError() {
+			return types.ObjectNull(attrTypes), dg
+		}
+		uris, saslHashFunc = kafka.Uris, kafka.SaslHashFunc
+		if !kafka.TlsDisabled.IsNull() && !kafka.TlsDisabled.IsUnknown() {
+			client.TlsEnabled = types.BoolValue(!kafka.TlsDisabled.ValueBool())
+		}
+		if !kafka.TlsVerifyServerHostname.IsNull() && !kafka.TlsVerifyServerHostname.IsUnknown() {
+			client.SkipTlsVerify = types.BoolValue(!kafka.TlsVerifyServerHostname.ValueBool())
+		}
+	case !store.ConfleuntKafka.IsNull() && !store.ConfleuntKafka.IsUnknown():
+		var confluentKafka models.ConfluentKafka
+		if dg = models.FromObject(ctx, store.ConfleuntKafka, &confluentKafka); dg.HasError() {
+			return types.ObjectNull(attrTypes), dg
+		}
+		uris, saslHashFunc = confluentKafka.Uris, confluentKafka.SaslHashFunc
+	case store.Kafka.IsUnknown() || store.ConfleuntKafka.IsUnknown():
+		return types.ObjectUnknown(attrTypes), dg
+	default:
+		return types.ObjectNull(attrTypes), dg
+	}
+	if uris.IsUnknown() || saslHashFunc.IsUnknown() {
+		return types.ObjectUnknown(attrTypes), dg
+	}
+
+	client.BootstrapServers, dg = types.ListValueFrom(ctx, types.StringType, bootstrapServers(uris.ValueString()))
+	if dg.HasError() {
+		return types.ObjectNull(attrTypes), dg
+	}
+	client.SaslMechanism = types.StringNull()
+	if mechanism, ok := kafkaSaslMechanisms[saslHashFunc.ValueString()]; ok {
+		client.SaslMechanism = types.StringValue(mechanism)
+	}
+	return types.ObjectValueFrom(ctx, attrTypes, client)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/store/models"
+)
+
+func TestBootstrapServers(t *testing.T) {
+	got := bootstrapServers(" SASL_SSL://b1:9096, b2:9096 ,,b1:9096,kafka://b3:9092/")
+	want := []string{"b1:9096", "b2:9096", "b3:9092"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bootstrapServers() = %v, want %v", got, want)
+	}
+}
+
+func TestStoreKafkaClient(t *testing.T) {
+	ctx := context.Background()
+
+	kafkaObject := func(m models.Kafka) types.Object {
+		m.ClientProperties = types.MapNull(types.StringType)
+		m.AdditionalProperties = types.MapNull(types.StringType)
+		obj, dg := models.ResourceObject(ctx, m)
+		if dg.HasError() {
+			t.Fatalf("failed to build kafka: %v", dg)
+		}
+		return obj
+	}
+
+	tests := []struct {
+		name  string
+		store StoreResourceData
+		want  *kafkaClient
+	}{
+		{
+			name: "kafka",
+			store: StoreResourceData{Kafka: kafkaObject(models.Kafka{
+				Uris:                    types.StringValue("b1:9096,b2:9096"),
+				SaslHashFunc:            types.StringValue("SHA512"),
+				TlsDisabled:             types.BoolValue(false),
+				TlsVerifyServerHostname: types.BoolValue(false),
+			})},
+			want: &kafkaClient{SaslMechanism: types.StringValue("scram-sha512"), TlsEnabled: types.BoolValue(true), SkipTlsVerify: types.BoolValue(true)},
+		},
+		{
+			name: "kafka without sasl or tls",
+			store: StoreResourceData{Kafka: kafkaObject(models.Kafka{
+				Uris:         types.StringValue("b1:9096,b2:9096"),
+				SaslHashFunc: types.StringValue("NONE"),
+				TlsDisabled:  types.BoolValue(true),
+			})},
+			want: &kafkaClient{SaslMechanism: types.StringNull(), TlsEnabled: types.BoolValue(false), SkipTlsVerify: types.BoolValue(false)},
+		},
+		{
+			name: "kafka with unknown uris",
+			store: StoreResourceData{Kafka: kafkaObject(models.Kafka{
+				Uris:         types.StringUnknown(),
+				SaslHashFunc: types.StringValue("PLAIN"),
+			})},
+		},
+		{name: "kinesis", store: StoreResourceData{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, dg := storeKafkaClient(ctx, tt.store)
+			if dg.HasError() {
+				t.Fatalf("storeKafkaClient() failed: %v", dg)
+			}
+			switch {
+			case tt.name == "kinesis":
+				if !obj.IsNull() {
+					t.Fatalf("storeKafkaClient() = %s, want null", obj)
+				}
+				return
+			case tt.want == nil:
+				if !obj.IsUnknown() {
+					t.Fatalf("storeKafkaClient() = %s, want unknown", obj)
+				}
+				return
+			}
+
+			var got kafkaClient
+			if dg := obj.As(ctx, &got, basetypes.ObjectAsOptions{}); dg.HasError() {
+				t.Fatalf("failed to read kafka_client: %v", dg)
+			}
+			var servers []string
+			got.BootstrapServers.ElementsAs(ctx, &servers, false)
+			if !reflect.DeepEqual(servers, []string{"b1:9096", "b2:9096"}) {
+				t.Errorf("bootstrap_servers = %v", servers)
+			}
+			got.BootstrapServers = types.ListNull(types.StringType)
+			tt.want.BootstrapServers = got.BootstrapServers
+			if !reflect.DeepEqual(got, *tt.want) {
+				t.Errorf("kafka_client = %+v, want %+v", got, *tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package models holds the nested attribute models of each Store type, shared by the store resource and data
+// sources so their attributes cannot drift apart.
+package models
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Model is the nested attribute model of a Store type. The resource exposes every attribute of the model, the data
+// sources only the ones DeltaStream describes back, credentials are never part of them.
+type Model interface {
+	AttributeTypes() map[string]attr.Type
+	DatasourceAttributeTypes() map[string]attr.Type
+}
+
+// ResourceObject converts a model to the value of its resource nested attribute.
+func ResourceObject(ctx context.Context, m Model) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, m.AttributeTypes(), m)
+}
+
+// DatasourceObject converts a model to the value of its data source nested attribute, leaving out the attributes
+// only the resource has. The resource only attributes may be left unset in m.
+func DatasourceObject(ctx context.Context, m Model) (types.Object, diag.Diagnostics) {
+	attrTypes := m.DatasourceAttributeTypes()
+
+	m, err := withNullZeroValues(ctx, m)
+	if err != nil {
+		var dg diag.Diagnostics
+		dg.AddError("invalid store properties", err.Error())
+		return types.ObjectNull(attrTypes), dg
+	}
+	full, dg := ResourceObject(ctx, m)
+	if dg.HasError() {
+		return types.ObjectNull(attrTypes), dg
+	}
+
+	values := make(map[string]attr.Value, len(attrTypes))
+	for name := range attrTypes {
+		values[name] = full.Attributes()[name]
+	}
+	obj, d := types.ObjectValue(attrTypes, values)
+	dg.Append(d...)
+	return obj, dg
+}
+
+// withNullZeroValues returns a copy of m with the attributes left unset, whose zero values carry no type, set to null.
+func withNullZeroValues(ctx context.Context, m Model) (Model, error) {
+	attrTypes := m.AttributeTypes()
+	v := reflect.New(reflect.TypeOf(m)).Elem()
+	v.Set(reflect.ValueOf(m))
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("tfsdk")
+		t, ok := attrTypes[name]
+		if !ok || !v.Field(i).IsZero() {
+			continue
+		}
+		null, err := t.ValueFromTerraform(ctx, tftypes.NewValue(t.TerraformType(ctx), nil))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build null %s: %w", name, err)
+		}
+		v.Field(i).Set(reflect.ValueOf(null))
+	}
+	return v.Interface().(Model), nil
+}
+
+// FromObject reads a resource or data source nested attribute into m, which must be a pointer to a model. Attributes
+// the object does not have are left null.
+func FromObject(ctx context.Context, obj types.Object, m Model) diag.Diagnostics {
+	var dg diag.Diagnostics
+	if obj.IsNull() || obj.IsUnknown() {
+		return dg
+	}
+
+	attrTypes := m.AttributeTypes()
+	values := make(map[string]attr.Value, len(attrTypes))
+	for name, t := range attrTypes {
+		if v, ok := obj.Attributes()[name]; ok {
+			values[name] = v
+			continue
+		}
+		v, err := t.ValueFromTerraform(ctx, tftypes.NewValue(t.TerraformType(ctx), nil))
+		if err != nil {
+			dg.AddError("invalid store properties", fmt.Sprintf("failed to build null %s: %s", name, err))
+			return dg
+		}
+		values[name] = v
+	}
+
+	full, d := types.ObjectValue(attrTypes, values)
+	dg.Append(d...)
+	if dg.HasError() {
+		return dg
+	}
+	dg.Append(full.As(ctx, m, basetypes.ObjectAsOptions{})...)
+	return dg
+}
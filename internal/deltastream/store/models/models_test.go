@@ -0,0 +1,80 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestObjectConversions(t *testing.T) {
+	ctx := context.Background()
+	postgres := Postgres{
+		Uris:                 types.StringValue("postgresql://db:5432/app"),
+		Username:             types.StringValue("app"),
+		Password:             types.StringValue("secret"),
+		AdditionalProperties: types.MapNull(types.StringType),
+	}
+
+	obj, dg := ResourceObject(ctx, postgres)
+	if dg.HasError() {
+		t.Fatalf("ResourceObject() diagnostics = %v", dg)
+	}
+	var fromResource Postgres
+	if dg := FromObject(ctx, obj, &fromResource); dg.HasError() {
+		t.Fatalf("FromObject(resource) diagnostics = %v", dg)
+	}
+	if !reflect.DeepEqual(fromResource, postgres) {
+		t.Errorf("FromObject(resource) = %+v, want %+v", fromResource, postgres)
+	}
+
+	obj, dg = DatasourceObject(ctx, postgres)
+	if dg.HasError() {
+		t.Fatalf("DatasourceObject() diagnostics = %v", dg)
+	}
+	if !reflect.DeepEqual(obj.AttributeTypes(ctx), postgres.DatasourceAttributeTypes()) {
+		t.Errorf("DatasourceObject() types = %v, want %v", obj.AttributeTypes(ctx), postgres.DatasourceAttributeTypes())
+	}
+	var fromDatasource Postgres
+	if dg := FromObject(ctx, obj, &fromDatasource); dg.HasError() {
+		t.Fatalf("FromObject(datasource) diagnostics = %v", dg)
+	}
+	want := Postgres{
+		Uris:                 postgres.Uris,
+		Username:             postgres.Username,
+		Password:             types.StringNull(),
+		AdditionalProperties: types.MapNull(types.StringType),
+	}
+	if !reflect.DeepEqual(fromDatasource, want) {
+		t.Errorf("FromObject(datasource) = %+v, want %+v", fromDatasource, want)
+	}
+}
+
+func TestDatasourceAttributesAreResourceAttributes(t *testing.T) {
+	for _, m := range []Model{Kafka{}, ConfluentKafka{}, Kinesis{}, Snowflake{}, Databricks{}, Postgres{}} {
+		resourceTypes := m.AttributeTypes()
+		for name, typ := range m.DatasourceAttributeTypes() {
+			if rt, ok := resourceTypes[name]; !ok || !rt.Equal(typ) {
+				t.Errorf("%T: data source attribute %s is not a resource attribute of the same type", m, name)
+			}
+		}
+	}
+}
+
+func TestDatasourceObjectUnsetResourceAttributes(t *testing.T) {
+	ctx := context.Background()
+	obj, dg := DatasourceObject(ctx, Kafka{Uris: types.StringValue("broker:9092"), TlsDisabled: types.BoolValue(false)})
+	if dg.HasError() {
+		t.Fatalf("DatasourceObject() diagnostics = %v", dg)
+	}
+	if got := obj.Attributes()["uris"]; !got.Equal(types.StringValue("broker:9092")) {
+		t.Errorf("uris = %s, want broker:9092", got)
+	}
+	if got := obj.Attributes()["schema_registry_name"]; !got.IsNull() {
+		t.Errorf("schema_registry_name = %s, want null", got)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var additionalPropertiesType = types.MapType{ElemType: types.StringType}
+
+type Kafka struct {
+	Uris                    types.String `tfsdk:"uris"`
+	SchemaRegistry          types.String `tfsdk:"schema_registry_name"`
+	SaslHashFunc            types.String `tfsdk:"sasl_hash_function"`
+	SaslUsername            types.String `tfsdk:"sasl_username"`
+	SaslPassword            types.String `tfsdk:"sasl_password"`
+	MskIamRoleArn           types.String `tfsdk:"msk_iam_role_arn"`
+	MskAwsRegion            types.String `tfsdk:"msk_aws_region"`
+	TlsDisabled             types.Bool   `tfsdk:"tls_disabled"`
+	TlsVerifyServerHostname types.Bool   `tfsdk:"tls_verify_server_hostname"`
+	TlsCaCertFile           types.String `tfsdk:"tls_ca_cert_file"`
+	CredentialsSecretArn    types.String `tfsdk:"credentials_secret_arn"`
+	ClientProperties        types.Map    `tfsdk:"client_properties"`
+	AdditionalProperties    types.Map    `tfsdk:"additional_properties"`
+
+	TopicAutoCreate        types.Bool  `tfsdk:"topic_auto_create"`
+	TopicDefaultPartitions types.Int64 `tfsdk:"topic_default_partitions"`
+	TopicDefaultReplicas   types.Int64 `tfsdk:"topic_default_replicas"`
+}
+
+func (Kafka) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":                       types.StringType,
+		"schema_registry_name":       types.StringType,
+		"sasl_hash_function":         types.StringType,
+		"sasl_username":              types.StringType,
+		"sasl_password":              types.StringType,
+		"msk_iam_role_arn":           types.StringType,
+		"msk_aws_region":             types.StringType,
+		"tls_disabled":               types.BoolType,
+		"tls_verify_server_hostname": types.BoolType,
+		"tls_ca_cert_file":           types.StringType,
+		"credentials_secret_arn":     types.StringType,
+		"client_properties":          additionalPropertiesType,
+		"additional_properties":      additionalPropertiesType,
+		"topic_auto_create":          types.BoolType,
+		"topic_default_partitions":   types.Int64Type,
+		"topic_default_replicas":     types.Int64Type,
+	}
+}
+
+func (Kafka) DatasourceAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":                       types.StringType,
+		"schema_registry_name":       types.StringType,
+		"tls_disabled":               types.BoolType,
+		"tls_verify_server_hostname": types.BoolType,
+	}
+}
+
+type ConfluentKafka struct {
+	Uris           types.String `tfsdk:"uris"`
+	SchemaRegistry types.String `tfsdk:"schema_registry_name"`
+	SaslHashFunc   types.String `tfsdk:"sasl_hash_function"`
+	SaslUsername   types.String `tfsdk:"sasl_username"`
+	SaslPassword   types.String `tfsdk:"sasl_password"`
+
+	ClusterApiKey    types.String `tfsdk:"cluster_api_key"`
+	ClusterApiSecret types.String `tfsdk:"cluster_api_secret"`
+
+	AdditionalProperties types.Map `tfsdk:"additional_properties"`
+}
+
+func (ConfluentKafka) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":                  types.StringType,
+		"schema_registry_name":  types.StringType,
+		"sasl_hash_function":    types.StringType,
+		"sasl_username":         types.StringType,
+		"sasl_password":         types.StringType,
+		"cluster_api_key":       types.StringType,
+		"cluster_api_secret":    types.StringType,
+		"additional_properties": additionalPropertiesType,
+	}
+}
+
+func (ConfluentKafka) DatasourceAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":                 types.StringType,
+		"schema_registry_name": types.StringType,
+	}
+}
+
+type Kinesis struct {
+	Uris            types.String `tfsdk:"uris"`
+	SchemaRegistry  types.String `tfsdk:"schema_registry_name"`
+	AccessKeyId     types.String `tfsdk:"access_key_id"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	RoleArn         types.String `tfsdk:"role_arn"`
+	ExternalId      types.String `tfsdk:"external_id"`
+
+	StreamMode     types.String `tfsdk:"stream_mode"`
+	EnhancedFanOut types.Bool   `tfsdk:"enhanced_fan_out"`
+
+	AdditionalProperties types.Map `tfsdk:"additional_properties"`
+}
+
+func (Kinesis) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":                  types.StringType,
+		"schema_registry_name":  types.StringType,
+		"access_key_id":         types.StringType,
+		"secret_access_key":     types.StringType,
+		"role_arn":              types.StringType,
+		"external_id":           types.StringType,
+		"stream_mode":           types.StringType,
+		"enhanced_fan_out":      types.BoolType,
+		"additional_properties": additionalPropertiesType,
+	}
+}
+
+func (Kinesis) DatasourceAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":                 types.StringType,
+		"schema_registry_name": types.StringType,
+	}
+}
+
+type Snowflake struct {
+	Uris                types.String `tfsdk:"uris"`
+	AccountId           types.String `tfsdk:"account_id"`
+	CloudRegion         types.String `tfsdk:"cloud_region"`
+	WarehouseName       types.String `tfsdk:"warehouse_name"`
+	RoleName            types.String `tfsdk:"role_name"`
+	Username            types.String `tfsdk:"username"`
+	ClientKeyFile       types.String `tfsdk:"client_key_file"`
+	ClientKeyPassphrase types.String `tfsdk:"client_key_passphrase"`
+	OAuth               types.Object `tfsdk:"oauth"`
+
+	CredentialsSecretArn types.String `tfsdk:"credentials_secret_arn"`
+
+	AdditionalProperties types.Map `tfsdk:"additional_properties"`
+}
+
+func (Snowflake) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":                   types.StringType,
+		"account_id":             types.StringType,
+		"cloud_region":           types.StringType,
+		"warehouse_name":         types.StringType,
+		"role_name":              types.StringType,
+		"username":               types.StringType,
+		"client_key_file":        types.StringType,
+		"client_key_passphrase":  types.StringType,
+		"oauth":                  types.ObjectType{AttrTypes: SnowflakeOAuth{}.AttributeTypes()},
+		"credentials_secret_arn": types.StringType,
+		"additional_properties":  additionalPropertiesType,
+	}
+}
+
+func (Snowflake) DatasourceAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":           types.StringType,
+		"account_id":     types.StringType,
+		"warehouse_name": types.StringType,
+		"role_name":      types.StringType,
+	}
+}
+
+type SnowflakeOAuth struct {
+	ClientId      types.String `tfsdk:"client_id"`
+	ClientSecret  types.String `tfsdk:"client_secret"`
+	TokenEndpoint types.String `tfsdk:"token_endpoint"`
+}
+
+func (SnowflakeOAuth) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"client_id":      types.StringType,
+		"client_secret":  types.StringType,
+		"token_endpoint": types.StringType,
+	}
+}
+
+type Databricks struct {
+	Uris            types.String `tfsdk:"uris"`
+	AppToken        types.String `tfsdk:"app_token"`
+	WarehouseId     types.String `tfsdk:"warehouse_id"`
+	AccessKeyId     types.String `tfsdk:"access_key_id"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	RoleArn         types.String `tfsdk:"role_arn"`
+	ExternalId      types.String `tfsdk:"external_id"`
+	CloudS3Bucket   types.String `tfsdk:"cloud_s3_bucket"`
+	CloudRegion     types.String `tfsdk:"cloud_region"`
+
+	AdditionalProperties types.Map `tfsdk:"additional_properties"`
+}
+
+func (Databricks) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":                  types.StringType,
+		"app_token":             types.StringType,
+		"warehouse_id":          types.StringType,
+		"access_key_id":         types.StringType,
+		"secret_access_key":     types.StringType,
+		"role_arn":              types.StringType,
+		"external_id":           types.StringType,
+		"cloud_s3_bucket":       types.StringType,
+		"cloud_region":          types.StringType,
+		"additional_properties": additionalPropertiesType,
+	}
+}
+
+func (Databricks) DatasourceAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":            types.StringType,
+		"warehouse_id":    types.StringType,
+		"cloud_s3_bucket": types.StringType,
+		"cloud_region":    types.StringType,
+	}
+}
+
+type Postgres struct {
+	Uris     types.String `tfsdk:"uris"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+
+	CredentialsSecretArn types.String `tfsdk:"credentials_secret_arn"`
+
+	AdditionalProperties types.Map `tfsdk:"additional_properties"`
+}
+
+func (Postgres) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":                   types.StringType,
+		"username":               types.StringType,
+		"password":               types.StringType,
+		"credentials_secret_arn": types.StringType,
+		"additional_properties":  additionalPropertiesType,
+	}
+}
+
+func (Postgres) DatasourceAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"uris":     types.StringType,
+		"username": types.StringType,
+	}
+}
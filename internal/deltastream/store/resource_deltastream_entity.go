@@ -8,17 +8,21 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"text/template"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -29,6 +33,8 @@ import (
 
 var _ resource.Resource = &EntityResource{}
 var _ resource.ResourceWithConfigure = &EntityResource{}
+var _ resource.ResourceWithImportState = &EntityResource{}
+var _ resource.ResourceWithModifyPlan = &EntityResource{}
 
 func NewEntityResource() resource.Resource {
 	return &EntityResource{}
@@ -39,6 +45,7 @@ type EntityResource struct {
 }
 
 type EntityResourceData struct {
+	ID                   types.String `tfsdk:"id"`
 	Store                types.String `tfsdk:"store"`
 	EntityPath           types.List   `tfsdk:"entity_path"`
 	KafkaProperties      types.Object `tfsdk:"kafka_properties"`
@@ -46,6 +53,10 @@ type EntityResourceData struct {
 	DatabricksProperties types.Object `tfsdk:"databricks_properties"`
 	SnowflakeProperties  types.Object `tfsdk:"snowflake_properties"`
 	PostgresProperties   types.Object `tfsdk:"postgres_properties"`
+
+	StatementID types.String `tfsdk:"statement_id"`
+
+	Comment types.String `tfsdk:"comment"`
 }
 
 type KafkaStoreEntityResourceData struct {
@@ -55,6 +66,10 @@ type KafkaStoreEntityResourceData struct {
 	ValueDescriptor types.String `tfsdk:"value_descriptor"`
 	Configs         types.Map    `tfsdk:"configs"`
 	AllConfigs      types.Map    `tfsdk:"all_configs"`
+
+	KeyFormat           types.String `tfsdk:"key_format"`
+	ValueFormat         types.String `tfsdk:"value_format"`
+	SubjectNameStrategy types.String `tfsdk:"subject_name_strategy"`
 }
 
 func (KafkaStoreEntityResourceData) AttributeTypes() map[string]attr.Type {
@@ -69,18 +84,28 @@ func (KafkaStoreEntityResourceData) AttributeTypes() map[string]attr.Type {
 		"all_configs": types.MapType{
 			ElemType: types.StringType,
 		},
+		"key_format":            types.StringType,
+		"value_format":          types.StringType,
+		"subject_name_strategy": types.StringType,
 	}
 }
 
 type KinesisStoreEntityResourceData struct {
 	KinesisShards types.Int64  `tfsdk:"kinesis_shards"`
 	Descriptor    types.String `tfsdk:"descriptor"`
+
+	StreamMode                 types.String `tfsdk:"stream_mode"`
+	EnhancedFanOut             types.Bool   `tfsdk:"enhanced_fan_out"`
+	EnhancedFanOutConsumerName types.String `tfsdk:"enhanced_fan_out_consumer_name"`
 }
 
 func (KinesisStoreEntityResourceData) AttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"kinesis_shards": types.Int64Type,
-		"descriptor":     types.StringType,
+		"kinesis_shards":                 types.Int64Type,
+		"descriptor":                     types.StringType,
+		"stream_mode":                    types.StringType,
+		"enhanced_fan_out":               types.BoolType,
+		"enhanced_fan_out_consumer_name": types.StringType,
 	}
 }
 
@@ -120,30 +145,58 @@ func (PostgresStoreEntityResourceData) AttributeTypes() map[string]attr.Type {
 	}
 }
 
+// entityFormats are the serialization formats that can be set for the keys and values of an entity.
+var entityFormats = []string{"json", "avro", "protobuf", "primitive"}
+
 func (d *EntityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Database resource",
 
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Entity",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"store": schema.StringAttribute{
 				Description: "Store name",
 				Required:    true,
 				Validators:  util.IdentifierValidators,
 			},
 			"entity_path": schema.ListAttribute{
-				Description: "Entity path",
+				Description: "Entity path. Snowflake and Databricks stores only create databases (catalogs) and schemas, entities cannot be created in Postgres stores",
 				Required:    true,
 				ElementType: types.StringType,
 			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the Entity, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "Comment describing the Entity, only supported for the databases, catalogs and schemas of Snowflake and Databricks stores. Changing the comment updates the Entity in place",
+				Optional:    true,
+			},
 			"kafka_properties": schema.SingleNestedAttribute{
 				Description: "Kafka properties",
 				Attributes: map[string]schema.Attribute{
 					"topic_partitions": schema.Int64Attribute{
-						Description: "Number of partitions",
+						Description: "Number of partitions. Increasing the number of partitions updates the topic in place, decreasing it replaces the topic",
 						Optional:    true,
 						Computed:    true,
 						PlanModifiers: []planmodifier.Int64{
-							int64planmodifier.RequiresReplace(),
+							int64planmodifier.RequiresReplaceIf(
+								func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+									resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsNull() && !req.PlanValue.IsUnknown() &&
+										req.PlanValue.ValueInt64() < req.StateValue.ValueInt64()
+								},
+								"Partitions cannot be removed from a topic, decreasing topic_partitions requires replacement",
+								"Partitions cannot be removed from a topic, decreasing `topic_partitions` requires replacement",
+							),
 						},
 					},
 					"topic_replicas": schema.Int64Attribute{
@@ -165,12 +218,13 @@ func (d *EntityResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Computed:    true,
 					},
 					"configs": schema.MapAttribute{
-						Description: "Additional topic configurations",
+						Description: "Additional topic configurations. Values are compared the way the broker normalizes them, ignoring case and the spacing of lists, and refreshed from all_configs when they drift",
 						Optional:    true,
 						Computed:    true,
 						ElementType: types.StringType,
 						PlanModifiers: []planmodifier.Map{
-							mapplanmodifier.RequiresReplace(),
+							normalizedConfigs{},
+							configsRequireReplace(),
 						},
 					},
 					"all_configs": schema.MapAttribute{
@@ -178,6 +232,36 @@ func (d *EntityResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Computed:    true,
 						ElementType: types.StringType,
 					},
+					"key_format": schema.StringAttribute{
+						Description: "Serialization format of the record keys, one of json, avro, protobuf or primitive",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOfCaseInsensitive(entityFormats...),
+						},
+						PlanModifiers: []planmodifier.String{
+							util.RequiresReplaceUnlessImported(),
+						},
+					},
+					"value_format": schema.StringAttribute{
+						Description: "Serialization format of the record values, one of json, avro, protobuf or primitive",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOfCaseInsensitive(entityFormats...),
+						},
+						PlanModifiers: []planmodifier.String{
+							util.RequiresReplaceUnlessImported(),
+						},
+					},
+					"subject_name_strategy": schema.StringAttribute{
+						Description: "Schema registry subject naming strategy, one of TopicNameStrategy, RecordNameStrategy or TopicRecordNameStrategy",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("TopicNameStrategy", "RecordNameStrategy", "TopicRecordNameStrategy"),
+						},
+						PlanModifiers: []planmodifier.String{
+							util.RequiresReplaceUnlessImported(),
+						},
+					},
 				},
 				Optional: true,
 				Computed: true,
@@ -195,6 +279,33 @@ func (d *EntityResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Optional:    true,
 						Computed:    true,
 					},
+					"stream_mode": schema.StringAttribute{
+						Description: "Capacity mode of the Kinesis data stream, defaults to the stream mode of the store. One of " + strings.Join(kinesisStreamModes, ", "),
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(kinesisStreamModes...),
+						},
+						PlanModifiers: []planmodifier.String{
+							util.RequiresReplaceUnlessImported(),
+						},
+					},
+					"enhanced_fan_out": schema.BoolAttribute{
+						Description: "Whether queries read the Kinesis data stream through an enhanced fan-out consumer, defaults to the setting of the store",
+						Optional:    true,
+						PlanModifiers: []planmodifier.Bool{
+							util.BoolRequiresReplaceUnlessImported(),
+						},
+					},
+					"enhanced_fan_out_consumer_name": schema.StringAttribute{
+						Description: "Name of the enhanced fan-out consumer registered on the Kinesis data stream. Generated by DeltaStream when not set",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("enhanced_fan_out")),
+						},
+						PlanModifiers: []planmodifier.String{
+							util.RequiresReplaceUnlessImported(),
+						},
+					},
 				},
 				Optional: true,
 				Computed: true,
@@ -255,6 +366,41 @@ func (d *EntityResource) Metadata(ctx context.Context, req resource.MetadataRequ
 	resp.TypeName = req.ProviderTypeName + "_entity"
 }
 
+// ModifyPlan rejects entities the store cannot hold while planning their creation. The check is left to Create when
+// the store type cannot be determined yet, such as for a store created in the same apply.
+func (d *EntityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil || req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() {
+		return
+	}
+
+	var entity EntityResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &entity)...)
+	if resp.Diagnostics.HasError() || entity.Store.IsUnknown() || entity.EntityPath.IsUnknown() {
+		return
+	}
+
+	components := []types.String{}
+	resp.Diagnostics.Append(entity.EntityPath.ElementsAs(ctx, &components, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entityPath := make([]string, 0, len(components))
+	for _, c := range components {
+		if c.IsUnknown() {
+			return
+		}
+		entityPath = append(entityPath, c.ValueString())
+	}
+
+	storeType, ok := plannedStoreType(ctx, d.cfg, d.cfg.ObjectName(entity.Store.ValueString()))
+	if !ok {
+		return
+	}
+	if attribute, err := checkEntityCreate(storeType, entity, entityPath); err != nil {
+		resp.Diagnostics.AddAttributeError(attribute, "unsupported entity", err.Error())
+	}
+}
+
 var createEntityStatement = `
 	CREATE ENTITY {{ range $index, $element := .EntityPath -}}
         {{- if $index}}.{{end -}}
@@ -289,17 +435,31 @@ func (d *EntityResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	storeType, err := getStoreType(ctx, conn, entity.Store.ValueString())
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "entity", strings.Join(append([]string{entity.Store.ValueString()}, entityPath...), "/"))...)
+		return
+	}
+
+	if err := util.WaitForStoreReady(ctx, conn, d.cfg.ObjectName(entity.Store.ValueString())); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "store not ready", err)
+		return
+	}
+
+	storeType, err := getStoreType(ctx, d.cfg, conn, d.cfg.ObjectName(entity.Store.ValueString()))
 	if err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "invalid store type", err)
 		return
 	}
+	if attribute, err := checkEntityCreate(storeType, entity, entityPath); err != nil {
+		resp.Diagnostics.AddAttributeError(attribute, "unsupported entity", err.Error())
+		return
+	}
 
 	properties := []string{}
 	switch storeType {
 	case "Kafka":
 		fallthrough
-	case "ConfluentKakfa":
+	case "ConfluentKafka":
 		var kafkaProperties KafkaStoreEntityResourceData
 		if !entity.KafkaProperties.IsNull() && !entity.KafkaProperties.IsUnknown() {
 			resp.Diagnostics.Append(entity.KafkaProperties.As(ctx, &kafkaProperties, basetypes.ObjectAsOptions{})...)
@@ -314,10 +474,20 @@ func (d *EntityResource) Create(ctx context.Context, req resource.CreateRequest,
 			properties = append(properties, fmt.Sprintf("'kafka.replicas' = %d", kafkaProperties.TopicReplicas.ValueInt64()))
 		}
 
-		if !kafkaProperties.Configs.IsNull() {
-			configProps := kafkaProperties.Configs.Elements()
-			for k, v := range configProps {
-				properties = append(properties, fmt.Sprintf("'kafka.topic.%s' = '%s'", k, v.(*types.String).ValueString()))
+		if configs, ok := stringMap(kafkaProperties.Configs); ok {
+			properties = append(properties, kafkaConfigProperties(configs)...)
+		}
+
+		for _, p := range []struct {
+			name  string
+			value types.String
+		}{
+			{"key.format", kafkaProperties.KeyFormat},
+			{"value.format", kafkaProperties.ValueFormat},
+			{"subject.name.strategy", kafkaProperties.SubjectNameStrategy},
+		} {
+			if !p.value.IsNull() && !p.value.IsUnknown() {
+				properties = append(properties, fmt.Sprintf("'%s' = '%s'", p.name, p.value.ValueString()))
 			}
 		}
 	case "Kinesis":
@@ -331,29 +501,44 @@ func (d *EntityResource) Create(ctx context.Context, req resource.CreateRequest,
 		if !kinesisProperties.KinesisShards.IsNull() && !kinesisProperties.KinesisShards.IsUnknown() {
 			properties = append(properties, fmt.Sprintf("'kinesis.shards' = %d", kinesisProperties.KinesisShards.ValueInt64()))
 		}
+		properties = append(properties, kinesisStreamProperties(kinesisProperties)...)
+	}
+	if !entity.Comment.IsNull() && !entity.Comment.IsUnknown() {
+		properties = append(properties, commentProperty(entity.Comment.ValueString()))
 	}
 
 	b := bytes.NewBuffer(nil)
 	template.Must(template.New("").Parse(createEntityStatement)).Execute(b, map[string]any{
-		"StoreName":  entity.Store.ValueString(),
+		"StoreName":  d.cfg.ObjectName(entity.Store.ValueString()),
 		"EntityPath": entityPath,
 		"Properties": strings.Join(properties, ", "),
 	})
 	sql := b.String()
-	if _, err := conn.ExecContext(ctx, sql); err != nil {
+	createCtx, statement := util.WithStatementRecorder(ctx)
+	if _, err := conn.ExecContext(createCtx, sql); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create entity", err)
 		return
 	}
+	entity.StatementID = statement.ID()
 
-	resp.Diagnostics.Append(d.updateComputed(ctx, &entity)...)
+	_, dg := d.updateComputed(ctx, &entity)
+	resp.Diagnostics.Append(dg...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Info(ctx, "Entity created", map[string]any{"store": entity.Store.String(), "name": entity.EntityPath.String()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_entity", entityFQN(ctx, entity))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, entity)...)
 }
 
+// entityFQN names an entity by its store followed by the components of its path, separated by dots.
+func entityFQN(ctx context.Context, entity EntityResourceData) string {
+	var entityPath []string
+	entity.EntityPath.ElementsAs(ctx, &entityPath, false)
+	return strings.Join(append([]string{entity.Store.ValueString()}, entityPath...), ".")
+}
+
 const dropEntityStatement = `DROP ENTITY 	
 	{{ range $index, $element := .EntityPath }}
 		{{ if $index }}.{{ end }}
@@ -370,6 +555,11 @@ func (d *EntityResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "entity", entityFQN(ctx, entity))...)
+		return
+	}
+
 	roleName := d.cfg.Role
 	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
 	if err != nil {
@@ -386,18 +576,150 @@ func (d *EntityResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	b := bytes.NewBuffer(nil)
 	template.Must(template.New("").Parse(dropEntityStatement)).Execute(b, map[string]any{
-		"StoreName":  entity.Store.ValueString(),
+		"StoreName":  d.cfg.ObjectName(entity.Store.ValueString()),
 		"EntityPath": entityPath,
 	})
-	if _, err := conn.ExecContext(ctx, b.String()); err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create database", err)
+	describe := fmt.Sprintf(`DESCRIBE ENTITY %s IN STORE "%s";`, strings.Join(entityPath, "."), d.cfg.ObjectName(entity.Store.ValueString()))
+	if err := util.DropAndWait(ctx, conn, "entity", b.String(), func(ctx context.Context) error {
+		found := false
+		if err := util.QueryRows(ctx, conn, describe, func(rows *sql.Rows) error {
+			found = true
+			return util.ErrStopRows
+		}); err != nil {
+			return err
+		}
+		if !found {
+			return sql.ErrNoRows
+		}
+		return nil
+	}); err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to delete entity", err)
 		return
 	}
 	tflog.Info(ctx, "Entity deleted", map[string]any{"store": entity.Store.String(), "name": entity.EntityPath.String()})
 }
 
+const updateEntityStatement = `UPDATE ENTITY {{ range $index, $element := .EntityPath -}}
+        {{- if $index}}.{{end -}}
+        "{{- $element}}"
+    {{- end }} IN STORE "{{ .StoreName }}" WITH ( {{ .Properties }} );`
+
 func (d *EntityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("store entity update not supported"))
+	var currentEntity EntityResourceData
+	var newEntity EntityResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &newEntity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentEntity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "entity", entityFQN(ctx, currentEntity))...)
+		return
+	}
+
+	// only increasing the number of topic partitions and changing the comment are supported in place
+	if !newEntity.Store.Equal(currentEntity.Store) || !newEntity.EntityPath.Equal(currentEntity.EntityPath) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("store and entity path cannot be changed"))
+		return
+	}
+
+	var currentKafka KafkaStoreEntityResourceData
+	var newKafka KafkaStoreEntityResourceData
+	if !currentEntity.KafkaProperties.IsNull() && !currentEntity.KafkaProperties.IsUnknown() {
+		resp.Diagnostics.Append(currentEntity.KafkaProperties.As(ctx, &currentKafka, basetypes.ObjectAsOptions{})...)
+	}
+	if !newEntity.KafkaProperties.IsNull() && !newEntity.KafkaProperties.IsUnknown() {
+		resp.Diagnostics.Append(newEntity.KafkaProperties.As(ctx, &newKafka, basetypes.ObjectAsOptions{})...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, v := range [][2]types.String{
+		{newKafka.KeyDescriptor, currentKafka.KeyDescriptor},
+		{newKafka.ValueDescriptor, currentKafka.ValueDescriptor},
+	} {
+		if !v[0].IsUnknown() && !v[0].IsNull() && !v[0].Equal(v[1]) {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("store entity update not supported, only topic_partitions can be increased and comment changed in place"))
+			return
+		}
+	}
+
+	properties := []string{}
+	if !newKafka.TopicPartitions.IsNull() && !newKafka.TopicPartitions.IsUnknown() && newKafka.TopicPartitions.ValueInt64() > currentKafka.TopicPartitions.ValueInt64() {
+		properties = append(properties, fmt.Sprintf("'kafka.partitions' = %d", newKafka.TopicPartitions.ValueInt64()))
+	}
+	commentChanged := !newEntity.Comment.Equal(currentEntity.Comment)
+	imported := util.Imported(ctx, req.Private)
+	if len(properties) == 0 && !commentChanged && !imported {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("store entity update not supported, only topic_partitions can be increased and comment changed in place"))
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	defer conn.Close()
+
+	if commentChanged {
+		storeType, err := getStoreType(ctx, d.cfg, conn, d.cfg.ObjectName(currentEntity.Store.ValueString()))
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "invalid store type", err)
+			return
+		}
+		if !entityCommentsSupported(storeType) {
+			resp.Diagnostics.AddAttributeError(path.Root("comment"), "unsupported entity", fmt.Sprintf("comment cannot be set for an entity in a %s store", storeType))
+			return
+		}
+		properties = append(properties, commentProperty(newEntity.Comment.ValueString()))
+	}
+
+	entityPath := []string{}
+	resp.Diagnostics.Append(currentEntity.EntityPath.ElementsAs(ctx, &entityPath, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(properties) > 0 {
+		b := bytes.NewBuffer(nil)
+		template.Must(template.New("").Parse(updateEntityStatement)).Execute(b, map[string]any{
+			"StoreName":  d.cfg.ObjectName(currentEntity.Store.ValueString()),
+			"EntityPath": entityPath,
+			"Properties": strings.Join(properties, ", "),
+		})
+		if _, err := conn.ExecContext(ctx, b.String()); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update entity", err)
+			return
+		}
+	}
+
+	currentEntity.Comment = newEntity.Comment
+	if imported {
+		// the first update of an imported entity records the settings DESCRIBE ENTITY does not report
+		currentEntity.KafkaProperties = newEntity.KafkaProperties
+		currentEntity.KinesisProperties = newEntity.KinesisProperties
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, util.ImportedKey, nil)...)
+	}
+	_, dg := d.updateComputed(ctx, &currentEntity)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Entity updated", map[string]any{
+		"store":      currentEntity.Store.String(),
+		"name":       currentEntity.EntityPath.String(),
+		"properties": properties,
+	})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_entity", entityFQN(ctx, currentEntity))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, currentEntity)...)
 }
 
 func (d *EntityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -409,7 +731,13 @@ func (d *EntityResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	resp.Diagnostics.Append(d.updateComputed(ctx, &entity)...)
+	notFound, dg := d.updateComputed(ctx, &entity)
+	if notFound {
+		tflog.Info(ctx, "entity not found, removing from state", map[string]any{"id": entity.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(dg...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -417,7 +745,9 @@ func (d *EntityResource) Read(ctx context.Context, req resource.ReadRequest, res
 	resp.Diagnostics.Append(resp.State.Set(ctx, entity)...)
 }
 
-func (d *EntityResource) updateComputed(ctx context.Context, entity *EntityResourceData) (diags diag.Diagnostics) {
+// updateComputed refreshes the computed attributes of an entity. notFound is set when the entity or its store no
+// longer exists.
+func (d *EntityResource) updateComputed(ctx context.Context, entity *EntityResourceData) (notFound bool, diags diag.Diagnostics) {
 	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
 	if err != nil {
 		diags.AddError("failed to connect", err.Error())
@@ -431,20 +761,29 @@ func (d *EntityResource) updateComputed(ctx context.Context, entity *EntityResou
 		return
 	}
 
-	storeType, err := getStoreType(ctx, conn, entity.Store.ValueString())
+	storeType, err := getStoreType(ctx, d.cfg, conn, d.cfg.ObjectName(entity.Store.ValueString()))
 	if err != nil {
+		notFound = util.IsNotFound("store", err)
 		diags.AddError(err.Error(), "")
 		return
 	}
+	entity.ID = util.ResourceID(d.cfg.Organization, "entity", append([]string{entity.Store.ValueString()}, entityPath...)...)
 
-	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`DESCRIBE ENTITY %s IN STORE "%s";`, strings.Join(entityPath, "."), entity.Store.ValueString()))
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`DESCRIBE ENTITY %s IN STORE "%s";`, strings.Join(entityPath, "."), d.cfg.ObjectName(entity.Store.ValueString())))
 	if err != nil {
+		notFound = util.IsNotFound("entity", err)
 		diags.AddError("failed to describe entity", err.Error())
 		return
 	}
 	defer rows.Close()
 
 	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			notFound = util.IsNotFound("entity", err)
+			diags.AddError("failed to describe entity", err.Error())
+			return
+		}
+		notFound = true
 		diags.AddError("entity not found", "")
 		return
 	}
@@ -481,6 +820,9 @@ func (d *EntityResource) updateComputed(ctx context.Context, entity *EntityResou
 			diags.AddError("failed to read entity configuration", err.Error())
 			return
 		}
+		if configs, ok := stringMap(kafkaProperties.Configs); ok {
+			kafkaProperties.Configs = stringMapValue(refreshConfigs(configs, configsOut))
+		}
 		var d diag.Diagnostics
 		kafkaProperties.AllConfigs, d = types.MapValueFrom(ctx, types.StringType, configsOut)
 		diags.Append(d...)
@@ -492,7 +834,7 @@ func (d *EntityResource) updateComputed(ctx context.Context, entity *EntityResou
 		if diags.HasError() {
 			return
 		}
-	case "Kinisis":
+	case "Kinesis":
 		var discard any
 		var topicShards int64
 		var descriptor string
@@ -563,7 +905,7 @@ func (d *EntityResource) updateComputed(ctx context.Context, entity *EntityResou
 		if diags.HasError() {
 			return
 		}
-		entity.SnowflakeProperties, d = types.ObjectValueFrom(ctx, databricksProperties.AttributeTypes(), databricksProperties)
+		entity.DatabricksProperties, d = types.ObjectValueFrom(ctx, databricksProperties.AttributeTypes(), databricksProperties)
 		diags.Append(d...)
 		if diags.HasError() {
 			return
@@ -587,7 +929,7 @@ func (d *EntityResource) updateComputed(ctx context.Context, entity *EntityResou
 		if diags.HasError() {
 			return
 		}
-		entity.SnowflakeProperties, d = types.ObjectValueFrom(ctx, postgresProperties.AttributeTypes(), postgresProperties)
+		entity.PostgresProperties, d = types.ObjectValueFrom(ctx, postgresProperties.AttributeTypes(), postgresProperties)
 		diags.Append(d...)
 		if diags.HasError() {
 			return
@@ -613,24 +955,128 @@ func (d *EntityResource) updateComputed(ctx context.Context, entity *EntityResou
 	return
 }
 
-func getStoreType(ctx context.Context, conn *sql.Conn, storeName string) (string, error) {
-	row := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT type FROM deltastream.sys."stores" WHERE name = '%s';`, storeName))
-	if row.Err() != nil {
-		if row.Err() == sql.ErrNoRows {
-			return "", fmt.Errorf("store not found: %s", storeName)
+// commentProperty returns the WITH clause entry setting the comment of an entity, an empty comment clears it.
+func commentProperty(comment string) string {
+	return fmt.Sprintf("'comment' = '%s'", strings.ReplaceAll(comment, "'", "''"))
+}
+
+// checkEntityCreate returns an error, along with the attribute it concerns, when the entity cannot be created in a
+// store of the given type. Topics and data streams take the properties of their own store type only, relational
+// stores only create databases and schemas, their tables are created by queries.
+func checkEntityCreate(storeType string, entity EntityResourceData, entityPath []string) (path.Path, error) {
+	set := func(obj types.Object) bool { return !obj.IsNull() && !obj.IsUnknown() }
+	others := map[string]types.Object{
+		"kafka_properties":      entity.KafkaProperties,
+		"kinesis_properties":    entity.KinesisProperties,
+		"snowflake_properties":  entity.SnowflakeProperties,
+		"databricks_properties": entity.DatabricksProperties,
+		"postgres_properties":   entity.PostgresProperties,
+	}
+
+	var own string
+	switch {
+	case isKafkaStoreType(storeType):
+		own = "kafka_properties"
+	case strings.EqualFold(storeType, "kinesis"):
+		own = "kinesis_properties"
+	case strings.EqualFold(storeType, "snowflake"), strings.EqualFold(storeType, "databricks"):
+		own = strings.ToLower(storeType) + "_properties"
+		container := "database"
+		if strings.EqualFold(storeType, "databricks") {
+			container = "catalog"
+		}
+		if len(entityPath) > 2 {
+			return path.Root("entity_path"), fmt.Errorf("%s stores only support creating a %s or a schema, entity_path must have at most 2 components, got %d. Tables are created by queries", storeType, container, len(entityPath))
+		}
+	case strings.EqualFold(storeType, "postgres"):
+		return path.Root("store"), fmt.Errorf("entities cannot be created in Postgres stores, %s is a Postgres store. import existing tables with terraform import or look them up with the deltastream_entities data source", entity.Store.ValueString())
+	default:
+		return path.Root("store"), fmt.Errorf("entities cannot be created in %s stores", storeType)
+	}
+
+	for _, name := range sortedKeys(others) {
+		if name != own && set(others[name]) {
+			return path.Root(name), fmt.Errorf("%s cannot be set for an entity in a %s store", name, storeType)
+		}
+	}
+	if !entity.Comment.IsNull() && !entityCommentsSupported(storeType) {
+		return path.Root("comment"), fmt.Errorf("comment cannot be set for an entity in a %s store", storeType)
+	}
+	return path.Empty(), nil
+}
+
+// entityCommentsSupported reports whether the entities of a store type can have a comment. Snowflake and Databricks
+// databases, catalogs and schemas have comments, Kafka topics and Kinesis streams do not.
+func entityCommentsSupported(storeType string) bool {
+	return strings.EqualFold(storeType, "snowflake") || strings.EqualFold(storeType, "databricks")
+}
+
+// kinesisStreamProperties returns the WITH clause entries of the stream mode and enhanced fan-out settings of a
+// Kinesis entity.
+func kinesisStreamProperties(p KinesisStoreEntityResourceData) []string {
+	properties := []string{}
+	if !p.StreamMode.IsNull() && !p.StreamMode.IsUnknown() {
+		properties = append(properties, fmt.Sprintf("'kinesis.stream_mode' = '%s'", p.StreamMode.ValueString()))
+	}
+	if !p.EnhancedFanOut.IsNull() && !p.EnhancedFanOut.IsUnknown() {
+		enabled := "FALSE"
+		if p.EnhancedFanOut.ValueBool() {
+			enabled = "TRUE"
 		}
+		properties = append(properties, fmt.Sprintf("'kinesis.enhanced_fan_out' = %s", enabled))
+	}
+	if !p.EnhancedFanOutConsumerName.IsNull() && !p.EnhancedFanOutConsumerName.IsUnknown() {
+		properties = append(properties, fmt.Sprintf("'kinesis.enhanced_fan_out.consumer_name' = '%s'", strings.ReplaceAll(p.EnhancedFanOutConsumerName.ValueString(), "'", "''")))
+	}
+	return properties
+}
 
+// getStoreType returns the type of a store, using the provider's cache when the store was already looked up in
+// this run.
+func getStoreType(ctx context.Context, cfg *config.DeltaStreamProviderCfg, conn *sql.Conn, storeName string) (string, error) {
+	if kind, ok := cfg.StoreType(storeName); ok {
+		return kind, nil
+	}
+
+	row := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT type FROM deltastream.sys."stores" WHERE name = '%s';`, storeName))
+	if row.Err() != nil {
 		return "", fmt.Errorf("failed to read store: %w", row.Err())
 	}
 
 	var kind string
 	if err := row.Scan(&kind); err != nil {
-		return "", fmt.Errorf("failed to read store: %w", row.Err())
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("store not found: %s: %w", storeName, err)
+		}
+		return "", fmt.Errorf("failed to read store: %w", err)
 	}
 
+	cfg.SetStoreType(storeName, kind)
 	return kind, nil
 }
 
+// plannedStoreType returns the type of a store while planning. ok is false when the type cannot be determined, such as
+// for a store created in the same apply, the checks relying on it then run when the resource is applied.
+func plannedStoreType(ctx context.Context, cfg *config.DeltaStreamProviderCfg, storeName string) (storeType string, ok bool) {
+	if kind, ok := cfg.StoreType(storeName); ok {
+		return kind, true
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, cfg.Db, cfg.SessionID, cfg.Organization, cfg.Role)
+	if err != nil {
+		tflog.Debug(ctx, "unable to determine store type while planning", map[string]any{"store": storeName, "error": err.Error()})
+		return "", false
+	}
+	defer conn.Close()
+
+	storeType, err = getStoreType(ctx, cfg, conn, storeName)
+	if err != nil {
+		tflog.Debug(ctx, "unable to determine store type while planning", map[string]any{"store": storeName, "error": err.Error()})
+		return "", false
+	}
+	return storeType, true
+}
+
 func rowsToMap(rows *sql.Rows) (map[string]string, error) {
 	cols, err := rows.Columns()
 	if err != nil {
@@ -0,0 +1,813 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ resource.Resource = &EntitySetResource{}
+var _ resource.ResourceWithConfigure = &EntitySetResource{}
+var _ resource.ResourceWithModifyPlan = &EntitySetResource{}
+
+// defaultEntitySetConcurrency is the number of workers used when concurrency is not set.
+const defaultEntitySetConcurrency = 4
+
+// entitySetBatchSize is the number of entities a worker handles over a single connection.
+const entitySetBatchSize = 25
+
+func NewEntitySetResource() resource.Resource {
+	return &EntitySetResource{}
+}
+
+type EntitySetResource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+type EntitySetResourceData struct {
+	ID          types.String `tfsdk:"id"`
+	Store       types.String `tfsdk:"store"`
+	Concurrency types.Int64  `tfsdk:"concurrency"`
+	Entities    types.Map    `tfsdk:"entities"`
+}
+
+type EntitySetItemData struct {
+	ID                  types.String `tfsdk:"id"`
+	TopicPartitions     types.Int64  `tfsdk:"topic_partitions"`
+	TopicReplicas       types.Int64  `tfsdk:"topic_replicas"`
+	Configs             types.Map    `tfsdk:"configs"`
+	KeyFormat           types.String `tfsdk:"key_format"`
+	ValueFormat         types.String `tfsdk:"value_format"`
+	SubjectNameStrategy types.String `tfsdk:"subject_name_strategy"`
+	KinesisShards       types.Int64  `tfsdk:"kinesis_shards"`
+}
+
+func (EntitySetItemData) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":               types.StringType,
+		"topic_partitions": types.Int64Type,
+		"topic_replicas":   types.Int64Type,
+		"configs": types.MapType{
+			ElemType: types.StringType,
+		},
+		"key_format":            types.StringType,
+		"value_format":          types.StringType,
+		"subject_name_strategy": types.StringType,
+		"kinesis_shards":        types.Int64Type,
+	}
+}
+
+func (d *EntitySetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages many top level entities of a Kafka or Kinesis store as a single resource. The store type is " +
+			"looked up once for the whole set, entities are then created, described and dropped by concurrent workers, each " +
+			"worker running the statements of a batch of entities over a single connection. Every entity still takes its own " +
+			"CREATE ENTITY statement. Entities that fail to be created are reported as warnings and left out of the state, the " +
+			"next plan adds them again without replacing the entities that were created. The apply only fails when none of the " +
+			"entities could be created.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the entity set",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"store": schema.StringAttribute{
+				Description: "Store name",
+				Required:    true,
+				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"concurrency": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of entities processed in parallel batches, defaults to %d", defaultEntitySetConcurrency),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32),
+				},
+			},
+			"entities": schema.MapNestedAttribute{
+				Description: "Entities of the set keyed by entity name, such as the topic name of a Kafka store",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Global identifier of the entity",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"topic_partitions": schema.Int64Attribute{
+							Description: "Number of partitions of a Kafka topic. Only increasing the number of partitions is supported in place",
+							Optional:    true,
+							Computed:    true,
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.UseStateForUnknown(),
+							},
+						},
+						"topic_replicas": schema.Int64Attribute{
+							Description: "Number of replicas of a Kafka topic",
+							Optional:    true,
+							Computed:    true,
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.UseStateForUnknown(),
+							},
+						},
+						"configs": schema.MapAttribute{
+							Description: "Additional topic configurations",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"key_format": schema.StringAttribute{
+							Description: "Serialization format of the record keys, one of json, avro, protobuf or primitive",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOfCaseInsensitive(entityFormats...),
+							},
+						},
+						"value_format": schema.StringAttribute{
+							Description: "Serialization format of the record values, one of json, avro, protobuf or primitive",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOfCaseInsensitive(entityFormats...),
+							},
+						},
+						"subject_name_strategy": schema.StringAttribute{
+							Description: "Schema registry subject naming strategy, one of TopicNameStrategy, RecordNameStrategy or TopicRecordNameStrategy",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("TopicNameStrategy", "RecordNameStrategy", "TopicRecordNameStrategy"),
+							},
+						},
+						"kinesis_shards": schema.Int64Attribute{
+							Description: "Number of shards of a Kinesis stream",
+							Optional:    true,
+							Computed:    true,
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EntitySetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "internal error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+func (d *EntitySetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_set"
+}
+
+// ModifyPlan rejects entities the store cannot hold while planning. The checks are left to apply when the store type
+// cannot be determined yet, such as for a store created in the same apply.
+func (d *EntitySetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var set EntitySetResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &set)...)
+	if resp.Diagnostics.HasError() || set.Store.IsUnknown() || set.Entities.IsUnknown() {
+		return
+	}
+
+	storeType, ok := plannedStoreType(ctx, d.cfg, set.Store.ValueString())
+	if !ok {
+		return
+	}
+	if err := checkEntitySetStore(set.Store.ValueString(), storeType); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("store"), "invalid store type", err.Error())
+		return
+	}
+
+	entities := map[string]EntitySetItemData{}
+	resp.Diagnostics.Append(set.Entities.ElementsAs(ctx, &entities, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, name := range sortedKeys(entities) {
+		if attribute, err := checkEntitySetItem(storeType, name, entities[name]); err != nil {
+			resp.Diagnostics.AddAttributeError(attribute, "invalid entity properties", err.Error())
+		}
+	}
+}
+
+func (d *EntitySetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var set EntitySetResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &set)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entities := map[string]EntitySetItemData{}
+	resp.Diagnostics.Append(set.Entities.ElementsAs(ctx, &entities, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "entity set", fmt.Sprintf("%d entities in %s", len(entities), set.Store.ValueString()))...)
+		return
+	}
+
+	storeType, dg := d.prepareStore(ctx, set.Store.ValueString())
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set.ID = util.ResourceID(d.cfg.Organization, "entity_set", set.Store.ValueString())
+	created := d.createEntities(ctx, set, storeType, entities, &resp.Diagnostics)
+	if len(created) == 0 && len(entities) > 0 {
+		resp.Diagnostics.AddError("failed to create entity set", fmt.Sprintf("none of the %d entities could be created in store %s", len(entities), set.Store.ValueString()))
+		return
+	}
+
+	// Create does not fail when only some entities failed, Terraform would otherwise taint the set and replace the
+	// entities that were created. The failed ones are left out of the state and added again by the next plan.
+	resp.Diagnostics.Append(d.setEntities(ctx, &set, created)...)
+	tflog.Info(ctx, "Entity set created", map[string]any{"store": set.Store.ValueString(), "entities": len(created)})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_entity_set", set.Store.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, set)...)
+}
+
+func (d *EntitySetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var set EntitySetResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &set)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entities := map[string]EntitySetItemData{}
+	resp.Diagnostics.Append(set.Entities.ElementsAs(ctx, &entities, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+		return
+	}
+	storeType, err := getStoreType(ctx, d.cfg, conn, set.Store.ValueString())
+	conn.Close()
+	if err != nil {
+		if util.RemoveIfNotFound(ctx, &resp.State, "store", err) {
+			return
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read store", err)
+		return
+	}
+
+	var mu sync.Mutex
+	errs := d.forEachBatch(ctx, sortedKeys(entities), set.Concurrency, func(ctx context.Context, conn *sql.Conn, name string) error {
+		mu.Lock()
+		item := entities[name]
+		mu.Unlock()
+		if err := describeSetEntity(ctx, conn, storeType, set.Store.ValueString(), name, &item); err != nil {
+			return err
+		}
+		mu.Lock()
+		entities[name] = item
+		mu.Unlock()
+		return nil
+	})
+	for _, name := range sortedKeys(errs) {
+		if util.IsNotFound("entity", errs[name]) {
+			// entities dropped outside of Terraform are removed from the set and created again by the next apply
+			tflog.Info(ctx, "entity not found, removing from entity set", map[string]any{"store": set.Store.ValueString(), "name": name})
+			delete(entities, name)
+			continue
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to describe entity "+name, errs[name])
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.setEntities(ctx, &set, entities)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, set)...)
+}
+
+func (d *EntitySetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var currentSet EntitySetResourceData
+	var newSet EntitySetResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &newSet)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentSet)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "entity set", currentSet.Store.ValueString())...)
+		return
+	}
+
+	current := map[string]EntitySetItemData{}
+	planned := map[string]EntitySetItemData{}
+	resp.Diagnostics.Append(currentSet.Entities.ElementsAs(ctx, &current, false)...)
+	resp.Diagnostics.Append(newSet.Entities.ElementsAs(ctx, &planned, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changes, err := entitySetChanges(current, planned)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", err)
+		return
+	}
+
+	storeType, dg := d.prepareStore(ctx, newSet.Store.ValueString())
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the state starts from the current entities so that a partial failure only records what was applied
+	entities := map[string]EntitySetItemData{}
+	for name, item := range current {
+		if !slices.Contains(changes.drop, name) {
+			entities[name] = item
+		}
+	}
+
+	errs := d.forEachBatch(ctx, changes.drop, newSet.Concurrency, func(ctx context.Context, conn *sql.Conn, name string) error {
+		return dropSetEntity(ctx, conn, newSet.Store.ValueString(), name)
+	})
+	for _, name := range sortedKeys(errs) {
+		if util.IsNotFound("entity", errs[name]) {
+			continue
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to drop entity "+name, errs[name])
+		entities[name] = current[name]
+	}
+
+	var mu sync.Mutex
+	errs = d.forEachBatch(ctx, changes.grow, newSet.Concurrency, func(ctx context.Context, conn *sql.Conn, name string) error {
+		item := planned[name]
+		if err := growSetEntity(ctx, conn, newSet.Store.ValueString(), name, item.TopicPartitions.ValueInt64()); err != nil {
+			return err
+		}
+		if err := describeSetEntity(ctx, conn, storeType, newSet.Store.ValueString(), name, &item); err != nil {
+			return err
+		}
+		mu.Lock()
+		entities[name] = item
+		mu.Unlock()
+		return nil
+	})
+	for _, name := range sortedKeys(errs) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update entity "+name, errs[name])
+	}
+
+	toCreate := map[string]EntitySetItemData{}
+	for _, name := range changes.create {
+		toCreate[name] = planned[name]
+	}
+	for name, item := range d.createEntities(ctx, newSet, storeType, toCreate, &resp.Diagnostics) {
+		entities[name] = item
+	}
+
+	// attributes that do not change the entities, such as the concurrency, are taken from the plan
+	newSet.ID = currentSet.ID
+	resp.Diagnostics.Append(d.setEntities(ctx, &newSet, entities)...)
+	tflog.Info(ctx, "Entity set updated", map[string]any{
+		"store":   newSet.Store.ValueString(),
+		"created": len(changes.create),
+		"dropped": len(changes.drop),
+		"updated": len(changes.grow),
+	})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_entity_set", newSet.Store.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, newSet)...)
+}
+
+func (d *EntitySetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var set EntitySetResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &set)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "entity set", set.Store.ValueString())...)
+		return
+	}
+
+	entities := map[string]EntitySetItemData{}
+	resp.Diagnostics.Append(set.Entities.ElementsAs(ctx, &entities, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errs := d.forEachBatch(ctx, sortedKeys(entities), set.Concurrency, func(ctx context.Context, conn *sql.Conn, name string) error {
+		return dropSetEntity(ctx, conn, set.Store.ValueString(), name)
+	})
+	remaining := map[string]EntitySetItemData{}
+	for _, name := range sortedKeys(errs) {
+		if util.IsNotFound("entity", errs[name]) {
+			continue
+		}
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to drop entity "+name, errs[name])
+		remaining[name] = entities[name]
+	}
+
+	if len(remaining) > 0 {
+		// keep the entities that could not be dropped so that the next destroy retries them
+		resp.Diagnostics.Append(d.setEntities(ctx, &set, remaining)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, set)...)
+		return
+	}
+	tflog.Info(ctx, "Entity set deleted", map[string]any{"store": set.Store.ValueString(), "entities": len(entities)})
+}
+
+// prepareStore waits for the store to be ready and returns its type, which is looked up once for the whole set.
+func (d *EntitySetResource) prepareStore(ctx context.Context, storeName string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+	if err != nil {
+		diags = util.LogError(ctx, diags, "failed to connect", err)
+		return "", diags
+	}
+	defer conn.Close()
+
+	if err := util.WaitForStoreReady(ctx, conn, storeName); err != nil {
+		diags = util.LogError(ctx, diags, "store not ready", err)
+		return "", diags
+	}
+
+	storeType, err := getStoreType(ctx, d.cfg, conn, storeName)
+	if err != nil {
+		diags = util.LogError(ctx, diags, "invalid store type", err)
+		return "", diags
+	}
+	if err := checkEntitySetStore(storeName, storeType); err != nil {
+		diags = util.LogError(ctx, diags, "invalid store type", err)
+		return "", diags
+	}
+	return storeType, diags
+}
+
+// checkEntitySetStore returns an error when entity sets are not supported in stores of the given type.
+func checkEntitySetStore(storeName, storeType string) error {
+	if !isKafkaStoreType(storeType) && !strings.EqualFold(storeType, "kinesis") {
+		return fmt.Errorf("entity sets are only supported in Kafka and Kinesis stores, %s is a %s store", storeName, storeType)
+	}
+	return nil
+}
+
+// checkEntitySetItem returns an error, along with the attribute it concerns, when a configured entity of the set
+// sets properties that stores of the given type do not support.
+func checkEntitySetItem(storeType, name string, item EntitySetItemData) (path.Path, error) {
+	entityPath := path.Root("entities").AtMapKey(name)
+	set := func(v attr.Value) bool { return !v.IsNull() && !v.IsUnknown() }
+
+	if isKafkaStoreType(storeType) {
+		if set(item.KinesisShards) {
+			return entityPath.AtName("kinesis_shards"), fmt.Errorf("kinesis_shards is only supported in Kinesis stores, entity %s is in a %s store", name, storeType)
+		}
+		return path.Empty(), nil
+	}
+
+	for _, p := range []struct {
+		name  string
+		value attr.Value
+	}{
+		{"topic_partitions", item.TopicPartitions},
+		{"topic_replicas", item.TopicReplicas},
+		{"configs", item.Configs},
+		{"key_format", item.KeyFormat},
+		{"value_format", item.ValueFormat},
+		{"subject_name_strategy", item.SubjectNameStrategy},
+	} {
+		if set(p.value) {
+			return entityPath.AtName(p.name), fmt.Errorf("%s is only supported in Kafka stores, entity %s is in a %s store", p.name, name, storeType)
+		}
+	}
+	return path.Empty(), nil
+}
+
+// createEntities creates and describes the given entities, returning the ones that were created. Failures are added
+// to diags as warnings.
+func (d *EntitySetResource) createEntities(ctx context.Context, set EntitySetResourceData, storeType string, entities map[string]EntitySetItemData, diags *diag.Diagnostics) map[string]EntitySetItemData {
+	created := map[string]EntitySetItemData{}
+	var mu sync.Mutex
+	errs := d.forEachBatch(ctx, sortedKeys(entities), set.Concurrency, func(ctx context.Context, conn *sql.Conn, name string) error {
+		item := entities[name]
+		if err := createSetEntity(ctx, conn, storeType, set.Store.ValueString(), name, item); err != nil {
+			return err
+		}
+		item.ID = util.ResourceID(d.cfg.Organization, "entity", set.Store.ValueString(), name)
+		if err := describeSetEntity(ctx, conn, storeType, set.Store.ValueString(), name, &item); err != nil {
+			// the entity exists, it is kept with the planned values and refreshed by the next read
+			tflog.Warn(ctx, "failed to describe created entity", map[string]any{"name": name, "error": err.Error()})
+			nullUnknownSetItem(&item)
+		}
+		mu.Lock()
+		created[name] = item
+		mu.Unlock()
+		return nil
+	})
+	for _, name := range sortedKeys(errs) {
+		tflog.Warn(ctx, "failed to create entity", map[string]any{"store": set.Store.ValueString(), "name": name, "error": errs[name].Error()})
+		diags.AddAttributeWarning(path.Root("entities").AtMapKey(name), "failed to create entity "+name,
+			errs[name].Error()+". The entity is left out of the state and created again by the next apply.")
+	}
+	return created
+}
+
+// setEntities stores the entities in the set.
+func (d *EntitySetResource) setEntities(ctx context.Context, set *EntitySetResourceData, entities map[string]EntitySetItemData) diag.Diagnostics {
+	var dg diag.Diagnostics
+	set.Entities, dg = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: EntitySetItemData{}.AttributeTypes()}, entities)
+	return dg
+}
+
+// forEachBatch runs fn for every entity name. Names are split in batches of entitySetBatchSize that are handled by up to
+// concurrency workers, each batch sharing a single connection. It returns the error of every name that failed.
+func (d *EntitySetResource) forEachBatch(ctx context.Context, names []string, concurrency types.Int64, fn func(context.Context, *sql.Conn, string) error) map[string]error {
+	workers := defaultEntitySetConcurrency
+	if !concurrency.IsNull() && !concurrency.IsUnknown() {
+		workers = int(concurrency.ValueInt64())
+	}
+
+	var mu sync.Mutex
+	errs := map[string]error{}
+	batches := make(chan []string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, d.cfg.Role)
+				if err != nil {
+					mu.Lock()
+					for _, name := range batch {
+						errs[name] = err
+					}
+					mu.Unlock()
+					continue
+				}
+				for _, name := range batch {
+					if err := fn(ctx, conn, name); err != nil {
+						mu.Lock()
+						errs[name] = err
+						mu.Unlock()
+					}
+				}
+				conn.Close()
+			}
+		}()
+	}
+	for _, batch := range entityBatches(names, entitySetBatchSize) {
+		batches <- batch
+	}
+	close(batches)
+	wg.Wait()
+	return errs
+}
+
+// entityBatches splits names in batches of at most size names.
+func entityBatches(names []string, size int) [][]string {
+	var batches [][]string
+	for len(names) > size {
+		batches = append(batches, names[:size])
+		names = names[size:]
+	}
+	if len(names) > 0 {
+		batches = append(batches, names)
+	}
+	return batches
+}
+
+// entitySetPlan lists the entities to create, drop and grow to reach the planned set.
+type entitySetPlan struct {
+	create []string
+	drop   []string
+	grow   []string
+}
+
+// entitySetChanges compares the current and planned entities of a set. Only adding and removing entities and
+// increasing the partitions of a topic are supported in place, any other change is returned as an error.
+func entitySetChanges(current, planned map[string]EntitySetItemData) (entitySetPlan, error) {
+	var changes entitySetPlan
+	for _, name := range sortedKeys(planned) {
+		cur, ok := current[name]
+		if !ok {
+			changes.create = append(changes.create, name)
+			continue
+		}
+		item := planned[name]
+		for attrName, v := range map[string][2]attr.Value{
+			"topic_replicas":        {item.TopicReplicas, cur.TopicReplicas},
+			"configs":               {item.Configs, cur.Configs},
+			"key_format":            {item.KeyFormat, cur.KeyFormat},
+			"value_format":          {item.ValueFormat, cur.ValueFormat},
+			"subject_name_strategy": {item.SubjectNameStrategy, cur.SubjectNameStrategy},
+			"kinesis_shards":        {item.KinesisShards, cur.KinesisShards},
+		} {
+			if !v[0].IsUnknown() && !v[0].Equal(v[1]) {
+				return changes, fmt.Errorf("%s of entity %s cannot be changed, remove the entity from the set and add it again to re-create it", attrName, name)
+			}
+		}
+		if item.TopicPartitions.IsUnknown() || item.TopicPartitions.Equal(cur.TopicPartitions) {
+			continue
+		}
+		if item.TopicPartitions.IsNull() || item.TopicPartitions.ValueInt64() < cur.TopicPartitions.ValueInt64() {
+			return changes, fmt.Errorf("partitions cannot be removed from topic %s, only increasing topic_partitions is supported", name)
+		}
+		changes.grow = append(changes.grow, name)
+	}
+	for _, name := range sortedKeys(current) {
+		if _, ok := planned[name]; !ok {
+			changes.drop = append(changes.drop, name)
+		}
+	}
+	return changes, nil
+}
+
+// createSetEntity creates a top level entity of the store.
+func createSetEntity(ctx context.Context, conn *sql.Conn, storeType, storeName, name string, item EntitySetItemData) error {
+	properties := []string{}
+	if isKafkaStoreType(storeType) {
+		if !item.TopicPartitions.IsNull() && !item.TopicPartitions.IsUnknown() {
+			properties = append(properties, fmt.Sprintf("'kafka.partitions' = %d", item.TopicPartitions.ValueInt64()))
+		}
+		if !item.TopicReplicas.IsNull() && !item.TopicReplicas.IsUnknown() {
+			properties = append(properties, fmt.Sprintf("'kafka.replicas' = %d", item.TopicReplicas.ValueInt64()))
+		}
+		if !item.Configs.IsNull() && !item.Configs.IsUnknown() {
+			configs := map[string]string{}
+			if dg := item.Configs.ElementsAs(ctx, &configs, false); dg.HasError() {
+				return fmt.Errorf("invalid configs: %v", dg)
+			}
+			for _, k := range sortedKeys(configs) {
+				properties = append(properties, fmt.Sprintf("'kafka.topic.%s' = '%s'", strings.ReplaceAll(k, "'", "''"), strings.ReplaceAll(configs[k], "'", "''")))
+			}
+		}
+		for _, p := range []struct {
+			name  string
+			value types.String
+		}{
+			{"key.format", item.KeyFormat},
+			{"value.format", item.ValueFormat},
+			{"subject.name.strategy", item.SubjectNameStrategy},
+		} {
+			if !p.value.IsNull() && !p.value.IsUnknown() {
+				properties = append(properties, fmt.Sprintf("'%s' = '%s'", p.name, p.value.ValueString()))
+			}
+		}
+	} else if !item.KinesisShards.IsNull() && !item.KinesisShards.IsUnknown() {
+		properties = append(properties, fmt.Sprintf("'kinesis.shards' = %d", item.KinesisShards.ValueInt64()))
+	}
+
+	b := bytes.NewBuffer(nil)
+	if err := template.Must(template.New("").Parse(createEntityStatement)).Execute(b, map[string]any{
+		"StoreName":  storeName,
+		"EntityPath": []string{name},
+		"Properties": strings.Join(properties, ", "),
+	}); err != nil {
+		return err
+	}
+	_, err := conn.ExecContext(ctx, b.String())
+	return err
+}
+
+// growSetEntity increases the number of partitions of a topic.
+func growSetEntity(ctx context.Context, conn *sql.Conn, storeName, name string, partitions int64) error {
+	b := bytes.NewBuffer(nil)
+	if err := template.Must(template.New("").Parse(updateEntityStatement)).Execute(b, map[string]any{
+		"StoreName":  storeName,
+		"EntityPath": []string{name},
+		"Properties": fmt.Sprintf("'kafka.partitions' = %d", partitions),
+	}); err != nil {
+		return err
+	}
+	_, err := conn.ExecContext(ctx, b.String())
+	return err
+}
+
+// dropSetEntity drops a top level entity of the store.
+func dropSetEntity(ctx context.Context, conn *sql.Conn, storeName, name string) error {
+	b := bytes.NewBuffer(nil)
+	if err := template.Must(template.New("").Parse(dropEntityStatement)).Execute(b, map[string]any{
+		"StoreName":  storeName,
+		"EntityPath": []string{name},
+	}); err != nil {
+		return err
+	}
+	_, err := conn.ExecContext(ctx, b.String())
+	return err
+}
+
+// describeSetEntity refreshes the computed attributes of an entity of the set. A missing entity is reported as
+// sql.ErrNoRows.
+func describeSetEntity(ctx context.Context, conn *sql.Conn, storeType, storeName, name string, item *EntitySetItemData) error {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`DESCRIBE ENTITY "%s" IN STORE "%s";`, name, storeName))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("entity not found: %s: %w", name, sql.ErrNoRows)
+	}
+
+	var discard any
+	if isKafkaStoreType(storeType) {
+		var topicPartitions int64
+		var topicReplicas int64
+		if err := rows.Scan(&discard, &discard, &topicPartitions, &topicReplicas, &discard, &discard, &discard); err != nil {
+			return fmt.Errorf("failed to read entity: %w", err)
+		}
+		item.TopicPartitions = types.Int64Value(topicPartitions)
+		item.TopicReplicas = types.Int64Value(topicReplicas)
+	} else {
+		var kinesisShards int64
+		if err := rows.Scan(&discard, &kinesisShards, &discard); err != nil {
+			return fmt.Errorf("failed to read entity: %w", err)
+		}
+		item.KinesisShards = types.Int64Value(kinesisShards)
+	}
+	nullUnknownSetItem(item)
+	return nil
+}
+
+// nullUnknownSetItem sets the computed attributes that do not apply to the store type to null.
+func nullUnknownSetItem(item *EntitySetItemData) {
+	for _, v := range []*types.Int64{&item.TopicPartitions, &item.TopicReplicas, &item.KinesisShards} {
+		if v.IsUnknown() {
+			*v = types.Int64Null()
+		}
+	}
+	if item.ID.IsUnknown() {
+		item.ID = types.StringNull()
+	}
+}
+
+// isKafkaStoreType reports whether entities of the store type are Kafka topics.
+func isKafkaStoreType(storeType string) bool {
+	return strings.EqualFold(storeType, "kafka") || strings.EqualFold(storeType, "confluentkafka")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
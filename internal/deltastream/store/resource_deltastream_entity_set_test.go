@@ -0,0 +1,156 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+)
+
+func TestEntityBatches(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e"}
+	got := entityBatches(names, 2)
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("entityBatches() = %v, want %v", got, want)
+	}
+	if got := entityBatches(nil, 2); got != nil {
+		t.Errorf("entityBatches(nil) = %v, want nil", got)
+	}
+}
+
+func TestEntitySetChanges(t *testing.T) {
+	item := func(partitions int64) EntitySetItemData {
+		return EntitySetItemData{
+			ID:                  types.StringValue("id"),
+			TopicPartitions:     types.Int64Value(partitions),
+			TopicReplicas:       types.Int64Value(3),
+			Configs:             types.MapNull(types.StringType),
+			KeyFormat:           types.StringNull(),
+			ValueFormat:         types.StringValue("json"),
+			SubjectNameStrategy: types.StringNull(),
+			KinesisShards:       types.Int64Null(),
+		}
+	}
+	current := map[string]EntitySetItemData{"kept": item(3), "grown": item(3), "dropped": item(3)}
+
+	changes, err := entitySetChanges(current, map[string]EntitySetItemData{"kept": item(3), "grown": item(6), "added": item(1)})
+	if err != nil {
+		t.Fatalf("entitySetChanges() error = %v", err)
+	}
+	want := entitySetPlan{create: []string{"added"}, drop: []string{"dropped"}, grow: []string{"grown"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("entitySetChanges() = %+v, want %+v", changes, want)
+	}
+
+	if _, err := entitySetChanges(current, map[string]EntitySetItemData{"kept": item(2)}); err == nil || !strings.Contains(err.Error(), "partitions cannot be removed") {
+		t.Errorf("entitySetChanges(fewer partitions) error = %v", err)
+	}
+
+	changed := item(3)
+	changed.ValueFormat = types.StringValue("avro")
+	if _, err := entitySetChanges(current, map[string]EntitySetItemData{"kept": changed}); err == nil || !strings.Contains(err.Error(), "value_format") {
+		t.Errorf("entitySetChanges(value_format) error = %v", err)
+	}
+}
+
+func TestCheckEntitySetItem(t *testing.T) {
+	item := EntitySetItemData{
+		ID:                  types.StringUnknown(),
+		TopicPartitions:     types.Int64Null(),
+		TopicReplicas:       types.Int64Null(),
+		Configs:             types.MapNull(types.StringType),
+		KeyFormat:           types.StringNull(),
+		ValueFormat:         types.StringNull(),
+		SubjectNameStrategy: types.StringNull(),
+		KinesisShards:       types.Int64Null(),
+	}
+	partitioned := item
+	partitioned.TopicPartitions = types.Int64Value(3)
+	sharded := item
+	sharded.KinesisShards = types.Int64Value(2)
+
+	tests := []struct {
+		name      string
+		storeType string
+		item      EntitySetItemData
+		wantPath  path.Path
+	}{
+		{name: "kafka topic", storeType: "KAFKA", item: partitioned, wantPath: path.Empty()},
+		{name: "kinesis stream", storeType: "KINESIS", item: sharded, wantPath: path.Empty()},
+		{name: "shards in kafka store", storeType: "KAFKA", item: sharded, wantPath: path.Root("entities").AtMapKey("t").AtName("kinesis_shards")},
+		{name: "partitions in kinesis store", storeType: "KINESIS", item: partitioned, wantPath: path.Root("entities").AtMapKey("t").AtName("topic_partitions")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkEntitySetItem(tt.storeType, "t", tt.item)
+			if (err != nil) != !tt.wantPath.Equal(path.Empty()) {
+				t.Fatalf("checkEntitySetItem() error = %v", err)
+			}
+			if !got.Equal(tt.wantPath) {
+				t.Errorf("checkEntitySetItem() path = %s, want %s", got, tt.wantPath)
+			}
+		})
+	}
+
+	if err := checkEntitySetStore("pg", "POSTGRES"); err == nil {
+		t.Errorf("checkEntitySetStore(POSTGRES) = nil, want an error")
+	}
+}
+
+func TestCreateEntitiesPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	_, db := mockserver.NewDB(t, []mockserver.Fixture{{
+		Statement: `CREATE ENTITY "orders"\s+IN STORE "kafka_store"`,
+	}, {
+		Statement: `CREATE ENTITY "clicks"\s+IN STORE "kafka_store"`,
+		SqlState:  string(gods.SqlStateInvalidParameter),
+		Message:   "topic clicks already exists",
+	}, {
+		Statement: `^DESCRIBE ENTITY "orders" IN STORE "kafka_store";$`,
+		Columns: []mockserver.Column{
+			{Name: "Name", Type: "VARCHAR"},
+			{Name: "Type", Type: "VARCHAR"},
+			{Name: "Partitions", Type: "BIGINT"},
+			{Name: "Replicas", Type: "BIGINT"},
+			{Name: "KeyFormat", Type: "VARCHAR"},
+			{Name: "ValueFormat", Type: "VARCHAR"},
+			{Name: "Configs", Type: "VARCHAR"},
+		},
+		Rows: [][]*string{mockserver.Row("orders", "topic", "3", "2", "json", "json", "{}")},
+	}})
+
+	d := &EntitySetResource{cfg: &config.DeltaStreamProviderCfg{Db: db, Organization: testOrganization, Role: "sysadmin"}}
+	set := EntitySetResourceData{Store: types.StringValue("kafka_store"), Concurrency: types.Int64Value(2)}
+	item := EntitySetItemData{
+		ID:              types.StringUnknown(),
+		TopicPartitions: types.Int64Value(3),
+		TopicReplicas:   types.Int64Unknown(),
+		Configs:         types.MapNull(types.StringType),
+		KinesisShards:   types.Int64Unknown(),
+	}
+
+	var diags diag.Diagnostics
+	created := d.createEntities(ctx, set, "kafka", map[string]EntitySetItemData{"orders": item, "clicks": item}, &diags)
+	if _, ok := created["orders"]; !ok || len(created) != 1 {
+		t.Fatalf("createEntities() = %v, want only orders", created)
+	}
+	if created["orders"].TopicReplicas.ValueInt64() != 2 {
+		t.Errorf("orders replicas = %s, want the described value", created["orders"].TopicReplicas)
+	}
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("createEntities() diagnostics = %v, want a single warning for clicks", diags)
+	}
+}
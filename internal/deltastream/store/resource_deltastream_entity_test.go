@@ -0,0 +1,118 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+)
+
+func TestKinesisStreamProperties(t *testing.T) {
+	got := kinesisStreamProperties(KinesisStoreEntityResourceData{
+		StreamMode:                 types.StringValue("PROVISIONED"),
+		EnhancedFanOut:             types.BoolValue(true),
+		EnhancedFanOutConsumerName: types.StringValue("ds-reader"),
+	})
+	want := []string{`'kinesis.stream_mode' = 'PROVISIONED'`, `'kinesis.enhanced_fan_out' = TRUE`, `'kinesis.enhanced_fan_out.consumer_name' = 'ds-reader'`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("kinesisStreamProperties() = %v, want %v", got, want)
+	}
+
+	if got := kinesisStreamProperties(KinesisStoreEntityResourceData{
+		StreamMode:                 types.StringNull(),
+		EnhancedFanOut:             types.BoolValue(false),
+		EnhancedFanOutConsumerName: types.StringNull(),
+	}); !reflect.DeepEqual(got, []string{`'kinesis.enhanced_fan_out' = FALSE`}) {
+		t.Errorf("kinesisStreamProperties() = %v, want only enhanced_fan_out", got)
+	}
+}
+
+func TestCheckEntityCreate(t *testing.T) {
+	kafka := types.ObjectNull(KafkaStoreEntityResourceData{}.AttributeTypes())
+	kinesis := types.ObjectNull(KinesisStoreEntityResourceData{}.AttributeTypes())
+	entity := func(kafkaSet bool) EntityResourceData {
+		e := EntityResourceData{
+			Store:                types.StringValue("s"),
+			KafkaProperties:      kafka,
+			KinesisProperties:    kinesis,
+			SnowflakeProperties:  types.ObjectUnknown(SnowflakeStoreEntityResourceData{}.AttributeTypes()),
+			DatabricksProperties: types.ObjectUnknown(DatabricksStoreEntityResourceData{}.AttributeTypes()),
+			PostgresProperties:   types.ObjectUnknown(PostgresStoreEntityResourceData{}.AttributeTypes()),
+		}
+		if kafkaSet {
+			e.KafkaProperties = types.ObjectValueMust(KafkaStoreEntityResourceData{}.AttributeTypes(), map[string]attr.Value{
+				"topic_partitions":      types.Int64Value(3),
+				"topic_replicas":        types.Int64Null(),
+				"key_descriptor":        types.StringNull(),
+				"value_descriptor":      types.StringNull(),
+				"configs":               types.MapNull(types.StringType),
+				"all_configs":           types.MapNull(types.StringType),
+				"key_format":            types.StringNull(),
+				"value_format":          types.StringNull(),
+				"subject_name_strategy": types.StringNull(),
+			})
+		}
+		return e
+	}
+
+	tests := []struct {
+		storeType  string
+		kafkaSet   bool
+		comment    string
+		entityPath []string
+		attribute  string
+	}{
+		{storeType: "Kafka", kafkaSet: true, entityPath: []string{"orders"}},
+		{storeType: "ConfluentKafka", entityPath: []string{"orders"}},
+		{storeType: "Kinesis", kafkaSet: true, entityPath: []string{"orders"}, attribute: "kafka_properties"},
+		{storeType: "Snowflake", entityPath: []string{"ANALYTICS", "PUBLIC"}},
+		{storeType: "Snowflake", entityPath: []string{"ANALYTICS", "PUBLIC", "ORDERS"}, attribute: "entity_path"},
+		{storeType: "Databricks", kafkaSet: true, entityPath: []string{"main"}, attribute: "kafka_properties"},
+		{storeType: "Postgres", entityPath: []string{"public"}, attribute: "store"},
+		{storeType: "Snowflake", comment: "analytics", entityPath: []string{"ANALYTICS"}},
+		{storeType: "Kafka", comment: "orders", entityPath: []string{"orders"}, attribute: "comment"},
+	}
+	for _, tt := range tests {
+		e := entity(tt.kafkaSet)
+		if tt.comment != "" {
+			e.Comment = types.StringValue(tt.comment)
+		}
+		attribute, err := checkEntityCreate(tt.storeType, e, tt.entityPath)
+		if tt.attribute == "" {
+			if err != nil {
+				t.Errorf("checkEntityCreate(%s, %v) error = %v", tt.storeType, tt.entityPath, err)
+			}
+			continue
+		}
+		if err == nil || !attribute.Equal(path.Root(tt.attribute)) {
+			t.Errorf("checkEntityCreate(%s, %v) = %s, %v, want an error on %s", tt.storeType, tt.entityPath, attribute, err, tt.attribute)
+		}
+	}
+}
+
+func TestPlannedStoreType(t *testing.T) {
+	ctx := context.Background()
+	_, db := mockserver.NewDB(t, []mockserver.Fixture{{
+		Statement: `^SELECT type FROM deltastream.sys."stores" WHERE name = 'kafka';$`,
+		Columns:   []mockserver.Column{{Name: "type", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("Kafka")},
+	}})
+	cfg := &config.DeltaStreamProviderCfg{Db: db, Organization: "00000000-0000-0000-0000-000000000001", Role: "sysadmin"}
+
+	if storeType, ok := plannedStoreType(ctx, cfg, "kafka"); !ok || storeType != "Kafka" {
+		t.Errorf("plannedStoreType(kafka) = %q, %v, want Kafka", storeType, ok)
+	}
+	// a store planned in the same apply does not exist yet, the checks are left to apply
+	if storeType, ok := plannedStoreType(ctx, cfg, "planned"); ok {
+		t.Errorf("plannedStoreType(planned) = %q, want not determined", storeType)
+	}
+}
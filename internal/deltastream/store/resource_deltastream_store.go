@@ -10,12 +10,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
 	"text/template"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -27,12 +32,15 @@ import (
 	"github.com/sethvargo/go-retry"
 
 	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/store/models"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
 )
 
 var _ resource.Resource = &StoreResource{}
 var _ resource.ResourceWithConfigure = &StoreResource{}
+var _ resource.ResourceWithModifyPlan = &StoreResource{}
+var _ resource.ResourceWithImportState = &StoreResource{}
 
 func NewStoreResource() resource.Resource {
 	return &StoreResource{}
@@ -42,78 +50,10 @@ type StoreResource struct {
 	cfg *config.DeltaStreamProviderCfg
 }
 
-type KafkaProperties struct {
-	Uris                    types.String `tfsdk:"uris"`
-	SchemaRegistry          types.String `tfsdk:"schema_registry_name"`
-	SaslHashFunc            types.String `tfsdk:"sasl_hash_function"`
-	SaslUsername            types.String `tfsdk:"sasl_username"`
-	SaslPassword            types.String `tfsdk:"sasl_password"`
-	MskIamRoleArn           types.String `tfsdk:"msk_iam_role_arn"`
-	MskAwsRegion            types.String `tfsdk:"msk_aws_region"`
-	TlsDisabled             types.Bool   `tfsdk:"tls_disabled"`
-	TlsVerifyServerHostname types.Bool   `tfsdk:"tls_verify_server_hostname"`
-	TlsCaCertFile           types.String `tfsdk:"tls_ca_cert_file"`
-}
-
-func (KafkaProperties) AttributeTypes() map[string]attr.Type {
-	return map[string]attr.Type{
-		"uris":                       types.StringType,
-		"schema_registry_name":       types.StringType,
-		"sasl_hash_function":         types.StringType,
-		"sasl_username":              types.StringType,
-		"sasl_password":              types.StringType,
-		"msk_iam_role_arn":           types.StringType,
-		"msk_aws_region":             types.StringType,
-		"tls_disabled":               types.BoolType,
-		"tls_verify_server_hostname": types.BoolType,
-		"tls_ca_cert_file":           types.StringType,
-	}
-}
-
-type ConfleuntKafkaProperties struct {
-	Uris           types.String `tfsdk:"uris"`
-	SchemaRegistry types.String `tfsdk:"schema_registry_name"`
-	SaslHashFunc   types.String `tfsdk:"sasl_hash_function"`
-	SaslUsername   types.String `tfsdk:"sasl_username"`
-	SaslPassword   types.String `tfsdk:"sasl_password"`
-}
-
-type KinesisProperties struct {
-	Uris            types.String `tfsdk:"uris"`
-	SchemaRegistry  types.String `tfsdk:"schema_registry_name"`
-	AccessKeyId     types.String `tfsdk:"access_key_id"`
-	SecretAccessKey types.String `tfsdk:"secret_access_key"`
-}
-
-type SnowflakeProperties struct {
-	Uris                types.String `tfsdk:"uris"`
-	AccountId           types.String `tfsdk:"account_id"`
-	CloudRegion         types.String `tfsdk:"cloud_region"`
-	WarehouseName       types.String `tfsdk:"warehouse_name"`
-	RoleName            types.String `tfsdk:"role_name"`
-	Username            types.String `tfsdk:"username"`
-	ClientKeyFile       types.String `tfsdk:"client_key_file"`
-	ClientKeyPassphrase types.String `tfsdk:"client_key_passphrase"`
-}
-
-type DatabricksProperties struct {
-	Uris            types.String `tfsdk:"uris"`
-	AppToken        types.String `tfsdk:"app_token"`
-	WarehouseId     types.String `tfsdk:"warehouse_id"`
-	AccessKeyId     types.String `tfsdk:"access_key_id"`
-	SecretAccessKey types.String `tfsdk:"secret_access_key"`
-	CloudS3Bucket   types.String `tfsdk:"cloud_s3_bucket"`
-	CloudRegion     types.String `tfsdk:"cloud_region"`
-}
-
-type PostgresProperties struct {
-	Uris     types.String `tfsdk:"uris"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-}
-
 type StoreResourceData struct {
+	ID             types.String `tfsdk:"id"`
 	Name           types.String `tfsdk:"name"`
+	NamePrefix     types.String `tfsdk:"name_prefix"`
 	AccessRegion   types.String `tfsdk:"access_region"`
 	Type           types.String `tfsdk:"type"`
 	Kafka          types.Object `tfsdk:"kafka"`
@@ -126,21 +66,58 @@ type StoreResourceData struct {
 	State          types.String `tfsdk:"state"`
 	UpdatedAt      types.String `tfsdk:"updated_at"`
 	CreatedAt      types.String `tfsdk:"created_at"`
+
+	ConnectivityVerify types.Bool   `tfsdk:"verify_connectivity_on_read"`
+	ConnectivityError  types.String `tfsdk:"connectivity_error"`
+
+	Tags    types.Map `tfsdk:"tags"`
+	TagsAll types.Map `tfsdk:"tags_all"`
+
+	StatementID types.String `tfsdk:"statement_id"`
+
+	KafkaClient types.Object `tfsdk:"kafka_client"`
 }
 
 func (d *StoreResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Store resource",
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the Store",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
-				Description: "Name of the Store",
-				Required:    true,
+				Description: "Name of the Store. Exactly one of name or name_prefix must be specified",
+				Optional:    true,
+				Computed:    true,
+				Validators: append([]validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name_prefix")),
+				}, util.IdentifierValidators...),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Creates a unique name beginning with the specified prefix, allowing the Store to be replaced with create_before_destroy",
+				Optional:    true,
 				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"access_region": schema.StringAttribute{
-				Description: "Specifies the region of the Store. In order to improve latency and reduce data transfer costs, the region should be the same cloud and region that the physical Store is running in.",
-				Required:    true,
+				Description: "Specifies the region of the Store. In order to improve latency and reduce data transfer costs, the region should be the same cloud and region that the physical Store is running in. Defaults to the default_access_region of the provider",
+				Optional:    true,
+				Computed:    true,
 				Validators:  util.IdentifierValidators,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Description: "Type of the Store",
@@ -200,8 +177,29 @@ func (d *StoreResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Description: "CA certificate in PEM format",
 						Optional:    true,
 					},
+					"credentials_secret_arn": credentialsSecretArnAttribute("SASL username and password", "sasl_username", "sasl_password", "msk_iam_role_arn"),
+					"client_properties":      kafkaClientPropertiesAttribute(),
+					"additional_properties":  additionalPropertiesAttribute(),
+					"topic_auto_create": schema.BoolAttribute{
+						Description: "Whether DeltaStream may create the topics that relations and queries write to when they do not exist. Follows the organization default when not set",
+						Optional:    true,
+					},
+					"topic_default_partitions": schema.Int64Attribute{
+						Description: "Number of partitions of the topics DeltaStream creates without an explicit partition count",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"topic_default_replicas": schema.Int64Attribute{
+						Description: "Replication factor of the topics DeltaStream creates without an explicit replication factor",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(1)},
+					},
+				},
+				Optional:   true,
+				Validators: []validator.Object{kafkaSaslValidator{}},
+				PlanModifiers: []planmodifier.Object{
+					util.ObjectRequiresReplaceUnlessImported(),
 				},
-				Optional: true,
 			},
 
 			"confluent_kafka": schema.SingleNestedAttribute{
@@ -214,24 +212,53 @@ func (d *StoreResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					"schema_registry_name": schema.StringAttribute{
 						Description: "Name of the schema registry",
 						Optional:    true,
+						Validators:  util.IdentifierValidators,
 					},
 					"sasl_hash_function": schema.StringAttribute{
-						Description: "SASL hash function to use when authenticating with Confluent Kafka brokers",
+						Description: "SASL hash function to use when authenticating with Confluent Kafka brokers. Must be PLAIN when authenticating with a cluster API key",
 						Validators:  []validator.String{stringvalidator.OneOf("PLAIN", "SHA256", "SHA512")},
 						Required:    true,
 					},
 					"sasl_username": schema.StringAttribute{
-						Description: "Username to use when authenticating with Apache Kafka brokers",
-						Required:    true,
+						Description: "Username to use when authenticating with Confluent Kafka brokers. Exactly one of sasl_username or cluster_api_key must be specified",
+						Optional:    true,
 						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("cluster_api_key")),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("sasl_password")),
+						},
 					},
 					"sasl_password": schema.StringAttribute{
-						Description: "Password to use when authenticating with Apache Kafka brokers",
-						Required:    true,
+						Description: "Password to use when authenticating with Confluent Kafka brokers",
+						Optional:    true,
 						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("sasl_username")),
+						},
 					},
+					"cluster_api_key": schema.StringAttribute{
+						Description: "Confluent Cloud cluster API key to use when authenticating with Confluent Kafka brokers",
+						Optional:    true,
+						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("cluster_api_secret")),
+						},
+					},
+					"cluster_api_secret": schema.StringAttribute{
+						Description: "Confluent Cloud cluster API secret to use when authenticating with Confluent Kafka brokers",
+						Optional:    true,
+						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("cluster_api_key")),
+						},
+					},
+					"additional_properties": additionalPropertiesAttribute(),
+				},
+				Optional:   true,
+				Validators: []validator.Object{confluentKafkaSaslValidator{}},
+				PlanModifiers: []planmodifier.Object{
+					util.ObjectRequiresReplaceUnlessImported(),
 				},
-				Optional: true,
 			},
 
 			"kinesis": schema.SingleNestedAttribute{
@@ -242,21 +269,45 @@ func (d *StoreResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Required:    true,
 					},
 					"schema_registry_name": schema.StringAttribute{
-						Description: "Name of the schema registry",
+						Description: "Name of the schema registry used to decode and encode Avro and Protobuf records of the Kinesis data streams",
 						Optional:    true,
+						Validators:  util.IdentifierValidators,
 					},
 					"access_key_id": schema.StringAttribute{
-						Description: "AWS IAM access key to use when authenticating with an Amazon Kinesis service",
+						Description: "AWS IAM access key to use when authenticating with an Amazon Kinesis service. Exactly one of access_key_id or role_arn must be specified",
 						Optional:    true,
 						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("secret_access_key")),
+						},
 					},
 					"secret_access_key": schema.StringAttribute{
 						Description: "AWS IAM secret access key to use when authenticating with an Amazon Kinesis service",
 						Optional:    true,
 						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("access_key_id")),
+						},
+					},
+					"role_arn":    awsRoleArnAttribute("Amazon Kinesis service"),
+					"external_id": awsExternalIdAttribute(),
+					"stream_mode": schema.StringAttribute{
+						Description: "Capacity mode of the Kinesis data streams created in the store, used unless an entity sets its own. One of " + strings.Join(kinesisStreamModes, ", "),
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(kinesisStreamModes...),
+						},
+					},
+					"enhanced_fan_out": schema.BoolAttribute{
+						Description: "Whether queries read the Kinesis data streams of the store through enhanced fan-out consumers, used unless an entity sets its own",
+						Optional:    true,
 					},
+					"additional_properties": additionalPropertiesAttribute(),
 				},
 				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					util.ObjectRequiresReplaceUnlessImported(),
+				},
 			},
 
 			"snowflake": schema.SingleNestedAttribute{
@@ -288,17 +339,51 @@ func (d *StoreResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Sensitive:   true,
 					},
 					"client_key_file": schema.StringAttribute{
-						Description: "Snowflake account's private key in PEM format",
-						Required:    true,
+						Description: "Snowflake account's private key in PEM format. Exactly one of client_key_file, oauth or credentials_secret_arn must be specified",
+						Optional:    true,
 						Sensitive:   true,
 					},
 					"client_key_passphrase": schema.StringAttribute{
-						Description: "Passphrase for decrypting the Snowflake account's private key",
-						Required:    true,
+						Description: "Passphrase for decrypting the Snowflake account's private key. Only required if the private key is encrypted",
+						Optional:    true,
 						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_key_file")),
+						},
+					},
+					"credentials_secret_arn": credentialsSecretArnAttribute("private key and its passphrase", "client_key_passphrase"),
+					"oauth": schema.SingleNestedAttribute{
+						Description: "OAuth client credentials used to authenticate with Snowflake. Exactly one of client_key_file, oauth or credentials_secret_arn must be specified",
+						Attributes: map[string]schema.Attribute{
+							"client_id": schema.StringAttribute{
+								Description: "OAuth client ID",
+								Required:    true,
+								Sensitive:   true,
+							},
+							"client_secret": schema.StringAttribute{
+								Description: "OAuth client secret",
+								Required:    true,
+								Sensitive:   true,
+							},
+							"token_endpoint": schema.StringAttribute{
+								Description: "OAuth token endpoint URL",
+								Required:    true,
+							},
+						},
+						Optional: true,
+						Validators: []validator.Object{
+							objectvalidator.ExactlyOneOf(
+								path.MatchRelative().AtParent().AtName("client_key_file"),
+								path.MatchRelative().AtParent().AtName("credentials_secret_arn"),
+							),
+						},
 					},
+					"additional_properties": additionalPropertiesAttribute(),
 				},
 				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					util.ObjectRequiresReplaceUnlessImported(),
+				},
 			},
 
 			"databricks": schema.SingleNestedAttribute{
@@ -317,15 +402,23 @@ func (d *StoreResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Required:    true,
 					},
 					"access_key_id": schema.StringAttribute{
-						Description: "AWS access key ID used for writing data to S3",
-						Required:    true,
+						Description: "AWS access key ID used for writing data to S3. Exactly one of access_key_id or role_arn must be specified",
+						Optional:    true,
 						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("secret_access_key")),
+						},
 					},
 					"secret_access_key": schema.StringAttribute{
 						Description: "AWS secret access key used for writing data to S3",
-						Required:    true,
+						Optional:    true,
 						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("access_key_id")),
+						},
 					},
+					"role_arn":    awsRoleArnAttribute("S3 bucket"),
+					"external_id": awsExternalIdAttribute(),
 					"cloud_s3_bucket": schema.StringAttribute{
 						Description: "The name of the S3 bucket where the data will be stored",
 						Required:    true,
@@ -334,8 +427,12 @@ func (d *StoreResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Description: "The region where the S3 bucket is located",
 						Required:    true,
 					},
+					"additional_properties": additionalPropertiesAttribute(),
 				},
 				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					util.ObjectRequiresReplaceUnlessImported(),
+				},
 			},
 
 			"postgres": schema.SingleNestedAttribute{
@@ -346,17 +443,29 @@ func (d *StoreResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Required:    true,
 					},
 					"username": schema.StringAttribute{
-						Description: "Username to use when authenticating with a Postgres database",
-						Required:    true,
+						Description: "Username to use when authenticating with a Postgres database. Exactly one of username or credentials_secret_arn must be specified",
+						Optional:    true,
 						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("credentials_secret_arn")),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+						},
 					},
 					"password": schema.StringAttribute{
 						Description: "Password to use when authenticating with a Postgres database",
-						Required:    true,
+						Optional:    true,
 						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("username")),
+						},
 					},
+					"credentials_secret_arn": credentialsSecretArnAttribute("username and password"),
+					"additional_properties":  additionalPropertiesAttribute(),
 				},
 				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					util.ObjectRequiresReplaceUnlessImported(),
+				},
 			},
 
 			"owner": schema.StringAttribute{
@@ -369,10 +478,37 @@ func (d *StoreResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Description: "State of the Store",
 				Computed:    true,
 			},
+			"verify_connectivity_on_read": schema.BoolAttribute{
+				Description: "Test the connectivity of the Store on every refresh, so that expired credentials are reported in state and connectivity_error",
+				Optional:    true,
+			},
+			"connectivity_error": schema.StringAttribute{
+				Description: "Error of the last connectivity test, null when the Store was reachable or verify_connectivity_on_read is not set",
+				Computed:    true,
+			},
 			"created_at": schema.StringAttribute{
 				Description: "Creation date of the Store",
 				Computed:    true,
 			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the Store. Tags can be changed without replacing the Store",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  util.TagValidators,
+			},
+			"tags_all": schema.MapAttribute{
+				Description: "Tags of the Store merged with the default_tags of the provider",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"statement_id": schema.StringAttribute{
+				Description: "ID of the statement that created the Store, to look it up with DeltaStream support",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kafka_client": kafkaClientAttribute(),
 			"updated_at": schema.StringAttribute{
 				Description: "Last update date of the Store",
 				Computed:    true,
@@ -396,15 +532,133 @@ func (d *StoreResource) Configure(ctx context.Context, req resource.ConfigureReq
 	d.cfg = cfg
 }
 
+// awsRoleArnAttribute is the assumed role alternative to static AWS access keys.
+func awsRoleArnAttribute(service string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "ARN of the AWS IAM role to assume when authenticating with the " + service + ". Exactly one of access_key_id or role_arn must be specified",
+		Optional:    true,
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("access_key_id")),
+		},
+	}
+}
+
+func awsExternalIdAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "External ID to present when assuming role_arn",
+		Optional:    true,
+		Validators: []validator.String{
+			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("role_arn")),
+		},
+	}
+}
+
+// kinesisStreamModes are the capacity modes of Kinesis data streams.
+var kinesisStreamModes = []string{"PROVISIONED", "ON_DEMAND"}
+
+// credentialsSecretArnAttribute references a secret holding the store credentials, which DeltaStream fetches itself
+// so the credentials never pass through the provider. conflicts are the attributes the secret replaces.
+func credentialsSecretArnAttribute(credentials string, conflicts ...string) schema.StringAttribute {
+	validators := []validator.String{
+		stringvalidator.RegexMatches(credentialsSecretReference, "must be an AWS Secrets Manager secret ARN or a GCP Secret Manager secret name"),
+	}
+	if len(conflicts) > 0 {
+		paths := make([]path.Expression, 0, len(conflicts))
+		for _, name := range conflicts {
+			paths = append(paths, path.MatchRelative().AtParent().AtName(name))
+		}
+		validators = append(validators, stringvalidator.ConflictsWith(paths...))
+	}
+
+	return schema.StringAttribute{
+		Description: "AWS Secrets Manager secret ARN or GCP Secret Manager secret name (projects/<project>/secrets/<secret>) holding the " + credentials + ". DeltaStream fetches the secret itself, only the reference is passed by the provider",
+		Optional:    true,
+		Validators:  validators,
+	}
+}
+
+var credentialsSecretReference = regexp.MustCompile(`^(arn:aws[a-z-]*:secretsmanager:[a-z0-9-]+:[0-9]{12}:secret:.+|projects/[^/]+/secrets/[^/]+(/versions/[^/]+)?)$`)
+
+// additionalPropertiesAttribute holds store properties that are not modelled by the provider.
+func additionalPropertiesAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: "Additional properties appended verbatim to the WITH clause of CREATE STORE. These properties are not validated by the provider",
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+}
+
+// kafkaClientPropertyPrefix is the prefix of the Kafka client configuration properties in the WITH clause of
+// CREATE STORE.
+const kafkaClientPropertyPrefix = "kafka."
+
+// kafkaClientPropertiesAttribute holds Kafka client configuration overrides, such as security.protocol. The
+// properties the provider sets from other attributes cannot be overridden.
+func kafkaClientPropertiesAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: "Kafka client configuration overrides, such as security.protocol or ssl.endpoint.identification.algorithm, passed to DeltaStream with the " + kafkaClientPropertyPrefix + " prefix. Values are sensitive as they may hold credentials",
+		ElementType: types.StringType,
+		Optional:    true,
+		Sensitive:   true,
+		Validators: []validator.Map{
+			mapvalidator.KeysAre(
+				stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*$`), "must be a Kafka client configuration name, such as security.protocol"),
+				stringvalidator.NoneOf(
+					"sasl.hash_function", "sasl.username", "sasl.password", "msk.iam_role_arn", "msk.aws_region",
+					"topic.auto_create", "topic.default_partitions", "topic.default_replicas",
+				),
+			),
+		},
+	}
+}
+
+// renderAdditionalProperties renders the additional properties as sorted, quoted WITH clause entries.
+func renderAdditionalProperties(ctx context.Context, m types.Map) ([]string, diag.Diagnostics) {
+	return util.RenderProperties(ctx, "", m)
+}
+
 func (d *StoreResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_store"
 }
 
+// ImportState adopts an existing store by its name. The connection settings of the store are not reported by the
+// server, they are taken from the configuration when the store is first updated.
+func (d *StoreResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, util.ImportedKey, []byte("true"))...)
+}
+
+func (d *StoreResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d.cfg == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultOwner(ctx, "store", req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultAccessRegion(ctx, req.Config, req.State, &resp.Plan)...)
+	resp.Diagnostics.Append(d.cfg.ValidateOwner(ctx, resp.Plan, req.State)...)
+	resp.Diagnostics.Append(d.cfg.ValidateIdentifierCase(ctx, resp.Plan, req.State, "name")...)
+	resp.Diagnostics.Append(d.cfg.ApplyDefaultTags(ctx, &resp.Plan)...)
+
+	if resp.Plan.Raw.IsNull() {
+		return
+	}
+	var store StoreResourceData
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &store)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	kafkaClient, dg := storeKafkaClient(ctx, store)
+	resp.Diagnostics.Append(dg...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("kafka_client"), kafkaClient)...)
+}
+
 const createStatement = `CREATE STORE "{{.Name}}" WITH(
 	{{- if eq .Type "KAFKA" }}
 		'type' = KAFKA, 'access_region' = "{{.AccessRegion}}", 'kafka.sasl.hash_function' = {{.Kafka.SaslHashFunc.ValueString}},
 		{{- if eq .Kafka.SaslHashFunc.ValueString "AWS_MSK_IAM" }}
 			'kafka.msk.iam_role_arn' = '{{.Kafka.MskIamRoleArn.ValueString}}', 'kafka.msk.aws_region' = '{{.Kafka.MskAwsRegion.ValueString}}',
+		{{- else if not (or .Kafka.CredentialsSecretArn.IsNull .Kafka.CredentialsSecretArn.IsUnknown) }}
+			'credentials.secret_arn' = '{{.Kafka.CredentialsSecretArn.ValueString}}',
 		{{- else if ne .Kafka.SaslHashFunc.ValueString "NONE" }}
 			'kafka.sasl.username' = '{{.Kafka.SaslUsername.ValueString}}', 'kafka.sasl.password' = '{{.Kafka.SaslPassword.ValueString}}',
 		{{- end }}
@@ -416,10 +670,24 @@ const createStatement = `CREATE STORE "{{.Name}}" WITH(
 		{{- if not (or .Kafka.TlsCaCertFile.IsNull .Kafka.TlsCaCertFile.IsUnknown) }}
 			'tls.ca_cert_file' = 'tls.ca_cert_file.pem',
 		{{- end }}
+		{{- if not (or .Kafka.TopicAutoCreate.IsNull .Kafka.TopicAutoCreate.IsUnknown) }}
+			'kafka.topic.auto_create' = {{ if .Kafka.TopicAutoCreate.ValueBool }}TRUE{{ else }}FALSE{{ end }},
+		{{- end }}
+		{{- if not (or .Kafka.TopicDefaultPartitions.IsNull .Kafka.TopicDefaultPartitions.IsUnknown) }}
+			'kafka.topic.default_partitions' = {{.Kafka.TopicDefaultPartitions.ValueInt64}},
+		{{- end }}
+		{{- if not (or .Kafka.TopicDefaultReplicas.IsNull .Kafka.TopicDefaultReplicas.IsUnknown) }}
+			'kafka.topic.default_replicas' = {{.Kafka.TopicDefaultReplicas.ValueInt64}},
+		{{- end }}
 		'uris' = '{{.Kafka.Uris.ValueString}}'
 	{{- end }}
 	{{- if eq .Type "CONFLUENT_KAFKA" }}
-		'type' = CONFLUENT_KAFKA, 'access_region' = "{{.AccessRegion}}", 'kafka.sasl.hash_function' = {{.ConfluentKafka.SaslHashFunc.ValueString}}, 'kafka.sasl.username' = '{{.ConfluentKafka.SaslUsername.ValueString}}', 'kafka.sasl.password' = '{{.ConfluentKafka.SaslPassword.ValueString}}',
+		'type' = CONFLUENT_KAFKA, 'access_region' = "{{.AccessRegion}}", 'kafka.sasl.hash_function' = {{.ConfluentKafka.SaslHashFunc.ValueString}},
+		{{- if not (or .ConfluentKafka.ClusterApiKey.IsNull .ConfluentKafka.ClusterApiKey.IsUnknown) }}
+			'kafka.sasl.username' = '{{.ConfluentKafka.ClusterApiKey.ValueString}}', 'kafka.sasl.password' = '{{.ConfluentKafka.ClusterApiSecret.ValueString}}',
+		{{- else }}
+			'kafka.sasl.username' = '{{.ConfluentKafka.SaslUsername.ValueString}}', 'kafka.sasl.password' = '{{.ConfluentKafka.SaslPassword.ValueString}}',
+		{{- end }}
 		{{- if not (or .ConfluentKafka.SchemaRegistry.IsNull .ConfluentKafka.SchemaRegistry.IsUnknown) }}
 			'schema_registry.name' = "{{.ConfluentKafka.SchemaRegistry.ValueString}}",
 		{{- end }}
@@ -428,22 +696,62 @@ const createStatement = `CREATE STORE "{{.Name}}" WITH(
 	{{- end }}
 	{{- if eq .Type "KINESIS" }}
 		'type' = KINESIS, 'access_region' = "{{.AccessRegion}}",
-		{{- if and .Kinesis.AccessKeyId .Kinesis.SecretAccessKey }}
+		{{- if not (or .Kinesis.RoleArn.IsNull .Kinesis.RoleArn.IsUnknown) }}
+			'aws.role_arn' = '{{.Kinesis.RoleArn.ValueString}}',
+			{{- if not (or .Kinesis.ExternalId.IsNull .Kinesis.ExternalId.IsUnknown) }}
+				'aws.external_id' = '{{.Kinesis.ExternalId.ValueString}}',
+			{{- end }}
+		{{- else if not (or .Kinesis.AccessKeyId.IsNull .Kinesis.AccessKeyId.IsUnknown) }}
 			'kinesis.access_key_id' = '{{.Kinesis.AccessKeyId.ValueString}}', 'kinesis.secret_access_key' = '{{.Kinesis.SecretAccessKey.ValueString}}',
 		{{- end }}
 		{{- if not (or .Kinesis.SchemaRegistry.IsNull .Kinesis.SchemaRegistry.IsUnknown) }}
 			'schema_registry.name' = "{{.Kinesis.SchemaRegistry.ValueString}}",
 		{{- end }}
+		{{- if not (or .Kinesis.StreamMode.IsNull .Kinesis.StreamMode.IsUnknown) }}
+			'kinesis.stream_mode' = '{{.Kinesis.StreamMode.ValueString}}',
+		{{- end }}
+		{{- if not (or .Kinesis.EnhancedFanOut.IsNull .Kinesis.EnhancedFanOut.IsUnknown) }}
+			'kinesis.enhanced_fan_out' = {{ if .Kinesis.EnhancedFanOut.ValueBool }}TRUE{{ else }}FALSE{{ end }},
+		{{- end }}
 		'uris' = '{{.Kinesis.Uris.ValueString}}'
 	{{- end }}
 	{{- if eq .Type "SNOWFLAKE" }}
-		'type' = SNOWFLAKE, 'access_region' = "{{.AccessRegion}}", 'snowflake.account_id' = '{{.Snowflake.AccountId.ValueString}}', 'snowflake.cloud.region' = '{{.Snowflake.CloudRegion.ValueString}}', 'snowflake.warehouse_name' = '{{.Snowflake.WarehouseName.ValueString}}', 'snowflake.role_name' = '{{.Snowflake.RoleName.ValueString}}', 'snowflake.username' = '{{.Snowflake.Username.ValueString}}', 'snowflake.client.key_file' = 'snowflake.client.key_file.pem', 'snowflake.client.key_passphrase' = '{{.Snowflake.ClientKeyPassphrase.ValueString}}', 'uris' = '{{.Snowflake.Uris.ValueString}}'
+		'type' = SNOWFLAKE, 'access_region' = "{{.AccessRegion}}", 'snowflake.account_id' = '{{.Snowflake.AccountId.ValueString}}', 'snowflake.cloud.region' = '{{.Snowflake.CloudRegion.ValueString}}', 'snowflake.warehouse_name' = '{{.Snowflake.WarehouseName.ValueString}}', 'snowflake.role_name' = '{{.Snowflake.RoleName.ValueString}}', 'snowflake.username' = '{{.Snowflake.Username.ValueString}}',
+		{{- if .SnowflakeOAuth }}
+			'snowflake.oauth.client_id' = '{{.SnowflakeOAuth.ClientId.ValueString}}', 'snowflake.oauth.client_secret' = '{{.SnowflakeOAuth.ClientSecret.ValueString}}', 'snowflake.oauth.token_endpoint' = '{{.SnowflakeOAuth.TokenEndpoint.ValueString}}',
+		{{- else if not (or .Snowflake.CredentialsSecretArn.IsNull .Snowflake.CredentialsSecretArn.IsUnknown) }}
+			'credentials.secret_arn' = '{{.Snowflake.CredentialsSecretArn.ValueString}}',
+		{{- else }}
+			'snowflake.client.key_file' = 'snowflake.client.key_file.pem',
+			{{- if not (or .Snowflake.ClientKeyPassphrase.IsNull .Snowflake.ClientKeyPassphrase.IsUnknown) }}
+				'snowflake.client.key_passphrase' = '{{.Snowflake.ClientKeyPassphrase.ValueString}}',
+			{{- end }}
+		{{- end }}
+		'uris' = '{{.Snowflake.Uris.ValueString}}'
 	{{- end }}
 	{{- if eq .Type "DATABRICKS" }}
-		'type' = DATABRICKS, 'access_region' = "{{.AccessRegion}}", 'databricks.app_token' = '{{.Databricks.AppToken.ValueString}}', 'databricks.warehouse_id' = '{{.Databricks.WarehouseId.ValueString}}', 'databricks.warehouse_port' = 443, 'aws.access_key_id' = '{{.Databricks.AccessKeyId.ValueString}}', 'aws.secret_access_key' = '{{.Databricks.SecretAccessKey.ValueString}}', 'databricks.cloud.s3.bucket' = '{{.Databricks.CloudS3Bucket.ValueString}}', 'databricks.cloud.region' = '{{.Databricks.CloudRegion.ValueString}}', 'uris' = '{{.Databricks.Uris.ValueString}}'
+		'type' = DATABRICKS, 'access_region' = "{{.AccessRegion}}", 'databricks.app_token' = '{{.Databricks.AppToken.ValueString}}', 'databricks.warehouse_id' = '{{.Databricks.WarehouseId.ValueString}}', 'databricks.warehouse_port' = 443,
+		{{- if not (or .Databricks.RoleArn.IsNull .Databricks.RoleArn.IsUnknown) }}
+			'aws.role_arn' = '{{.Databricks.RoleArn.ValueString}}',
+			{{- if not (or .Databricks.ExternalId.IsNull .Databricks.ExternalId.IsUnknown) }}
+				'aws.external_id' = '{{.Databricks.ExternalId.ValueString}}',
+			{{- end }}
+		{{- else }}
+			'aws.access_key_id' = '{{.Databricks.AccessKeyId.ValueString}}', 'aws.secret_access_key' = '{{.Databricks.SecretAccessKey.ValueString}}',
+		{{- end }}
+		'databricks.cloud.s3.bucket' = '{{.Databricks.CloudS3Bucket.ValueString}}', 'databricks.cloud.region' = '{{.Databricks.CloudRegion.ValueString}}', 'uris' = '{{.Databricks.Uris.ValueString}}'
 	{{- end }}
 	{{- if eq .Type "POSTGRESQL" }}
-		'type' = POSTGRESQL, 'access_region' = "{{.AccessRegion}}", 'postgres.username' = '{{.Postgres.Username.ValueString}}', 'postgres.password' = '{{.Postgres.Password.ValueString}}', 'uris' = '{{.Postgres.Uris.ValueString}}'
+		'type' = POSTGRESQL, 'access_region' = "{{.AccessRegion}}",
+		{{- if not (or .Postgres.CredentialsSecretArn.IsNull .Postgres.CredentialsSecretArn.IsUnknown) }}
+			'credentials.secret_arn' = '{{.Postgres.CredentialsSecretArn.ValueString}}',
+		{{- else }}
+			'postgres.username' = '{{.Postgres.Username.ValueString}}', 'postgres.password' = '{{.Postgres.Password.ValueString}}',
+		{{- end }}
+		'uris' = '{{.Postgres.Uris.ValueString}}'
+	{{- end }}
+	{{- range .AdditionalProperties }},
+		{{ . }}
 	{{- end }}
 );`
 
@@ -457,6 +765,10 @@ func (d *StoreResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	if store.Name.IsNull() || store.Name.IsUnknown() {
+		store.Name = types.StringValue(util.PrefixedUniqueName(store.NamePrefix.ValueString()))
+	}
+
 	roleName := d.cfg.Role
 	if !store.Owner.IsNull() && !store.Owner.IsUnknown() {
 		roleName = store.Owner.ValueString()
@@ -469,18 +781,26 @@ func (d *StoreResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	defer conn.Close()
 
-	var kafkaProperties KafkaProperties
-	var confluentKafkaProperties ConfleuntKafkaProperties
-	var kinesisProperties KinesisProperties
-	var snowflakeProperties SnowflakeProperties
-	var databricksProperties DatabricksProperties
-	var postgresProperties PostgresProperties
+	d.cfg.InvalidateStoreType(d.cfg.ObjectName(store.Name.ValueString()))
+
+	var kafkaProperties models.Kafka
+	var confluentKafkaProperties models.ConfluentKafka
+	var kinesisProperties models.Kinesis
+	var snowflakeProperties models.Snowflake
+	var snowflakeOAuthProperties *models.SnowflakeOAuth
+	var databricksProperties models.Databricks
+	var postgresProperties models.Postgres
 	var stype string
+	var additionalProperties types.Map
+	var typeAttribute string
+	var schemaRegistry types.String
 
 	switch {
 	case !store.Kafka.IsNull() && !store.Kafka.IsUnknown():
 		stype = "KAFKA"
-		resp.Diagnostics.Append(store.Kafka.As(ctx, &kafkaProperties, basetypes.ObjectAsOptions{})...)
+		resp.Diagnostics.Append(models.FromObject(ctx, store.Kafka, &kafkaProperties)...)
+		additionalProperties, typeAttribute = kafkaProperties.AdditionalProperties, "kafka"
+		schemaRegistry = kafkaProperties.SchemaRegistry
 		if kafkaProperties.TlsDisabled.IsNull() || kafkaProperties.TlsDisabled.IsUnknown() {
 			kafkaProperties.TlsDisabled = types.BoolValue(false)
 		}
@@ -488,25 +808,37 @@ func (d *StoreResource) Create(ctx context.Context, req resource.CreateRequest,
 			kafkaProperties.TlsVerifyServerHostname = types.BoolValue(true)
 		}
 		var dg diag.Diagnostics
-		store.Kafka, dg = types.ObjectValueFrom(ctx, kafkaProperties.AttributeTypes(), kafkaProperties)
+		store.Kafka, dg = models.ResourceObject(ctx, kafkaProperties)
 		resp.Diagnostics.Append(dg...)
 	case !store.ConfleuntKafka.IsNull() && !store.ConfleuntKafka.IsUnknown():
 		stype = "CONFLUENT_KAFKA"
-		resp.Diagnostics.Append(store.ConfleuntKafka.As(ctx, &confluentKafkaProperties, basetypes.ObjectAsOptions{})...)
+		resp.Diagnostics.Append(models.FromObject(ctx, store.ConfleuntKafka, &confluentKafkaProperties)...)
+		additionalProperties, typeAttribute = confluentKafkaProperties.AdditionalProperties, "confluent_kafka"
+		schemaRegistry = confluentKafkaProperties.SchemaRegistry
 	case !store.Kinesis.IsNull() && !store.Kinesis.IsUnknown():
 		stype = "KINESIS"
-		resp.Diagnostics.Append(store.Kinesis.As(ctx, &kinesisProperties, basetypes.ObjectAsOptions{})...)
+		resp.Diagnostics.Append(models.FromObject(ctx, store.Kinesis, &kinesisProperties)...)
+		additionalProperties, typeAttribute = kinesisProperties.AdditionalProperties, "kinesis"
+		schemaRegistry = kinesisProperties.SchemaRegistry
 	case !store.Snowflake.IsNull() && !store.Snowflake.IsUnknown():
 		stype = "SNOWFLAKE"
-		resp.Diagnostics.Append(store.Snowflake.As(ctx, &snowflakeProperties, basetypes.ObjectAsOptions{})...)
-		b := io.NopCloser(bytes.NewBuffer([]byte(snowflakeProperties.ClientKeyFile.ValueString())))
-		ctx = gods.WithAttachment(ctx, "snowflake.client.key_file.pem", b)
+		resp.Diagnostics.Append(models.FromObject(ctx, store.Snowflake, &snowflakeProperties)...)
+		additionalProperties, typeAttribute = snowflakeProperties.AdditionalProperties, "snowflake"
+		if !snowflakeProperties.OAuth.IsNull() && !snowflakeProperties.OAuth.IsUnknown() {
+			snowflakeOAuthProperties = &models.SnowflakeOAuth{}
+			resp.Diagnostics.Append(snowflakeProperties.OAuth.As(ctx, snowflakeOAuthProperties, basetypes.ObjectAsOptions{})...)
+		} else if snowflakeProperties.CredentialsSecretArn.IsNull() {
+			b := io.NopCloser(bytes.NewBuffer([]byte(snowflakeProperties.ClientKeyFile.ValueString())))
+			ctx = gods.WithAttachment(ctx, "snowflake.client.key_file.pem", b)
+		}
 	case !store.Databricks.IsNull() && !store.Databricks.IsUnknown():
 		stype = "DATABRICKS"
-		resp.Diagnostics.Append(store.Databricks.As(ctx, &databricksProperties, basetypes.ObjectAsOptions{})...)
+		resp.Diagnostics.Append(models.FromObject(ctx, store.Databricks, &databricksProperties)...)
+		additionalProperties, typeAttribute = databricksProperties.AdditionalProperties, "databricks"
 	case !store.Postgres.IsNull() && !store.Postgres.IsUnknown():
 		stype = "POSTGRESQL"
-		resp.Diagnostics.Append(store.Postgres.As(ctx, &postgresProperties, basetypes.ObjectAsOptions{})...)
+		resp.Diagnostics.Append(models.FromObject(ctx, store.Postgres, &postgresProperties)...)
+		additionalProperties, typeAttribute = postgresProperties.AdditionalProperties, "postgres"
 	default:
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "invalid store", fmt.Errorf("must specify atleast one store type properties"))
 	}
@@ -514,28 +846,81 @@ func (d *StoreResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	extraProperties, dg := renderAdditionalProperties(ctx, additionalProperties)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(extraProperties) > 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root(typeAttribute).AtName("additional_properties"), "unvalidated store properties", "additional_properties are passed to DeltaStream as is and are not validated by the provider")
+	}
+	clientProperties, dg := util.RenderProperties(ctx, kafkaClientPropertyPrefix, kafkaProperties.ClientProperties)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	extraProperties = append(clientProperties, extraProperties...)
+	tags, dg := util.TagsProperty(ctx, store.TagsAll)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if tags != "" {
+		extraProperties = append(extraProperties, tags)
+	}
+
 	b := bytes.NewBuffer(nil)
 	if err := template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
-		"Name":           store.Name.ValueString(),
+		"Name":           d.cfg.ObjectName(store.Name.ValueString()),
 		"Type":           stype,
 		"AccessRegion":   store.AccessRegion.ValueString(),
 		"Kafka":          kafkaProperties,
 		"ConfluentKafka": confluentKafkaProperties,
 		"Kinesis":        kinesisProperties,
 		"Snowflake":      snowflakeProperties,
+		"SnowflakeOAuth": snowflakeOAuthProperties,
 		"Databricks":     databricksProperties,
 		"Postgres":       postgresProperties,
+
+		"AdditionalProperties": extraProperties,
 	}); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to render store sql", err)
 		return
 	}
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunCreate(ctx, req.Plan, &resp.State, "store", store.Name.ValueString())...)
+		return
+	}
+	if !schemaRegistry.IsNull() && !schemaRegistry.IsUnknown() {
+		if err := waitForSchemaRegistry(ctx, conn, schemaRegistry.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(typeAttribute).AtName("schema_registry_name"), "schema registry not ready", err.Error())
+			return
+		}
+	}
 	dsql := b.String()
-	if _, err := conn.ExecContext(ctx, dsql); err != nil {
+	createCtx, statement := util.WithStatementRecorder(ctx)
+	created, err := util.DescribeRows(createCtx, conn, dsql)
+	if err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create store", err)
 		return
 	}
+	store.StatementID = statement.ID()
 
-	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
+	// the store is looked up right away, a store that is already ready needs no further poll; a hint from CREATE STORE
+	// delays the first lookup and becomes the base of the following ones
+	backoff := storeReadyBaseBackoff
+	if hint := storeReadyHint(created); hint > 0 {
+		backoff = hint
+		tflog.Debug(ctx, "waiting for store readiness hint", map[string]any{"name": store.Name.ValueString(), "retry_after": hint.String()})
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create store", ctx.Err())
+			return
+		case <-time.After(backoff):
+		}
+	}
+
+	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(backoff)), func(ctx context.Context) (err error) {
 		store, err = d.updateComputed(ctx, conn, store)
 		if err != nil {
 			return err
@@ -546,7 +931,7 @@ func (d *StoreResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 		return nil
 	}); err != nil {
-		if _, derr := conn.ExecContext(ctx, `DROP STORE "`+store.Name.ValueString()+`";`); derr != nil {
+		if _, derr := conn.ExecContext(ctx, `DROP STORE "`+d.cfg.ObjectName(store.Name.ValueString())+`";`); derr != nil {
 			var sqlErr gods.ErrSQLError
 			if !(errors.As(derr, &sqlErr) && sqlErr.SQLCode != gods.SqlStateInvalidParameter) {
 				tflog.Error(ctx, "failed to clean up store", map[string]any{
@@ -559,15 +944,18 @@ func (d *StoreResource) Create(ctx context.Context, req resource.CreateRequest,
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to create store", err)
 		return
 	}
+	// the store was just reported ready, connectivity is tested from the next refresh on
+	store.ConnectivityError = types.StringNull()
 	tflog.Info(ctx, "Store created", map[string]any{"name": store.Name.ValueString()})
+	resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestCreated, "deltastream_store", store.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, store)...)
 }
 
 func (d *StoreResource) updateComputed(ctx context.Context, conn *sql.Conn, store StoreResourceData) (StoreResourceData, error) {
-	row := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT "region", type, status, "owner", created_at, updated_at FROM deltastream.sys."stores" WHERE name = '%s';`, store.Name.ValueString()))
+	row := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT "region", type, status, "owner", created_at, updated_at FROM deltastream.sys."stores" WHERE name = '%s';`, d.cfg.ObjectName(store.Name.ValueString())))
 	if row.Err() != nil {
 		if errors.Is(row.Err(), sql.ErrNoRows) {
-			return store, &gods.ErrSQLError{SQLCode: gods.SqlStateInvalidStore}
+			return store, gods.ErrSQLError{SQLCode: gods.SqlStateInvalidStore}
 		}
 
 		return store, row.Err()
@@ -582,16 +970,19 @@ func (d *StoreResource) updateComputed(ctx context.Context, conn *sql.Conn, stor
 	if err := row.Scan(&accessRegion, &kind, &state, &owner, &createdAt, &updatedAt); err != nil {
 		return store, err
 	}
+	return d.setComputed(store, accessRegion, kind, state, owner, createdAt, updatedAt), nil
+}
 
+func (d *StoreResource) setComputed(store StoreResourceData, accessRegion, kind, state, owner string, createdAt, updatedAt time.Time) StoreResourceData {
+	store.ID = util.ResourceID(d.cfg.Organization, "store", store.Name.ValueString())
 	store.Type = types.StringValue(kind)
+	d.cfg.SetStoreType(d.cfg.ObjectName(store.Name.ValueString()), kind)
 	store.AccessRegion = types.StringValue(accessRegion)
 	store.State = types.StringValue(state)
 	store.Owner = types.StringValue(owner)
-	store.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-	store.UpdatedAt = types.StringValue(updatedAt.Format(time.RFC3339))
-	store.Owner = types.StringValue(owner)
-	store.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
-	return store, nil
+	store.CreatedAt = util.TimestampValue(createdAt)
+	store.UpdatedAt = util.TimestampValue(updatedAt)
+	return store
 }
 
 func (d *StoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -602,6 +993,11 @@ func (d *StoreResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunDelete(ctx, "store", store.Name.ValueString())...)
+		return
+	}
+
 	roleName := d.cfg.Role
 	if !store.Owner.IsNull() && !store.Owner.IsUnknown() {
 		roleName = store.Owner.ValueString()
@@ -614,38 +1010,76 @@ func (d *StoreResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 	defer conn.Close()
 
-	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) error {
-		if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DROP STORE "%s";`, store.Name.ValueString())); err != nil {
-			var sqlErr gods.ErrSQLError
-			if !errors.As(err, &sqlErr) || sqlErr.SQLCode != gods.SqlStateInvalidStore {
-				return retry.RetryableError(err)
-			}
-		}
-		return nil
-	}); err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to drop store", err)
-		return
-	}
-
-	if err := retry.Do(ctx, retry.WithMaxDuration(time.Minute*5, retry.NewExponential(time.Second)), func(ctx context.Context) (err error) {
-		store, err = d.updateComputed(ctx, conn, store)
-		if err != nil {
-			var godsErr gods.ErrSQLError
-			if errors.As(err, &godsErr) && godsErr.SQLCode != gods.SqlStateInvalidStore {
-				return retry.RetryableError(err)
-			}
-			return nil
-		}
-		return retry.RetryableError(fmt.Errorf("timedout waiting for store to be deleted"))
+	d.cfg.InvalidateStoreType(d.cfg.ObjectName(store.Name.ValueString()))
+	if err := util.DropAndWait(ctx, conn, "store", fmt.Sprintf(`DROP STORE "%s";`, d.cfg.ObjectName(store.Name.ValueString())), func(ctx context.Context) error {
+		_, err := d.updateComputed(ctx, conn, store)
+		return err
 	}); err != nil {
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to delete store", err)
+		return
 	}
 
 	tflog.Info(ctx, "Store deleted", map[string]any{"name": store.Name.ValueString()})
 }
 
+// Update only supports changing verify_connectivity_on_read and the tags, every other attribute requires replacing the
+// store.
 func (d *StoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("store update not supported"))
+	var plan, store StoreResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &store)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.cfg.DryRun {
+		resp.Diagnostics.Append(util.DryRunUpdate(ctx, req.Plan, &resp.State, "store", store.Name.ValueString())...)
+		return
+	}
+
+	if !plan.Owner.IsUnknown() && !plan.Owner.Equal(store.Owner) {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "update not supported", fmt.Errorf("store update not supported"))
+		return
+	}
+
+	if !plan.TagsAll.Equal(store.TagsAll) {
+		roleName := d.cfg.Role
+		if !store.Owner.IsNull() && !store.Owner.IsUnknown() {
+			roleName = store.Owner.ValueString()
+		}
+
+		ctx, conn, err := util.GetConnection(ctx, d.cfg.Db, d.cfg.SessionID, d.cfg.Organization, roleName)
+		if err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to connect", err)
+			return
+		}
+		defer conn.Close()
+
+		stmt, dg := util.SetTagsStatement(ctx, "STORE", `"`+d.cfg.ObjectName(store.Name.ValueString())+`"`, plan.TagsAll)
+		resp.Diagnostics.Append(dg...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to set store tags", err)
+			return
+		}
+		resp.Diagnostics.Append(d.cfg.RecordManifest(ctx, util.ManifestUpdated, "deltastream_store", store.Name.ValueString())...)
+	}
+	store.Tags = plan.Tags
+	store.TagsAll = plan.TagsAll
+
+	if util.Imported(ctx, req.Private) {
+		store.Kafka, store.ConfleuntKafka, store.Kinesis = plan.Kafka, plan.ConfleuntKafka, plan.Kinesis
+		store.Snowflake, store.Databricks, store.Postgres = plan.Snowflake, plan.Databricks, plan.Postgres
+		store.KafkaClient = plan.KafkaClient
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, util.ImportedKey, nil)...)
+	}
+	store.ConnectivityVerify = plan.ConnectivityVerify
+	if !store.ConnectivityVerify.ValueBool() {
+		store.ConnectivityError = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, store)...)
 }
 
 func (d *StoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -669,16 +1103,41 @@ func (d *StoreResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 	defer conn.Close()
 
+	recorded := store
 	store, err = d.updateComputed(ctx, conn, store)
 	if err != nil {
-		var godsErr gods.ErrSQLError
-		if errors.As(err, &godsErr) && godsErr.SQLCode == gods.SqlStateInvalidStore {
-			resp.State.RemoveResource(ctx)
+		if util.RemoveIfNotFound(ctx, &resp.State, "store", err) {
 			return
 		}
 		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
 		return
 	}
+	resp.Diagnostics.Append(d.cfg.ReportDrift("store", store.Name.ValueString(), path.Root("owner"), recorded.Owner, store.Owner)...)
+	resp.Diagnostics.Append(d.cfg.ReportDrift("store", store.Name.ValueString(), path.Root("type"), recorded.Type, store.Type)...)
+	resp.Diagnostics.Append(d.cfg.ReadTags(ctx, conn, fmt.Sprintf(`DESCRIBE STORE "%s";`, d.cfg.ObjectName(store.Name.ValueString())), &store.Tags, &store.TagsAll)...)
+	if d.cfg.StrictDriftChecks {
+		resp.Diagnostics.Append(d.reportURIDrift(ctx, conn, store)...)
+	}
+
+	if store.ConnectivityVerify.ValueBool() {
+		store, err = d.verifyConnectivity(ctx, conn, store)
+		if err != nil {
+			if util.RemoveIfNotFound(ctx, &resp.State, "store", err) {
+				return
+			}
+			resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to update state", err)
+			return
+		}
+		if !store.ConnectivityError.IsNull() {
+			resp.Diagnostics.AddAttributeWarning(path.Root("connectivity_error"), "store connectivity test failed",
+				fmt.Sprintf("DeltaStream could not connect to store %s: %s", store.Name.ValueString(), store.ConnectivityError.ValueString()))
+		}
+	} else {
+		store.ConnectivityError = types.StringNull()
+	}
 
+	var dg diag.Diagnostics
+	store.KafkaClient, dg = storeKafkaClient(ctx, store)
+	resp.Diagnostics.Append(dg...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, store)...)
 }
@@ -0,0 +1,245 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/store/models"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+func TestRenderAdditionalProperties(t *testing.T) {
+	ctx := context.Background()
+	m := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"kafka.client.id":    types.StringValue("ds"),
+		"a'quoted":           types.StringValue("it's"),
+		"kafka.fetch.max.ms": types.StringValue("500"),
+	})
+
+	got, dg := renderAdditionalProperties(ctx, m)
+	if dg.HasError() {
+		t.Fatalf("renderAdditionalProperties() diagnostics = %v", dg)
+	}
+	want := []string{`'a''quoted' = 'it''s'`, `'kafka.client.id' = 'ds'`, `'kafka.fetch.max.ms' = '500'`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("renderAdditionalProperties() = %v, want %v", got, want)
+	}
+
+	if got, _ := renderAdditionalProperties(ctx, types.MapNull(types.StringType)); got != nil {
+		t.Errorf("renderAdditionalProperties(null) = %v, want nil", got)
+	}
+}
+
+func TestRenderKafkaClientProperties(t *testing.T) {
+	ctx := context.Background()
+	m := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"security.protocol": types.StringValue("SASL_SSL"),
+		"sasl.jaas.config":  types.StringValue("username='ds' password='s3cr3t'"),
+	})
+
+	got, dg := util.RenderProperties(ctx, kafkaClientPropertyPrefix, m)
+	if dg.HasError() {
+		t.Fatalf("RenderProperties() diagnostics = %v", dg)
+	}
+	want := []string{`'kafka.sasl.jaas.config' = 'username=''ds'' password=''s3cr3t'''`, `'kafka.security.protocol' = 'SASL_SSL'`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RenderProperties() = %v, want %v", got, want)
+	}
+}
+
+func TestCreateStatementConfluentKafka(t *testing.T) {
+	tests := []struct {
+		name  string
+		props models.ConfluentKafka
+		want  string
+	}{
+		{
+			name: "sasl credentials",
+			props: models.ConfluentKafka{
+				SaslHashFunc: types.StringValue("SHA512"),
+				SaslUsername: types.StringValue("user"),
+				SaslPassword: types.StringValue("pass"),
+			},
+			want: `'kafka.sasl.username' = 'user', 'kafka.sasl.password' = 'pass',`,
+		},
+		{
+			name: "cluster api key",
+			props: models.ConfluentKafka{
+				SaslHashFunc:     types.StringValue("PLAIN"),
+				ClusterApiKey:    types.StringValue("key"),
+				ClusterApiSecret: types.StringValue("secret"),
+				SchemaRegistry:   types.StringValue("registry"),
+			},
+			want: `'kafka.sasl.username' = 'key', 'kafka.sasl.password' = 'secret',`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.props.Uris = types.StringValue("broker:9092")
+			b := bytes.NewBuffer(nil)
+			if err := template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
+				"Name":           "s",
+				"Type":           "CONFLUENT_KAFKA",
+				"AccessRegion":   "AWS us-east-1",
+				"ConfluentKafka": tt.props,
+			}); err != nil {
+				t.Fatalf("failed to render statement: %v", err)
+			}
+			if !strings.Contains(b.String(), tt.want) {
+				t.Errorf("statement %q does not contain %q", b.String(), tt.want)
+			}
+			if hasRegistry := strings.Contains(b.String(), `'schema_registry.name' = "registry"`); hasRegistry == tt.props.SchemaRegistry.IsNull() {
+				t.Errorf("statement %q, schema registry rendered = %t", b.String(), hasRegistry)
+			}
+		})
+	}
+}
+
+func TestStoreSchemasMatchModels(t *testing.T) {
+	ctx := context.Background()
+
+	resourceResp := &resource.SchemaResponse{}
+	(&StoreResource{}).Schema(ctx, resource.SchemaRequest{}, resourceResp)
+	datasourceResp := &datasource.SchemaResponse{}
+	(&StoreDataSource{}).Schema(ctx, datasource.SchemaRequest{}, datasourceResp)
+
+	for name, m := range map[string]models.Model{
+		"kafka":           models.Kafka{},
+		"confluent_kafka": models.ConfluentKafka{},
+		"kinesis":         models.Kinesis{},
+		"snowflake":       models.Snowflake{},
+		"databricks":      models.Databricks{},
+		"postgres":        models.Postgres{},
+	} {
+		want := types.ObjectType{AttrTypes: m.AttributeTypes()}
+		if got := resourceResp.Schema.Attributes[name].GetType(); !got.Equal(want) {
+			t.Errorf("resource %s = %s, want %s", name, got, want)
+		}
+		want = types.ObjectType{AttrTypes: m.DatasourceAttributeTypes()}
+		if got := datasourceResp.Schema.Attributes[name].GetType(); !got.Equal(want) {
+			t.Errorf("data source %s = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestCreateStatementKinesisSchemaRegistry(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	if err := template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
+		"Name":         "s",
+		"Type":         "KINESIS",
+		"AccessRegion": "AWS us-east-1",
+		"Kinesis": models.Kinesis{
+			Uris:           types.StringValue("https://kinesis.us-east-1.amazonaws.com"),
+			RoleArn:        types.StringValue("arn:aws:iam::123456789012:role/kinesis"),
+			SchemaRegistry: types.StringValue("registry"),
+		},
+	}); err != nil {
+		t.Fatalf("failed to render statement: %v", err)
+	}
+	if want := `'schema_registry.name' = "registry",`; !strings.Contains(b.String(), want) {
+		t.Errorf("statement %q does not contain %q", b.String(), want)
+	}
+}
+
+func TestCreateStatementKinesisStreamMode(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	if err := template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
+		"Name":         "s",
+		"Type":         "KINESIS",
+		"AccessRegion": "AWS us-east-1",
+		"Kinesis": models.Kinesis{
+			Uris:           types.StringValue("https://kinesis.us-east-1.amazonaws.com"),
+			RoleArn:        types.StringValue("arn:aws:iam::123456789012:role/kinesis"),
+			StreamMode:     types.StringValue("ON_DEMAND"),
+			EnhancedFanOut: types.BoolValue(true),
+		},
+	}); err != nil {
+		t.Fatalf("failed to render statement: %v", err)
+	}
+	for _, want := range []string{`'kinesis.stream_mode' = 'ON_DEMAND',`, `'kinesis.enhanced_fan_out' = TRUE,`} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("statement %q does not contain %q", b.String(), want)
+		}
+	}
+}
+
+func TestCreateStatementCredentialsSecret(t *testing.T) {
+	arn := "arn:aws:secretsmanager:us-east-1:123456789012:secret:store-credentials-AbCdEf"
+	b := bytes.NewBuffer(nil)
+	if err := template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
+		"Name":         "s",
+		"Type":         "POSTGRESQL",
+		"AccessRegion": "AWS us-east-1",
+		"Postgres": models.Postgres{
+			Uris:                 types.StringValue("postgresql://db:5432/app"),
+			CredentialsSecretArn: types.StringValue(arn),
+		},
+	}); err != nil {
+		t.Fatalf("failed to render statement: %v", err)
+	}
+	if want := `'credentials.secret_arn' = '` + arn + `',`; !strings.Contains(b.String(), want) {
+		t.Errorf("statement %q does not contain %q", b.String(), want)
+	}
+	if strings.Contains(b.String(), "postgres.password") {
+		t.Errorf("statement %q passes a password", b.String())
+	}
+}
+
+func TestCredentialsSecretReference(t *testing.T) {
+	for ref, want := range map[string]bool{
+		"arn:aws:secretsmanager:us-east-1:123456789012:secret:store-AbCdEf":     true,
+		"arn:aws-us-gov:secretsmanager:us-gov-west-1:123456789012:secret:store": true,
+		"projects/analytics/secrets/store":                                      true,
+		"projects/analytics/secrets/store/versions/3":                           true,
+		"arn:aws:iam::123456789012:role/store":                                  false,
+		"store-credentials":                                                     false,
+	} {
+		if got := credentialsSecretReference.MatchString(ref); got != want {
+			t.Errorf("credentialsSecretReference.MatchString(%q) = %t, want %t", ref, got, want)
+		}
+	}
+}
+
+func TestCreateStatementKafkaTopicPolicy(t *testing.T) {
+	render := func(props models.Kafka) string {
+		props.Uris = types.StringValue("broker:9092")
+		props.SaslHashFunc = types.StringValue("NONE")
+		b := bytes.NewBuffer(nil)
+		if err := template.Must(template.New("").Parse(createStatement)).Execute(b, map[string]any{
+			"Name":         "s",
+			"Type":         "KAFKA",
+			"AccessRegion": "AWS us-east-1",
+			"Kafka":        props,
+		}); err != nil {
+			t.Fatalf("failed to render statement: %v", err)
+		}
+		return b.String()
+	}
+
+	stmt := render(models.Kafka{
+		TopicAutoCreate:        types.BoolValue(false),
+		TopicDefaultPartitions: types.Int64Value(6),
+		TopicDefaultReplicas:   types.Int64Value(3),
+	})
+	for _, want := range []string{`'kafka.topic.auto_create' = FALSE,`, `'kafka.topic.default_partitions' = 6,`, `'kafka.topic.default_replicas' = 3,`} {
+		if !strings.Contains(stmt, want) {
+			t.Errorf("statement %q does not contain %q", stmt, want)
+		}
+	}
+
+	if stmt := render(models.Kafka{}); strings.Contains(stmt, "kafka.topic.") {
+		t.Errorf("statement %q sets topic properties that are not configured", stmt)
+	}
+}
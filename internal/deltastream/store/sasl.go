@@ -0,0 +1,105 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/store/models"
+)
+
+// kafkaSaslValidator checks that the attributes of a kafka block match its SASL hash function, so that a missing or
+// stray credential is reported at plan time against the attribute to fix rather than by CREATE STORE.
+type kafkaSaslValidator struct{}
+
+func (v kafkaSaslValidator) Description(ctx context.Context) string {
+	return "validates that the credentials match sasl_hash_function: AWS_MSK_IAM requires msk_iam_role_arn and msk_aws_region, " +
+		"PLAIN, SHA256 and SHA512 require sasl_username and sasl_password or credentials_secret_arn, NONE takes no credentials"
+}
+
+func (v kafkaSaslValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v kafkaSaslValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var props models.Kafka
+	resp.Diagnostics.Append(models.FromObject(ctx, req.ConfigValue, &props)...)
+	if resp.Diagnostics.HasError() || props.SaslHashFunc.IsUnknown() {
+		return
+	}
+	hashFunc := props.SaslHashFunc.ValueString()
+
+	required := func(name string, value attr.Value) {
+		if value.IsNull() {
+			resp.Diagnostics.AddAttributeError(req.Path.AtName(name), "missing attribute",
+				fmt.Sprintf("%s is required when sasl_hash_function is %s", name, hashFunc))
+		}
+	}
+	forbidden := func(name string, value attr.Value) {
+		if !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(req.Path.AtName(name), "invalid attribute",
+				fmt.Sprintf("%s cannot be set when sasl_hash_function is %s", name, hashFunc))
+		}
+	}
+
+	switch hashFunc {
+	case "AWS_MSK_IAM":
+		required("msk_iam_role_arn", props.MskIamRoleArn)
+		required("msk_aws_region", props.MskAwsRegion)
+		forbidden("sasl_username", props.SaslUsername)
+		forbidden("sasl_password", props.SaslPassword)
+		forbidden("credentials_secret_arn", props.CredentialsSecretArn)
+	case "PLAIN", "SHA256", "SHA512":
+		// the secret holds the SASL credentials, the schema validators reject setting both
+		if props.CredentialsSecretArn.IsNull() {
+			required("sasl_username", props.SaslUsername)
+			required("sasl_password", props.SaslPassword)
+		}
+		forbidden("msk_iam_role_arn", props.MskIamRoleArn)
+		forbidden("msk_aws_region", props.MskAwsRegion)
+	case "NONE":
+		forbidden("sasl_username", props.SaslUsername)
+		forbidden("sasl_password", props.SaslPassword)
+		forbidden("msk_iam_role_arn", props.MskIamRoleArn)
+		forbidden("msk_aws_region", props.MskAwsRegion)
+		forbidden("credentials_secret_arn", props.CredentialsSecretArn)
+	}
+}
+
+// confluentKafkaSaslValidator checks that Confluent Cloud API keys are used with SASL/PLAIN, the only mechanism
+// accepting them.
+type confluentKafkaSaslValidator struct{}
+
+func (v confluentKafkaSaslValidator) Description(ctx context.Context) string {
+	return "validates that sasl_hash_function is PLAIN when authenticating with cluster_api_key"
+}
+
+func (v confluentKafkaSaslValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v confluentKafkaSaslValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var props models.ConfluentKafka
+	resp.Diagnostics.Append(models.FromObject(ctx, req.ConfigValue, &props)...)
+	if resp.Diagnostics.HasError() || props.SaslHashFunc.IsUnknown() {
+		return
+	}
+
+	if !props.ClusterApiKey.IsNull() && props.SaslHashFunc.ValueString() != "PLAIN" {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("sasl_hash_function"), "invalid SASL hash function",
+			fmt.Sprintf("sasl_hash_function must be PLAIN when authenticating with cluster_api_key, got %s", props.SaslHashFunc.ValueString()))
+	}
+}
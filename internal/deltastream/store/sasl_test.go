@@ -0,0 +1,92 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/store/models"
+)
+
+func TestKafkaSaslValidator(t *testing.T) {
+	ctx := context.Background()
+	set := types.StringValue("x")
+
+	tests := []struct {
+		name  string
+		props models.Kafka
+		want  []string
+	}{
+		{name: "none", props: models.Kafka{SaslHashFunc: types.StringValue("NONE")}},
+		{
+			name:  "none with credentials",
+			props: models.Kafka{SaslHashFunc: types.StringValue("NONE"), SaslUsername: set, CredentialsSecretArn: set},
+			want:  []string{"kafka.sasl_username", "kafka.credentials_secret_arn"},
+		},
+		{
+			name:  "iam",
+			props: models.Kafka{SaslHashFunc: types.StringValue("AWS_MSK_IAM"), MskIamRoleArn: set, MskAwsRegion: types.StringUnknown()},
+		},
+		{
+			name:  "iam without region",
+			props: models.Kafka{SaslHashFunc: types.StringValue("AWS_MSK_IAM"), MskIamRoleArn: set, SaslPassword: set},
+			want:  []string{"kafka.msk_aws_region", "kafka.sasl_password"},
+		},
+		{
+			name:  "iam with secret",
+			props: models.Kafka{SaslHashFunc: types.StringValue("AWS_MSK_IAM"), MskIamRoleArn: set, MskAwsRegion: set, CredentialsSecretArn: set},
+			want:  []string{"kafka.credentials_secret_arn"},
+		},
+		{
+			name:  "plain",
+			props: models.Kafka{SaslHashFunc: types.StringValue("PLAIN"), SaslUsername: set, SaslPassword: set},
+		},
+		{
+			name:  "plain with secret",
+			props: models.Kafka{SaslHashFunc: types.StringValue("SHA512"), CredentialsSecretArn: set},
+		},
+		{
+			name:  "scram without credentials",
+			props: models.Kafka{SaslHashFunc: types.StringValue("SHA256"), MskAwsRegion: set},
+			want:  []string{"kafka.sasl_username", "kafka.sasl_password", "kafka.msk_aws_region"},
+		},
+		{
+			name:  "unknown hash function",
+			props: models.Kafka{SaslHashFunc: types.StringUnknown(), SaslUsername: set, MskIamRoleArn: set},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.props.AdditionalProperties = types.MapNull(types.StringType)
+			tt.props.ClientProperties = types.MapNull(types.StringType)
+			obj, dg := models.ResourceObject(ctx, tt.props)
+			if dg.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", dg)
+			}
+
+			var resp validator.ObjectResponse
+			kafkaSaslValidator{}.ValidateObject(ctx, validator.ObjectRequest{Path: path.Root("kafka"), ConfigValue: obj}, &resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics {
+				if withPath, ok := d.(interface{ Path() path.Path }); ok {
+					got = append(got, withPath.Path().String())
+				}
+			}
+			if len(got) != len(resp.Diagnostics) {
+				t.Fatalf("diagnostics without an attribute path: %v", resp.Diagnostics)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateObject() paths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
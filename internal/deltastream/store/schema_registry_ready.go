@@ -0,0 +1,62 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sethvargo/go-retry"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// schemaRegistryReadyMaxDuration bounds the wait for the schema registry of a store to be reported ready. Registries
+// created in the same apply are usually ready within seconds, a longer wait points at a misconfigured registry.
+var schemaRegistryReadyMaxDuration = time.Minute
+
+const schemaRegistryReadyBaseBackoff = time.Second
+
+// schemaRegistryState returns the state LIST SCHEMA_REGISTRIES reports for the named registry, found is false when
+// the registry is not listed.
+func schemaRegistryState(ctx context.Context, conn *sql.Conn, name string) (state string, found bool, err error) {
+	err = util.QueryRows(ctx, conn, `LIST SCHEMA_REGISTRIES;`, func(rows *sql.Rows) error {
+		var discard any
+		var srName string
+		var kind string
+		var srState string
+		var owner string
+		var createdAt time.Time
+		var updatedAt time.Time
+		if err := rows.Scan(&srName, &kind, &srState, &discard, &owner, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+		if srName != name {
+			return nil
+		}
+		state, found = srState, true
+		return util.ErrStopRows
+	})
+	return state, found, err
+}
+
+// waitForSchemaRegistry waits for the named schema registry to be listed as ready so that CREATE STORE does not fail
+// on a registry that was only just created. The error of the last check is returned when it never becomes ready.
+func waitForSchemaRegistry(ctx context.Context, conn *sql.Conn, name string) error {
+	backoff := retry.WithMaxDuration(schemaRegistryReadyMaxDuration, retry.NewExponential(schemaRegistryReadyBaseBackoff))
+	return retry.Do(ctx, backoff, func(ctx context.Context) error {
+		state, found, err := schemaRegistryState(ctx, conn, name)
+		switch {
+		case err != nil:
+			return err
+		case !found:
+			return retry.RetryableError(fmt.Errorf("schema registry %q does not exist", name))
+		case state != "ready":
+			return retry.RetryableError(fmt.Errorf("schema registry %q is %s, it must be ready before a store can use it", name, state))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestWaitForSchemaRegistry(t *testing.T) {
+	defer func(d time.Duration) { schemaRegistryReadyMaxDuration = d }(schemaRegistryReadyMaxDuration)
+	schemaRegistryReadyMaxDuration = 2 * time.Second
+
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^LIST SCHEMA_REGISTRIES;$`,
+		Columns: []mockserver.Column{
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "type", Type: "VARCHAR"},
+			{Name: "state", Type: "VARCHAR"},
+			{Name: "uris", Type: "VARCHAR"},
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+			{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("ready_registry", "CONFLUENT", "ready", "https://registry", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+			mockserver.Row("pending_registry", "CONFLUENT", "creating", "https://registry", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+		},
+	}})
+
+	if err := waitForSchemaRegistry(ctx, conn, "ready_registry"); err != nil {
+		t.Errorf("waitForSchemaRegistry(ready_registry) error = %v", err)
+	}
+	if err := waitForSchemaRegistry(ctx, conn, "pending_registry"); err == nil || !strings.Contains(err.Error(), `"pending_registry" is creating`) {
+		t.Errorf("waitForSchemaRegistry(pending_registry) error = %v, want not ready", err)
+	}
+	if err := waitForSchemaRegistry(ctx, conn, "missing_registry"); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("waitForSchemaRegistry(missing_registry) error = %v, want does not exist", err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package version
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+var _ datasource.DataSource = &VersionDataSource{}
+var _ datasource.DataSourceWithConfigure = &VersionDataSource{}
+
+func NewVersionDataSource() datasource.DataSource {
+	return &VersionDataSource{}
+}
+
+// VersionDataSource reports the provider and server versions, so that modules can check that the server supports a
+// feature before using it instead of failing at apply.
+type VersionDataSource struct {
+	cfg *config.DeltaStreamProviderCfg
+}
+
+func (d *VersionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DeltaStreamProviderCfg)
+	if !ok {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "provider error", fmt.Errorf("invalid provider data"))
+		return
+	}
+
+	d.cfg = cfg
+}
+
+type VersionDataSourceData struct {
+	ID              types.String `tfsdk:"id"`
+	ProviderVersion types.String `tfsdk:"provider_version"`
+	ServerVersion   types.String `tfsdk:"server_version"`
+	ServerMajor     types.Int64  `tfsdk:"server_major"`
+	ServerMinor     types.Int64  `tfsdk:"server_minor"`
+}
+
+func (d *VersionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Provider and DeltaStream server versions. Compare `server_major` and `server_minor` with the server release that introduced a feature to only create resources the server supports.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Global identifier of the version information",
+				Computed:    true,
+			},
+			"provider_version": schema.StringAttribute{
+				Description: "Version of the provider",
+				Computed:    true,
+			},
+			"server_version": schema.StringAttribute{
+				Description: "Version of the DeltaStream API server, such as 2.1.0",
+				Computed:    true,
+			},
+			"server_major": schema.Int64Attribute{
+				Description: "Major version of the DeltaStream API server",
+				Computed:    true,
+			},
+			"server_minor": schema.Int64Attribute{
+				Description: "Minor version of the DeltaStream API server",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *VersionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_version"
+}
+
+func (d *VersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	v, err := d.cfg.ServerVersion(ctx)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "failed to read server version", err)
+		return
+	}
+
+	serverVersion := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	data := VersionDataSourceData{
+		ID:              util.ResourceID(d.cfg.Organization, "version", serverVersion),
+		ProviderVersion: types.StringValue(d.cfg.ProviderVersion),
+		ServerVersion:   types.StringValue(serverVersion),
+		ServerMajor:     types.Int64Value(int64(v.Major)),
+		ServerMinor:     types.Int64Value(int64(v.Minor)),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,353 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package importgen renders Terraform import blocks and skeleton resource configurations for the objects of an
+// existing organization, so that they can be brought under management of the provider.
+package importgen
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const header = `# Generated by terraform-provider-deltastream import-gen.
+#
+# Complete the TODO items with the settings the server does not report, then check with terraform plan that no
+# object is replaced before applying the imports.
+
+`
+
+// storeTypeAttributes maps the store types reported by the server to the attribute configuring them.
+var storeTypeAttributes = map[string]string{
+	"KAFKA":           "kafka",
+	"CONFLUENT_KAFKA": "confluent_kafka",
+	"KINESIS":         "kinesis",
+	"SNOWFLAKE":       "snowflake",
+	"DATABRICKS":      "databricks",
+	"POSTGRESQL":      "postgres",
+}
+
+type schemaName struct {
+	database string
+	name     string
+}
+
+type storeObject struct {
+	name string
+	kind string
+}
+
+type relationObject struct {
+	database string
+	schema   string
+	name     string
+	kind     string
+}
+
+type queryObject struct {
+	id   string
+	name string
+	sql  string
+	// sources and sinks are nil when the statement of the query could not be planned
+	sources []string
+	sinks   []string
+}
+
+// Generate enumerates the databases, schemas, stores, relations and running queries visible to the role of the
+// connection and writes an import block and a skeleton resource for each of them to w. organization is the prefix of
+// the relation FQNs the server plans queries with.
+func Generate(ctx context.Context, conn *sql.Conn, organization string, w io.Writer) error {
+	databases, err := listDatabases(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("failed to list databases: %w", err)
+	}
+	schemas := []schemaName{}
+	for _, database := range databases {
+		names, err := listSchemas(ctx, conn, database)
+		if err != nil {
+			return fmt.Errorf("failed to list schemas in database %s: %w", database, err)
+		}
+		for _, name := range names {
+			schemas = append(schemas, schemaName{database: database, name: name})
+		}
+	}
+	stores, err := listStores(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("failed to list stores: %w", err)
+	}
+	relations, err := listRelations(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("failed to list relations: %w", err)
+	}
+	queries, err := listQueries(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("failed to list queries: %w", err)
+	}
+	for i := range queries {
+		describeQueryRelations(ctx, conn, organization, &queries[i])
+	}
+
+	r := newRenderer()
+	r.b.WriteString(header)
+	databaseRefs := map[string]string{}
+	for _, database := range databases {
+		label := r.resource("deltastream_database", database, database)
+		r.attribute("name", quote(database))
+		r.end()
+		databaseRefs[database] = "deltastream_database." + label + ".name"
+	}
+	schemaRefs := map[schemaName]string{}
+	for _, s := range schemas {
+		label := r.resource("deltastream_schema", s.database+"."+s.name, s.database, s.name)
+		r.attribute("database", reference(databaseRefs, s.database))
+		r.attribute("name", quote(s.name))
+		r.end()
+		schemaRefs[s] = "deltastream_schema." + label + ".name"
+	}
+	for _, s := range stores {
+		r.resource("deltastream_store", s.name, s.name)
+		r.attribute("name", quote(s.name))
+		if attr, ok := storeTypeAttributes[s.kind]; ok {
+			fmt.Fprintf(&r.b, "  %s = {\n    # TODO: connection settings of the store, they are not reported by the server\n  }\n", attr)
+		} else {
+			fmt.Fprintf(&r.b, "  # TODO: stores of type %s are not supported by the provider\n", s.kind)
+		}
+		r.end()
+	}
+	relationRefs := map[string]string{}
+	for _, rel := range relations {
+		fqn := rel.database + "." + rel.schema + "." + rel.name
+		label := r.resource("deltastream_relation", fqn, rel.database, rel.schema, rel.name)
+		relationRefs[fqn] = "deltastream_relation." + label + ".fqn"
+		r.attribute("database", reference(databaseRefs, rel.database))
+		if ref, ok := schemaRefs[schemaName{database: rel.database, name: rel.schema}]; ok {
+			r.attribute("schema", ref)
+		} else {
+			r.attribute("schema", quote(rel.schema))
+		}
+		fmt.Fprintf(&r.b, "  # TODO: set sql to the statement that created the %s, it is not reported by the server\n", strings.ToLower(rel.kind))
+		r.end()
+	}
+	for _, q := range queries {
+		name := q.name
+		if name == "" {
+			name = "query_" + strings.SplitN(q.id, "-", 2)[0]
+		}
+		r.resource("deltastream_query", q.id, name)
+		if q.name != "" {
+			r.attribute("query_name", quote(q.name))
+		}
+		if q.sources != nil && q.sinks != nil {
+			r.attribute("source_relation_fqns", references(relationRefs, q.sources))
+			r.attribute("sink_relation_fqns", references(relationRefs, q.sinks))
+		} else {
+			r.b.WriteString("  # TODO: set source_relation_fqns and sink_relation_fqns, the statement of the query could not be planned\n")
+		}
+		r.attribute("sql", heredoc(q.sql))
+		r.end()
+	}
+
+	_, err = w.Write(hclwrite.Format([]byte(r.b.String())))
+	return err
+}
+
+// renderer writes the import block and resource of each object, keeping the resource labels unique per type.
+type renderer struct {
+	b      strings.Builder
+	labels map[string]map[string]bool
+}
+
+func newRenderer() *renderer {
+	return &renderer{labels: map[string]map[string]bool{}}
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// resource starts the resource of an object after its import block and returns the label of the resource, derived
+// from the name parts of the object.
+func (r *renderer) resource(resourceType, id string, nameParts ...string) string {
+	label := invalidLabelChars.ReplaceAllString(strings.ToLower(strings.Join(nameParts, "_")), "_")
+	label = strings.Trim(label, "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "_" + label
+	}
+	if r.labels[resourceType] == nil {
+		r.labels[resourceType] = map[string]bool{}
+	}
+	for base, i := label, 2; r.labels[resourceType][label]; i++ {
+		label = base + "_" + strconv.Itoa(i)
+	}
+	r.labels[resourceType][label] = true
+
+	fmt.Fprintf(&r.b, "import {\n  to = %s.%s\n  id = %s\n}\n\n", resourceType, label, quote(id))
+	fmt.Fprintf(&r.b, "resource %q %q {\n", resourceType, label)
+	return label
+}
+
+func (r *renderer) attribute(name, expr string) {
+	fmt.Fprintf(&r.b, "  %s = %s\n", name, expr)
+}
+
+func (r *renderer) end() {
+	r.b.WriteString("}\n\n")
+}
+
+// reference returns the expression of a reference to a generated resource, or the quoted name when the resource was
+// not generated.
+func reference(refs map[string]string, name string) string {
+	if ref, ok := refs[name]; ok {
+		return ref
+	}
+	return quote(name)
+}
+
+// references returns the expression of a list of references to generated resources.
+func references(refs map[string]string, names []string) string {
+	exprs := make([]string, 0, len(names))
+	for _, name := range names {
+		exprs = append(exprs, reference(refs, name))
+	}
+	return "[" + strings.Join(exprs, ", ") + "]"
+}
+
+// escapeTemplate escapes the sequences HCL would otherwise read as template interpolations or directives.
+func escapeTemplate(s string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+}
+
+func quote(s string) string {
+	return escapeTemplate(strconv.Quote(s))
+}
+
+// heredoc renders a multi-line string as an indented heredoc, picking a delimiter that does not appear as a line of
+// the string.
+func heredoc(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	delimiter := "SQL"
+	for used := true; used; {
+		used = false
+		for _, l := range lines {
+			if strings.TrimSpace(l) == delimiter {
+				used = true
+				delimiter += "_"
+				break
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<<-" + delimiter + "\n")
+	for _, l := range lines {
+		b.WriteString("    " + escapeTemplate(l) + "\n")
+	}
+	b.WriteString("  " + delimiter)
+	return b.String()
+}
+
+func listDatabases(ctx context.Context, conn *sql.Conn) ([]string, error) {
+	names := []string{}
+	err := util.QueryRows(ctx, conn, `SELECT name FROM deltastream.sys."databases";`, func(rows *sql.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		names = append(names, name)
+		return nil
+	})
+	sort.Strings(names)
+	return names, err
+}
+
+func listSchemas(ctx context.Context, conn *sql.Conn, database string) ([]string, error) {
+	names := []string{}
+	err := util.QueryRows(ctx, conn, fmt.Sprintf(`LIST SCHEMAS IN DATABASE "%s";`, database), func(rows *sql.Rows) error {
+		var discard any
+		var name string
+		var owner string
+		var createdAt time.Time
+		if err := rows.Scan(&name, &discard, &owner, &createdAt); err != nil {
+			return err
+		}
+		names = append(names, name)
+		return nil
+	})
+	sort.Strings(names)
+	return names, err
+}
+
+func listStores(ctx context.Context, conn *sql.Conn) ([]storeObject, error) {
+	stores := []storeObject{}
+	err := util.QueryRows(ctx, conn, `SELECT "name", type FROM deltastream.sys."stores";`, func(rows *sql.Rows) error {
+		var s storeObject
+		if err := rows.Scan(&s.name, &s.kind); err != nil {
+			return err
+		}
+		stores = append(stores, s)
+		return nil
+	})
+	sort.Slice(stores, func(i, j int) bool { return stores[i].name < stores[j].name })
+	return stores, err
+}
+
+func listRelations(ctx context.Context, conn *sql.Conn) ([]relationObject, error) {
+	relations := []relationObject{}
+	err := util.QueryRows(ctx, conn, `SELECT database_name, schema_name, name, relation_type FROM deltastream.sys."relations";`, func(rows *sql.Rows) error {
+		var r relationObject
+		if err := rows.Scan(&r.database, &r.schema, &r.name, &r.kind); err != nil {
+			return err
+		}
+		relations = append(relations, r)
+		return nil
+	})
+	sort.Slice(relations, func(i, j int) bool {
+		a, b := relations[i], relations[j]
+		return a.database+"."+a.schema+"."+a.name < b.database+"."+b.schema+"."+b.name
+	})
+	return relations, err
+}
+
+// listQueries lists the queries LIST QUERIES reports, stopped and terminated queries are left out.
+func listQueries(ctx context.Context, conn *sql.Conn) ([]queryObject, error) {
+	queries := []queryObject{}
+	err := util.QueryRows(ctx, conn, `LIST QUERIES;`, func(rows *sql.Rows) error {
+		var (
+			q             queryObject
+			version       int64
+			intendedState string
+			actualState   string
+			owner         string
+			createdAt     time.Time
+			updatedAt     time.Time
+		)
+		if err := rows.Scan(&q.id, &q.name, &version, &intendedState, &actualState, &q.sql, &owner, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+		queries = append(queries, q)
+		return nil
+	})
+	sort.Slice(queries, func(i, j int) bool { return queries[i].id < queries[j].id })
+	return queries, err
+}
+
+// describeQueryRelations plans the statement of a query to read the relations it reads from and writes to. A
+// statement that cannot be planned, such as one naming relations relative to a database the session does not use, is
+// left for the user to complete.
+func describeQueryRelations(ctx context.Context, conn *sql.Conn, organization string, q *queryObject) {
+	_, plan, err := util.DescribeStatement(ctx, conn, q.sql)
+	if err != nil {
+		return
+	}
+	q.sources = util.RelationFqns(organization, plan.Sources)
+	q.sinks = util.RelationFqns(organization, plan.AllSinks())
+}
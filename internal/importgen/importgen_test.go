@@ -0,0 +1,169 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package importgen
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+const wantConfig = header + `import {
+  to = deltastream_database.analytics
+  id = "analytics"
+}
+
+resource "deltastream_database" "analytics" {
+  name = "analytics"
+}
+
+import {
+  to = deltastream_schema.analytics_public
+  id = "analytics.public"
+}
+
+resource "deltastream_schema" "analytics_public" {
+  database = deltastream_database.analytics.name
+  name     = "public"
+}
+
+import {
+  to = deltastream_store.msk
+  id = "msk"
+}
+
+resource "deltastream_store" "msk" {
+  name = "msk"
+  kafka = {
+    # TODO: connection settings of the store, they are not reported by the server
+  }
+}
+
+import {
+  to = deltastream_relation.analytics_public_pageviews
+  id = "analytics.public.PageViews"
+}
+
+resource "deltastream_relation" "analytics_public_pageviews" {
+  database = deltastream_database.analytics.name
+  schema   = deltastream_schema.analytics_public.name
+  # TODO: set sql to the statement that created the changelog, it is not reported by the server
+}
+
+import {
+  to = deltastream_relation.analytics_public_pageviews_2
+  id = "analytics.public.pageviews"
+}
+
+resource "deltastream_relation" "analytics_public_pageviews_2" {
+  database = deltastream_database.analytics.name
+  schema   = deltastream_schema.analytics_public.name
+  # TODO: set sql to the statement that created the stream, it is not reported by the server
+}
+
+import {
+  to = deltastream_query.query_0b9d0a5e
+  id = "0b9d0a5e-0000-0000-0000-000000000001"
+}
+
+resource "deltastream_query" "query_0b9d0a5e" {
+  # TODO: set source_relation_fqns and sink_relation_fqns, the statement of the query could not be planned
+  sql = <<-SQL
+    INSERT INTO users
+    SELECT * FROM pageviews WHERE page = '$${home}';
+  SQL
+}
+
+import {
+  to = deltastream_query.rollup
+  id = "1c5e2f7a-0000-0000-0000-000000000002"
+}
+
+resource "deltastream_query" "rollup" {
+  query_name           = "rollup"
+  source_relation_fqns = [deltastream_relation.analytics_public_pageviews_2.fqn]
+  sink_relation_fqns   = ["analytics.public.totals"]
+  sql                  = <<-SQL
+    INSERT INTO totals SELECT COUNT(*) FROM pageviews;
+  SQL
+}
+
+`
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^SELECT name FROM deltastream.sys."databases";$`,
+		Columns:   []mockserver.Column{{Name: "name", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("analytics")},
+	}, {
+		Statement: `^LIST SCHEMAS IN DATABASE "analytics";$`,
+		Columns: []mockserver.Column{
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "is_default", Type: "BOOLEAN"},
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+		},
+		Rows: [][]*string{mockserver.Row("public", "true", "sysadmin", "2024-01-01 00:00:00Z")},
+	}, {
+		Statement: `^SELECT "name", type FROM deltastream.sys."stores";$`,
+		Columns:   []mockserver.Column{{Name: "name", Type: "VARCHAR"}, {Name: "type", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("msk", "KAFKA")},
+	}, {
+		Statement: `^SELECT database_name, schema_name, name, relation_type FROM deltastream.sys."relations";$`,
+		Columns: []mockserver.Column{
+			{Name: "database_name", Type: "VARCHAR"},
+			{Name: "schema_name", Type: "VARCHAR"},
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "relation_type", Type: "VARCHAR"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("analytics", "public", "pageviews", "STREAM"),
+			mockserver.Row("analytics", "public", "PageViews", "CHANGELOG"),
+		},
+	}, {
+		Statement: `^LIST QUERIES;$`,
+		Columns: []mockserver.Column{
+			{Name: "id", Type: "VARCHAR"},
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "version", Type: "BIGINT"},
+			{Name: "intended_state", Type: "VARCHAR"},
+			{Name: "actual_state", Type: "VARCHAR"},
+			{Name: "query", Type: "VARCHAR"},
+			{Name: "owner", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP_LTZ"},
+			{Name: "updated_at", Type: "TIMESTAMP_LTZ"},
+		},
+		Rows: [][]*string{
+			mockserver.Row("1c5e2f7a-0000-0000-0000-000000000002", "rollup", "1", "running", "running", "INSERT INTO totals SELECT COUNT(*) FROM pageviews;", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+			mockserver.Row("0b9d0a5e-0000-0000-0000-000000000001", "", "1", "running", "running", "INSERT INTO users\nSELECT * FROM pageviews WHERE page = '${home}';", "sysadmin", "2024-01-01 00:00:00Z", "2024-01-01 00:00:00Z"),
+		},
+	}, {
+		Statement: `^DESCRIBE INSERT INTO totals `,
+		Columns:   []mockserver.Column{{Name: "kind", Type: "VARCHAR"}, {Name: "plan", Type: "VARCHAR"}},
+		Rows: [][]*string{mockserver.Row("INSERT_INTO", `{"sink":{"fqn":"00000000-0000-0000-0000-000000000001.analytics.public.totals"},`+
+			`"sources":[{"fqn":"00000000-0000-0000-0000-000000000001.analytics.public.pageviews"}]}`)},
+	}, {
+		Statement: `^DESCRIBE INSERT INTO users`,
+		SqlState:  string(gods.SqlStateInvalidRelation),
+		Message:   "relation users does not exist",
+	}})
+
+	var b bytes.Buffer
+	if err := Generate(ctx, conn, "00000000-0000-0000-0000-000000000001", &b); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got := b.String(); got != wantConfig {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, wantConfig)
+	}
+	if _, diags := hclsyntax.ParseConfig(b.Bytes(), "imports.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Errorf("generated configuration does not parse: %s", diags.Error())
+	}
+}
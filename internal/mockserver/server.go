@@ -0,0 +1,312 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mockserver emulates the subset of the DeltaStream v2 API used by the provider. Statements are answered
+// from prepared fixtures so acceptance tests can run without credentials or network access.
+package mockserver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/deltastreaminc/go-deltastream/apiv2"
+	"github.com/google/uuid"
+	"k8s.io/utils/ptr"
+)
+
+// Fixture is the prepared response to every statement matching Statement.
+type Fixture struct {
+	// Statement is a regular expression matched against the submitted statement.
+	Statement string `json:"statement"`
+	// Role, when set, restricts the fixture to statements run as that role.
+	Role string `json:"role,omitempty"`
+	// SqlState defaults to successful completion.
+	SqlState string      `json:"sqlState,omitempty"`
+	Message  string      `json:"message,omitempty"`
+	Columns  []Column    `json:"columns,omitempty"`
+	Rows     [][]*string `json:"rows,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// Column describes a result set column. Type is a DeltaStream SQL type such as VARCHAR or TIMESTAMP_LTZ.
+type Column struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+}
+
+// Row builds a fixture row of non-null values.
+func Row(values ...string) []*string {
+	row := make([]*string, len(values))
+	for i := range values {
+		row[i] = &values[i]
+	}
+	return row
+}
+
+// LoadFixtures reads every *.json file in dir. Each file holds a list of fixtures.
+func LoadFixtures(dir string) ([]Fixture, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures := []Fixture{}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		fileFixtures := []Fixture{}
+		if err := json.Unmarshal(data, &fileFixtures); err != nil {
+			return nil, fmt.Errorf("failed to parse fixtures in %s: %w", f, err)
+		}
+		fixtures = append(fixtures, fileFixtures...)
+	}
+	return fixtures, nil
+}
+
+// Server is an httptest server answering statements from fixtures.
+type Server struct {
+	*httptest.Server
+
+	fixtures []Fixture
+
+	mu         sync.Mutex
+	statements []string
+}
+
+// New starts a server answering from the fixtures. The first fixture matching a statement wins.
+func New(fixtures []Fixture) (*Server, error) {
+	s := &Server{}
+	for _, f := range fixtures {
+		re, err := regexp.Compile(f.Statement)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixture statement %q: %w", f.Statement, err)
+		}
+		f.re = re
+		if f.SqlState == "" {
+			f.SqlState = "00000"
+		}
+		s.fixtures = append(s.fixtures, f)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/version", s.version)
+	mux.HandleFunc("/v2/statements", s.submitStatement)
+	s.Server = httptest.NewServer(mux)
+	return s, nil
+}
+
+// APIURL returns the value to use for the provider server setting.
+func (s *Server) APIURL() string {
+	return s.URL + "/v2"
+}
+
+// OpenDB returns a database handle connected to the server, for unit tests that exercise code taking a *sql.Conn.
+func (s *Server) OpenDB(ctx context.Context) (*sql.DB, error) {
+	connector, err := gods.ConnectorWithOptions(ctx, gods.WithStaticToken("mock-token"), gods.WithServer(s.APIURL()))
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// Organization is the organization of the connections NewConn opens.
+const Organization = "00000000-0000-0000-0000-000000000001"
+
+// Start starts a server answering from the fixtures for the duration of a test.
+func Start(t testing.TB, fixtures []Fixture) *Server {
+	t.Helper()
+	s, err := New(fixtures)
+	if err != nil {
+		t.Fatalf("failed to start mock server: %v", err)
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// NewDB starts a server answering from the fixtures and opens a database handle connected to it, for unit tests of
+// code taking a *sql.DB. Both are closed when the test ends.
+func NewDB(t testing.TB, fixtures []Fixture) (*Server, *sql.DB) {
+	t.Helper()
+	s := Start(t, fixtures)
+
+	db, err := s.OpenDB(context.Background())
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return s, db
+}
+
+// NewConn starts a server answering from the fixtures and returns a connection to it as the sysadmin role of
+// Organization, for unit tests of code taking a *sql.Conn. The server and the connection are closed when the test
+// ends.
+func NewConn(t testing.TB, fixtures []Fixture) (*Server, *sql.Conn) {
+	t.Helper()
+	s, db := NewDB(t, fixtures)
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := conn.Raw(func(driverConn any) error {
+		c := driverConn.(*gods.Conn)
+		ctx := c.GetContext()
+		ctx.OrganizationID = ptr.To(uuid.MustParse(Organization))
+		ctx.RoleName = ptr.To("sysadmin")
+		c.SetContext(ctx)
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to set connection context: %v", err)
+	}
+	return s, conn
+}
+
+// Statements returns the statements received so far, in order.
+func (s *Server) Statements() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.statements...)
+}
+
+func (s *Server) version(w http.ResponseWriter, r *http.Request) {
+	if !authorized(w, r) {
+		return
+	}
+	writeJSON(w, http.StatusOK, apiv2.Version{Major: 2})
+}
+
+func (s *Server) submitStatement(w http.ResponseWriter, r *http.Request) {
+	if !authorized(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusBadRequest, apiv2.ErrorResponse{Message: "method not allowed"})
+		return
+	}
+
+	req, err := decodeStatementRequest(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiv2.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	statement := strings.TrimSpace(req.Statement)
+	s.mu.Lock()
+	s.statements = append(s.statements, statement)
+	s.mu.Unlock()
+
+	for _, f := range s.fixtures {
+		if !f.re.MatchString(statement) || (f.Role != "" && f.Role != ptr.Deref(req.Role, "")) {
+			continue
+		}
+
+		rows := f.Rows
+		if rows == nil {
+			rows = [][]*string{}
+		}
+		columns := apiv2.ResultSetColumns{}
+		for _, c := range f.Columns {
+			columns = append(columns, struct {
+				DisplayHint *string `json:"display_hint,omitempty"`
+				Name        string  `json:"name"`
+				Nullable    bool    `json:"nullable"`
+				Type        string  `json:"type"`
+			}{Name: c.Name, Type: c.Type, Nullable: c.Nullable})
+		}
+		rs := apiv2.ResultSet{
+			CreatedOn:   time.Now().Unix(),
+			SqlState:    f.SqlState,
+			StatementID: uuid.New(),
+			Data:        &rows,
+			Metadata: apiv2.ResultSetMetadata{
+				Encoding:      "json",
+				Columns:       columns,
+				PartitionInfo: []apiv2.ResultSetPartitionInfo{{RowCount: int32(len(rows))}},
+				Context: &apiv2.ResultSetContext{
+					DatabaseName: req.Database,
+					SchemaName:   req.Schema,
+					StoreName:    req.Store,
+					RoleName:     req.Role,
+				},
+			},
+		}
+		if f.Message != "" {
+			rs.Message = &f.Message
+		}
+		if req.Organization != nil {
+			if orgID, err := uuid.Parse(*req.Organization); err == nil {
+				rs.Metadata.Context.OrganizationID = &orgID
+			}
+		}
+		writeJSON(w, http.StatusOK, rs)
+		return
+	}
+
+	writeJSON(w, http.StatusBadRequest, apiv2.ErrorResponse{Message: fmt.Sprintf("mock server has no fixture for statement: %s", statement)})
+}
+
+func decodeStatementRequest(r *http.Request) (*apiv2.StatementRequest, error) {
+	req := &apiv2.StatementRequest{}
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			return nil, err
+		}
+		return req, nil
+	}
+
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, fmt.Errorf("request part missing")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if p.FormName() == "request" {
+			if err := json.NewDecoder(p).Decode(req); err != nil {
+				return nil, err
+			}
+			return req, nil
+		}
+	}
+}
+
+func authorized(w http.ResponseWriter, r *http.Request) bool {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeJSON(w, http.StatusForbidden, apiv2.ErrorResponse{Message: "no token"})
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -41,7 +41,7 @@ func TestAccDeltaStreamDatabase(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(dbNames, listNames) {
+					if !util.SubsetOf(dbNames, listNames) {
 						return fmt.Errorf("Database names not found in list: %v", listNames)
 					}
 
@@ -46,7 +46,7 @@ func TestAccDeltaStreamStore(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(sNames, listNames) {
+					if !util.SubsetOf(sNames, listNames) {
 						return fmt.Errorf("Store names %v not found in list: %v", sNames, listNames)
 					}
 
@@ -76,7 +76,7 @@ func TestAccDeltaStreamStore(t *testing.T) {
 					}
 
 					expectedTopics := []string{"ds_pageviews"}
-					if !util.ArrayContains(expectedTopics, topicNames) {
+					if !util.SubsetOf(expectedTopics, topicNames) {
 						return fmt.Errorf("Topic names %v not found in list: %v", expectedTopics, topicNames)
 					}
 
@@ -107,7 +107,7 @@ func TestAccDeltaStreamStore(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(sNames, listNames) {
+					if !util.SubsetOf(sNames, listNames) {
 						return fmt.Errorf("Store names %v not found in list: %v", sNames, listNames)
 					}
 
@@ -137,7 +137,7 @@ func TestAccDeltaStreamStore(t *testing.T) {
 					}
 
 					expectedEnt := []string{s.RootModule().Resources["deltastream_entity.test_topic"].Primary.Attributes["entity_path.0"]}
-					if !util.ArrayContains(expectedEnt, entNames) {
+					if !util.SubsetOf(expectedEnt, entNames) {
 						return fmt.Errorf("Test topic name %v not found in list: %v", expectedEnt, entNames)
 					}
 
@@ -169,7 +169,7 @@ func TestAccDeltaStreamStore(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(sNames, listNames) {
+					if !util.SubsetOf(sNames, listNames) {
 						return fmt.Errorf("Store names %v not found in list: %v", sNames, listNames)
 					}
 
@@ -213,7 +213,7 @@ func TestAccDeltaStreamStore(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(sNames, listNames) {
+					if !util.SubsetOf(sNames, listNames) {
 						return fmt.Errorf("Store names %v not found in list: %v", sNames, listNames)
 					}
 
@@ -242,7 +242,7 @@ func TestAccDeltaStreamStore(t *testing.T) {
 					}
 
 					expectedEnt := []string{"system"}
-					if !util.ArrayContains(expectedEnt, entNames) {
+					if !util.SubsetOf(expectedEnt, entNames) {
 						return fmt.Errorf("Entity names %v not found in list: %v", expectedEnt, entNames)
 					}
 
@@ -278,7 +278,7 @@ func TestAccDeltaStreamStore(t *testing.T) {
 			// 				}
 			// 			}
 
-			// 			if !util.ArrayContains(sNames, listNames) {
+			// 			if !util.SubsetOf(sNames, listNames) {
 			// 				return fmt.Errorf("Store names %v not found in list: %v", sNames, listNames)
 			// 			}
 
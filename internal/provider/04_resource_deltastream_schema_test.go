@@ -41,7 +41,7 @@ func TestAccDeltaStreamSchema(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(schNames, listNames) {
+					if !util.SubsetOf(schNames, listNames) {
 						return fmt.Errorf("Schema names not found in list: %v", listNames)
 					}
 
@@ -50,7 +50,7 @@ func TestAccDeltaStreamSecret(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(sNames, listNames) {
+					if !util.SubsetOf(sNames, listNames) {
 						return fmt.Errorf("Secret names not found in list: %v", listNames)
 					}
 
@@ -59,7 +59,7 @@ func TestAccDeltaRelationStore(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(relNames, listNames) {
+					if !util.SubsetOf(relNames, listNames) {
 						return fmt.Errorf("Relation names not found in list: %v", listNames)
 					}
 
@@ -56,7 +56,7 @@ func TestAccDeltaSchemaRegistry(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(srNames, listNames) {
+					if !util.SubsetOf(srNames, listNames) {
 						return fmt.Errorf("Schema registry names %v not found in list: %v", srNames, listNames)
 					}
 
@@ -102,7 +102,7 @@ func TestAccDeltaSchemaRegistry(t *testing.T) {
 						}
 					}
 
-					if !util.ArrayContains(srNames, listNames) {
+					if !util.SubsetOf(srNames, listNames) {
 						return fmt.Errorf("Schema registry names %v not found in list: %v", srNames, listNames)
 					}
 
@@ -0,0 +1,87 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testcaseCredentials maps the variables of the testcases to the test environment entries they are read from, when
+// the two names differ.
+var testcaseCredentials = map[string]string{
+	"kinesis_url":    "kinesis_uri",
+	"snowflake_uris": "snowflake_uri",
+}
+
+// testcaseSkipped lists the testcases TestAccTestcases does not apply, with the reason.
+var testcaseSkipped = map[string]string{
+	"store_snowflake.tf": "the test environment has no Snowflake account, see TestAccDeltaStreamStore",
+}
+
+// TestAccTestcases applies every testcases/*.tf configuration, checks that planning it again is empty, then destroys
+// it and applies it again so that a drop leaving an object behind fails the second apply.
+func TestAccTestcases(t *testing.T) {
+	creds, err := util.LoadTestEnv()
+	if err != nil {
+		t.Fatalf("Failed to load test environment: %v", err)
+	}
+	files, err := filepath.Glob("testcases/*.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		name := filepath.Base(f)
+		t.Run(strings.TrimSuffix(name, ".tf"), func(t *testing.T) {
+			if reason := testcaseSkipped[name]; reason != "" {
+				t.Skip(reason)
+			}
+			body, err := parseTestcase(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			vars := config.Variables{}
+			for _, v := range testcaseVariables(body) {
+				key := v
+				if k, ok := testcaseCredentials[v]; ok {
+					key = k
+				}
+				if creds[key] == "" && !mockMode() {
+					t.Skipf("no %s in the test environment", key)
+				}
+				vars[v] = config.StringVariable(creds[key])
+			}
+
+			step := resource.TestStep{
+				ProtoV6ProviderFactories: testAccProviders,
+				ConfigFile:               config.StaticFile(f),
+				ConfigVariables:          vars,
+			}
+			apply, plan, destroy, recreate := step, step, step, step
+			plan.PlanOnly = true
+			destroy.Destroy = true
+
+			resource.ParallelTest(t, resource.TestCase{
+				PreCheck: func() { testAccPreCheck(t) },
+				Steps:    []resource.TestStep{apply, plan, destroy, recreate},
+				CheckDestroy: func(s *terraform.State) error {
+					for address := range s.RootModule().Resources {
+						if strings.HasPrefix(address, "deltastream_") {
+							return fmt.Errorf("%s is still in state after destroy", address)
+						}
+					}
+					return nil
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// ValidateAccess verifies once, while the provider is configured, that the organization exists and that the
+// configured role can be used in it. Failures are reported against the provider attribute that needs fixing.
+func (c *DeltaStreamProviderCfg) ValidateAccess(ctx context.Context) (d diag.Diagnostics) {
+	if _, err := uuid.Parse(c.Organization); err != nil {
+		d.AddAttributeError(path.Root("organization"), "Invalid organization ID", fmt.Sprintf("%q is not a valid organization ID: %s. The organization ID is a UUID, it can be found with LIST ORGANIZATIONS in the DeltaStream CLI or console.", c.Organization, err))
+		return
+	}
+
+	roles, err := c.Roles(ctx)
+	if err != nil {
+		var sqlErr gods.ErrSQLError
+		switch {
+		case errors.Is(err, gods.ErrAuthenticationError):
+			d.AddAttributeError(path.Root("api_key"), "Authentication failed", fmt.Sprintf("the API key was rejected: %s. Check that the API key is valid and has not expired.", err))
+		case errors.As(err, &sqlErr) && sqlErr.SQLCode == gods.SqlStateInvalidOrganization:
+			d.AddAttributeError(path.Root("organization"), "Organization not found", fmt.Sprintf("organization %s does not exist or is not accessible with this API key: %s. Check the organization ID and that the API key was created in this organization.", c.Organization, err))
+		case errors.As(err, &sqlErr) && (sqlErr.SQLCode == gods.SqlStateInvalidRole || sqlErr.SQLCode == gods.SqlStateInsufficientPrivilege):
+			d.AddAttributeError(path.Root("role"), "Role cannot be used", fmt.Sprintf("role %q cannot be used in organization %s: %s. Check that the role exists and is granted to the user owning the API key.", c.Role, c.Organization, err))
+		default:
+			d.AddError("Failed to verify provider configuration", err.Error())
+		}
+		return
+	}
+
+	if _, ok := roles[c.Role]; ok {
+		return
+	}
+
+	// servers may only list the roles granted to the user, a role missing from the list is looked up directly
+	err = c.describeRole(ctx)
+	switch {
+	case err == nil:
+	case util.IsNotFound("role", err):
+		d.AddAttributeError(path.Root("role"), "Role not found", fmt.Sprintf("role %q does not exist in organization %s. Set role to an existing role that is granted to the user owning the API key.", c.Role, c.Organization))
+	default:
+		d.AddAttributeWarning(path.Root("role"), "Unable to verify role", fmt.Sprintf("role %q is not listed by LIST ROLES in organization %s and could not be described: %s. Statements run as this role fail if it does not exist.", c.Role, c.Organization, err))
+	}
+	return
+}
+
+// describeRole runs DESCRIBE ROLE for the configured role.
+func (c *DeltaStreamProviderCfg) describeRole(ctx context.Context) error {
+	ctx, conn, err := util.GetConnection(ctx, c.Db, c.SessionID, c.Organization, c.Role)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = util.DescribeRows(ctx, conn, fmt.Sprintf(`DESCRIBE ROLE "%s";`, c.Role))
+	return err
+}
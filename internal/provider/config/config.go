@@ -3,11 +3,47 @@
 
 package config
 
-import "database/sql"
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/deltastreaminc/go-deltastream/apiv2"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
 
 type DeltaStreamProviderCfg struct {
 	Db           *sql.DB
 	Organization string
 	Role         string
 	SessionID    *string
+	// DryRun makes resources skip the statements of Create, Update and Delete
+	DryRun bool
+	// DefaultOwners maps a resource kind, such as store or relation, to the role owning resources of that kind that
+	// are created without an owner
+	DefaultOwners map[string]string
+	// DefaultTags are merged into the tags of every resource that supports tags
+	DefaultTags map[string]string
+	// DefaultAccessRegion is the access region of stores and schema registries created without one
+	DefaultAccessRegion string
+	// CaseSensitivity is one of CaseSensitive, CaseInsensitive or CaseNormalize, see ValidateIdentifierCase and
+	// ObjectName
+	CaseSensitivity string
+	// API is the client of the DeltaStream API endpoints that are not SQL statements
+	API apiv2.ClientWithResponsesInterface
+	// ProviderVersion is the version of the provider binary
+	ProviderVersion string
+	// Manifest records the resources created or updated by the apply, nil when no manifest file is configured
+	Manifest *util.Manifest
+	// StrictDriftChecks makes refreshes warn about computed attributes changed outside of Terraform, see ReportDrift
+	StrictDriftChecks bool
+
+	rolesMu sync.Mutex
+	roles   map[string]struct{}
+
+	storeTypesMu sync.Mutex
+	storeTypes   map[string]string
+
+	serverVersionMu sync.Mutex
+	serverVersion   *apiv2.Version
 }
@@ -0,0 +1,35 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ApplyDefaultAccessRegion sets the access region of a resource being created to the default access region of the
+// provider when the configuration does not set one. A configured region only known at apply is left unknown. Existing
+// resources keep their access region, so changing the default does not replace them.
+func (c *DeltaStreamProviderCfg) ApplyDefaultAccessRegion(ctx context.Context, config tfsdk.Config, state tfsdk.State, plan *tfsdk.Plan) (d diag.Diagnostics) {
+	if plan.Raw.IsNull() || !state.Raw.IsNull() {
+		return
+	}
+
+	var configured types.String
+	d.Append(config.GetAttribute(ctx, path.Root("access_region"), &configured)...)
+	if d.HasError() || !configured.IsNull() {
+		return
+	}
+
+	if c.DefaultAccessRegion == "" {
+		d.AddAttributeError(path.Root("access_region"), "Access region not specified", "access_region must be set on the resource or default_access_region on the provider")
+		return
+	}
+	d.Append(plan.SetAttribute(ctx, path.Root("access_region"), types.StringValue(c.DefaultAccessRegion))...)
+	return
+}
@@ -0,0 +1,61 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestApplyDefaultAccessRegion(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		"access_region": schema.StringAttribute{Optional: true, Computed: true},
+	}}
+	value := func(v any) tftypes.Value {
+		return tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"access_region": tftypes.NewValue(tftypes.String, v),
+		})
+	}
+	nullState := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+
+	tests := []struct {
+		name       string
+		def        string
+		configured any
+		planned    any
+		state      tfsdk.State
+		want       types.String
+		wantErr    bool
+	}{
+		{name: "default applied", def: "AWS us-east-1", planned: tftypes.UnknownValue, state: nullState, want: types.StringValue("AWS us-east-1")},
+		{name: "configured region kept", def: "AWS us-east-1", configured: "AWS eu-west-1", planned: "AWS eu-west-1", state: nullState, want: types.StringValue("AWS eu-west-1")},
+		{name: "configured region known at apply", def: "AWS us-east-1", configured: tftypes.UnknownValue, planned: tftypes.UnknownValue, state: nullState, want: types.StringUnknown()},
+		{name: "configured region known at apply without default", configured: tftypes.UnknownValue, planned: tftypes.UnknownValue, state: nullState, want: types.StringUnknown()},
+		{name: "no default", planned: tftypes.UnknownValue, state: nullState, want: types.StringUnknown(), wantErr: true},
+		{name: "existing resource", def: "AWS us-east-1", planned: tftypes.UnknownValue, state: tfsdk.State{Schema: s, Raw: value("AWS eu-west-1")}, want: types.StringUnknown()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &DeltaStreamProviderCfg{DefaultAccessRegion: tt.def}
+			p := tfsdk.Plan{Schema: s, Raw: value(tt.planned)}
+			cfg := tfsdk.Config{Schema: s, Raw: value(tt.configured)}
+			dg := c.ApplyDefaultAccessRegion(ctx, cfg, tt.state, &p)
+			if dg.HasError() != tt.wantErr {
+				t.Fatalf("ApplyDefaultAccessRegion() diagnostics = %v, want error %v", dg, tt.wantErr)
+			}
+			var got types.String
+			p.GetAttribute(ctx, path.Root("access_region"), &got)
+			if !got.Equal(tt.want) {
+				t.Errorf("access_region = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
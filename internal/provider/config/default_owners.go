@@ -0,0 +1,36 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// OwnedResourceKinds are the keys of the default_owners provider setting, one per resource type with an owner.
+var OwnedResourceKinds = []string{"database", "schema", "store", "secret", "schema_registry", "relation", "query", "pipeline", "notification_target"}
+
+// ApplyDefaultOwner sets the owner of a resource being created to the default owner configured for its kind when
+// the configuration does not set one. An owner configured from a value only known at apply, such as the name of a
+// role created in the same run, is left alone. Existing resources keep their owner, so changing the default does not
+// replace them.
+func (c *DeltaStreamProviderCfg) ApplyDefaultOwner(ctx context.Context, kind string, config tfsdk.Config, state tfsdk.State, plan *tfsdk.Plan) (d diag.Diagnostics) {
+	owner, ok := c.DefaultOwners[kind]
+	if !ok || plan.Raw.IsNull() || !state.Raw.IsNull() {
+		return
+	}
+
+	var configured types.String
+	d.Append(config.GetAttribute(ctx, path.Root("owner"), &configured)...)
+	if d.HasError() || !configured.IsNull() {
+		return
+	}
+
+	d.Append(plan.SetAttribute(ctx, path.Root("owner"), types.StringValue(owner))...)
+	return
+}
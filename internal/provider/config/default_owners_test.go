@@ -0,0 +1,56 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestApplyDefaultOwner(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		"owner": schema.StringAttribute{Optional: true, Computed: true},
+	}}
+	value := func(v any) tftypes.Value {
+		return tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"owner": tftypes.NewValue(tftypes.String, v),
+		})
+	}
+	nullState := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+
+	tests := []struct {
+		name       string
+		configured any
+		planned    any
+		state      tfsdk.State
+		want       types.String
+	}{
+		{name: "default applied", planned: tftypes.UnknownValue, state: nullState, want: types.StringValue("analysts")},
+		{name: "configured owner kept", configured: "engineers", planned: "engineers", state: nullState, want: types.StringValue("engineers")},
+		{name: "configured owner known at apply", configured: tftypes.UnknownValue, planned: tftypes.UnknownValue, state: nullState, want: types.StringUnknown()},
+		{name: "existing resource", planned: tftypes.UnknownValue, state: tfsdk.State{Schema: s, Raw: value("engineers")}, want: types.StringUnknown()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &DeltaStreamProviderCfg{DefaultOwners: map[string]string{"database": "analysts"}}
+			p := tfsdk.Plan{Schema: s, Raw: value(tt.planned)}
+			cfg := tfsdk.Config{Schema: s, Raw: value(tt.configured)}
+			if dg := c.ApplyDefaultOwner(ctx, "database", cfg, tt.state, &p); dg.HasError() {
+				t.Fatalf("ApplyDefaultOwner() diagnostics = %v", dg)
+			}
+			var got types.String
+			p.GetAttribute(ctx, path.Root("owner"), &got)
+			if !got.Equal(tt.want) {
+				t.Errorf("owner = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// ApplyDefaultTags plans the tags_all attribute of a resource as the default tags of the provider merged with the
+// tags of the resource, the tags of the resource taking precedence. tags_all is planned null when neither sets a tag.
+func (c *DeltaStreamProviderCfg) ApplyDefaultTags(ctx context.Context, plan *tfsdk.Plan) (d diag.Diagnostics) {
+	if plan.Raw.IsNull() {
+		return
+	}
+
+	var tags types.Map
+	d.Append(plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if d.HasError() {
+		return
+	}
+	if tags.IsUnknown() {
+		d.Append(plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	merged := map[string]string{}
+	for k, v := range c.DefaultTags {
+		merged[k] = v
+	}
+	for k, v := range tags.Elements() {
+		value, ok := v.(types.String)
+		if !ok || value.IsUnknown() {
+			d.Append(plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+			return
+		}
+		merged[k] = value.ValueString()
+	}
+	if len(merged) == 0 {
+		d.Append(plan.SetAttribute(ctx, path.Root("tags_all"), types.MapNull(types.StringType))...)
+		return
+	}
+
+	tagsAll, dg := types.MapValueFrom(ctx, types.StringType, merged)
+	d.Append(dg...)
+	if d.HasError() {
+		return
+	}
+	d.Append(plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+	return
+}
+
+// RefreshTags reconciles the tags of a resource with the tags the server reports for it. tags_all becomes the reported
+// tags. tags keeps the reported tags set on the resource and the ones that are not default tags of the provider with
+// the same value, so that default tags do not show up in tags while a tag added outside of Terraform does.
+func (c *DeltaStreamProviderCfg) RefreshTags(ctx context.Context, tags types.Map, reported map[string]string) (types.Map, types.Map, diag.Diagnostics) {
+	var d diag.Diagnostics
+	if len(reported) == 0 {
+		if tags.IsNull() {
+			return tags, types.MapNull(types.StringType), d
+		}
+		return types.MapValueMust(types.StringType, map[string]attr.Value{}), types.MapNull(types.StringType), d
+	}
+
+	own := map[string]string{}
+	for k, v := range reported {
+		_, set := tags.Elements()[k]
+		if def, ok := c.DefaultTags[k]; set || !ok || def != v {
+			own[k] = v
+		}
+	}
+
+	tagsAll, dg := types.MapValueFrom(ctx, types.StringType, reported)
+	d.Append(dg...)
+	if len(own) == 0 && tags.IsNull() {
+		return tags, tagsAll, d
+	}
+	refreshed, dg := types.MapValueFrom(ctx, types.StringType, own)
+	d.Append(dg...)
+	return refreshed, tagsAll, d
+}
+
+// ReadTags refreshes tags and tags_all from the DESCRIBE statement of an object. Both are kept as recorded when the
+// statement fails or the object reports no tags.
+func (c *DeltaStreamProviderCfg) ReadTags(ctx context.Context, conn *sql.Conn, statement string, tags, tagsAll *types.Map) (d diag.Diagnostics) {
+	reported, ok, err := util.ReadTags(ctx, conn, statement)
+	if err != nil {
+		tflog.Warn(ctx, "unable to read tags", map[string]any{"statement": statement, "error": err.Error()})
+		return
+	}
+	if !ok {
+		return
+	}
+	*tags, *tagsAll, d = c.RefreshTags(ctx, *tags, reported)
+	return
+}
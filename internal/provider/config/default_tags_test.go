@@ -0,0 +1,137 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestApplyDefaultTags(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		"tags":     schema.MapAttribute{ElementType: types.StringType, Optional: true},
+		"tags_all": schema.MapAttribute{ElementType: types.StringType, Computed: true},
+	}}
+	mapType := tftypes.Map{ElementType: tftypes.String}
+	plan := func(tags map[string]tftypes.Value) tfsdk.Plan {
+		value := tftypes.NewValue(mapType, nil)
+		if tags != nil {
+			value = tftypes.NewValue(mapType, tags)
+		}
+		return tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"tags":     value,
+			"tags_all": tftypes.NewValue(mapType, tftypes.UnknownValue),
+		})}
+	}
+
+	tests := []struct {
+		name     string
+		defaults map[string]string
+		tags     map[string]tftypes.Value
+		want     map[string]string
+	}{
+		{name: "no tags"},
+		{
+			name:     "defaults only",
+			defaults: map[string]string{"team": "data"},
+			want:     map[string]string{"team": "data"},
+		},
+		{
+			name:     "resource tags take precedence",
+			defaults: map[string]string{"team": "data", "cost-center": "42"},
+			tags:     map[string]tftypes.Value{"team": tftypes.NewValue(tftypes.String, "platform")},
+			want:     map[string]string{"team": "platform", "cost-center": "42"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &DeltaStreamProviderCfg{DefaultTags: tt.defaults}
+			p := plan(tt.tags)
+			if dg := c.ApplyDefaultTags(ctx, &p); dg.HasError() {
+				t.Fatalf("ApplyDefaultTags() diagnostics = %v", dg)
+			}
+			var tagsAll types.Map
+			p.GetAttribute(ctx, path.Root("tags_all"), &tagsAll)
+			if tt.want == nil {
+				if !tagsAll.IsNull() {
+					t.Errorf("tags_all = %v, want null", tagsAll)
+				}
+				return
+			}
+			got := map[string]string{}
+			tagsAll.ElementsAs(ctx, &got, false)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tags_all = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshTags(t *testing.T) {
+	ctx := context.Background()
+	mapOf := func(m map[string]string) types.Map {
+		v, dg := types.MapValueFrom(ctx, types.StringType, m)
+		if dg.HasError() {
+			t.Fatalf("failed to build map: %v", dg)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name        string
+		tags        types.Map
+		reported    map[string]string
+		wantTags    types.Map
+		wantTagsAll types.Map
+	}{
+		{
+			name:        "default tags stay out of tags",
+			tags:        types.MapNull(types.StringType),
+			reported:    map[string]string{"team": "data"},
+			wantTags:    types.MapNull(types.StringType),
+			wantTagsAll: mapOf(map[string]string{"team": "data"}),
+		},
+		{
+			name:        "resource tags kept",
+			tags:        mapOf(map[string]string{"team": "data", "app": "web"}),
+			reported:    map[string]string{"team": "data", "app": "web"},
+			wantTags:    mapOf(map[string]string{"team": "data", "app": "web"}),
+			wantTagsAll: mapOf(map[string]string{"team": "data", "app": "web"}),
+		},
+		{
+			name:        "changed outside of terraform",
+			tags:        mapOf(map[string]string{"app": "web"}),
+			reported:    map[string]string{"team": "platform", "app": "api"},
+			wantTags:    mapOf(map[string]string{"team": "platform", "app": "api"}),
+			wantTagsAll: mapOf(map[string]string{"team": "platform", "app": "api"}),
+		},
+		{
+			name:        "removed outside of terraform",
+			tags:        mapOf(map[string]string{"app": "web"}),
+			reported:    map[string]string{},
+			wantTags:    mapOf(map[string]string{}),
+			wantTagsAll: types.MapNull(types.StringType),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &DeltaStreamProviderCfg{DefaultTags: map[string]string{"team": "data"}}
+			tags, tagsAll, dg := c.RefreshTags(ctx, tt.tags, tt.reported)
+			if dg.HasError() {
+				t.Fatalf("RefreshTags() diagnostics = %v", dg)
+			}
+			if !tags.Equal(tt.wantTags) || !tagsAll.Equal(tt.wantTagsAll) {
+				t.Errorf("RefreshTags() = %s, %s, want %s, %s", tags, tagsAll, tt.wantTags, tt.wantTagsAll)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// ReportDrift warns that the server reports a different value for an attribute than the one recorded in state, when
+// strict drift checks are enabled. The refresh otherwise records such changes made outside of Terraform silently.
+// Attributes without a recorded value, such as after an import, are not reported.
+func (c *DeltaStreamProviderCfg) ReportDrift(kind, name string, attribute path.Path, recorded, current attr.Value) (d diag.Diagnostics) {
+	if !c.StrictDriftChecks || recorded.IsNull() || recorded.IsUnknown() || recorded.Equal(current) {
+		return
+	}
+
+	d.AddAttributeWarning(attribute, "Change made outside of Terraform",
+		fmt.Sprintf("The %s of %s %s changed from %s to %s outside of Terraform", attribute, kind, name, recorded, current))
+	return
+}
@@ -0,0 +1,42 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReportDrift(t *testing.T) {
+	tests := []struct {
+		name     string
+		strict   bool
+		recorded types.String
+		current  types.String
+		want     bool
+	}{
+		{name: "changed", strict: true, recorded: types.StringValue("sysadmin"), current: types.StringValue("analyst"), want: true},
+		{name: "unchanged", strict: true, recorded: types.StringValue("sysadmin"), current: types.StringValue("sysadmin")},
+		{name: "not recorded", strict: true, recorded: types.StringNull(), current: types.StringValue("analyst")},
+		{name: "not strict", recorded: types.StringValue("sysadmin"), current: types.StringValue("analyst")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &DeltaStreamProviderCfg{StrictDriftChecks: tt.strict}
+			d := c.ReportDrift("store", "kafka", path.Root("owner"), tt.recorded, tt.current)
+			if d.HasError() {
+				t.Fatalf("ReportDrift() errors = %v", d)
+			}
+			if got := d.WarningsCount() == 1; got != tt.want {
+				t.Fatalf("ReportDrift() warnings = %v, want a warning %v", d, tt.want)
+			}
+			if tt.want && !strings.Contains(d[0].Detail(), `from "sysadmin" to "analyst"`) {
+				t.Errorf("ReportDrift() detail = %q", d[0].Detail())
+			}
+		})
+	}
+}
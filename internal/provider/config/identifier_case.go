@@ -0,0 +1,76 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+const (
+	// CaseSensitive creates objects under their names as written, SQL statements must quote the names that are not
+	// in their normalized form
+	CaseSensitive = "sensitive"
+	// CaseInsensitive requires names to be in their normalized form, so that they can be used unquoted
+	CaseInsensitive = "insensitive"
+	// CaseNormalize creates and looks up objects under the normalized form of their names, so that they can be used
+	// unquoted whatever the case they are configured in
+	CaseNormalize = "normalize"
+)
+
+// CaseSensitivities lists the values of the case_sensitivity provider option.
+var CaseSensitivities = []string{CaseSensitive, CaseInsensitive, CaseNormalize}
+
+// ObjectName returns the name an object is created and looked up under, the normalized form of name when the provider
+// normalizes names and name as written otherwise.
+func (c *DeltaStreamProviderCfg) ObjectName(name string) string {
+	if c.CaseSensitivity == CaseNormalize {
+		return util.NormalizeIdentifier(name)
+	}
+	return name
+}
+
+// ValidateIdentifierCase checks the name a resource is created or renamed with against the case_sensitivity of the
+// provider. A name that is not in its normalized form is reported with the quoted form the provider creates it under,
+// as a warning when names are case sensitive or normalized and as an error otherwise.
+func (c *DeltaStreamProviderCfg) ValidateIdentifierCase(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, attr string) (d diag.Diagnostics) {
+	if plan.Raw.IsNull() {
+		return
+	}
+
+	var name types.String
+	d.Append(plan.GetAttribute(ctx, path.Root(attr), &name)...)
+	if d.HasError() || name.IsNull() || name.IsUnknown() {
+		return
+	}
+	if !state.Raw.IsNull() {
+		var current types.String
+		d.Append(state.GetAttribute(ctx, path.Root(attr), &current)...)
+		if d.HasError() || name.Equal(current) {
+			return
+		}
+	}
+
+	normalized := util.NormalizeIdentifier(name.ValueString())
+	if normalized == name.ValueString() {
+		return
+	}
+	switch c.CaseSensitivity {
+	case CaseNormalize:
+		d.AddAttributeWarning(path.Root(attr), "Identifier is normalized", fmt.Sprintf("%q is created as %s, the provider sets case_sensitivity to %q. SQL statements can refer to it unquoted.", name.ValueString(), util.QuoteIdentifier(normalized), CaseNormalize))
+		return
+	case CaseInsensitive:
+		d.AddAttributeError(path.Root(attr), "Identifier is not normalized", fmt.Sprintf("%q would be created as the case sensitive identifier %s. The provider sets case_sensitivity to %q, use %q instead.", name.ValueString(), util.QuoteIdentifier(name.ValueString()), CaseInsensitive, normalized))
+		return
+	}
+	d.AddAttributeWarning(path.Root(attr), "Identifier is case sensitive", fmt.Sprintf("%q is created as %s, SQL statements must refer to it quoted, as %q resolves to %s when unquoted.", name.ValueString(), util.QuoteIdentifier(name.ValueString()), name.ValueString(), util.QuoteIdentifier(normalized)))
+	return
+}
@@ -0,0 +1,77 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateIdentifierCase(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		"name": schema.StringAttribute{Required: true},
+	}}
+	value := func(v any) tftypes.Value {
+		return tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, v),
+		})
+	}
+	nullState := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+
+	tests := []struct {
+		name        string
+		sensitivity string
+		planned     string
+		state       tfsdk.State
+		wantErr     bool
+		wantWarning string
+	}{
+		{name: "normalized name", sensitivity: CaseInsensitive, planned: "pageviews", state: nullState},
+		{name: "case sensitive name", sensitivity: CaseSensitive, planned: "PageViews", state: nullState, wantWarning: `"PageViews"`},
+		{name: "case sensitive name rejected", sensitivity: CaseInsensitive, planned: "PageViews", state: nullState, wantErr: true},
+		{name: "existing resource", sensitivity: CaseInsensitive, planned: "PageViews", state: tfsdk.State{Schema: s, Raw: value("PageViews")}},
+		{name: "normalized name created", sensitivity: CaseNormalize, planned: "PageViews", state: nullState, wantWarning: `"pageviews"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &DeltaStreamProviderCfg{CaseSensitivity: tt.sensitivity}
+			dg := c.ValidateIdentifierCase(ctx, tfsdk.Plan{Schema: s, Raw: value(tt.planned)}, tt.state, "name")
+			if dg.HasError() != tt.wantErr {
+				t.Fatalf("ValidateIdentifierCase() diagnostics = %v, want error %v", dg, tt.wantErr)
+			}
+			if tt.wantWarning == "" {
+				if dg.WarningsCount() != 0 {
+					t.Errorf("unexpected warnings %v", dg.Warnings())
+				}
+				return
+			}
+			if dg.WarningsCount() != 1 || !strings.Contains(dg.Warnings()[0].Detail(), tt.wantWarning) {
+				t.Errorf("expected a warning mentioning %s, got %v", tt.wantWarning, dg.Warnings())
+			}
+		})
+	}
+}
+
+func TestObjectName(t *testing.T) {
+	for _, tt := range []struct {
+		sensitivity string
+		want        string
+	}{
+		{sensitivity: "", want: "PageViews"},
+		{sensitivity: CaseSensitive, want: "PageViews"},
+		{sensitivity: CaseInsensitive, want: "PageViews"},
+		{sensitivity: CaseNormalize, want: "pageviews"},
+	} {
+		c := &DeltaStreamProviderCfg{CaseSensitivity: tt.sensitivity}
+		if got := c.ObjectName("PageViews"); got != tt.want {
+			t.Errorf("ObjectName() with case_sensitivity %q = %s, want %s", tt.sensitivity, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// RecordManifest records a resource created or updated by the apply, along with the queries it runs, into the
+// manifest file when one is configured. Failing to write the manifest does not fail the apply, it is reported as a
+// warning.
+func (c *DeltaStreamProviderCfg) RecordManifest(ctx context.Context, action, resourceType, fqn string, queryIDs ...string) diag.Diagnostics {
+	var dg diag.Diagnostics
+	if c.Manifest == nil || fqn == "" {
+		return dg
+	}
+
+	if err := c.Manifest.Record(util.ManifestEntry{
+		ResourceType: resourceType,
+		FQN:          fqn,
+		QueryIDs:     queryIDs,
+		Action:       action,
+		Timestamp:    time.Now().UTC(),
+	}); err != nil {
+		tflog.Warn(ctx, "failed to record manifest entry", map[string]any{"fqn": fqn, "error": err.Error()})
+		dg.AddWarning("failed to record manifest entry", "the "+resourceType+" "+fqn+" is missing from the manifest file: "+err.Error())
+	}
+	return dg
+}
@@ -0,0 +1,99 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// Roles returns the names of the roles in the organization. The result is cached for the lifetime of the
+// provider process, which spans a single plan or apply.
+func (c *DeltaStreamProviderCfg) Roles(ctx context.Context) (map[string]struct{}, error) {
+	c.rolesMu.Lock()
+	defer c.rolesMu.Unlock()
+
+	if c.roles != nil {
+		return c.roles, nil
+	}
+
+	ctx, conn, err := util.GetConnection(ctx, c.Db, c.SessionID, c.Organization, c.Role)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	roles := map[string]struct{}{}
+	if err := util.QueryRows(ctx, conn, `LIST ROLES;`, func(rows *sql.Rows) error {
+		cols, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		var name string
+		dest := make([]any, len(cols))
+		dest[0] = &name
+		for i := 1; i < len(cols); i++ {
+			dest[i] = new(any)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		roles[name] = struct{}{}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	c.roles = roles
+	return c.roles, nil
+}
+
+// ValidateOwner checks that the owner role set in the plan exists in the organization. Lookups are skipped when the
+// resource is being destroyed, the owner is not known yet or the owner is unchanged from the current state.
+func (c *DeltaStreamProviderCfg) ValidateOwner(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) (d diag.Diagnostics) {
+	if plan.Raw.IsNull() {
+		return
+	}
+
+	var owner types.String
+	d.Append(plan.GetAttribute(ctx, path.Root("owner"), &owner)...)
+	if d.HasError() || owner.IsNull() || owner.IsUnknown() {
+		return
+	}
+
+	if !state.Raw.IsNull() {
+		var currentOwner types.String
+		d.Append(state.GetAttribute(ctx, path.Root("owner"), &currentOwner)...)
+		if d.HasError() || owner.Equal(currentOwner) {
+			return
+		}
+	}
+
+	roles, err := c.Roles(ctx)
+	if err != nil {
+		d.AddAttributeWarning(path.Root("owner"), "Unable to verify owner role", fmt.Sprintf("failed to list roles: %s", err))
+		return
+	}
+
+	if _, ok := roles[owner.ValueString()]; ok {
+		return
+	}
+	// role names are case sensitive identifiers, point out a role that only differs by case
+	for role := range roles {
+		if util.NormalizeIdentifier(role) == util.NormalizeIdentifier(owner.ValueString()) {
+			d.AddAttributeError(path.Root("owner"), "Owner role not found", fmt.Sprintf("role %s does not exist in organization %s, role names are case sensitive. Use %q to make the role %s the owner.", util.QuoteIdentifier(owner.ValueString()), c.Organization, role, util.QuoteIdentifier(role)))
+			return
+		}
+	}
+	d.AddAttributeError(path.Root("owner"), "Owner role not found", fmt.Sprintf("role %q does not exist in organization %s. Create the role or choose an existing role as the owner.", owner.ValueString(), c.Organization))
+	return
+}
@@ -0,0 +1,34 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+// SetStoreType records the type of a store so entity operations can skip looking it up. The cache lives for the
+// provider process, which spans a single plan or apply.
+func (c *DeltaStreamProviderCfg) SetStoreType(store, kind string) {
+	c.storeTypesMu.Lock()
+	defer c.storeTypesMu.Unlock()
+
+	if c.storeTypes == nil {
+		c.storeTypes = map[string]string{}
+	}
+	c.storeTypes[store] = kind
+}
+
+// StoreType returns the cached type of a store, if any.
+func (c *DeltaStreamProviderCfg) StoreType(store string) (string, bool) {
+	c.storeTypesMu.Lock()
+	defer c.storeTypesMu.Unlock()
+
+	kind, ok := c.storeTypes[store]
+	return kind, ok
+}
+
+// InvalidateStoreType drops the cached type of a store. It is called when the store is created, replaced or
+// dropped in the current run.
+func (c *DeltaStreamProviderCfg) InvalidateStoreType(store string) {
+	c.storeTypesMu.Lock()
+	defer c.storeTypesMu.Unlock()
+
+	delete(c.storeTypes, store)
+}
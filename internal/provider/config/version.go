@@ -0,0 +1,36 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deltastreaminc/go-deltastream/apiv2"
+)
+
+// ServerVersion returns the version of the DeltaStream API server. The result is cached for the lifetime of the
+// provider process.
+func (c *DeltaStreamProviderCfg) ServerVersion(ctx context.Context) (apiv2.Version, error) {
+	c.serverVersionMu.Lock()
+	defer c.serverVersionMu.Unlock()
+
+	if c.serverVersion != nil {
+		return *c.serverVersion, nil
+	}
+	if c.API == nil {
+		return apiv2.Version{}, fmt.Errorf("API client not configured")
+	}
+
+	resp, err := c.API.GetVersionWithResponse(ctx)
+	if err != nil {
+		return apiv2.Version{}, err
+	}
+	if resp.JSON200 == nil {
+		return apiv2.Version{}, fmt.Errorf("failed to read server version: %s", resp.Status())
+	}
+
+	c.serverVersion = resp.JSON200
+	return *c.serverVersion, nil
+}
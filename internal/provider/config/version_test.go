@@ -0,0 +1,35 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/deltastreaminc/go-deltastream/apiv2"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestServerVersion(t *testing.T) {
+	server := mockserver.Start(t, nil)
+
+	api, err := apiv2.NewClientWithResponses(server.APIURL(), apiv2.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer mock-token")
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("failed to create API client: %v", err)
+	}
+
+	c := &DeltaStreamProviderCfg{API: api}
+	v, err := c.ServerVersion(context.Background())
+	if err != nil {
+		t.Fatalf("ServerVersion() error = %v", err)
+	}
+	if want := (apiv2.Version{Major: 2}); v != want {
+		t.Errorf("ServerVersion() = %+v, want %+v", v, want)
+	}
+}
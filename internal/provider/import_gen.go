@@ -0,0 +1,72 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/importgen"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// ImportGen runs the import-gen command of the provider binary. It connects with the credentials of the
+// DELTASTREAM_* environment variables the provider reads and writes import blocks and skeleton resources for the
+// objects of the organization to the -out file, or to stdout.
+func ImportGen(ctx context.Context, version string, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("import-gen", flag.ContinueOnError)
+	out := flags.String("out", "", "file to write the generated configuration to, defaults to stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	settings, diags := resolveSettings(DeltaStreamProviderModel{})
+	if err := diagsError(diags); err != nil {
+		return err
+	}
+	httpClient, diags := newHTTPClient(ctx, settings, version)
+	if err := diagsError(diags); err != nil {
+		return err
+	}
+	db, err := openDB(ctx, settings, httpClient)
+	if err != nil {
+		return fmt.Errorf("failed to configure connection: %w", err)
+	}
+	defer db.Close()
+
+	ctx, conn, err := util.GetConnection(ctx, db, settings.SessionID, settings.Organization, settings.Role)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	w := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return importgen.Generate(ctx, conn, settings.Organization, w)
+}
+
+// diagsError joins the errors of diags, warnings are ignored.
+func diagsError(diags diag.Diagnostics) error {
+	if !diags.HasError() {
+		return nil
+	}
+	msgs := []string{}
+	for _, d := range diags.Errors() {
+		msgs = append(msgs, d.Summary()+": "+d.Detail())
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "; "))
+}
@@ -13,32 +13,44 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"k8s.io/utils/ptr"
 
 	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/deltastreaminc/go-deltastream/apiv2"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/database"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/notification"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/pipeline"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/query"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/region"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/relation"
 	dsschema "github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/schema"
 	schemaregistry "github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/schema_registry"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/secret"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/session"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/store"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/deltastream/version"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
 )
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
 var _ provider.Provider = &DeltaStreamProvider{}
+var _ provider.ProviderWithEphemeralResources = &DeltaStreamProvider{}
 
 // DeltaStreamProvider defines the provider implementation.
 type DeltaStreamProvider struct {
@@ -53,6 +65,23 @@ type DeltaStreamProviderModel struct {
 	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
 	Organization       types.String `tfsdk:"organization"`
 	Role               types.String `tfsdk:"role"`
+	OtelEndpoint       types.String `tfsdk:"otel_endpoint"`
+	DryRun             types.Bool   `tfsdk:"dry_run"`
+	DefaultOwners      types.Map    `tfsdk:"default_owners"`
+
+	StrictRoleIsolation types.Bool `tfsdk:"strict_role_isolation"`
+
+	DefaultTags types.Map `tfsdk:"default_tags"`
+
+	StatementLogFile types.String `tfsdk:"statement_log_file"`
+
+	DefaultAccessRegion types.String `tfsdk:"default_access_region"`
+
+	CaseSensitivity types.String `tfsdk:"case_sensitivity"`
+
+	ManifestFile types.String `tfsdk:"manifest_file"`
+
+	StrictDriftChecks types.Bool `tfsdk:"strict_drift_checks"`
 }
 
 func (p *DeltaStreamProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -85,6 +114,55 @@ func providerSchema() schema.Schema {
 				Optional:    true,
 				Validators:  util.IdentifierValidators,
 			},
+			"otel_endpoint": schema.StringAttribute{
+				Description: "OTLP/HTTP endpoint, such as http://localhost:4318, to send traces of provider operations and SQL statements to. Can also be set via the DELTASTREAM_OTEL_ENDPOINT environment variable. Tracing is disabled when not set",
+				Optional:    true,
+			},
+			"dry_run": schema.BoolAttribute{
+				Description: "Skip the statements that create, update and delete resources. Created and updated resources are recorded in state with their planned values, computed attributes unset, and are planned again on the next run. Deletes fail and keep the resource in state, so a replacement never drops the existing object. Can also be set via the DELTASTREAM_DRY_RUN environment variable",
+				Optional:    true,
+			},
+			"strict_role_isolation": schema.BoolAttribute{
+				Description: "Fail any statement that switches its connection to a role other than the one the operation runs as, such as a USE ROLE in the SQL of a relation or query. Each operation binds its role, the provider role or the owner of the resource, when it checks out a connection. Can also be set via the DELTASTREAM_STRICT_ROLE_ISOLATION environment variable",
+				Optional:    true,
+			},
+			"default_owners": schema.MapAttribute{
+				Description: "Owning role of resources created without an owner, keyed by resource kind: " + strings.Join(config.OwnedResourceKinds, ", ") + ". Resources that already exist keep their owner",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(config.OwnedResourceKinds...)),
+					mapvalidator.ValueStringsAre(util.IdentifierValidators...),
+				},
+			},
+			"default_tags": schema.MapAttribute{
+				Description: "Tags applied to every resource that supports tags (databases, schemas, stores, schema registries, relations, secrets and queries), merged into the tags_all attribute of each resource. Tags set on a resource take precedence over the default tags with the same key",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  util.TagValidators,
+			},
+			"statement_log_file": schema.StringAttribute{
+				Description: "Path of a file to append every statement the provider submits to, as newline-delimited JSON with the time, the Terraform operation and resource type, and the outcome of the statement. Credentials in statements are redacted. Can also be set via the DELTASTREAM_STATEMENT_LOG_FILE environment variable",
+				Optional:    true,
+			},
+			"default_access_region": schema.StringAttribute{
+				Description: "Access region of stores and schema registries created without an access_region. Resources that already exist keep their access region. Can also be set via the DELTASTREAM_DEFAULT_ACCESS_REGION environment variable",
+				Optional:    true,
+				Validators:  util.IdentifierValidators,
+			},
+			"case_sensitivity": schema.StringAttribute{
+				Description: "How the names of new resources are checked, one of " + strings.Join(config.CaseSensitivities, ", ") + ". With sensitive, the default, names are created as written and names that are not lower case are reported with the quoted form SQL statements must use. With insensitive, names must be lower case so that they can be used unquoted. With normalize, databases, schemas, stores, entities, secrets, schema registries, notification targets and renamed relations are created and looked up under their lower case names, whatever the case they are configured in",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(config.CaseSensitivities...)},
+			},
+			"manifest_file": schema.StringAttribute{
+				Description: "Path of a JSON file listing the resource type, fully qualified name and query IDs of every database, schema, store, entity, entity set, secret, secret version, region, relation, query, query savepoint, pipeline, schema registry, schema exchange and notification target the apply created or updated. Entity sets are recorded under their store name, schema exchanges under their schema registry and subject. The file is truncated when an apply starts changing resources, rewritten as resources are applied and left untouched by plans. Can also be set via the DELTASTREAM_MANIFEST_FILE environment variable",
+				Optional:    true,
+			},
+			"strict_drift_checks": schema.BoolAttribute{
+				Description: "Warn during refresh when the server reports a different owner or type for a database, schema, store, relation, query or schema registry, or different URIs for a store, than the ones recorded in state. Such changes made outside of Terraform are otherwise recorded silently. Can also be set via the DELTASTREAM_STRICT_DRIFT_CHECKS environment variable",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -141,55 +219,79 @@ func (p *DeltaStreamProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	cfg := &config.DeltaStreamProviderCfg{
-		Organization: os.Getenv("DELTASTREAM_ORGANIZATION"),
-		Role:         os.Getenv("DELTASTREAM_ROLE"),
-		SessionID:    ptr.To(os.Getenv("DELTASTREAM_SESSION_ID")),
+	settings, dg := resolveSettings(data)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	apiKey := os.Getenv("DELTASTREAM_API_KEY")
-	server := os.Getenv("DELTASTREAM_SERVER")
-	debug := os.Getenv("DELTASTREAM_DEBUG") != ""
-	insecureSkipVerify := os.Getenv("DELTASTREAM_INSECURE_SKIP_VERIFY") != ""
 
-	if !data.Organization.IsNull() {
-		cfg.Organization = data.Organization.ValueString()
-	}
-	if !data.Role.IsNull() {
-		cfg.Role = data.Role.ValueString()
-	}
-	if !data.APIKey.IsNull() {
-		apiKey = data.APIKey.ValueString()
+	cfg := &config.DeltaStreamProviderCfg{
+		Organization:    settings.Organization,
+		Role:            settings.Role,
+		SessionID:       settings.SessionID,
+		DryRun:          settings.DryRun,
+		DefaultOwners:   settings.DefaultOwners,
+		DefaultTags:     settings.DefaultTags,
+		ProviderVersion: p.version,
+
+		DefaultAccessRegion: settings.DefaultAccessRegion,
+
+		CaseSensitivity: settings.CaseSensitivity,
+
+		StrictDriftChecks: settings.StrictDriftChecks,
 	}
-	if !data.Server.IsNull() {
-		server = data.Server.ValueString()
+	if settings.ManifestFile != "" {
+		manifest, err := util.OpenManifest(settings.ManifestFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("manifest_file"), "Failed to open manifest", err.Error())
+			return
+		}
+		cfg.Manifest = manifest
 	}
 
-	if cfg.Organization == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("organization"), "Organization ID not specified", "Organization ID must be specified in the configuration or via the DELTASTREAM_ORGANIZATION environment variable")
+	httpClient, dg := newHTTPClient(ctx, settings, p.version)
+	resp.Diagnostics.Append(dg...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if cfg.Role == "" {
-		resp.Diagnostics.AddAttributeWarning(path.Root("role"), "Role not specified", "Role not specified in the configuration or via the DELTASTREAM_ORGANIZATION environment variable, defaulting to sysadmin")
-		cfg.Role = "sysadmin"
+
+	db, err := openDB(ctx, settings, httpClient)
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "Failed to configure connection", err)
+		return
 	}
-	if apiKey == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("api_key"), "API key not specified", "API key must be specified in the configuration or via the DELTASTREAM_API_KEY environment variable")
+	cfg.Db = db
+
+	cfg.API, err = apiv2.NewClientWithResponses(settings.Server, apiv2.WithHTTPClient(httpClient), apiv2.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+settings.APIKey)
+		return nil
+	}))
+	if err != nil {
+		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "Failed to configure API client", err)
+		return
 	}
-	if server == "" {
-		server = "https://api.deltastream.io/v2"
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	connOptions := []gods.ConnectionOption{gods.WithStaticToken(apiKey)}
-	var sessionID *string
-	if v := os.Getenv("DELTASTREAM_SESSION_ID"); v != "" {
-		if v == "RANDOM" {
-			v = uuid.NewString()
-		}
-		connOptions = append(connOptions, gods.WithSessionID(v))
-		sessionID = ptr.To(v)
+	resp.Diagnostics.Append(cfg.ValidateAccess(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	resp.ResourceData = cfg
+	resp.DataSourceData = cfg
+	resp.EphemeralResourceData = cfg
+}
+
+// newHTTPClient builds the client the connection and the API client send their requests with, wrapping the
+// transport with the tracing, role isolation and statement logging the settings enable.
+func newHTTPClient(ctx context.Context, settings providerSettings, version string) (*http.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	tlsConfig := &tls.Config{}
-	if insecureSkipVerify {
+	if settings.InsecureSkipVerify {
 		tlsConfig = &tls.Config{InsecureSkipVerify: true}
 	}
 
@@ -209,35 +311,54 @@ func (p *DeltaStreamProvider) Configure(ctx context.Context, req provider.Config
 
 	transport := http.RoundTripper(&httpTransport{
 		r:         t,
-		sessionID: sessionID,
+		sessionID: settings.SessionID,
 	})
 
-	if debug {
+	if settings.Debug {
 		transport = &debugTransport{
 			r:         t,
 			stderr:    os.Stderr,
-			sessionID: sessionID,
+			sessionID: settings.SessionID,
 		}
 	}
 
-	httpClient := &http.Client{
-		Transport: transport,
+	if settings.OtelEndpoint != "" {
+		if err := util.ConfigureTracing(ctx, settings.OtelEndpoint, version); err != nil {
+			diags.AddAttributeError(path.Root("otel_endpoint"), "Failed to configure tracing", err.Error())
+			return nil, diags
+		}
+		transport = util.TracingTransport(transport)
+	}
+	if settings.StrictRoleIsolation {
+		transport = util.RoleIsolationTransport(transport)
+	}
+	transport = util.StatementIDTransport(transport)
+	if settings.StatementLogFile != "" {
+		log, err := util.OpenStatementLog(settings.StatementLogFile)
+		if err != nil {
+			diags.AddAttributeError(path.Root("statement_log_file"), "Failed to open statement log", err.Error())
+			return nil, diags
+		}
+		transport = util.StatementLogTransport(transport, log)
 	}
 
-	connOptions = append(connOptions, gods.WithServer(server), gods.WithHTTPClient(httpClient))
+	return &http.Client{
+		Transport: transport,
+	}, diags
+}
+
+// openDB opens the SQL connection pool to DeltaStream with the credentials of the settings.
+func openDB(ctx context.Context, settings providerSettings, httpClient *http.Client) (*sql.DB, error) {
+	connOptions := []gods.ConnectionOption{gods.WithStaticToken(settings.APIKey)}
+	if settings.SessionID != nil {
+		connOptions = append(connOptions, gods.WithSessionID(*settings.SessionID))
+	}
+	connOptions = append(connOptions, gods.WithServer(settings.Server), gods.WithHTTPClient(httpClient))
 	connector, err := gods.ConnectorWithOptions(ctx, connOptions...)
 	if err != nil {
-		resp.Diagnostics = util.LogError(ctx, resp.Diagnostics, "Failed to configure connection", err)
-		return
-	}
-	cfg.Db = sql.OpenDB(connector)
-
-	if resp.Diagnostics.HasError() {
-		return
+		return nil, err
 	}
-
-	resp.ResourceData = cfg
-	resp.DataSourceData = cfg
+	return sql.OpenDB(connector), nil
 }
 
 func (p *DeltaStreamProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -246,10 +367,23 @@ func (p *DeltaStreamProvider) Resources(ctx context.Context) []func() resource.R
 		dsschema.NewSchemaResource,
 		store.NewStoreResource,
 		store.NewEntityResource,
+		store.NewEntitySetResource,
 		secret.NewSecretResource,
+		secret.NewSecretVersionResource,
 		relation.NewRelationResource,
 		query.NewQueryResource,
+		query.NewQuerySavepointResource,
+		pipeline.NewPipelineResource,
 		schemaregistry.NewSchemaRegistryResource,
+		schemaregistry.NewSchemaExchangeResource,
+		region.NewRegionResource,
+		notification.NewNotificationTargetResource,
+	}
+}
+
+func (p *DeltaStreamProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		store.NewEntityDataEphemeralResource,
 	}
 }
 
@@ -266,17 +400,31 @@ func (p *DeltaStreamProvider) DataSources(ctx context.Context) []func() datasour
 
 		store.NewStoreDataSource,
 		store.NewStoresDataSource,
+		store.NewStoreTypesDataSource,
 		store.NewEntitiesDataSource,
 		store.NewEntityDataDataSource,
 
 		relation.NewRelationDataSource,
 		relation.NewRelationsDataSource,
+		relation.NewStatementPlanDataSource,
+		relation.NewRelationFreshnessDataSource,
+		relation.NewRelationQueriesDataSource,
+		relation.NewTopicRelationsDataSource,
+
+		query.NewQueriesDataSource,
+		query.NewQueryStateDataSource,
+		query.NewQueryVersionsDataSource,
+		query.NewQuerySavepointsDataSource,
 
 		secret.NewSecretDataSource,
 		secret.NewSecretsDataSources,
 
 		schemaregistry.NewSchemaRegistryDataSource,
 		schemaregistry.NewSchemaRegistriesDataSource,
+
+		session.NewCurrentSessionDataSource,
+
+		version.NewVersionDataSource,
 	}
 }
 
@@ -4,15 +4,58 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
 )
 
+// mockFixturesDir holds the fixtures served in mock mode, one file per test named after the test.
+const mockFixturesDir = "testdata/mock"
+
 var testAccProviders = map[string]func() (tfprotov6.ProviderServer, error){
 	"deltastream": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+func mockMode() bool {
+	return os.Getenv("DELTASTREAM_MOCK") != ""
+}
+
+func TestMain(m *testing.M) {
+	if mockMode() {
+		fixtures, err := mockserver.LoadFixtures(mockFixturesDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load mock fixtures: %v\n", err)
+			os.Exit(1)
+		}
+		server, err := mockserver.New(fixtures)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to start mock server: %v\n", err)
+			os.Exit(1)
+		}
+
+		os.Setenv("DELTASTREAM_SERVER", server.APIURL())
+		os.Setenv("DELTASTREAM_API_KEY", "mock-token")
+		os.Setenv("DELTASTREAM_ORGANIZATION", "00000000-0000-0000-0000-000000000001")
+		os.Setenv("DELTASTREAM_ROLE", "sysadmin")
+
+		code := m.Run()
+		server.Close()
+		os.Exit(code)
+	}
+
+	os.Exit(m.Run())
+}
+
 func testAccPreCheck(t *testing.T) {
+	if mockMode() {
+		if _, err := os.Stat(filepath.Join(mockFixturesDir, t.Name()+".json")); err != nil {
+			t.Skipf("no mock fixtures for %s", t.Name())
+		}
+	}
 }
@@ -0,0 +1,162 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+)
+
+const (
+	defaultServer = "https://api.deltastream.io/v2"
+	defaultRole   = "sysadmin"
+)
+
+// providerSettings are the connection settings of the provider, resolved from its configuration and the
+// environment.
+type providerSettings struct {
+	APIKey             string
+	Server             string
+	Organization       string
+	Role               string
+	OtelEndpoint       string
+	SessionID          *string
+	InsecureSkipVerify bool
+	Debug              bool
+	DryRun             bool
+	DefaultOwners      map[string]string
+
+	StrictRoleIsolation bool
+
+	DefaultTags map[string]string
+
+	StatementLogFile string
+
+	DefaultAccessRegion string
+
+	CaseSensitivity string
+
+	ManifestFile string
+
+	StrictDriftChecks bool
+}
+
+// resolveSettings applies the provider configuration over the DELTASTREAM_* environment variables. Missing
+// credentials are reported as errors against the attribute that sets them, a missing role defaults to sysadmin.
+func resolveSettings(data DeltaStreamProviderModel) (providerSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	s := providerSettings{
+		APIKey:             os.Getenv("DELTASTREAM_API_KEY"),
+		Server:             os.Getenv("DELTASTREAM_SERVER"),
+		Organization:       os.Getenv("DELTASTREAM_ORGANIZATION"),
+		Role:               os.Getenv("DELTASTREAM_ROLE"),
+		OtelEndpoint:       os.Getenv("DELTASTREAM_OTEL_ENDPOINT"),
+		InsecureSkipVerify: os.Getenv("DELTASTREAM_INSECURE_SKIP_VERIFY") != "",
+		Debug:              os.Getenv("DELTASTREAM_DEBUG") != "",
+		DryRun:             envBool("DELTASTREAM_DRY_RUN", "dry_run", data.DryRun, &diags),
+
+		StrictRoleIsolation: envBool("DELTASTREAM_STRICT_ROLE_ISOLATION", "strict_role_isolation", data.StrictRoleIsolation, &diags),
+
+		StatementLogFile: os.Getenv("DELTASTREAM_STATEMENT_LOG_FILE"),
+
+		DefaultAccessRegion: os.Getenv("DELTASTREAM_DEFAULT_ACCESS_REGION"),
+
+		ManifestFile: os.Getenv("DELTASTREAM_MANIFEST_FILE"),
+
+		StrictDriftChecks: envBool("DELTASTREAM_STRICT_DRIFT_CHECKS", "strict_drift_checks", data.StrictDriftChecks, &diags),
+	}
+
+	override := func(dst *string, v types.String) {
+		if !v.IsNull() && !v.IsUnknown() {
+			*dst = v.ValueString()
+		}
+	}
+	override(&s.APIKey, data.APIKey)
+	override(&s.Server, data.Server)
+	override(&s.Organization, data.Organization)
+	override(&s.Role, data.Role)
+	override(&s.OtelEndpoint, data.OtelEndpoint)
+	override(&s.StatementLogFile, data.StatementLogFile)
+	override(&s.DefaultAccessRegion, data.DefaultAccessRegion)
+	override(&s.CaseSensitivity, data.CaseSensitivity)
+	override(&s.ManifestFile, data.ManifestFile)
+	if !data.InsecureSkipVerify.IsNull() && !data.InsecureSkipVerify.IsUnknown() {
+		s.InsecureSkipVerify = data.InsecureSkipVerify.ValueBool()
+	}
+	if !data.DryRun.IsNull() && !data.DryRun.IsUnknown() {
+		s.DryRun = data.DryRun.ValueBool()
+	}
+	if !data.StrictRoleIsolation.IsNull() && !data.StrictRoleIsolation.IsUnknown() {
+		s.StrictRoleIsolation = data.StrictRoleIsolation.ValueBool()
+	}
+	if !data.StrictDriftChecks.IsNull() && !data.StrictDriftChecks.IsUnknown() {
+		s.StrictDriftChecks = data.StrictDriftChecks.ValueBool()
+	}
+
+	if !data.DefaultOwners.IsNull() && !data.DefaultOwners.IsUnknown() {
+		s.DefaultOwners = map[string]string{}
+		for kind, v := range data.DefaultOwners.Elements() {
+			if owner, ok := v.(types.String); ok && !owner.IsNull() && !owner.IsUnknown() {
+				s.DefaultOwners[kind] = owner.ValueString()
+			}
+		}
+	}
+	if !data.DefaultTags.IsNull() && !data.DefaultTags.IsUnknown() {
+		s.DefaultTags = map[string]string{}
+		for key, v := range data.DefaultTags.Elements() {
+			if value, ok := v.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+				s.DefaultTags[key] = value.ValueString()
+			}
+		}
+	}
+
+	if v := os.Getenv("DELTASTREAM_SESSION_ID"); v != "" {
+		if v == "RANDOM" {
+			v = uuid.NewString()
+		}
+		s.SessionID = ptr.To(v)
+	}
+
+	if s.Organization == "" {
+		diags.AddAttributeError(path.Root("organization"), "Organization ID not specified", "Organization ID must be specified in the configuration or via the DELTASTREAM_ORGANIZATION environment variable")
+	}
+	if s.Role == "" {
+		diags.AddAttributeWarning(path.Root("role"), "Role not specified", "Role not specified in the configuration or via the DELTASTREAM_ROLE environment variable, defaulting to "+defaultRole)
+		s.Role = defaultRole
+	}
+	if s.APIKey == "" {
+		diags.AddAttributeError(path.Root("api_key"), "API key not specified", "API key must be specified in the configuration or via the DELTASTREAM_API_KEY environment variable")
+	}
+	if s.Server == "" {
+		s.Server = defaultServer
+	}
+	if s.CaseSensitivity == "" {
+		s.CaseSensitivity = config.CaseSensitive
+	}
+
+	return s, diags
+}
+
+// envBool parses the boolean environment variable name. It is not read when the attribute that takes precedence over
+// it is configured, an invalid value is reported against that attribute.
+func envBool(name, attribute string, configured types.Bool, diags *diag.Diagnostics) bool {
+	v := os.Getenv(name)
+	if v == "" || !configured.IsNull() {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		diags.AddAttributeError(path.Root(attribute), "Invalid "+name, name+" must be a boolean such as true or false: "+err.Error())
+	}
+	return b
+}
@@ -0,0 +1,386 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider/config"
+)
+
+const (
+	testOrganization      = "00000000-0000-0000-0000-000000000001"
+	testOtherOrganization = "00000000-0000-4000-8000-000000000002"
+)
+
+// providerConfig builds a provider configuration setting the given string attributes, the others are null.
+func providerConfig(t *testing.T, attrs map[string]string) tfsdk.Config {
+	t.Helper()
+
+	s := providerSchema()
+	objType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, v := range attrs {
+		values[name] = tftypes.NewValue(tftypes.String, v)
+	}
+	return tfsdk.Config{Schema: s, Raw: tftypes.NewValue(objType, values)}
+}
+
+func TestConfigure(t *testing.T) {
+	server := mockserver.Start(t, []mockserver.Fixture{{
+		Statement: `^LIST ROLES;$`,
+		Columns:   []mockserver.Column{{Name: "name", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("sysadmin"), mockserver.Row("analyst")},
+	}, {
+		Statement: `^DESCRIBE ROLE "missing";$`,
+		SqlState:  string(gods.SqlStateInvalidRole),
+		Message:   "role missing not found",
+	}, {
+		Statement: `^DESCRIBE ROLE "unlisted";$`,
+		Columns:   []mockserver.Column{{Name: "name", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("unlisted")},
+	}, {
+		Statement: `^DESCRIBE ROLE "hidden";$`,
+		SqlState:  string(gods.SqlStateInsufficientPrivilege),
+		Message:   "insufficient privilege",
+	}})
+
+	tests := []struct {
+		name       string
+		env        map[string]string
+		attrs      map[string]string
+		wantOrg    string
+		wantRole   string
+		wantDryRun bool
+		// wantWarning and wantError are the attributes expected to be reported, if any
+		wantWarning string
+		wantError   string
+	}{
+		{
+			name:     "environment only",
+			env:      map[string]string{"DELTASTREAM_ORGANIZATION": testOrganization, "DELTASTREAM_ROLE": "analyst"},
+			wantOrg:  testOrganization,
+			wantRole: "analyst",
+		},
+		{
+			name:     "attributes only",
+			attrs:    map[string]string{"api_key": "key", "organization": testOrganization, "role": "analyst"},
+			env:      map[string]string{"DELTASTREAM_API_KEY": ""},
+			wantOrg:  testOrganization,
+			wantRole: "analyst",
+		},
+		{
+			name:     "attributes override environment",
+			env:      map[string]string{"DELTASTREAM_ORGANIZATION": testOtherOrganization, "DELTASTREAM_ROLE": "sysadmin"},
+			attrs:    map[string]string{"organization": testOrganization, "role": "analyst"},
+			wantOrg:  testOrganization,
+			wantRole: "analyst",
+		},
+		{
+			name:        "role defaults to sysadmin",
+			env:         map[string]string{"DELTASTREAM_ORGANIZATION": testOrganization},
+			wantOrg:     testOrganization,
+			wantRole:    "sysadmin",
+			wantWarning: "role",
+		},
+		{
+			name:       "dry run from environment",
+			env:        map[string]string{"DELTASTREAM_ORGANIZATION": testOrganization, "DELTASTREAM_ROLE": "analyst", "DELTASTREAM_DRY_RUN": "1"},
+			wantOrg:    testOrganization,
+			wantRole:   "analyst",
+			wantDryRun: true,
+		},
+		{
+			name:      "role not found",
+			env:       map[string]string{"DELTASTREAM_ORGANIZATION": testOrganization},
+			attrs:     map[string]string{"role": "missing"},
+			wantError: "role",
+		},
+		{
+			name:     "role described but not listed",
+			env:      map[string]string{"DELTASTREAM_ORGANIZATION": testOrganization},
+			attrs:    map[string]string{"role": "unlisted"},
+			wantOrg:  testOrganization,
+			wantRole: "unlisted",
+		},
+		{
+			name:        "role cannot be verified",
+			env:         map[string]string{"DELTASTREAM_ORGANIZATION": testOrganization},
+			attrs:       map[string]string{"role": "hidden"},
+			wantOrg:     testOrganization,
+			wantRole:    "hidden",
+			wantWarning: "role",
+		},
+		{
+			name:      "organization missing",
+			env:       map[string]string{"DELTASTREAM_ROLE": "sysadmin"},
+			wantError: "organization",
+		},
+		{
+			name:      "api key missing",
+			env:       map[string]string{"DELTASTREAM_API_KEY": "", "DELTASTREAM_ORGANIZATION": testOrganization},
+			wantError: "api_key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"DELTASTREAM_ORGANIZATION", "DELTASTREAM_ROLE", "DELTASTREAM_SESSION_ID", "DELTASTREAM_OTEL_ENDPOINT", "DELTASTREAM_DEBUG", "DELTASTREAM_DRY_RUN"} {
+				t.Setenv(name, "")
+			}
+			t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+			t.Setenv("DELTASTREAM_SERVER", server.APIURL())
+			for name, v := range tt.env {
+				t.Setenv(name, v)
+			}
+
+			p := &DeltaStreamProvider{version: "test"}
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: providerConfig(t, tt.attrs)}, resp)
+
+			for _, d := range resp.Diagnostics {
+				t.Logf("%s: %s: %s", d.Severity(), d.Summary(), d.Detail())
+			}
+			if tt.wantError != "" {
+				if !hasAttributeDiagnostic(resp, tt.wantError, true) {
+					t.Fatalf("expected an error on %s", tt.wantError)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatal("unexpected error")
+			}
+			if tt.wantWarning != "" && !hasAttributeDiagnostic(resp, tt.wantWarning, false) {
+				t.Errorf("expected a warning on %s", tt.wantWarning)
+			}
+
+			cfg, ok := resp.ResourceData.(*config.DeltaStreamProviderCfg)
+			if !ok {
+				t.Fatalf("ResourceData = %T, want *config.DeltaStreamProviderCfg", resp.ResourceData)
+			}
+			if cfg.Organization != tt.wantOrg {
+				t.Errorf("Organization = %q, want %q", cfg.Organization, tt.wantOrg)
+			}
+			if cfg.Role != tt.wantRole {
+				t.Errorf("Role = %q, want %q", cfg.Role, tt.wantRole)
+			}
+			if cfg.DryRun != tt.wantDryRun {
+				t.Errorf("DryRun = %t, want %t", cfg.DryRun, tt.wantDryRun)
+			}
+		})
+	}
+}
+
+func hasAttributeDiagnostic(resp *provider.ConfigureResponse, attribute string, isError bool) bool {
+	want := path.Root(attribute)
+	for _, d := range resp.Diagnostics {
+		wd, ok := d.(interface{ Path() path.Path })
+		if !ok || !wd.Path().Equal(want) {
+			continue
+		}
+		if isError == (d.Severity().String() == "Error") {
+			return true
+		}
+	}
+	return false
+}
+
+func TestResolveDefaultOwners(t *testing.T) {
+	t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+	t.Setenv("DELTASTREAM_ORGANIZATION", testOrganization)
+
+	data := DeltaStreamProviderModel{
+		DefaultOwners: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"store":    types.StringValue("infra_admin"),
+			"relation": types.StringValue("data_eng"),
+		}),
+	}
+	s, dg := resolveSettings(data)
+	if dg.HasError() {
+		t.Fatalf("resolveSettings() diagnostics = %v", dg)
+	}
+	want := map[string]string{"store": "infra_admin", "relation": "data_eng"}
+	if !reflect.DeepEqual(s.DefaultOwners, want) {
+		t.Errorf("DefaultOwners = %v, want %v", s.DefaultOwners, want)
+	}
+
+	s, _ = resolveSettings(DeltaStreamProviderModel{DefaultOwners: types.MapNull(types.StringType)})
+	if s.DefaultOwners != nil {
+		t.Errorf("DefaultOwners = %v, want nil", s.DefaultOwners)
+	}
+}
+
+func TestResolveDefaultTags(t *testing.T) {
+	t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+	t.Setenv("DELTASTREAM_ORGANIZATION", testOrganization)
+
+	s, dg := resolveSettings(DeltaStreamProviderModel{
+		DefaultTags: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"team":        types.StringValue("data"),
+			"cost-center": types.StringValue("42"),
+		}),
+	})
+	if dg.HasError() {
+		t.Fatalf("resolveSettings() diagnostics = %v", dg)
+	}
+	want := map[string]string{"team": "data", "cost-center": "42"}
+	if !reflect.DeepEqual(s.DefaultTags, want) {
+		t.Errorf("DefaultTags = %v, want %v", s.DefaultTags, want)
+	}
+
+	s, _ = resolveSettings(DeltaStreamProviderModel{DefaultTags: types.MapNull(types.StringType)})
+	if s.DefaultTags != nil {
+		t.Errorf("DefaultTags = %v, want nil", s.DefaultTags)
+	}
+}
+
+func TestResolveStatementLogFile(t *testing.T) {
+	t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+	t.Setenv("DELTASTREAM_ORGANIZATION", testOrganization)
+	t.Setenv("DELTASTREAM_STATEMENT_LOG_FILE", "env.jsonl")
+
+	s, _ := resolveSettings(DeltaStreamProviderModel{})
+	if s.StatementLogFile != "env.jsonl" {
+		t.Errorf("StatementLogFile = %q, want env.jsonl", s.StatementLogFile)
+	}
+
+	s, _ = resolveSettings(DeltaStreamProviderModel{StatementLogFile: types.StringValue("apply.jsonl")})
+	if s.StatementLogFile != "apply.jsonl" {
+		t.Errorf("StatementLogFile = %q, want apply.jsonl", s.StatementLogFile)
+	}
+}
+
+func TestResolveManifestFile(t *testing.T) {
+	t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+	t.Setenv("DELTASTREAM_ORGANIZATION", testOrganization)
+	t.Setenv("DELTASTREAM_MANIFEST_FILE", "env.json")
+
+	s, _ := resolveSettings(DeltaStreamProviderModel{})
+	if s.ManifestFile != "env.json" {
+		t.Errorf("ManifestFile = %q, want env.json", s.ManifestFile)
+	}
+
+	s, _ = resolveSettings(DeltaStreamProviderModel{ManifestFile: types.StringValue("apply.json")})
+	if s.ManifestFile != "apply.json" {
+		t.Errorf("ManifestFile = %q, want apply.json", s.ManifestFile)
+	}
+}
+
+func TestResolveDefaultAccessRegion(t *testing.T) {
+	t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+	t.Setenv("DELTASTREAM_ORGANIZATION", testOrganization)
+
+	s, dg := resolveSettings(DeltaStreamProviderModel{DefaultAccessRegion: types.StringValue("AWS us-east-1")})
+	if dg.HasError() {
+		t.Fatalf("resolveSettings() diagnostics = %v", dg)
+	}
+	if s.DefaultAccessRegion != "AWS us-east-1" {
+		t.Errorf("DefaultAccessRegion = %q, want AWS us-east-1", s.DefaultAccessRegion)
+	}
+
+	s, _ = resolveSettings(DeltaStreamProviderModel{DefaultAccessRegion: types.StringNull()})
+	if s.DefaultAccessRegion != "" {
+		t.Errorf("DefaultAccessRegion = %q, want empty", s.DefaultAccessRegion)
+	}
+
+	t.Setenv("DELTASTREAM_DEFAULT_ACCESS_REGION", "AWS eu-west-1")
+	s, _ = resolveSettings(DeltaStreamProviderModel{DefaultAccessRegion: types.StringNull()})
+	if s.DefaultAccessRegion != "AWS eu-west-1" {
+		t.Errorf("DefaultAccessRegion = %q, want AWS eu-west-1 from the environment", s.DefaultAccessRegion)
+	}
+}
+
+func TestResolveCaseSensitivity(t *testing.T) {
+	t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+	t.Setenv("DELTASTREAM_ORGANIZATION", testOrganization)
+
+	s, _ := resolveSettings(DeltaStreamProviderModel{CaseSensitivity: types.StringNull()})
+	if s.CaseSensitivity != config.CaseSensitive {
+		t.Errorf("CaseSensitivity = %q, want %q", s.CaseSensitivity, config.CaseSensitive)
+	}
+
+	s, _ = resolveSettings(DeltaStreamProviderModel{CaseSensitivity: types.StringValue(config.CaseInsensitive)})
+	if s.CaseSensitivity != config.CaseInsensitive {
+		t.Errorf("CaseSensitivity = %q, want %q", s.CaseSensitivity, config.CaseInsensitive)
+	}
+}
+
+func TestResolveDryRun(t *testing.T) {
+	t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+	t.Setenv("DELTASTREAM_ORGANIZATION", testOrganization)
+
+	for v, want := range map[string]bool{"1": true, "true": true, "0": false, "false": false} {
+		t.Setenv("DELTASTREAM_DRY_RUN", v)
+		s, diags := resolveSettings(DeltaStreamProviderModel{})
+		if s.DryRun != want || diags.HasError() {
+			t.Errorf("DELTASTREAM_DRY_RUN=%s: DryRun = %v, %v, want %v", v, s.DryRun, diags, want)
+		}
+	}
+
+	t.Setenv("DELTASTREAM_DRY_RUN", "yes")
+	if _, diags := resolveSettings(DeltaStreamProviderModel{}); !diags.HasError() {
+		t.Errorf("resolveSettings() expected an error for an invalid DELTASTREAM_DRY_RUN")
+	}
+	if s, diags := resolveSettings(DeltaStreamProviderModel{DryRun: types.BoolValue(true)}); !s.DryRun || diags.HasError() {
+		t.Errorf("DryRun = %v, %v, want the configuration to take precedence", s.DryRun, diags)
+	}
+}
+
+func TestResolveStrictRoleIsolation(t *testing.T) {
+	t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+	t.Setenv("DELTASTREAM_ORGANIZATION", testOrganization)
+
+	for v, want := range map[string]bool{"1": true, "0": false, "false": false} {
+		t.Setenv("DELTASTREAM_STRICT_ROLE_ISOLATION", v)
+		s, diags := resolveSettings(DeltaStreamProviderModel{})
+		if s.StrictRoleIsolation != want || diags.HasError() {
+			t.Errorf("DELTASTREAM_STRICT_ROLE_ISOLATION=%s: StrictRoleIsolation = %v, %v, want %v", v, s.StrictRoleIsolation, diags, want)
+		}
+	}
+
+	t.Setenv("DELTASTREAM_STRICT_ROLE_ISOLATION", "on")
+	if _, diags := resolveSettings(DeltaStreamProviderModel{}); !diags.HasError() {
+		t.Errorf("resolveSettings() expected an error for an invalid DELTASTREAM_STRICT_ROLE_ISOLATION")
+	}
+}
+
+func TestResolveStrictDriftChecks(t *testing.T) {
+	t.Setenv("DELTASTREAM_API_KEY", "mock-token")
+	t.Setenv("DELTASTREAM_ORGANIZATION", testOrganization)
+	t.Setenv("DELTASTREAM_STRICT_DRIFT_CHECKS", "1")
+
+	s, _ := resolveSettings(DeltaStreamProviderModel{})
+	if !s.StrictDriftChecks {
+		t.Errorf("StrictDriftChecks = false, want true from the environment")
+	}
+
+	s, _ = resolveSettings(DeltaStreamProviderModel{StrictDriftChecks: types.BoolValue(false)})
+	if s.StrictDriftChecks {
+		t.Errorf("StrictDriftChecks = true, want the configuration to take precedence")
+	}
+
+	t.Setenv("DELTASTREAM_STRICT_DRIFT_CHECKS", "false")
+	s, diags := resolveSettings(DeltaStreamProviderModel{})
+	if s.StrictDriftChecks || diags.HasError() {
+		t.Errorf("StrictDriftChecks = %v, %v, want false from the environment", s.StrictDriftChecks, diags)
+	}
+
+	t.Setenv("DELTASTREAM_STRICT_DRIFT_CHECKS", "sometimes")
+	if _, diags := resolveSettings(DeltaStreamProviderModel{}); !diags.HasError() {
+		t.Errorf("resolveSettings() expected an error for an invalid DELTASTREAM_STRICT_DRIFT_CHECKS")
+	}
+}
@@ -0,0 +1,12 @@
+provider "deltastream" {}
+
+resource "random_id" "id1" {
+  byte_length = 8
+}
+
+resource "deltastream_notification_target" "webhook" {
+  name = "notification_target_${random_id.id1.hex}"
+  webhook = {
+    url = "https://example.com/deltastream/alerts"
+  }
+}
@@ -30,8 +30,8 @@ resource "deltastream_store" "kafka_with_iam" {
   kafka = {
     uris               = var.pub_msk_iam_uri
     sasl_hash_function = "AWS_MSK_IAM"
-    msk_iam_role_arn   = var.pub_msk_region
-    msk_aws_region     = var.msk_region
+    msk_iam_role_arn   = var.pub_msk_iam_role
+    msk_aws_region     = var.pub_msk_region
   }
 }
 
@@ -55,7 +55,7 @@ resource "deltastream_store" "kinesis_creds" {
   name          = "query_kinesis_kinesis_sink_${random_id.suffix.hex}"
   access_region = var.kinesis_region
   kinesis = {
-    uris              = var.kinesis_url
+    uris              = var.kinesis_uri
     access_key_id     = var.kinesis_key
     secret_access_key = var.kinesis_secret
   }
@@ -77,7 +77,7 @@ resource "deltastream_relation" "pageviews" {
 resource "deltastream_entity" "pageviews_6" {
   store       = deltastream_store.kinesis_creds.name
   entity_path = ["query_kinesis_pageviews_6_${random_id.suffix.hex}"]
-  kafka_properties = {
+  kinesis_properties = {
     kinesis_shards = 1
   }
 }
@@ -93,7 +93,7 @@ resource "deltastream_relation" "pageviews_6" {
 
 resource "deltastream_query" "insert_into_pageviews_6" {
   source_relation_fqns = [deltastream_relation.pageviews.fqn]
-  sink_relation_fqn    = deltastream_relation.pageviews_6.fqn
+  sink_relation_fqns   = [deltastream_relation.pageviews_6.fqn]
   sql                  = <<EOF
     INSERT INTO ${deltastream_relation.pageviews_6.fqn} SELECT * FROM ${deltastream_relation.pageviews.fqn} WHERE userid = 'User_6';
   EOF
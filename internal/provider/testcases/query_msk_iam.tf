@@ -68,7 +68,7 @@ resource "deltastream_relation" "pageviews_6" {
 
 resource "deltastream_query" "insert_into_pageviews_6" {
   source_relation_fqns = [deltastream_relation.pageviews.fqn]
-  sink_relation_fqn    = deltastream_relation.pageviews_6.fqn
+  sink_relation_fqns   = [deltastream_relation.pageviews_6.fqn]
   sql                  = <<EOF
     INSERT INTO ${deltastream_relation.pageviews_6.fqn} SELECT * FROM ${deltastream_relation.pageviews.fqn} WHERE userid = 'User_6';
   EOF
@@ -32,9 +32,6 @@ resource "deltastream_store" "kafka_with_sasl" {
     sasl_hash_function = "SHA512"
     sasl_username      = var.pub_msk_username
     sasl_password      = var.pub_msk_password
-    config = {
-      "cleanup.policy"                      = "compact"
-    }
   }
 }
 
@@ -0,0 +1,254 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// testcaseExempt lists the resources no testcase configures, with the reason.
+var testcaseExempt = map[string]string{
+	"deltastream_region":          "regions are provided by DeltaStream and cannot be created or dropped",
+	"deltastream_secret_version":  "versions of a secret are not dropped on their own, destroy is not symmetric",
+	"deltastream_entity_set":      "depends on the topics available on the test brokers",
+	"deltastream_query_savepoint": "needs a running query, savepoints are not dropped on their own",
+	"deltastream_pipeline":        "pipelines run a query for the whole test, covered by the query tests",
+	"deltastream_schema_exchange": "needs the credentials of a schema registry the test organization has attached",
+}
+
+func resourceSchemas(ctx context.Context) (map[string]rschema.Schema, error) {
+	p := New("test")()
+	schemas := map[string]rschema.Schema{}
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		mresp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "deltastream"}, mresp)
+		sresp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, sresp)
+		if sresp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("invalid schema of %s: %v", mresp.TypeName, sresp.Diagnostics)
+		}
+		schemas[mresp.TypeName] = sresp.Schema
+	}
+	return schemas, nil
+}
+
+func dataSourceSchemas(ctx context.Context) (map[string]dschema.Schema, error) {
+	p := New("test")()
+	schemas := map[string]dschema.Schema{}
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+		mresp := &datasource.MetadataResponse{}
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "deltastream"}, mresp)
+		sresp := &datasource.SchemaResponse{}
+		d.Schema(ctx, datasource.SchemaRequest{}, sresp)
+		if sresp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("invalid schema of %s: %v", mresp.TypeName, sresp.Diagnostics)
+		}
+		schemas[mresp.TypeName] = sresp.Schema
+	}
+	return schemas, nil
+}
+
+// parseTestcase parses a testcase configuration.
+func parseTestcase(f string) (*hclsyntax.Body, error) {
+	src, err := os.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	file, diags := hclsyntax.ParseConfig(src, f, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("%s: %v", f, diags)
+	}
+	return file.Body.(*hclsyntax.Body), nil
+}
+
+// testcaseVariables returns the variables a testcase declares.
+func testcaseVariables(body *hclsyntax.Body) []string {
+	var variables []string
+	for _, block := range body.Blocks {
+		if block.Type == "variable" && len(block.Labels) == 1 {
+			variables = append(variables, block.Labels[0])
+		}
+	}
+	return variables
+}
+
+// schemaAttribute is the part of the resource and data source schema attributes the testcases are checked against.
+type schemaAttribute interface {
+	IsRequired() bool
+	IsOptional() bool
+	IsComputed() bool
+}
+
+func resourceAttributes(attrs map[string]rschema.Attribute) map[string]schemaAttribute {
+	out := make(map[string]schemaAttribute, len(attrs))
+	for name, a := range attrs {
+		out[name] = a
+	}
+	return out
+}
+
+func dataSourceAttributes(attrs map[string]dschema.Attribute) map[string]schemaAttribute {
+	out := make(map[string]schemaAttribute, len(attrs))
+	for name, a := range attrs {
+		out[name] = a
+	}
+	return out
+}
+
+// nestedAttributes returns the attributes of a single nested attribute.
+func nestedAttributes(a schemaAttribute) (map[string]schemaAttribute, bool) {
+	switch a := a.(type) {
+	case rschema.SingleNestedAttribute:
+		return resourceAttributes(a.Attributes), true
+	case dschema.SingleNestedAttribute:
+		return dataSourceAttributes(a.Attributes), true
+	}
+	return nil, false
+}
+
+// metaArguments are the arguments Terraform accepts on every resource and data source block.
+var metaArguments = map[string]bool{"count": true, "depends_on": true, "for_each": true, "provider": true}
+
+// checkAttributes checks that the attributes a testcase sets are configurable attributes of the schema and that it
+// sets the required ones.
+func checkAttributes(path string, set map[string]hcl.Expression, attrs map[string]schemaAttribute) []error {
+	var errs []error
+	for name, expr := range set {
+		if metaArguments[name] && path == "" {
+			continue
+		}
+		a, ok := attrs[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown attribute %s%s", path, name))
+			continue
+		}
+		if !a.IsRequired() && !a.IsOptional() {
+			errs = append(errs, fmt.Errorf("computed attribute %s%s is set", path, name))
+			continue
+		}
+		nested, ok := nestedAttributes(a)
+		obj, isObj := expr.(*hclsyntax.ObjectConsExpr)
+		if !ok || !isObj {
+			continue
+		}
+		items := map[string]hcl.Expression{}
+		for _, item := range obj.Items {
+			if key := hcl.ExprAsKeyword(item.KeyExpr); key != "" {
+				items[key] = item.ValueExpr
+			}
+		}
+		errs = append(errs, checkAttributes(path+name+".", items, nested)...)
+	}
+	for name, a := range attrs {
+		if _, ok := set[name]; !ok && a.IsRequired() {
+			errs = append(errs, fmt.Errorf("required attribute %s%s is not set", path, name))
+		}
+	}
+	return errs
+}
+
+func TestTestcasesMatchSchemas(t *testing.T) {
+	ctx := context.Background()
+	resources, err := resourceSchemas(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataSources, err := dataSourceSchemas(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob("testcases/*.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	covered := map[string]bool{}
+	for _, f := range files {
+		body, err := parseTestcase(f)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		declared := map[string]bool{}
+		for _, block := range body.Blocks {
+			if len(block.Labels) == 0 {
+				continue
+			}
+			typeName := block.Labels[0]
+
+			var attrs map[string]schemaAttribute
+			switch {
+			case block.Type == "variable":
+				declared[typeName] = true
+				continue
+			case block.Type == "resource" && strings.HasPrefix(typeName, "deltastream_"):
+				s, ok := resources[typeName]
+				if !ok {
+					t.Errorf("%s: unknown resource %s", f, typeName)
+					continue
+				}
+				attrs = resourceAttributes(s.Attributes)
+				covered[typeName] = true
+			case block.Type == "data" && strings.HasPrefix(typeName, "deltastream_"):
+				s, ok := dataSources[typeName]
+				if !ok {
+					t.Errorf("%s: unknown data source %s", f, typeName)
+					continue
+				}
+				attrs = dataSourceAttributes(s.Attributes)
+			default:
+				continue
+			}
+
+			set := map[string]hcl.Expression{}
+			for name, a := range block.Body.Attributes {
+				set[name] = a.Expr
+			}
+			for _, err := range checkAttributes("", set, attrs) {
+				t.Errorf("%s: %s %s.%s: %v", f, block.Type, typeName, block.Labels[1], err)
+			}
+		}
+
+		used := map[string]bool{}
+		_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			if expr, ok := node.(*hclsyntax.ScopeTraversalExpr); ok && expr.Traversal.RootName() == "var" && len(expr.Traversal) > 1 {
+				if attr, ok := expr.Traversal[1].(hcl.TraverseAttr); ok {
+					used[attr.Name] = true
+				}
+			}
+			return nil
+		})
+		for name := range declared {
+			if !used[name] {
+				t.Errorf("%s: variable %s is declared but not used", f, name)
+			}
+		}
+		for name := range used {
+			if !declared[name] {
+				t.Errorf("%s: variable %s is used but not declared", f, name)
+			}
+		}
+	}
+
+	for typeName := range resources {
+		if !covered[typeName] && testcaseExempt[typeName] == "" {
+			t.Errorf("resource %s is in no testcase, add one to testcases or list it in testcaseExempt", typeName)
+		}
+	}
+}
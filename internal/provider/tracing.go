@@ -0,0 +1,118 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/util"
+)
+
+// NewServer returns the protocol server of the provider. Resource and data source operations are wrapped in spans,
+// which become the parents of the spans recorded for the SQL statements they run. Terraform does not send resource
+// addresses to providers, so spans are identified by resource type. The operation is also recorded in the context
+// for the statement log.
+func NewServer(version string) func() tfprotov6.ProviderServer {
+	return func() tfprotov6.ProviderServer {
+		return &tracingServer{ProviderServer: providerserver.NewProtocol6(New(version)())()}
+	}
+}
+
+type tracingServer struct {
+	tfprotov6.ProviderServer
+}
+
+func startRPCSpan(ctx context.Context, rpc, typeName string) (context.Context, trace.Span) {
+	ctx = util.WithResourceOperation(ctx, rpc, typeName)
+	return util.Tracer().Start(ctx, "terraform."+rpc, trace.WithAttributes(
+		attribute.String("terraform.rpc", rpc),
+		attribute.String("terraform.resource_type", typeName),
+	))
+}
+
+func endRPCSpan(ctx context.Context, span trace.Span, diags []*tfprotov6.Diagnostic, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	for _, d := range diags {
+		if d != nil && d.Severity == tfprotov6.DiagnosticSeverityError {
+			span.SetStatus(codes.Error, d.Summary)
+			break
+		}
+	}
+	span.End()
+	util.FlushTracing(ctx)
+}
+
+func (s *tracingServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (resp *tfprotov6.PlanResourceChangeResponse, err error) {
+	ctx, span := startRPCSpan(ctx, "PlanResourceChange", req.TypeName)
+	defer func() {
+		if resp != nil {
+			endRPCSpan(ctx, span, resp.Diagnostics, err)
+		} else {
+			endRPCSpan(ctx, span, nil, err)
+		}
+	}()
+	return s.ProviderServer.PlanResourceChange(ctx, req)
+}
+
+func (s *tracingServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (resp *tfprotov6.ApplyResourceChangeResponse, err error) {
+	ctx, span := startRPCSpan(ctx, "ApplyResourceChange", req.TypeName)
+	// the first change of the apply truncates the manifest of the previous apply
+	if err := util.BeginManifests(); err != nil {
+		tflog.Warn(ctx, "failed to truncate manifest", map[string]any{"error": err.Error()})
+	}
+	defer func() {
+		if resp != nil {
+			endRPCSpan(ctx, span, resp.Diagnostics, err)
+		} else {
+			endRPCSpan(ctx, span, nil, err)
+		}
+	}()
+	return s.ProviderServer.ApplyResourceChange(ctx, req)
+}
+
+func (s *tracingServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (resp *tfprotov6.ReadResourceResponse, err error) {
+	ctx, span := startRPCSpan(ctx, "ReadResource", req.TypeName)
+	defer func() {
+		if resp != nil {
+			endRPCSpan(ctx, span, resp.Diagnostics, err)
+		} else {
+			endRPCSpan(ctx, span, nil, err)
+		}
+	}()
+	return s.ProviderServer.ReadResource(ctx, req)
+}
+
+func (s *tracingServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (resp *tfprotov6.ImportResourceStateResponse, err error) {
+	ctx, span := startRPCSpan(ctx, "ImportResourceState", req.TypeName)
+	defer func() {
+		if resp != nil {
+			endRPCSpan(ctx, span, resp.Diagnostics, err)
+		} else {
+			endRPCSpan(ctx, span, nil, err)
+		}
+	}()
+	return s.ProviderServer.ImportResourceState(ctx, req)
+}
+
+func (s *tracingServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (resp *tfprotov6.ReadDataSourceResponse, err error) {
+	ctx, span := startRPCSpan(ctx, "ReadDataSource", req.TypeName)
+	defer func() {
+		if resp != nil {
+			endRPCSpan(ctx, span, resp.Diagnostics, err)
+		} else {
+			endRPCSpan(ctx, span, nil, err)
+		}
+	}()
+	return s.ProviderServer.ReadDataSource(ctx, req)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+)
+
+const (
+	// breakerThreshold is the number of consecutive connection failures, each after exhausting its retries,
+	// after which the API is considered down.
+	breakerThreshold = 2
+	// breakerCooldown is how long connection attempts fail fast once the API is considered down.
+	breakerCooldown = time.Minute
+)
+
+// ErrAPIUnavailable is returned without contacting the server while the circuit breaker is open.
+type ErrAPIUnavailable struct {
+	LastErr error
+	RetryAt time.Time
+}
+
+func (e *ErrAPIUnavailable) Error() string {
+	return fmt.Sprintf("DeltaStream API is unavailable, not retrying until %s: %v", e.RetryAt.Format(time.RFC3339), e.LastErr)
+}
+
+func (e *ErrAPIUnavailable) Unwrap() error {
+	return e.LastErr
+}
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	lastErr   error
+	openUntil time.Time
+}
+
+// breakers holds one circuit breaker per provider configuration, keyed by its *sql.DB.
+var breakers sync.Map
+
+func breakerFor(db *sql.DB) *circuitBreaker {
+	b, _ := breakers.LoadOrStore(db, &circuitBreaker{})
+	return b.(*circuitBreaker)
+}
+
+// isTransientConnectError reports whether a failure to connect may succeed when retried: network failures, timeouts
+// and server side errors. Authentication and client configuration errors are permanent, as is a host that does not
+// resolve.
+func isTransientConnectError(err error) bool {
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	var netErr net.Error
+	var serverErr *gods.ErrServerError
+	switch {
+	case errors.Is(err, gods.ErrAuthenticationError):
+		return false
+	case errors.As(err, &dnsErr):
+		return !dnsErr.IsNotFound
+	case errors.Is(err, gods.ErrServiceUnavailable), errors.Is(err, gods.ErrDeadlineExceeded), errors.Is(err, driver.ErrBadConn),
+		errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.As(err, &serverErr), errors.As(err, &opErr):
+		return true
+	case errors.As(err, &netErr):
+		return netErr.Timeout()
+	}
+	return false
+}
+
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if time.Now().Before(b.openUntil) {
+		return &ErrAPIUnavailable{LastErr: b.lastErr, RetryAt: b.openUntil}
+	}
+	return nil
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.lastErr = nil
+	b.openUntil = time.Time{}
+}
+
+func (b *circuitBreaker) failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.lastErr = err
+	if b.failures >= breakerThreshold {
+		b.openUntil = time.Now().Add(breakerCooldown)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+)
+
+// failingConnector fails every connection attempt with err and counts the attempts.
+type failingConnector struct {
+	err      error
+	attempts int
+}
+
+func (c *failingConnector) Connect(context.Context) (driver.Conn, error) {
+	c.attempts++
+	return nil, c.err
+}
+
+func (c *failingConnector) Driver() driver.Driver {
+	return nil
+}
+
+func TestIsTransientConnectError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, want: true},
+		{name: "service unavailable", err: fmt.Errorf("maintenance: %w", gods.ErrServiceUnavailable), want: true},
+		{name: "bad connection", err: driver.ErrBadConn, want: true},
+		{name: "temporary dns failure", err: &net.DNSError{Err: "server misbehaving", Name: "api.deltastream.io", IsTemporary: true}, want: true},
+		{name: "unknown host", err: &net.DNSError{Err: "no such host", Name: "api.deltastream.invalid", IsNotFound: true}, want: false},
+		{name: "authentication", err: fmt.Errorf("login: %w", gods.ErrAuthenticationError), want: false},
+		{name: "client error", err: gods.ErrNotSupported, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientConnectError(tt.err); got != tt.want {
+				t.Errorf("isTransientConnectError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	b := &circuitBreaker{}
+	for i := 0; i < breakerThreshold-1; i++ {
+		b.failure(gods.ErrServiceUnavailable)
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() below the threshold error = %v", err)
+	}
+
+	b.failure(gods.ErrServiceUnavailable)
+	var unavailable *ErrAPIUnavailable
+	if err := b.allow(); !errors.As(err, &unavailable) || !errors.Is(err, gods.ErrServiceUnavailable) {
+		t.Fatalf("allow() at the threshold error = %v, want ErrAPIUnavailable wrapping the last error", err)
+	}
+
+	b.success()
+	if err := b.allow(); err != nil {
+		t.Errorf("allow() after a success error = %v", err)
+	}
+}
+
+func TestGetConnectionPermanentError(t *testing.T) {
+	connector := &failingConnector{err: fmt.Errorf("login: %w", gods.ErrAuthenticationError)}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	for i := 0; i < breakerThreshold+1; i++ {
+		if _, _, err := GetConnection(context.Background(), db, nil, "00000000-0000-0000-0000-000000000001", "sysadmin"); !errors.Is(err, gods.ErrAuthenticationError) {
+			t.Fatalf("GetConnection() error = %v, want the authentication error", err)
+		}
+	}
+	if connector.attempts != breakerThreshold+1 {
+		t.Errorf("connection attempts = %d, want %d, permanent errors must not be retried", connector.attempts, breakerThreshold+1)
+	}
+	if err := breakerFor(db).allow(); err != nil {
+		t.Errorf("permanent errors opened the circuit breaker: %v", err)
+	}
+}
+
+func TestGetConnectionOpenBreaker(t *testing.T) {
+	connector := &failingConnector{err: gods.ErrServiceUnavailable}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	for i := 0; i < breakerThreshold; i++ {
+		breakerFor(db).failure(gods.ErrServiceUnavailable)
+	}
+	var unavailable *ErrAPIUnavailable
+	if _, _, err := GetConnection(context.Background(), db, nil, "00000000-0000-0000-0000-000000000001", "sysadmin"); !errors.As(err, &unavailable) {
+		t.Fatalf("GetConnection() error = %v, want ErrAPIUnavailable", err)
+	}
+	if connector.attempts != 0 {
+		t.Errorf("connection attempts = %d while the breaker is open, want 0", connector.attempts)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sethvargo/go-retry"
+)
+
+// conflictStates lists the SQL states DeltaStream reports when the backend could not apply a statement in time because
+// of contention, such as when many schemas are created in one database at once. The statement may still have been
+// applied.
+var conflictStates = []gods.SqlState{
+	gods.SqlStateRemoteUnavailable,
+	gods.SqlStateTimeout,
+}
+
+// duplicateStates lists, per kind of object, the SQL states DeltaStream reports when the object already exists.
+var duplicateStates = map[string][]gods.SqlState{
+	"database": {gods.SqlStateDuplicateObject, gods.SqlStateDuplicateDatabase},
+	"schema":   {gods.SqlStateDuplicateObject, gods.SqlStateDuplicateSchema},
+}
+
+const (
+	// conflictMaxDuration bounds the time spent retrying a statement that keeps conflicting.
+	conflictMaxDuration = time.Minute * 2
+	conflictBaseBackoff = 500 * time.Millisecond
+	conflictMaxBackoff  = 10 * time.Second
+)
+
+// IsConflict reports whether err means the statement conflicted with a concurrent one and can be run again as is.
+func IsConflict(err error) bool {
+	var sqlErr gods.ErrSQLError
+	return errors.As(err, &sqlErr) && slices.Contains(conflictStates, sqlErr.SQLCode)
+}
+
+// IsDuplicate reports whether err means an object of the given kind already exists.
+func IsDuplicate(kind string, err error) bool {
+	var sqlErr gods.ErrSQLError
+	return errors.As(err, &sqlErr) && slices.Contains(duplicateStates[kind], sqlErr.SQLCode)
+}
+
+// RetryCreateOnConflict runs the CREATE statement of an object of the given kind until it succeeds or fails with an
+// error other than a conflict. An attempt that conflicted may have created the object, the object already existing
+// on a later attempt is then not an error.
+func RetryCreateOnConflict(ctx context.Context, kind string, fn func(ctx context.Context) error) error {
+	return retryOnConflict(ctx, func(err error) bool { return IsDuplicate(kind, err) }, fn)
+}
+
+// RetryDropOnConflict runs the DROP statement of an object of the given kind until it succeeds or fails with an error
+// other than a conflict. An attempt that conflicted may have dropped the object, the object no longer existing on a
+// later attempt is then not an error.
+func RetryDropOnConflict(ctx context.Context, kind string, fn func(ctx context.Context) error) error {
+	return retryOnConflict(ctx, func(err error) bool { return IsNotFound(kind, err) }, fn)
+}
+
+// retryOnConflict runs fn until it succeeds or fails with an error other than a conflict, applied reports whether the
+// error of a retry means an earlier attempt went through. The backoff is jittered so that statements which conflicted
+// with each other do not collide again on their next attempt.
+func retryOnConflict(ctx context.Context, applied func(error) bool, fn func(ctx context.Context) error) error {
+	backoff := retry.WithMaxDuration(conflictMaxDuration, retry.WithJitterPercent(20, retry.WithCappedDuration(conflictMaxBackoff, retry.NewExponential(conflictBaseBackoff))))
+	conflicted := false
+	return retry.Do(ctx, backoff, func(ctx context.Context) error {
+		err := fn(ctx)
+		switch {
+		case err == nil:
+			return nil
+		case IsConflict(err):
+			conflicted = true
+			tflog.Warn(ctx, "statement conflicted with a concurrent one, retrying", map[string]any{"error": err.Error()})
+			return retry.RetryableError(err)
+		case conflicted && applied(err):
+			tflog.Info(ctx, "statement applied by an attempt that conflicted", map[string]any{"error": err.Error()})
+			return nil
+		}
+		return err
+	})
+}
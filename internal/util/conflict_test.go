@@ -0,0 +1,82 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+)
+
+func TestRetryCreateOnConflict(t *testing.T) {
+	ctx := context.Background()
+	conflict := gods.ErrSQLError{SQLCode: gods.SqlStateRemoteUnavailable}
+	duplicate := gods.ErrSQLError{SQLCode: gods.SqlStateDuplicateSchema}
+
+	calls := 0
+	if err := RetryCreateOnConflict(ctx, "schema", func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("failed to create schema: %w", conflict)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("RetryCreateOnConflict() = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+
+	// the first attempt created the schema although it conflicted
+	calls = 0
+	if err := RetryCreateOnConflict(ctx, "schema", func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			return conflict
+		}
+		return duplicate
+	}); err != nil {
+		t.Fatalf("RetryCreateOnConflict() = %v, want the duplicate of a retry ignored", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+
+	calls = 0
+	if err := RetryCreateOnConflict(ctx, "schema", func(ctx context.Context) error {
+		calls++
+		return duplicate
+	}); !errors.Is(err, duplicate) {
+		t.Fatalf("RetryCreateOnConflict() = %v, want %v", err, duplicate)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestRetryDropOnConflict(t *testing.T) {
+	ctx := context.Background()
+	conflict := gods.ErrSQLError{SQLCode: gods.SqlStateTimeout}
+	notFound := gods.ErrSQLError{SQLCode: gods.SqlStateInvalidSchema}
+
+	calls := 0
+	if err := RetryDropOnConflict(ctx, "schema", func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			return conflict
+		}
+		return notFound
+	}); err != nil {
+		t.Fatalf("RetryDropOnConflict() = %v, want the not found of a retry ignored", err)
+	}
+
+	if err := RetryDropOnConflict(ctx, "schema", func(ctx context.Context) error {
+		return notFound
+	}); !errors.Is(err, notFound) {
+		t.Fatalf("RetryDropOnConflict() = %v, want %v", err, notFound)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sethvargo/go-retry"
+)
+
+// deleteMaxDuration bounds the time spent dropping an object and then waiting for it to be gone.
+const deleteMaxDuration = time.Minute * 5
+
+// DropAndWait runs the DROP statement of an object of the given kind and waits until lookup reports it gone. The
+// statement is retried until it succeeds or the object is not found, so deleting an object that is already gone is not
+// an error. lookup must return an error IsNotFound recognizes for the kind once the object no longer exists.
+func DropAndWait(ctx context.Context, conn *sql.Conn, kind, statement string, lookup func(ctx context.Context) error) error {
+	if err := retry.Do(ctx, retry.WithMaxDuration(deleteMaxDuration, retry.NewExponential(time.Second)), func(ctx context.Context) error {
+		if _, err := conn.ExecContext(ctx, statement); err != nil && !IsNotFound(kind, err) {
+			return retry.RetryableError(err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to drop %s: %w", kind, err)
+	}
+
+	return retry.Do(ctx, retry.WithMaxDuration(deleteMaxDuration, retry.NewExponential(time.Second)), func(ctx context.Context) error {
+		err := lookup(ctx)
+		switch {
+		case err == nil:
+			return retry.RetryableError(fmt.Errorf("timed out waiting for %s to be deleted", kind))
+		case IsNotFound(kind, err):
+			return nil
+		default:
+			return retry.RetryableError(err)
+		}
+	})
+}
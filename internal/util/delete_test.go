@@ -0,0 +1,48 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestDropAndWait(t *testing.T) {
+	ctx := context.Background()
+	server, conn := mockserver.NewConn(t, []mockserver.Fixture{
+		{Statement: `^DROP ENTITY "orders" IN STORE "kafka";$`},
+		{Statement: `^DROP ENTITY "gone" IN STORE "kafka";$`, SqlState: string(gods.SqlStateInvalidTopic), Message: "entity not found"},
+	})
+
+	lookups := 0
+	if err := DropAndWait(ctx, conn, "entity", `DROP ENTITY "orders" IN STORE "kafka";`, func(ctx context.Context) error {
+		lookups++
+		if lookups < 2 {
+			return nil
+		}
+		return sql.ErrNoRows
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lookups != 2 {
+		t.Errorf("expected to wait until the entity is gone, looked it up %d times", lookups)
+	}
+
+	if err := DropAndWait(ctx, conn, "entity", `DROP ENTITY "gone" IN STORE "kafka";`, func(ctx context.Context) error {
+		return gods.ErrSQLError{SQLCode: gods.SqlStateInvalidTopic}
+	}); err != nil {
+		t.Fatalf("expected dropping a missing entity to succeed, got %v", err)
+	}
+
+	want := []string{`DROP ENTITY "orders" IN STORE "kafka";`, `DROP ENTITY "gone" IN STORE "kafka";`}
+	if got := server.Statements(); !reflect.DeepEqual(got[len(got)-2:], want) {
+		t.Errorf("expected statements %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DescribeRow is the row a DESCRIBE statement returned, with every value as text.
+type DescribeRow struct {
+	Columns []string
+	Values  []sql.NullString
+}
+
+// Describe runs a DESCRIBE statement and returns its first row. It returns sql.ErrNoRows when the statement returned
+// no rows.
+func Describe(ctx context.Context, conn *sql.Conn, statement string) (DescribeRow, error) {
+	var row DescribeRow
+	found := false
+	if err := QueryRows(ctx, conn, statement, func(rows *sql.Rows) (err error) {
+		row, err = scanDescribeRow(rows)
+		if err != nil {
+			return err
+		}
+		found = true
+		return ErrStopRows
+	}); err != nil {
+		return DescribeRow{}, err
+	}
+	if !found {
+		return DescribeRow{}, sql.ErrNoRows
+	}
+	return row, nil
+}
+
+// DescribeRows runs a statement whose columns are not fixed, such as DESCRIBE ROLE, and returns all of its rows.
+func DescribeRows(ctx context.Context, conn *sql.Conn, statement string) ([]DescribeRow, error) {
+	result := []DescribeRow{}
+	if err := QueryRows(ctx, conn, statement, func(rows *sql.Rows) error {
+		row, err := scanDescribeRow(rows)
+		if err != nil {
+			return err
+		}
+		result = append(result, row)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func scanDescribeRow(rows *sql.Rows) (DescribeRow, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return DescribeRow{}, err
+	}
+	raw := make([]any, len(cols))
+	dest := make([]any, len(cols))
+	for i := range raw {
+		dest[i] = &raw[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return DescribeRow{}, err
+	}
+
+	// values are scanned as is and formatted afterwards, the driver returns numbers such as BIGINT as *big.Int which
+	// cannot be scanned into a string
+	values := make([]sql.NullString, len(cols))
+	for i, v := range raw {
+		switch v := v.(type) {
+		case nil:
+		case []byte:
+			values[i] = sql.NullString{String: string(v), Valid: true}
+		case time.Time:
+			values[i] = sql.NullString{String: v.Format(time.RFC3339Nano), Valid: true}
+		default:
+			values[i] = sql.NullString{String: fmt.Sprint(v), Valid: true}
+		}
+	}
+	return DescribeRow{Columns: cols, Values: values}, nil
+}
+
+// Column returns the value of the column with the given name, compared case insensitively and ignoring underscores.
+// ok is false when the row has no such column.
+func (r DescribeRow) Column(name string) (value sql.NullString, ok bool) {
+	normalize := func(s string) string { return strings.ToLower(strings.ReplaceAll(s, "_", "")) }
+	for i, col := range r.Columns {
+		if normalize(col) == normalize(name) {
+			return r.Values[i], true
+		}
+	}
+	return sql.NullString{}, false
+}
+
+// Map returns the values of the row keyed by the snake cased names of their columns, such as object_type for a column
+// named "Object Type". Null values are left out.
+func (r DescribeRow) Map() map[string]string {
+	m := make(map[string]string, len(r.Columns))
+	for i, col := range r.Columns {
+		if r.Values[i].Valid {
+			m[strings.ReplaceAll(strings.ToLower(col), " ", "_")] = r.Values[i].String
+		}
+	}
+	return m
+}
+
+// JSON encodes the row as an object of the column names to their values, verbatim, with null for the columns that are
+// not set.
+func (r DescribeRow) JSON() (string, error) {
+	obj := make(map[string]*string, len(r.Columns))
+	for i, col := range r.Columns {
+		if r.Values[i].Valid {
+			obj[col] = &r.Values[i].String
+		} else {
+			obj[col] = nil
+		}
+	}
+	b, err := json.Marshal(obj)
+	return string(b), err
+}
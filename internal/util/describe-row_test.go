@@ -0,0 +1,81 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestDescribe(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{
+		{
+			Statement: `^DESCRIBE STORE "kafka";$`,
+			Columns: []mockserver.Column{
+				{Name: "Uri", Type: "VARCHAR"},
+				{Name: "TlsEnabled", Type: "BOOLEAN"},
+				{Name: "SchemaRegistryName", Type: "VARCHAR", Nullable: true},
+			},
+			Rows: [][]*string{{ptr.To("broker:9092"), ptr.To("true"), nil}},
+		},
+		{
+			Statement: `^DESCRIBE STORE "missing";$`,
+			Columns:   []mockserver.Column{{Name: "Uri", Type: "VARCHAR"}},
+		},
+		{
+			Statement: `^DESCRIBE ROLE "sysadmin";$`,
+			Columns: []mockserver.Column{
+				{Name: "Privilege", Type: "VARCHAR"},
+				{Name: "Object Type", Type: "VARCHAR", Nullable: true},
+			},
+			Rows: [][]*string{{ptr.To("CREATE_DATABASE"), ptr.To("organization")}, {ptr.To("CREATE_STORE"), nil}},
+		},
+	})
+
+	row, err := Describe(ctx, conn, `DESCRIBE STORE "kafka";`)
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	raw, err := row.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	if want := `{"SchemaRegistryName":null,"TlsEnabled":"true","Uri":"broker:9092"}`; raw != want {
+		t.Errorf("JSON() = %s, want %s", raw, want)
+	}
+	if v, ok := row.Column("tls_enabled"); !ok || v.String != "true" {
+		t.Errorf("Column(tls_enabled) = %v, %v, want true", v, ok)
+	}
+	if _, ok := row.Column("Properties"); ok {
+		t.Errorf("Column(Properties) found a column the row does not have")
+	}
+
+	if _, err := Describe(ctx, conn, `DESCRIBE STORE "missing";`); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Describe() error = %v, want %v", err, sql.ErrNoRows)
+	}
+
+	rows, err := DescribeRows(ctx, conn, `DESCRIBE ROLE "sysadmin";`)
+	if err != nil {
+		t.Fatalf("DescribeRows() error = %v", err)
+	}
+	got := []map[string]string{}
+	for _, row := range rows {
+		got = append(got, row.Map())
+	}
+	want := []map[string]string{
+		{"privilege": "CREATE_DATABASE", "object_type": "organization"},
+		{"privilege": "CREATE_STORE"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DescribeRows() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DryRunCreate records a planned resource in state without creating it. Terraform does not accept unknown values
+// once a resource is applied, so computed values that only exist once the resource is created are left null. The
+// next refresh does not find the resource and plans its creation again.
+func DryRunCreate(ctx context.Context, plan tfsdk.Plan, state *tfsdk.State, kind, name string) diag.Diagnostics {
+	return dryRunApply(ctx, plan, state, "created", kind, name)
+}
+
+// DryRunUpdate records the planned values of a resource in state without updating it. The next refresh reads the
+// actual values back and plans the update again.
+func DryRunUpdate(ctx context.Context, plan tfsdk.Plan, state *tfsdk.State, kind, name string) diag.Diagnostics {
+	return dryRunApply(ctx, plan, state, "updated", kind, name)
+}
+
+// DryRunDelete keeps a resource instead of deleting it. Terraform removes a resource from state once Delete succeeds,
+// so the resource is kept by failing the delete, which also stops a replacement before its new object is created.
+func DryRunDelete(ctx context.Context, kind, name string) diag.Diagnostics {
+	var d diag.Diagnostics
+	tflog.Info(ctx, "dry run, "+kind+" not deleted", map[string]any{"name": name})
+	d.AddError(fmt.Sprintf("Dry run: %s %s not deleted", kind, name), "The provider is configured with dry_run, the statement was skipped and the "+kind+" is kept in state. Disable dry_run to delete or replace it.")
+	return d
+}
+
+func dryRunApply(ctx context.Context, plan tfsdk.Plan, state *tfsdk.State, action, kind, name string) diag.Diagnostics {
+	var d diag.Diagnostics
+
+	raw, err := tftypes.Transform(plan.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		d.AddError("dry run failed", fmt.Sprintf("failed to record planned %s %s: %s", kind, name, err))
+		return d
+	}
+	state.Raw = raw
+
+	tflog.Info(ctx, "dry run, "+kind+" not "+action, map[string]any{"name": name})
+	d.AddWarning(fmt.Sprintf("Dry run: %s %s not %s", kind, name, action), "The provider is configured with dry_run, the statement was skipped.")
+	return d
+}
@@ -0,0 +1,45 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDryRunUpdate(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		"name":       schema.StringAttribute{Required: true},
+		"updated_at": schema.StringAttribute{Computed: true},
+	}}
+	typ := s.Type().TerraformType(ctx)
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(typ, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "db"),
+		"updated_at": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})}
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(typ, nil)}
+
+	dg := DryRunUpdate(ctx, plan, &state, "database", "db")
+	if dg.HasError() || dg.WarningsCount() != 1 {
+		t.Fatalf("DryRunUpdate() = %v, want a single warning", dg)
+	}
+	var name, updatedAt *string
+	state.GetAttribute(ctx, path.Root("name"), &name)
+	state.GetAttribute(ctx, path.Root("updated_at"), &updatedAt)
+	if name == nil || *name != "db" || updatedAt != nil {
+		t.Errorf("state = %v, want the planned name and a null updated_at", state.Raw)
+	}
+}
+
+func TestDryRunDelete(t *testing.T) {
+	if dg := DryRunDelete(context.Background(), "database", "db"); !dg.HasError() {
+		t.Errorf("DryRunDelete() = %v, want an error keeping the database in state", dg)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ResourceID builds the global identifier exposed as the `id` attribute of every
+// resource and data source: <organization>/<type>/<fully qualified name>.
+func ResourceID(organization, kind string, fqn ...string) types.String {
+	return types.StringValue(organization + "/" + kind + "/" + strings.Join(fqn, "."))
+}
+
+// PrefixedUniqueName returns prefix followed by a UTC timestamp and a random suffix. Generated names sort by creation
+// time, which keeps the replacement of a resource created before its predecessor is destroyed recognizable.
+func PrefixedUniqueName(prefix string) string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return prefix + time.Now().UTC().Format("20060102150405") + hex.EncodeToString(suffix)
+}
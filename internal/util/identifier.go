@@ -0,0 +1,17 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import "strings"
+
+// QuoteIdentifier returns name as the quoted identifier the provider uses in its statements. Quoted identifiers are
+// case sensitive.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// NormalizeIdentifier returns the form DeltaStream resolves name to when it is used unquoted in a statement.
+func NormalizeIdentifier(name string) string {
+	return strings.ToLower(name)
+}
@@ -0,0 +1,23 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import "testing"
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"pageviews":  `"pageviews"`,
+		"Page Views": `"Page Views"`,
+		`a"b`:        `"a""b"`,
+	}
+	for name, want := range tests {
+		if got := QuoteIdentifier(name); got != want {
+			t.Errorf("QuoteIdentifier(%q) = %s, want %s", name, got, want)
+		}
+	}
+
+	if got := NormalizeIdentifier("PageViews_2"); got != "pageviews_2" {
+		t.Errorf("NormalizeIdentifier() = %s, want pageviews_2", got)
+	}
+}
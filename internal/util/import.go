@@ -0,0 +1,50 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+// ImportedKey marks resources adopted with terraform import in private state until their first update.
+const ImportedKey = "imported"
+
+type PrivateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// Imported reports whether the resource was imported and not updated since.
+func Imported(ctx context.Context, private PrivateState) bool {
+	v, _ := private.GetKey(ctx, ImportedKey)
+	return string(v) == "true"
+}
+
+const adoptImportedDescription = "Settings the server does not report are taken from the configuration when an imported resource is first updated, other changes require replacement"
+
+// RequiresReplaceUnlessImported replaces the resource when a create-only setting changes, unless the resource was
+// imported and the setting was never recorded in state. The configuration is trusted to describe the resource being
+// adopted.
+func RequiresReplaceUnlessImported() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = !(req.StateValue.IsNull() && Imported(ctx, req.Private))
+	}, adoptImportedDescription, adoptImportedDescription)
+}
+
+func ObjectRequiresReplaceUnlessImported() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = !(req.StateValue.IsNull() && Imported(ctx, req.Private))
+	}, adoptImportedDescription, adoptImportedDescription)
+}
+
+func BoolRequiresReplaceUnlessImported() planmodifier.Bool {
+	return boolplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = !(req.StateValue.IsNull() && Imported(ctx, req.Private))
+	}, adoptImportedDescription, adoptImportedDescription)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ItemsByNameAttribute returns the items_by_name attribute of a plural data source, a map of the objects of list keyed
+// by their key attribute.
+func ItemsByNameAttribute(list schema.ListNestedAttribute, key string) schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Description:  fmt.Sprintf("Same objects as the list, keyed by %s, to use with for_each", key),
+		Computed:     true,
+		NestedObject: list.NestedObject,
+	}
+}
+
+// ItemsByName keys the objects of list by their key attribute, so that for_each can iterate over a plural data source
+// without relying on list indexes. Objects without a key are left out, of the objects sharing a key the first one is
+// kept and the others are reported in a warning.
+func ItemsByName(ctx context.Context, list types.List, key string) (types.Map, diag.Diagnostics) {
+	var dg diag.Diagnostics
+	elemType := list.ElementType(ctx)
+	if list.IsNull() || list.IsUnknown() {
+		return types.MapNull(elemType), dg
+	}
+
+	items := map[string]attr.Value{}
+	var duplicates []string
+	for _, elem := range list.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok {
+			dg.AddError("internal error", fmt.Sprintf("items are %T, not objects", elem))
+			return types.MapNull(elemType), dg
+		}
+		name, ok := obj.Attributes()[key].(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+		if _, ok := items[name.ValueString()]; ok {
+			duplicates = append(duplicates, name.ValueString())
+			continue
+		}
+		items[name.ValueString()] = obj
+	}
+	if len(duplicates) > 0 {
+		dg.AddWarning("duplicate items", fmt.Sprintf("items_by_name only holds the first item of each %s, these are listed more than once: %v", key, duplicates))
+	}
+
+	m, d := types.MapValue(elemType, items)
+	dg.Append(d...)
+	return m, dg
+}
@@ -0,0 +1,45 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestItemsByName(t *testing.T) {
+	ctx := context.Background()
+	itemType := types.ObjectType{AttrTypes: map[string]attr.Type{"name": types.StringType, "owner": types.StringType}}
+	item := func(name types.String, owner string) attr.Value {
+		return types.ObjectValueMust(itemType.AttrTypes, map[string]attr.Value{"name": name, "owner": types.StringValue(owner)})
+	}
+
+	list := types.ListValueMust(itemType, []attr.Value{
+		item(types.StringValue("a"), "r1"),
+		item(types.StringValue("b"), "r2"),
+		item(types.StringNull(), "r3"),
+		item(types.StringValue("a"), "r4"),
+	})
+	m, dg := ItemsByName(ctx, list, "name")
+	if dg.HasError() {
+		t.Fatalf("ItemsByName() diagnostics = %v", dg)
+	}
+	if dg.WarningsCount() != 1 {
+		t.Errorf("ItemsByName() warnings = %v, want one for the duplicate", dg)
+	}
+	want := types.MapValueMust(itemType, map[string]attr.Value{
+		"a": item(types.StringValue("a"), "r1"),
+		"b": item(types.StringValue("b"), "r2"),
+	})
+	if !m.Equal(want) {
+		t.Errorf("ItemsByName() = %s, want %s", m, want)
+	}
+
+	if m, _ := ItemsByName(ctx, types.ListNull(itemType), "name"); !m.IsNull() {
+		t.Errorf("ItemsByName(null) = %s, want null", m)
+	}
+}
@@ -7,8 +7,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// LogError logs err and adds it to the diagnostics. Failed statements carry the ID DeltaStream assigned them, it is
+// added to the detail so that the failure can be looked up with DeltaStream support.
 func LogError(ctx context.Context, d diag.Diagnostics, summary string, err error) diag.Diagnostics {
-	tflog.Info(ctx, err.Error())
-	d.AddError(summary, err.Error())
+	detail := err.Error()
+	if id := StatementID(err); id != "" {
+		detail += "\n\nStatement ID: " + id
+	}
+	tflog.Info(ctx, detail)
+	d.AddError(summary, detail)
 	return d
 }
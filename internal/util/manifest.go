@@ -0,0 +1,139 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Actions of manifest entries.
+const (
+	ManifestCreated = "created"
+	ManifestUpdated = "updated"
+)
+
+// ManifestEntry describes a resource created or updated by an apply.
+type ManifestEntry struct {
+	ResourceType string    `json:"resource_type"`
+	FQN          string    `json:"fqn"`
+	QueryIDs     []string  `json:"query_ids,omitempty"`
+	Action       string    `json:"action"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// Manifest records the resources an apply created or updated into a JSON file, for automation that syncs catalogs or
+// ingests lineage without parsing Terraform state. The file is rewritten as a whole when the apply starts and on every
+// entry, so that it is a complete document of the last apply once the apply is over.
+type Manifest struct {
+	mu      sync.Mutex
+	path    string
+	started bool
+	entries map[[2]string]ManifestEntry
+}
+
+var (
+	manifestsMu sync.Mutex
+	manifests   = map[string]*Manifest{}
+)
+
+// OpenManifest returns the manifest written to path. The file is only written once the apply starts, see
+// BeginManifests, so that plans leave the manifest of the last apply in place. Provider configurations of the process
+// recording to the same file share it.
+func OpenManifest(path string) (*Manifest, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestsMu.Lock()
+	defer manifestsMu.Unlock()
+
+	if m, ok := manifests[abs]; ok {
+		return m, nil
+	}
+	m := &Manifest{path: abs, entries: map[[2]string]ManifestEntry{}}
+	manifests[abs] = m
+	return m, nil
+}
+
+// BeginManifests truncates the manifests opened by the process to an empty document, so that the manifest of a
+// previous apply does not outlive an apply that records nothing, such as one that only deletes resources. Terraform
+// runs every apply in a new provider process, manifests are only truncated the first time they are begun.
+func BeginManifests() error {
+	manifestsMu.Lock()
+	defer manifestsMu.Unlock()
+
+	var errs []error
+	for _, m := range manifests {
+		errs = append(errs, m.Begin())
+	}
+	return errors.Join(errs...)
+}
+
+// Begin truncates the manifest to an empty document unless the apply already started writing it.
+func (m *Manifest) Begin() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return nil
+	}
+	m.started = true
+	m.entries = map[[2]string]ManifestEntry{}
+	return m.write()
+}
+
+// Record adds entry to the manifest, replacing the entry of the same resource type and FQN, and writes the manifest.
+func (m *Manifest) Record(entry ManifestEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.started = true
+	key := [2]string{entry.ResourceType, entry.FQN}
+	if prev, ok := m.entries[key]; ok && prev.Action == ManifestCreated {
+		// a resource created then updated by the same apply is still new to downstream consumers
+		entry.Action = ManifestCreated
+	}
+	m.entries[key] = entry
+	return m.write()
+}
+
+// write replaces the manifest file with the recorded entries, m.mu must be held.
+func (m *Manifest) write() error {
+	resources := make([]ManifestEntry, 0, len(m.entries))
+	for _, e := range m.entries {
+		resources = append(resources, e)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].ResourceType != resources[j].ResourceType {
+			return resources[i].ResourceType < resources[j].ResourceType
+		}
+		return resources[i].FQN < resources[j].FQN
+	})
+	data, err := json.MarshalIndent(map[string]any{"resources": resources}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that readers never see a partial manifest
+	tmp, err := os.CreateTemp(filepath.Dir(m.path), filepath.Base(m.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), m.path)
+}
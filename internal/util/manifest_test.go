@@ -0,0 +1,98 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	m, err := OpenManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("manifest written before any entry was recorded: %v", err)
+	}
+	if shared, _ := OpenManifest(path); shared != m {
+		t.Error("OpenManifest() did not share the manifest of the same file")
+	}
+
+	now := time.Now().UTC()
+	for _, e := range []ManifestEntry{
+		{ResourceType: "deltastream_query", FQN: "q", QueryIDs: []string{"id1"}, Action: ManifestCreated, Timestamp: now},
+		{ResourceType: "deltastream_database", FQN: "db", Action: ManifestUpdated, Timestamp: now},
+		{ResourceType: "deltastream_query", FQN: "q", QueryIDs: []string{"id2"}, Action: ManifestUpdated, Timestamp: now},
+	} {
+		if err := m.Record(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Resources []ManifestEntry `json:"resources"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []ManifestEntry{
+		{ResourceType: "deltastream_database", FQN: "db", Action: ManifestUpdated, Timestamp: now},
+		{ResourceType: "deltastream_query", FQN: "q", QueryIDs: []string{"id2"}, Action: ManifestCreated, Timestamp: now},
+	}
+	if !reflect.DeepEqual(got.Resources, want) {
+		t.Errorf("manifest = %+v, want %+v", got.Resources, want)
+	}
+}
+
+func TestManifestBegin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := os.WriteFile(path, []byte(`{"resources": [{"resource_type": "deltastream_database", "fqn": "old"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := OpenManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := func() []ManifestEntry {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got struct {
+			Resources []ManifestEntry `json:"resources"`
+		}
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		return got.Resources
+	}
+
+	if err := BeginManifests(); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(); len(got) != 0 {
+		t.Errorf("manifest = %+v, want the entries of the previous apply truncated", got)
+	}
+
+	if err := m.Record(ManifestEntry{ResourceType: "deltastream_database", FQN: "db", Action: ManifestCreated}); err != nil {
+		t.Fatal(err)
+	}
+	if err := BeginManifests(); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(); len(got) != 1 || got[0].FQN != "db" {
+		t.Errorf("manifest = %+v, want the entry of the apply kept", got)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// notFoundStates lists, per kind of object, the SQL states DeltaStream reports once the object or one of the
+// objects containing it no longer exists.
+var notFoundStates = map[string][]gods.SqlState{
+	"database":        {gods.SqlStateInvalidDatabase},
+	"schema":          {gods.SqlStateInvalidDatabase, gods.SqlStateInvalidSchema},
+	"relation":        {gods.SqlStateInvalidDatabase, gods.SqlStateInvalidSchema, gods.SqlStateInvalidRelation},
+	"query":           {gods.SqlStateInvalidQuery},
+	"pipeline":        {gods.SqlStateInvalidQuery, gods.SqlStateInvalidRelation},
+	"store":           {gods.SqlStateInvalidStore},
+	"entity":          {gods.SqlStateInvalidStore, gods.SqlStateInvalidTopic},
+	"schema_registry": {gods.SqlStateInvalidSchemaRegistry},
+	"secret":          {gods.SqlStateInvalidSecret},
+	"region":          {gods.SqlStateInvalidRegion},
+	"role":            {gods.SqlStateInvalidRole},
+}
+
+// IsNotFound reports whether err means an object of the given kind no longer exists. Any other error, such as a
+// dropped connection or a timeout, is transient and says nothing about the object.
+func IsNotFound(kind string, err error) bool {
+	if errors.Is(err, sql.ErrNoRows) {
+		return true
+	}
+
+	var sqlErr gods.ErrSQLError
+	return errors.As(err, &sqlErr) && slices.Contains(notFoundStates[kind], sqlErr.SQLCode)
+}
+
+// RemoveIfNotFound removes a resource from state when err means it no longer exists, so that the next plan creates
+// it again. It returns false for any other error, which the caller reports.
+func RemoveIfNotFound(ctx context.Context, state *tfsdk.State, kind string, err error) bool {
+	if !IsNotFound(kind, err) {
+		return false
+	}
+
+	tflog.Info(ctx, kind+" not found, removing from state", map[string]any{"error": err.Error()})
+	state.RemoveResource(ctx)
+	return true
+}
@@ -0,0 +1,39 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+		err  error
+		want bool
+	}{
+		{name: "own state", kind: "relation", err: gods.ErrSQLError{SQLCode: gods.SqlStateInvalidRelation}, want: true},
+		{name: "parent state", kind: "relation", err: gods.ErrSQLError{SQLCode: gods.SqlStateInvalidSchema}, want: true},
+		{name: "wrapped", kind: "entity", err: fmt.Errorf("failed to describe: %w", gods.ErrSQLError{SQLCode: gods.SqlStateInvalidStore}), want: true},
+		{name: "no rows", kind: "database", err: fmt.Errorf("store not found: %w", sql.ErrNoRows), want: true},
+		{name: "other kind state", kind: "database", err: gods.ErrSQLError{SQLCode: gods.SqlStateInvalidSchema}, want: false},
+		{name: "insufficient privilege", kind: "secret", err: gods.ErrSQLError{SQLCode: gods.SqlStateInsufficientPrivilege}, want: false},
+		{name: "transient", kind: "store", err: context.DeadlineExceeded, want: false},
+		{name: "connection", kind: "store", err: errors.New("connection reset by peer"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.kind, tt.err); got != tt.want {
+				t.Errorf("IsNotFound(%q, %v) = %t, want %t", tt.kind, tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RenderProperties renders properties as sorted, quoted WITH clause entries with their names prefixed by prefix.
+func RenderProperties(ctx context.Context, prefix string, m types.Map) ([]string, diag.Diagnostics) {
+	if m.IsNull() || m.IsUnknown() {
+		return nil, nil
+	}
+
+	props := map[string]string{}
+	if dg := m.ElementsAs(ctx, &props, false); dg.HasError() {
+		return nil, dg
+	}
+
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, fmt.Sprintf(`'%s' = '%s'`, strings.ReplaceAll(prefix+k, "'", "''"), strings.ReplaceAll(props[k], "'", "''")))
+	}
+	return entries, nil
+}
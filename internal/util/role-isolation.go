@@ -0,0 +1,77 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/deltastreaminc/go-deltastream/apiv2"
+)
+
+type boundRoleKey struct{}
+
+// withBoundRole records the role a connection was checked out with in the context of the operation using it.
+func withBoundRole(ctx context.Context, roleName string) context.Context {
+	return context.WithValue(ctx, boundRoleKey{}, roleName)
+}
+
+// ErrRoleLeak is returned by RoleIsolationTransport when a statement switched the role of its connection away from
+// the role the operation checked it out with.
+type ErrRoleLeak struct {
+	Bound  string
+	Actual string
+}
+
+func (e *ErrRoleLeak) Error() string {
+	return fmt.Sprintf("strict role isolation: statement switched the role of the connection from %q to %q, remove USE ROLE from the statement or set the owner of the resource instead", e.Bound, e.Actual)
+}
+
+// RoleIsolationTransport fails statements whose result switches the connection to a role other than the one it was
+// checked out with by GetConnection, so that a role change cannot leak into the following statements or into the
+// operations the connection is reused by. Requests made outside of an operation are passed through untouched.
+func RoleIsolationTransport(r http.RoundTripper) http.RoundTripper {
+	return &roleIsolationTransport{r: r}
+}
+
+type roleIsolationTransport struct {
+	r http.RoundTripper
+}
+
+func (t *roleIsolationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	bound, ok := req.Context().Value(boundRoleKey{}).(string)
+	if !ok || !strings.Contains(req.URL.Path, "/statements") {
+		return t.r.RoundTrip(req)
+	}
+
+	resp, err := t.r.RoundTrip(req)
+	if err != nil || resp.Body == nil || resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return resp, nil
+	}
+
+	var rs struct {
+		Metadata struct {
+			Context *apiv2.ResultSetContext `json:"context"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &rs); err != nil || rs.Metadata.Context == nil || rs.Metadata.Context.RoleName == nil {
+		return resp, nil
+	}
+	if actual := *rs.Metadata.Context.RoleName; actual != bound {
+		return nil, &ErrRoleLeak{Bound: bound, Actual: actual}
+	}
+	return resp, nil
+}
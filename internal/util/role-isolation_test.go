@@ -0,0 +1,57 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRoleIsolationTransport(t *testing.T) {
+	respondAs := func(role string) http.RoundTripper {
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"sqlState":"00000","metadata":{"context":{"roleName":"` + role + `"}}}`)),
+			}, nil
+		})
+	}
+	statement := func(ctx context.Context) *http.Request {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.deltastream.io/v2/statements", nil)
+		if err != nil {
+			t.Fatalf("failed to build request: %v", err)
+		}
+		return req
+	}
+
+	ctx := withBoundRole(context.Background(), "analyst")
+	resp, err := RoleIsolationTransport(respondAs("analyst")).RoundTrip(statement(ctx))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body, _ := io.ReadAll(resp.Body); !strings.Contains(string(body), `"roleName":"analyst"`) {
+		t.Errorf("expected response body to be preserved, got %s", body)
+	}
+
+	_, err = RoleIsolationTransport(respondAs("sysadmin")).RoundTrip(statement(ctx))
+	var leak *ErrRoleLeak
+	if !errors.As(err, &leak) || leak.Bound != "analyst" || leak.Actual != "sysadmin" {
+		t.Errorf("expected role leak from analyst to sysadmin, got %v", err)
+	}
+
+	if _, err := RoleIsolationTransport(respondAs("sysadmin")).RoundTrip(statement(context.Background())); err != nil {
+		t.Errorf("expected requests outside of an operation to pass through, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrStopRows is returned by a QueryRows callback once it found what it was looking for, the remaining rows are
+// skipped and QueryRows returns nil.
+var ErrStopRows = errors.New("stop scanning rows")
+
+// QueryRows runs a statement and calls scan for every row of its result. Large results are split in partitions the
+// driver fetches while the rows are iterated, an error fetching one ends the iteration early and is only reported by
+// rows.Err. QueryRows always checks it so that a truncated result is never mistaken for a complete one.
+func QueryRows(ctx context.Context, conn *sql.Conn, statement string, scan func(rows *sql.Rows) error) error {
+	rows, err := conn.QueryContext(ctx, statement)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			if errors.Is(err, ErrStopRows) {
+				return rows.Close()
+			}
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return rows.Close()
+}
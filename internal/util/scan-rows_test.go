@@ -0,0 +1,69 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestQueryRows(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{
+		{
+			Statement: `^LIST REGIONS;$`,
+			Columns:   []mockserver.Column{{Name: "name", Type: "VARCHAR"}},
+			Rows:      [][]*string{mockserver.Row("AWS us-east-1"), mockserver.Row("AWS us-west-2"), mockserver.Row("GCP us-central1")},
+		},
+		{
+			Statement: `^LIST SECRETS;$`,
+			SqlState:  "XX000",
+			Message:   "internal error",
+		},
+	})
+
+	scanNames := func(names *[]string, stopAt string) func(rows *sql.Rows) error {
+		return func(rows *sql.Rows) error {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			*names = append(*names, name)
+			if name == stopAt {
+				return ErrStopRows
+			}
+			return nil
+		}
+	}
+
+	var names []string
+	if err := QueryRows(ctx, conn, `LIST REGIONS;`, scanNames(&names, "")); err != nil {
+		t.Fatalf("QueryRows() error = %v", err)
+	}
+	if want := []string{"AWS us-east-1", "AWS us-west-2", "GCP us-central1"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("QueryRows() scanned %v, want %v", names, want)
+	}
+
+	names = nil
+	if err := QueryRows(ctx, conn, `LIST REGIONS;`, scanNames(&names, "AWS us-west-2")); err != nil {
+		t.Fatalf("QueryRows() error = %v, want nil when stopped", err)
+	}
+	if want := []string{"AWS us-east-1", "AWS us-west-2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("QueryRows() scanned %v, want %v", names, want)
+	}
+
+	scanErr := errors.New("bad row")
+	if err := QueryRows(ctx, conn, `LIST REGIONS;`, func(rows *sql.Rows) error { return scanErr }); !errors.Is(err, scanErr) {
+		t.Errorf("QueryRows() error = %v, want %v", err, scanErr)
+	}
+
+	if err := QueryRows(ctx, conn, `LIST SECRETS;`, scanNames(&names, "")); err == nil {
+		t.Error("QueryRows() error = nil, want the statement error")
+	}
+}
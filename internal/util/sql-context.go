@@ -6,11 +6,14 @@ package util
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"time"
 
 	gods "github.com/deltastreaminc/go-deltastream"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sethvargo/go-retry"
 	"k8s.io/utils/ptr"
 )
 
@@ -32,11 +35,135 @@ func SetSqlContext(ctx context.Context, conn *sql.Conn, dbName, schemaName, stor
 	return nil
 }
 
+// SqlSession pins the statements of an operation to one driver connection and the SQL context they were planned
+// against. The server may update the context of a connection with the result of any statement, and a broken
+// connection can be replaced, so statements that must compile against the same database, schema and store as an
+// earlier DESCRIBE verify the session before they run.
+type SqlSession struct {
+	conn   *sql.Conn
+	driver *gods.Conn
+	sqlctx sqlContext
+}
+
+type sqlContext struct {
+	organization string
+	role         string
+	database     string
+	schema       string
+	store        string
+}
+
+func (c sqlContext) String() string {
+	return fmt.Sprintf("role %q, database %q, schema %q, store %q", c.role, c.database, c.schema, c.store)
+}
+
+// PinSqlContext records the driver connection and SQL context of conn, which is expected to be set with SetSqlContext
+// first.
+func PinSqlContext(conn *sql.Conn) (*SqlSession, error) {
+	s := &SqlSession{conn: conn}
+	var err error
+	s.driver, s.sqlctx, err = currentSqlContext(conn)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Verify returns an error when the connection or its SQL context changed since the session was pinned.
+func (s *SqlSession) Verify() error {
+	driver, sqlctx, err := currentSqlContext(s.conn)
+	if err != nil {
+		return err
+	}
+	if driver != s.driver {
+		return fmt.Errorf("connection was re-established since the statement was planned")
+	}
+	if sqlctx != s.sqlctx {
+		return fmt.Errorf("sql context changed since the statement was planned from %s to %s", s.sqlctx, sqlctx)
+	}
+	return nil
+}
+
+// Organization returns the ID of the organization the session was pinned in.
+func (s *SqlSession) Organization() string {
+	return s.sqlctx.organization
+}
+
+// Role returns the role the session was pinned as.
+func (s *SqlSession) Role() string {
+	return s.sqlctx.role
+}
+
+func currentSqlContext(conn *sql.Conn) (driver *gods.Conn, sqlctx sqlContext, err error) {
+	err = conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*gods.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		rsctx := c.GetContext()
+		driver = c
+		sqlctx = sqlContext{
+			role:     ptr.Deref(rsctx.RoleName, ""),
+			database: ptr.Deref(rsctx.DatabaseName, ""),
+			schema:   ptr.Deref(rsctx.SchemaName, ""),
+			store:    ptr.Deref(rsctx.StoreName, ""),
+		}
+		if rsctx.OrganizationID != nil {
+			sqlctx.organization = rsctx.OrganizationID.String()
+		}
+		return nil
+	})
+	return driver, sqlctx, err
+}
+
+const (
+	// connectMaxDuration bounds the time spent retrying connection setup before the operation fails.
+	connectMaxDuration = time.Minute
+	connectBaseBackoff = 500 * time.Millisecond
+	connectMaxBackoff  = 10 * time.Second
+)
+
+// GetConnection returns a connection scoped to the organization and role. The role is bound to the connection when it
+// is checked out and recorded in the returned context, which RoleIsolationTransport checks statements against.
+// Transient failures are retried with exponential backoff, permanent ones such as authentication or client
+// configuration errors fail immediately. Once connections keep failing transiently the circuit breaker opens and
+// further calls fail fast.
 func GetConnection(ctx context.Context, db *sql.DB, sessionID *string, org, roleName string) (context.Context, *sql.Conn, error) {
 	ctx = tflog.SetField(ctx, "session-id", ptr.Deref(sessionID, ""))
+	ctx = withBoundRole(ctx, roleName)
+
+	breaker := breakerFor(db)
+	if err := breaker.allow(); err != nil {
+		return ctx, nil, err
+	}
+
+	var conn *sql.Conn
+	backoff := retry.WithMaxDuration(connectMaxDuration, retry.WithCappedDuration(connectMaxBackoff, retry.NewExponential(connectBaseBackoff)))
+	if err := retry.Do(ctx, backoff, func(ctx context.Context) (err error) {
+		conn, err = connect(ctx, db, org, roleName)
+		if err != nil {
+			if ctx.Err() != nil || !isTransientConnectError(err) {
+				return err
+			}
+			tflog.Warn(ctx, "failed to establish connection, retrying", map[string]any{"error": err.Error()})
+			return retry.RetryableError(err)
+		}
+		return nil
+	}); err != nil {
+		if ctx.Err() == nil && isTransientConnectError(err) {
+			breaker.failure(err)
+		}
+		return ctx, nil, fmt.Errorf("failed to establish connection: %w", err)
+	}
+	breaker.success()
+
+	return ctx, conn, nil
+}
+
+func connect(ctx context.Context, db *sql.DB, org, roleName string) (*sql.Conn, error) {
 	conn, err := db.Conn(ctx)
 	if err != nil {
-		return ctx, nil, err
+		return nil, err
 	}
 
 	conn.Raw(func(driverConn interface{}) error {
@@ -50,8 +177,18 @@ func GetConnection(ctx context.Context, db *sql.DB, sessionID *string, org, role
 
 	if err := conn.PingContext(ctx); err != nil {
 		conn.Close()
-		return ctx, nil, fmt.Errorf("failed to establish connection: %w", err)
+		return nil, err
 	}
 
-	return ctx, conn, nil
+	return conn, nil
+}
+
+// DiscardConnection closes a connection without returning its driver connection to the pool. Connections whose SQL
+// context or session settings were changed for a single operation are discarded so that later operations do not
+// inherit them.
+func DiscardConnection(conn *sql.Conn) {
+	conn.Raw(func(driverConn interface{}) error {
+		return driver.ErrBadConn
+	})
+	conn.Close()
 }
@@ -0,0 +1,63 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestSqlSessionVerify(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE `,
+		Columns:   []mockserver.Column{{Name: "kind", Type: "VARCHAR"}, {Name: "plan", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("CREATE_STREAM", "{}")},
+	}})
+
+	if err := SetSqlContext(ctx, conn, ptr.To("db1"), ptr.To("public"), ptr.To("kafka")); err != nil {
+		t.Fatalf("SetSqlContext() error = %v", err)
+	}
+	session, err := PinSqlContext(conn)
+	if err != nil {
+		t.Fatalf("PinSqlContext() error = %v", err)
+	}
+
+	var kind, plan string
+	if err := conn.QueryRowContext(ctx, "DESCRIBE CREATE STREAM s AS SELECT * FROM t;").Scan(&kind, &plan); err != nil {
+		t.Fatalf("failed to describe statement: %v", err)
+	}
+	if err := session.Verify(); err != nil {
+		t.Errorf("Verify() after a statement in the same context error = %v", err)
+	}
+
+	if err := SetSqlContext(ctx, conn, ptr.To("db2"), nil, nil); err != nil {
+		t.Fatalf("SetSqlContext() error = %v", err)
+	}
+	if err := session.Verify(); err == nil || !strings.Contains(err.Error(), `database "db2"`) {
+		t.Errorf("Verify() after the database changed error = %v", err)
+	}
+}
+
+func TestDiscardConnection(t *testing.T) {
+	ctx := context.Background()
+	_, db := mockserver.NewDB(t, nil)
+	ctx, conn, err := GetConnection(ctx, db, nil, "00000000-0000-0000-0000-000000000001", "sysadmin")
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := SetSqlContext(ctx, conn, ptr.To("db1"), nil, nil); err != nil {
+		t.Fatalf("SetSqlContext() error = %v", err)
+	}
+
+	DiscardConnection(conn)
+	if stats := db.Stats(); stats.OpenConnections != 0 {
+		t.Errorf("open connections after discard = %d, want 0", stats.OpenConnections)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type statementRecorderKey struct{}
+
+// StatementRecorder keeps the ID DeltaStream assigned to the last statement submitted with its context. The driver
+// does not return statement IDs of successful statements, they are read from the API responses by
+// StatementIDTransport instead.
+type StatementRecorder struct {
+	mu sync.Mutex
+	id string
+}
+
+// WithStatementRecorder returns a context recording the IDs of the statements submitted with it.
+func WithStatementRecorder(ctx context.Context) (context.Context, *StatementRecorder) {
+	r := &StatementRecorder{}
+	return context.WithValue(ctx, statementRecorderKey{}, r), r
+}
+
+func (r *StatementRecorder) record(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.id = id
+}
+
+// ID returns the ID of the last statement submitted, or null when none was.
+func (r *StatementRecorder) ID() types.String {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.id == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(r.id)
+}
+
+// StatementIDTransport records the statement ID of statement submissions made with a context holding a
+// StatementRecorder. Other requests are passed through untouched.
+func StatementIDTransport(r http.RoundTripper) http.RoundTripper {
+	return &statementIDTransport{r: r}
+}
+
+type statementIDTransport struct {
+	r http.RoundTripper
+}
+
+func (t *statementIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder, ok := req.Context().Value(statementRecorderKey{}).(*StatementRecorder)
+	if !ok || req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/statements") {
+		return t.r.RoundTrip(req)
+	}
+
+	resp, err := t.r.RoundTrip(req)
+	if err != nil || resp.Body == nil || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return resp, nil
+	}
+
+	var status struct {
+		StatementID uuid.UUID `json:"statementID"`
+	}
+	if err := json.Unmarshal(data, &status); err == nil && status.StatementID != uuid.Nil {
+		recorder.record(status.StatementID.String())
+	}
+	return resp, nil
+}
+
+// StatementID returns the ID of the statement that failed with err, or an empty string when err is not a statement
+// failure.
+func StatementID(err error) string {
+	var sqlErr gods.ErrSQLError
+	if errors.As(err, &sqlErr) && sqlErr.StatementID != uuid.Nil {
+		return sqlErr.StatementID.String()
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestStatementRecorder(t *testing.T) {
+	ctx := context.Background()
+	server := mockserver.Start(t, []mockserver.Fixture{
+		{Statement: `^CREATE DATABASE "db";$`},
+		{Statement: `^CREATE DATABASE "broken";$`, SqlState: "XX000", Message: "internal error"},
+	})
+
+	connector, err := gods.ConnectorWithOptions(ctx, gods.WithStaticToken("mock-token"), gods.WithServer(server.APIURL()),
+		gods.WithHTTPClient(&http.Client{Transport: StatementIDTransport(http.DefaultTransport)}))
+	if err != nil {
+		t.Fatalf("failed to create connector: %v", err)
+	}
+	db := sql.OpenDB(connector)
+	ctx, conn, err := GetConnection(ctx, db, nil, "00000000-0000-0000-0000-000000000001", "sysadmin")
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `CREATE DATABASE "db";`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	createCtx, statement := WithStatementRecorder(ctx)
+	if !statement.ID().IsNull() {
+		t.Errorf("expected null statement ID before any statement, got %s", statement.ID())
+	}
+	if _, err := conn.ExecContext(createCtx, `CREATE DATABASE "db";`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uuid.Parse(statement.ID().ValueString()); err != nil {
+		t.Errorf("expected statement ID to be recorded, got %s", statement.ID())
+	}
+
+	_, err = conn.ExecContext(ctx, `CREATE DATABASE "broken";`)
+	if err == nil {
+		t.Fatal("expected statement to fail")
+	}
+	id := StatementID(err)
+	if _, perr := uuid.Parse(id); perr != nil {
+		t.Fatalf("expected statement ID of failed statement, got %q", id)
+	}
+	diags := LogError(ctx, diag.Diagnostics{}, "failed to create database", err)
+	if !strings.Contains(diags[0].Detail(), "Statement ID: "+id) {
+		t.Errorf("expected diagnostic to include statement ID, got %q", diags[0].Detail())
+	}
+
+	if id := StatementID(fmt.Errorf("not a statement error")); id != "" {
+		t.Errorf("expected no statement ID, got %q", id)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gods "github.com/deltastreaminc/go-deltastream"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestRedactStatement(t *testing.T) {
+	tests := map[string]string{
+		`CREATE STORE "k" WITH ('type' = KAFKA, 'kafka.sasl.password' = 'p''w', 'kafka.sasl.username' = 'u');`: `CREATE STORE "k" WITH ('type' = KAFKA, 'kafka.sasl.password' = '***', 'kafka.sasl.username' = 'u');`,
+		`CREATE SECRET "s" WITH ('type' = generic_string, 'secret_string' = 'value');`:                         `CREATE SECRET "s" WITH ('type' = generic_string, 'secret_string' = '***');`,
+		`CREATE NOTIFICATION TARGET "n" WITH ('webhook.header.authorization' = 'Bearer x');`:                   `CREATE NOTIFICATION TARGET "n" WITH ('webhook.header.authorization' = '***');`,
+		`CREATE STREAM pageviews WITH ('topic' = 'pageviews');`:                                                `CREATE STREAM pageviews WITH ('topic' = 'pageviews');`,
+		`CREATE STORE "pg" WITH ('type' = POSTGRESQL, 'uris' = 'postgres://user:p@ss@db:5432/app');`:           `CREATE STORE "pg" WITH ('type' = POSTGRESQL, 'uris' = 'postgres://***@db:5432/app');`,
+		`CREATE STORE "k" WITH ('uris' = 'kafka://a:1@b1:9092,kafka://b2:9092');`:                              `CREATE STORE "k" WITH ('uris' = 'kafka://***@b1:9092,kafka://b2:9092');`,
+	}
+
+	for stmt, want := range tests {
+		if got := RedactStatement(stmt); got != want {
+			t.Errorf("RedactStatement(%q) = %q, want %q", stmt, got, want)
+		}
+	}
+}
+
+func TestStatementLogTransport(t *testing.T) {
+	ctx := context.Background()
+	server := mockserver.Start(t, []mockserver.Fixture{
+		{Statement: `^CREATE SECRET "s"`},
+		{Statement: `^CREATE DATABASE "broken";$`, SqlState: "XX000", Message: "internal error"},
+	})
+
+	path := filepath.Join(t.TempDir(), "statements.jsonl")
+	log, err := OpenStatementLog(path)
+	if err != nil {
+		t.Fatalf("failed to open statement log: %v", err)
+	}
+	if again, err := OpenStatementLog(path); err != nil || again != log {
+		t.Errorf("expected the statement log to be shared, got %v, %v", again, err)
+	}
+
+	connector, err := gods.ConnectorWithOptions(ctx, gods.WithStaticToken("mock-token"), gods.WithServer(server.APIURL()),
+		gods.WithHTTPClient(&http.Client{Transport: StatementLogTransport(http.DefaultTransport, log)}))
+	if err != nil {
+		t.Fatalf("failed to create connector: %v", err)
+	}
+	db := sql.OpenDB(connector)
+	ctx, conn, err := GetConnection(WithResourceOperation(ctx, "ApplyResourceChange", "deltastream_secret"), db, nil, "00000000-0000-0000-0000-000000000001", "sysadmin")
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `CREATE SECRET "s" WITH ('type' = generic_string, 'secret_string' = 'value');`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, `CREATE DATABASE "broken";`); err == nil {
+		t.Fatal("expected statement to fail")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to read statement log: %v", err)
+	}
+	defer f.Close()
+	entries := map[string]StatementLogEntry{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry StatementLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid statement log line %q: %v", scanner.Text(), err)
+		}
+		entries[StatementKind(entry.Statement)] = entry
+	}
+
+	secret, ok := entries["CREATE SECRET"]
+	if !ok {
+		t.Fatalf("expected CREATE SECRET to be logged, got %v", entries)
+	}
+	if secret.Statement != `CREATE SECRET "s" WITH ('type' = generic_string, 'secret_string' = '***');` {
+		t.Errorf("expected redacted statement, got %q", secret.Statement)
+	}
+	if secret.Outcome != StatementSucceeded || secret.StatementID == "" || secret.Timestamp.IsZero() {
+		t.Errorf("unexpected entry %+v", secret)
+	}
+	if secret.RPC != "ApplyResourceChange" || secret.ResourceType != "deltastream_secret" {
+		t.Errorf("expected the operation to be logged, got %+v", secret)
+	}
+
+	broken := entries["CREATE DATABASE"]
+	if broken.Outcome != StatementFailed || broken.SQLState != "XX000" || broken.Error != "internal error" {
+		t.Errorf("expected failed statement to be logged, got %+v", broken)
+	}
+}
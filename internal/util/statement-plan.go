@@ -0,0 +1,90 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// StatementPlan is the plan DESCRIBE returns for a statement.
+type StatementPlan struct {
+	Ddl     *RelationPlan  `json:"ddl,omitempty"`
+	Sink    *RelationPlan  `json:"sink,omitempty"`
+	Sinks   []RelationPlan `json:"sinks,omitempty"`
+	Sources []RelationPlan `json:"sources,omitempty"`
+}
+
+// RelationPlan is a relation a statement plan creates, reads from or writes to. Fqn is prefixed with the organization.
+type RelationPlan struct {
+	Fqn        string `json:"fqn"`
+	Type       string `json:"type"`
+	DbName     string `json:"db_name"`
+	SchemaName string `json:"schema_name"`
+	Name       string `json:"name"`
+	StoreName  string `json:"store_name"`
+}
+
+// ArtifactDDL is the row returned by a statement creating a relation or launching a query.
+type ArtifactDDL struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	Summary string `json:"summary"`
+}
+
+// AllSinks returns every sink relation of the plan, regardless of whether the engine reported a single sink or a list
+// of them.
+func (p StatementPlan) AllSinks() []RelationPlan {
+	sinks := append([]RelationPlan{}, p.Sinks...)
+	if p.Sink != nil {
+		found := false
+		for _, sink := range sinks {
+			if sink.Fqn == p.Sink.Fqn {
+				found = true
+				break
+			}
+		}
+		if !found {
+			sinks = append(sinks, *p.Sink)
+		}
+	}
+	return sinks
+}
+
+// String names the relation and the store it resolves to.
+func (p RelationPlan) String() string {
+	if p.StoreName == "" {
+		return p.Fqn
+	}
+	return fmt.Sprintf("%s in store %s", p.Fqn, p.StoreName)
+}
+
+// RelationFqns returns the FQNs of relations without the organization prefix, as the fqn attribute of relation
+// resources names them.
+func RelationFqns(organization string, relations []RelationPlan) []string {
+	fqns := make([]string, 0, len(relations))
+	for _, rel := range relations {
+		fqns = append(fqns, strings.TrimPrefix(rel.Fqn, organization+"."))
+	}
+	return fqns
+}
+
+// DescribeStatement plans a statement with DESCRIBE and returns the kind of the statement and its plan.
+func DescribeStatement(ctx context.Context, conn *sql.Conn, statement string) (string, StatementPlan, error) {
+	plan := StatementPlan{}
+
+	var kind string
+	var descJson string
+	if err := conn.QueryRowContext(ctx, "DESCRIBE "+statement).Scan(&kind, &descJson); err != nil {
+		return "", plan, err
+	}
+	if err := json.Unmarshal([]byte(descJson), &plan); err != nil {
+		return kind, plan, fmt.Errorf("failed to parse statement plan: %w", err)
+	}
+	return kind, plan, nil
+}
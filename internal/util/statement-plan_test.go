@@ -0,0 +1,33 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatementPlanAllSinks(t *testing.T) {
+	a := RelationPlan{Fqn: "org.db.public.a"}
+	b := RelationPlan{Fqn: "org.db.public.b"}
+
+	tests := []struct {
+		name string
+		plan StatementPlan
+		want []RelationPlan
+	}{
+		{name: "no sinks", plan: StatementPlan{}, want: []RelationPlan{}},
+		{name: "single sink", plan: StatementPlan{Sink: &a}, want: []RelationPlan{a}},
+		{name: "list of sinks", plan: StatementPlan{Sinks: []RelationPlan{a, b}}, want: []RelationPlan{a, b}},
+		{name: "single sink also listed", plan: StatementPlan{Sink: &b, Sinks: []RelationPlan{a, b}}, want: []RelationPlan{a, b}},
+		{name: "single sink not listed", plan: StatementPlan{Sink: &b, Sinks: []RelationPlan{a}}, want: []RelationPlan{a, b}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.plan.AllSinks(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllSinks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
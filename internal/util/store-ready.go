@@ -0,0 +1,43 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sethvargo/go-retry"
+)
+
+const (
+	// storeReadyMaxDuration bounds the wait for a store to be visible and ready before objects are created in it.
+	storeReadyMaxDuration = time.Minute
+	storeReadyBaseBackoff = time.Second
+)
+
+// WaitForStoreReady waits until a store exists and is ready. A store that was just created may not be visible to
+// every part of the backend yet, creating objects in it right away can fail.
+func WaitForStoreReady(ctx context.Context, conn *sql.Conn, storeName string) error {
+	backoff := retry.WithMaxDuration(storeReadyMaxDuration, retry.NewExponential(storeReadyBaseBackoff))
+	return retry.Do(ctx, backoff, func(ctx context.Context) error {
+		var status string
+		if err := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT status FROM deltastream.sys."stores" WHERE name = '%s';`, storeName)).Scan(&status); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				tflog.Debug(ctx, "store not found yet, retrying", map[string]any{"store": storeName})
+				return retry.RetryableError(fmt.Errorf("store %s not found", storeName))
+			}
+			return retry.RetryableError(fmt.Errorf("failed to read store %s: %w", storeName, err))
+		}
+
+		if status != "ready" {
+			tflog.Debug(ctx, "store not ready yet, retrying", map[string]any{"store": storeName, "status": status})
+			return retry.RetryableError(fmt.Errorf("store %s is not ready, status: %s", storeName, status))
+		}
+		return nil
+	})
+}
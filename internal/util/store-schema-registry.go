@@ -0,0 +1,48 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// StoreSchemaRegistry returns the name of the schema registry attached to a store, or nil when none is attached.
+func StoreSchemaRegistry(ctx context.Context, conn *sql.Conn, storeName string) (*string, error) {
+	var discard any
+	var schemaRegistryName *string
+	row := conn.QueryRowContext(ctx, fmt.Sprintf(`DESCRIBE STORE "%s";`, storeName))
+	if err := row.Scan(&discard, &discard, &discard, &discard, &discard, &schemaRegistryName); err != nil {
+		return nil, fmt.Errorf("failed to describe store %s: %w", storeName, err)
+	}
+	return schemaRegistryName, nil
+}
+
+// StoresUsingSchemaRegistry returns the names of the stores the schema registry is attached to.
+func StoresUsingSchemaRegistry(ctx context.Context, conn *sql.Conn, schemaRegistryName string) ([]string, error) {
+	storeNames := []string{}
+	if err := QueryRows(ctx, conn, `SELECT "name" FROM deltastream.sys."stores";`, func(rows *sql.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		storeNames = append(storeNames, name)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	stores := []string{}
+	for _, name := range storeNames {
+		sr, err := StoreSchemaRegistry(ctx, conn, name)
+		if err != nil {
+			return nil, err
+		}
+		if sr != nil && *sr == schemaRegistryName {
+			stores = append(stores, name)
+		}
+	}
+	return stores, nil
+}
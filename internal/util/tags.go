@@ -0,0 +1,92 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TagValidators validate the keys of tags, in resources and in the default_tags of the provider.
+var TagValidators = []validator.Map{
+	mapvalidator.KeysAre(stringvalidator.LengthBetween(1, 128)),
+}
+
+// TagsProperty returns the property setting the tags of an object to the given map, rendered as a JSON object, or an
+// empty string when there are no tags.
+func TagsProperty(ctx context.Context, tags types.Map) (string, diag.Diagnostics) {
+	var d diag.Diagnostics
+	if tags.IsNull() || tags.IsUnknown() || len(tags.Elements()) == 0 {
+		return "", d
+	}
+
+	values := map[string]string{}
+	d.Append(tags.ElementsAs(ctx, &values, false)...)
+	if d.HasError() {
+		return "", d
+	}
+	// json.Marshal sorts the keys of maps, so the rendered property is stable
+	b, err := json.Marshal(values)
+	if err != nil {
+		d.AddError("invalid tags", err.Error())
+		return "", d
+	}
+	return `'tags' = '` + strings.ReplaceAll(string(b), "'", "''") + `'`, d
+}
+
+// SetTagsStatement returns the statement replacing the tags of an object, kind being the keyword of the object such as
+// DATABASE and name its identifier as written in statements. Tags left out of the map are removed from the object.
+func SetTagsStatement(ctx context.Context, kind, name string, tags types.Map) (string, diag.Diagnostics) {
+	property, d := TagsProperty(ctx, tags)
+	if d.HasError() {
+		return "", d
+	}
+	if property == "" {
+		property = `'tags' = '{}'`
+	}
+	return fmt.Sprintf(`ALTER %s %s SET (%s);`, kind, name, property), d
+}
+
+// ReadTags runs the DESCRIBE statement of an object and returns the tags it reports, from a Tags column or the tags of
+// its Properties column. ok is false when the object reports no tags at all, the tags recorded in state are then kept.
+func ReadTags(ctx context.Context, conn *sql.Conn, statement string) (tags map[string]string, ok bool, err error) {
+	row, err := Describe(ctx, conn, statement)
+	if err != nil {
+		return nil, false, err
+	}
+
+	raw := json.RawMessage(nil)
+	if v, found := row.Column("tags"); found && v.Valid {
+		raw = json.RawMessage(v.String)
+	} else if v, found := row.Column("properties"); found && v.Valid {
+		properties := map[string]json.RawMessage{}
+		if err := json.Unmarshal([]byte(v.String), &properties); err != nil {
+			return nil, false, fmt.Errorf("failed to parse properties: %w", err)
+		}
+		raw = properties["tags"]
+	}
+	if raw == nil {
+		return nil, false, nil
+	}
+
+	// tags are set as a JSON object rendered in a string, servers may report either form
+	var rendered string
+	if json.Unmarshal(raw, &rendered) == nil {
+		raw = json.RawMessage(rendered)
+	}
+	tags = map[string]string{}
+	if err := json.Unmarshal(raw, &tags); err != nil {
+		return nil, false, fmt.Errorf("failed to parse tags: %w", err)
+	}
+	return tags, true, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/deltastreaminc/terraform-provider-deltastream/internal/mockserver"
+)
+
+func TestTagsProperty(t *testing.T) {
+	tests := []struct {
+		name string
+		tags types.Map
+		want string
+	}{
+		{name: "null", tags: types.MapNull(types.StringType)},
+		{name: "empty", tags: types.MapValueMust(types.StringType, map[string]attr.Value{})},
+		{
+			name: "sorted and quoted",
+			tags: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"team":        types.StringValue("data"),
+				"cost-center": types.StringValue("o'brien"),
+			}),
+			want: `'tags' = '{"cost-center":"o''brien","team":"data"}'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dg := TagsProperty(context.Background(), tt.tags)
+			if dg.HasError() {
+				t.Fatalf("TagsProperty() diagnostics = %v", dg)
+			}
+			if got != tt.want {
+				t.Errorf("TagsProperty() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTagsStatement(t *testing.T) {
+	ctx := context.Background()
+	got, dg := SetTagsStatement(ctx, "DATABASE", `"db"`, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"team": types.StringValue("data"),
+	}))
+	if dg.HasError() {
+		t.Fatalf("SetTagsStatement() diagnostics = %v", dg)
+	}
+	if want := `ALTER DATABASE "db" SET ('tags' = '{"team":"data"}');`; got != want {
+		t.Errorf("SetTagsStatement() = %s, want %s", got, want)
+	}
+
+	got, _ = SetTagsStatement(ctx, "RELATION", "db.public.pageviews", types.MapNull(types.StringType))
+	if want := `ALTER RELATION db.public.pageviews SET ('tags' = '{}');`; got != want {
+		t.Errorf("SetTagsStatement(null) = %s, want %s", got, want)
+	}
+}
+
+func TestReadTags(t *testing.T) {
+	ctx := context.Background()
+	_, conn := mockserver.NewConn(t, []mockserver.Fixture{{
+		Statement: `^DESCRIBE DATABASE "db";$`,
+		Columns:   []mockserver.Column{{Name: "Name", Type: "VARCHAR"}, {Name: "Tags", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("db", `{"team":"data"}`)},
+	}, {
+		Statement: `^DESCRIBE SECRET "api";$`,
+		Columns:   []mockserver.Column{{Name: "Name", Type: "VARCHAR"}, {Name: "Properties", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("api", `{"type":"generic_string","tags":"{\"team\":\"platform\"}"}`)},
+	}, {
+		Statement: `^DESCRIBE STORE "legacy";$`,
+		Columns:   []mockserver.Column{{Name: "Name", Type: "VARCHAR"}},
+		Rows:      [][]*string{mockserver.Row("legacy")},
+	}})
+
+	tests := []struct {
+		statement string
+		want      map[string]string
+		ok        bool
+	}{
+		{statement: `DESCRIBE DATABASE "db";`, want: map[string]string{"team": "data"}, ok: true},
+		{statement: `DESCRIBE SECRET "api";`, want: map[string]string{"team": "platform"}, ok: true},
+		{statement: `DESCRIBE STORE "legacy";`},
+	}
+	for _, tt := range tests {
+		got, ok, err := ReadTags(ctx, conn, tt.statement)
+		if err != nil {
+			t.Fatalf("ReadTags(%s) error = %v", tt.statement, err)
+		}
+		if ok != tt.ok || (tt.ok && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("ReadTags(%s) = %v, %v, want %v, %v", tt.statement, got, ok, tt.want, tt.ok)
+		}
+	}
+}
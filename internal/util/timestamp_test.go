@@ -0,0 +1,21 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestampValue(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("PST", -8*60*60))
+	if got, want := TimestampValue(ts).ValueString(), "2024-01-02T11:04:05.123456789Z"; got != want {
+		t.Fatalf("TimestampValue() = %s, want %s", got, want)
+	}
+
+	ts = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if got, want := TimestampValue(ts).ValueString(), "2024-01-02T03:04:05Z"; got != want {
+		t.Fatalf("TimestampValue() = %s, want %s", got, want)
+	}
+}
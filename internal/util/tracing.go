@@ -0,0 +1,209 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/deltastreaminc/terraform-provider-deltastream"
+
+var (
+	tracingMu       sync.Mutex
+	tracingProvider *sdktrace.TracerProvider
+)
+
+// ConfigureTracing installs an OTLP/HTTP span exporter sending to endpoint. Only the first call has an effect, the
+// exporter is shared by every provider configuration of the process.
+func ConfigureTracing(ctx context.Context, endpoint, version string) error {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+
+	if tracingProvider != nil {
+		return nil
+	}
+
+	if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%q is not a valid OTLP endpoint URL", endpoint)
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return err
+	}
+
+	tracingProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName("terraform-provider-deltastream"),
+			semconv.ServiceVersion(version),
+		)),
+	)
+	otel.SetTracerProvider(tracingProvider)
+	return nil
+}
+
+// FlushTracing exports the spans ended so far. Terraform stops the provider process without notice, so spans are
+// flushed at the end of every RPC rather than on shutdown.
+func FlushTracing(ctx context.Context) {
+	tracingMu.Lock()
+	tp := tracingProvider
+	tracingMu.Unlock()
+
+	if tp != nil {
+		_ = tp.ForceFlush(ctx)
+	}
+}
+
+// Tracer returns the tracer used for provider spans. Spans are discarded until tracing is configured.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// TracingTransport records a span for every request sent to the DeltaStream API. Statement submissions carry the
+// statement kind and the resulting SQL state, the statement text itself is not recorded as it may hold secrets.
+func TracingTransport(r http.RoundTripper) http.RoundTripper {
+	return &tracingTransport{r: r}
+}
+
+type tracingTransport struct {
+	r http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := "deltastream.http"
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLPath(req.URL.Path),
+	}
+
+	isStatement := req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/statements")
+	if isStatement {
+		name = "deltastream.statement"
+		attrs = append(attrs, semconv.DBSystemKey.String("deltastream"))
+		if stmt, err := requestStatement(req); err == nil {
+			attrs = append(attrs, semconv.DBOperationName(StatementKind(stmt)))
+		}
+	} else if req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/statements/") {
+		name = "deltastream.statement.status"
+	}
+
+	ctx, span := Tracer().Start(req.Context(), name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	defer span.End()
+
+	resp, err := t.r.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	if sqlState := responseSQLState(resp); sqlState != "" {
+		span.SetAttributes(attribute.String("deltastream.sql_state", sqlState))
+		if sqlState != "00000" {
+			span.SetStatus(codes.Error, "statement failed with SQL state "+sqlState)
+		}
+	}
+	return resp, nil
+}
+
+// requestStatement extracts the statement from a statement submission without consuming the request body.
+func requestStatement(req *http.Request) (string, error) {
+	if req.GetBody == nil {
+		return "", io.EOF
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return "", err
+	}
+	mr := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			return "", err
+		}
+		if part.FormName() != "request" {
+			continue
+		}
+
+		var stmtReq struct {
+			Statement string `json:"statement"`
+		}
+		if err := json.NewDecoder(part).Decode(&stmtReq); err != nil {
+			return "", err
+		}
+		return stmtReq.Statement, nil
+	}
+}
+
+// responseSQLState reads the SQL state from a JSON result set, leaving the response body readable.
+func responseSQLState(resp *http.Response) string {
+	if resp.Body == nil || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return ""
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+
+	var rs struct {
+		SQLState string `json:"sqlState"`
+	}
+	if err := json.Unmarshal(data, &rs); err != nil {
+		return ""
+	}
+	return rs.SQLState
+}
+
+// statementObjectKeywords are the leading keywords that are followed by the kind of object they act on.
+var statementObjectKeywords = map[string]struct{}{
+	"ALTER": {}, "CREATE": {}, "DESCRIBE": {}, "DROP": {}, "GRANT": {}, "INSERT": {}, "LIST": {},
+	"RESTART": {}, "REVOKE": {}, "SHOW": {}, "TERMINATE": {}, "UPDATE": {},
+}
+
+// StatementKind returns the leading keywords of a statement, such as CREATE STREAM or SELECT.
+func StatementKind(stmt string) string {
+	words := strings.Fields(stmt)
+	if len(words) == 0 {
+		return ""
+	}
+
+	kind := strings.ToUpper(strings.TrimSuffix(words[0], ";"))
+	if _, ok := statementObjectKeywords[kind]; ok && len(words) > 1 {
+		kind += " " + strings.ToUpper(strings.TrimSuffix(words[1], ";"))
+	}
+	return kind
+}
@@ -0,0 +1,22 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import "testing"
+
+func TestStatementKind(t *testing.T) {
+	tests := map[string]string{
+		`CREATE STREAM pageviews WITH ('topic' = 'pageviews');`: "CREATE STREAM",
+		`describe query state 1234;`:                            "DESCRIBE QUERY",
+		`LIST ROLES;`:                                           "LIST ROLES",
+		`SELECT name FROM deltastream.sys."stores";`:            "SELECT",
+		`  `: "",
+	}
+
+	for stmt, want := range tests {
+		if got := StatementKind(stmt); got != want {
+			t.Errorf("StatementKind(%q) = %q, want %q", stmt, got, want)
+		}
+	}
+}
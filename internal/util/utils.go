@@ -5,25 +5,36 @@ package util
 
 import (
 	"os"
+	"slices"
 
 	"sigs.k8s.io/yaml"
 )
 
-func ArrayContains[T comparable](searchTerms []T, list []T) bool {
-	for _, term := range searchTerms {
-		found := false
-		for _, item := range list {
-			if term == item {
-				found = true
-			}
-		}
-		if !found {
+// SubsetOf reports whether every element of subset is in set. An empty subset is a subset of any set.
+func SubsetOf[T comparable](subset, set []T) bool {
+	return ContainsAll(set, subset...)
+}
+
+// ContainsAll reports whether list contains every one of items. It is true when no items are given.
+func ContainsAll[T comparable](list []T, items ...T) bool {
+	for _, item := range items {
+		if !slices.Contains(list, item) {
 			return false
 		}
 	}
 	return true
 }
 
+// ContainsAny reports whether list contains at least one of items. It is false when no items are given.
+func ContainsAny[T comparable](list []T, items ...T) bool {
+	for _, item := range items {
+		if slices.Contains(list, item) {
+			return true
+		}
+	}
+	return false
+}
+
 func Must[T any](val T, err error) T {
 	if err != nil {
 		panic(err)
@@ -31,7 +42,16 @@ func Must[T any](val T, err error) T {
 	return val
 }
 
+// LoadTestEnv loads the acceptance test credentials. When DELTASTREAM_MOCK is set the test harness points the
+// provider at the local mock server and no credentials file is needed.
 func LoadTestEnv() (map[string]string, error) {
+	if os.Getenv("DELTASTREAM_MOCK") != "" {
+		return map[string]string{
+			"org":  os.Getenv("DELTASTREAM_ORGANIZATION"),
+			"role": os.Getenv("DELTASTREAM_ROLE"),
+		}, nil
+	}
+
 	fdata, err := os.ReadFile(os.Getenv("DELTASTREAM_CRED_FILE"))
 	if err != nil {
 		return nil, err
@@ -0,0 +1,43 @@
+// Copyright (c) DeltaStream, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import "testing"
+
+func TestSetHelpers(t *testing.T) {
+	list := []string{"CREATE_STREAM", "CREATE_CHANGELOG", "INSERT_INTO"}
+	tests := []struct {
+		name  string
+		items []string
+		all   bool
+		any   bool
+	}{
+		{name: "no items", all: true, any: false},
+		{name: "one present", items: []string{"INSERT_INTO"}, all: true, any: true},
+		{name: "all present", items: []string{"CREATE_CHANGELOG", "CREATE_STREAM"}, all: true, any: true},
+		{name: "some present", items: []string{"CREATE_STREAM", "CREATE_TABLE"}, all: false, any: true},
+		{name: "none present", items: []string{"CREATE_TABLE"}, all: false, any: false},
+		{name: "case sensitive", items: []string{"insert_into"}, all: false, any: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContainsAll(list, tt.items...); got != tt.all {
+				t.Errorf("ContainsAll(%v) = %v, want %v", tt.items, got, tt.all)
+			}
+			if got := SubsetOf(tt.items, list); got != tt.all {
+				t.Errorf("SubsetOf(%v) = %v, want %v", tt.items, got, tt.all)
+			}
+			if got := ContainsAny(list, tt.items...); got != tt.any {
+				t.Errorf("ContainsAny(%v) = %v, want %v", tt.items, got, tt.any)
+			}
+		})
+	}
+
+	if SubsetOf(list, []string{"INSERT_INTO"}) {
+		t.Error("expected a larger list not to be a subset of a smaller one")
+	}
+	if !SubsetOf([]int{}, nil) || ContainsAny(nil, 1) {
+		t.Error("expected the empty subset to be a subset of the empty set, and the empty set to contain nothing")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -48,3 +49,29 @@ func (v UrlsValidator) ValidateString(ctx context.Context, req validator.StringR
 		}
 	}
 }
+
+// DurationValidator validates a duration string such as 30s or 5m.
+type DurationValidator struct{}
+
+func (v DurationValidator) Description(ctx context.Context) string {
+	return "validates a duration such as 30s or 5m"
+}
+
+func (v DurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v DurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid duration", fmt.Sprintf("%s is not a valid duration: %s", req.ConfigValue.ValueString(), err))
+		return
+	}
+	if d < 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid duration", "duration must not be negative")
+	}
+}
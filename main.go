@@ -7,9 +7,10 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/deltastreaminc/terraform-provider-deltastream/internal/provider"
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 var (
@@ -19,20 +20,28 @@ var (
 )
 
 func main() {
+	// import-gen generates the configuration to adopt existing objects instead of serving the provider
+	if len(os.Args) > 1 && os.Args[1] == "import-gen" {
+		if err := provider.ImportGen(context.Background(), version, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		// TODO: Update this string with the published name of your provider.
-		// Also update the tfplugindocs generate command to either remove the
-		// -provider-name flag or set its value to the updated provider name.
-		Address: "registry.terraform.io/deltastreaminc/deltastream",
-		Debug:   debug,
+	var opts []tf6server.ServeOpt
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	// TODO: Update this string with the published name of your provider.
+	// Also update the tfplugindocs generate command to either remove the
+	// -provider-name flag or set its value to the updated provider name.
+	err := tf6server.Serve("registry.terraform.io/deltastreaminc/deltastream", provider.NewServer(version), opts...)
 
 	if err != nil {
 		log.Fatal(err.Error())